@@ -59,6 +59,7 @@ describe('getCompletionItems()', () => {
             'count',
             'file',
             '-file',
+            'follow',
             'fork',
             'lang',
             '-lang',
@@ -119,6 +120,7 @@ describe('getCompletionItems()', () => {
             'count',
             'file',
             '-file',
+            'follow',
             'fork',
             'lang',
             '-lang',
@@ -161,6 +163,7 @@ describe('getCompletionItems()', () => {
             'count',
             'file',
             '-file',
+            'follow',
             'fork',
             'lang',
             '-lang',
@@ -211,6 +214,7 @@ describe('getCompletionItems()', () => {
             'count',
             'file',
             '-file',
+            'follow',
             'fork',
             'lang',
             '-lang',
@@ -252,6 +256,7 @@ describe('getCompletionItems()', () => {
             'count',
             'file',
             '-file',
+            'follow',
             'fork',
             'lang',
             '-lang',
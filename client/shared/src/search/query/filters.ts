@@ -18,6 +18,7 @@ export enum FilterType {
     context = 'context',
     count = 'count',
     file = 'file',
+    follow = 'follow',
     fork = 'fork',
     lang = 'lang',
     message = 'message',
@@ -225,6 +226,12 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
             `${negated ? 'Exclude' : 'Include only'} results from files matching the given search pattern.`,
         suggestions: 'path',
     },
+    [FilterType.follow]: {
+        description: 'Follow files matched by file: across renames in diff searches.',
+        discreteValues: () => ['yes', 'no'].map(value => ({ label: value })),
+        default: 'no',
+        singular: true,
+    },
     [FilterType.fork]: {
         discreteValues: () => ['yes', 'no', 'only'].map(value => ({ label: value })),
         description: 'Include results from forked repositories.',
@@ -243,7 +250,7 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
             `${negated ? 'Exclude' : 'Include only'} Commits with messages matching a certain string`,
     },
     [FilterType.patterntype]: {
-        discreteValues: () => ['regexp', 'literal', 'structural'].map(value => ({ label: value })),
+        discreteValues: () => ['regexp', 'literal', 'structural', 'fuzzy'].map(value => ({ label: value })),
         description: 'The pattern type (regexp, literal, structural) in use',
         singular: true,
     },
@@ -288,7 +295,7 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
     },
     [FilterType.type]: {
         description: 'Limit results to the specified type.',
-        discreteValues: () => ['diff', 'commit', 'symbol', 'repo', 'path', 'file'].map(value => ({ label: value })),
+        discreteValues: () => ['diff', 'commit', 'symbol', 'repo', 'path', 'file', 'matchdiff'].map(value => ({ label: value })),
     },
     [FilterType.visibility]: {
         discreteValues: () => ['any', 'private', 'public'].map(value => ({ label: value })),
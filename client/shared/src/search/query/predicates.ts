@@ -24,8 +24,13 @@ export const PREDICATES: Access[] = [
                         name: 'commit',
                         fields: [{ name: 'after' }],
                     },
+                    { name: 'symbol' },
                 ],
             },
+            {
+                name: 'has',
+                fields: [{ name: 'topic' }, { name: 'description' }],
+            },
         ],
     },
     {
@@ -33,7 +38,7 @@ export const PREDICATES: Access[] = [
         fields: [
             {
                 name: 'contains',
-                fields: [{ name: 'content' }],
+                fields: [{ name: 'content' }, { name: 'symbol' }],
             },
         ],
     },
@@ -481,6 +481,7 @@ export const scanSearchQuery = (
     let patternKind
     switch (searchPatternType) {
         case SearchPatternType.literal:
+        case SearchPatternType.fuzzy:
             patternKind = PatternKind.Literal
             break
         case SearchPatternType.regexp:
@@ -17,7 +17,7 @@ export type SearchEvent =
     | { type: 'error'; data: ErrorLike }
     | { type: 'done'; data: {} }
 
-export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch
+export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch | OwnerMatch | MatchDiffMatch
 
 export interface PathMatch {
     type: 'path'
@@ -108,6 +108,15 @@ export interface CommitMatch {
 
     content: MarkdownText
     ranges: number[][]
+    renames?: FileRename[]
+}
+
+/**
+ * A file which had the name oldPath before it was renamed to newPath.
+ */
+export interface FileRename {
+    oldPath: string
+    newPath: string
 }
 
 export interface RepositoryMatch {
@@ -122,6 +131,48 @@ export interface RepositoryMatch {
     branches?: string[]
 }
 
+/**
+ * A code owner of files matched by a select:file.owners search.
+ */
+export interface OwnerMatch {
+    type: 'owner'
+    owner: string
+    repository: string
+    repoStars?: number
+    repoLastFetched?: string
+    commit?: string
+}
+
+/**
+ * The line matches of a file which were added, removed or moved between the
+ * base and head revision of a rev:base..head search.
+ */
+export interface MatchDiffMatch {
+    type: 'matchdiff'
+    path: string
+    repository: string
+    repoStars?: number
+    repoLastFetched?: string
+    base: string
+    head: string
+    baseCommit: string
+    headCommit: string
+    lineMatches: MatchDiffLine[]
+}
+
+/**
+ * A line match of a MatchDiffMatch. lineNumber refers to the head revision,
+ * except for removed lines where it refers to the base revision.
+ */
+export interface MatchDiffLine {
+    status: 'added' | 'removed' | 'moved'
+    line: string
+    lineNumber: number
+    offsetAndLengths: number[][]
+    oldPath?: string
+    oldLineNumber?: number
+}
+
 /**
  * An aggregate type representing a progress update.
  * Should be replaced when a new ones come in.
@@ -166,6 +217,7 @@ export interface Skipped {
      * - excluded-fork :: we did not search a repository because it is a fork.
      * - excluded-archive :: we did not search a repository because it is archived.
      * - display :: we hit the display limit, so we stopped sending results from the backend.
+     * - query-cost-budget :: the query was queued, downgraded or not run because it exceeded the search cost budget of the user.
      */
     reason:
         | 'document-match-limit'
@@ -177,6 +229,7 @@ export interface Skipped {
         | 'excluded-fork'
         | 'excluded-archive'
         | 'display'
+        | 'query-cost-budget'
         | 'error'
     /**
      * A short message. eg 1,200 timed out.
@@ -520,13 +573,34 @@ export function getMatchUrl(match: SearchMatch): string {
             return match.url
         case 'repo':
             return getRepoMatchUrl(match)
+        case 'owner':
+            return getOwnerMatchUrl(match)
+        case 'matchdiff':
+            return getMatchDiffMatchUrl(match)
     }
 }
 
-export function getMatchTitle(match: RepositoryMatch | CommitMatch): MarkdownText {
+export function getMatchDiffMatchUrl(matchDiffMatch: MatchDiffMatch): string {
+    return `/${matchDiffMatch.repository}@${matchDiffMatch.headCommit}/-/blob/${matchDiffMatch.path}`
+}
+
+export function getOwnerMatchUrl(ownerMatch: OwnerMatch): string {
+    const revision = ownerMatch.commit ? `@${ownerMatch.commit}` : ''
+    return '/' + encodeURI(ownerMatch.repository + revision)
+}
+
+export function getMatchTitle(match: RepositoryMatch | CommitMatch | OwnerMatch | MatchDiffMatch): MarkdownText {
     if (match.type === 'commit') {
         return match.label
     }
+    if (match.type === 'owner') {
+        return `\`${match.owner}\` in [${displayRepoName(match.repository)}](${getOwnerMatchUrl(match)})`
+    }
+    if (match.type === 'matchdiff') {
+        return `[${displayRepoName(match.repository)} › ${match.path}](${getMatchDiffMatchUrl(match)}) (\`${
+            match.base
+        }..${match.head}\`)`
+    }
 
     return `[${displayRepoName(getRepoMatchLabel(match))}](${getRepoMatchUrl(match)})`
 }
@@ -9,7 +9,14 @@ import { Markdown } from '@sourcegraph/shared/src/components/Markdown'
 import { RepoIcon } from '@sourcegraph/shared/src/components/RepoIcon'
 import { ResultContainer } from '@sourcegraph/shared/src/components/ResultContainer'
 import { SearchResultStar } from '@sourcegraph/shared/src/components/SearchResultStar'
-import { CommitMatch, getMatchTitle, RepositoryMatch } from '@sourcegraph/shared/src/search/stream'
+import {
+    CommitMatch,
+    getMatchTitle,
+    MatchDiffLine,
+    MatchDiffMatch,
+    OwnerMatch,
+    RepositoryMatch,
+} from '@sourcegraph/shared/src/search/stream'
 import { TelemetryProps } from '@sourcegraph/shared/src/telemetry/telemetryService'
 import { renderMarkdown } from '@sourcegraph/shared/src/util/markdown'
 import { formatRepositoryStarCount } from '@sourcegraph/shared/src/util/stars'
@@ -17,8 +24,19 @@ import { formatRepositoryStarCount } from '@sourcegraph/shared/src/util/stars'
 import { CommitSearchResultMatch } from './CommitSearchResultMatch'
 import styles from './SearchResult.module.scss'
 
+const matchDiffLineStatus = (line: MatchDiffLine): string => {
+    switch (line.status) {
+        case 'added':
+            return 'Added'
+        case 'removed':
+            return 'Removed'
+        case 'moved':
+            return line.oldPath ? `Moved from ${line.oldPath}:${(line.oldLineNumber ?? 0) + 1}` : 'Moved'
+    }
+}
+
 interface Props extends TelemetryProps {
-    result: CommitMatch | RepositoryMatch
+    result: CommitMatch | RepositoryMatch | OwnerMatch | MatchDiffMatch
     repoName: string
     icon: React.ComponentType<{ className?: string }>
 }
@@ -117,6 +135,42 @@ export const SearchResult: React.FunctionComponent<Props> = ({ result, icon, rep
             )
         }
 
+        if (result.type === 'owner') {
+            return (
+                <div data-testid="search-owner-result">
+                    <div className={classNames(styles.searchResultMatch, 'p-2 flex-column')}>
+                        {result.repoLastFetched && <LastSyncedIcon lastSyncedTime={result.repoLastFetched} />}
+                        <div className="d-flex align-items-center flex-row">
+                            <div className={styles.matchType}>
+                                <small>Code owner match</small>
+                            </div>
+                        </div>
+                    </div>
+                </div>
+            )
+        }
+
+        if (result.type === 'matchdiff') {
+            return (
+                <div data-testid="search-matchdiff-result">
+                    {result.lineMatches.map(line => (
+                        <div
+                            key={`${line.status}:${line.lineNumber}`}
+                            className={classNames(styles.searchResultMatch, 'p-2 d-flex align-items-center flex-row')}
+                        >
+                            <div className={styles.matchType}>
+                                <small>{matchDiffLineStatus(line)}</small>
+                            </div>
+                            <div className={styles.divider} />
+                            <code className="text-truncate">
+                                {line.lineNumber + 1}: {line.line}
+                            </code>
+                        </div>
+                    ))}
+                </div>
+            )
+        }
+
         return <CommitSearchResultMatch key={result.url} item={result} />
     }
 
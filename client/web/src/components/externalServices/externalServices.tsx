@@ -17,10 +17,12 @@ import githubSchemaJSON from '../../../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
 import jvmPackagesSchemaJSON from '../../../../../schema/jvm-packages.schema.json'
+import mercurialSchemaJSON from '../../../../../schema/mercurial.schema.json'
 import otherExternalServiceSchemaJSON from '../../../../../schema/other_external_service.schema.json'
 import pagureSchemaJSON from '../../../../../schema/pagure.schema.json'
 import perforceSchemaJSON from '../../../../../schema/perforce.schema.json'
 import phabricatorSchemaJSON from '../../../../../schema/phabricator.schema.json'
+import svnSchemaJSON from '../../../../../schema/svn.schema.json'
 import { ExternalServiceKind } from '../../graphql-operations'
 import { EditorAction } from '../../site-admin/configHelpers'
 import { PerforceIcon } from '../PerforceIcon'
@@ -1233,6 +1235,59 @@ const PAGURE: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const MERCURIAL: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.MERCURIAL,
+    title: 'Mercurial',
+    icon: GitIcon,
+    jsonSchema: mercurialSchemaJSON,
+    defaultDisplayName: 'Mercurial',
+    defaultConfig: `{
+  "url": "https://hg.example.com/",
+  "repos": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the base URL of the Mercurial server.
+                </li>
+                <li>
+                    Add the paths of the repositories relative to <Field>url</Field> to <Field>repos</Field>. Their
+                    history is converted to Git when they are cloned.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const SVN: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.SVN,
+    title: 'Subversion',
+    icon: GitIcon,
+    jsonSchema: svnSchemaJSON,
+    defaultDisplayName: 'Subversion',
+    defaultConfig: `{
+  "url": "https://svn.example.com/repos/"
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the root URL of the Subversion repositories.
+                    Every directory directly below it is added as a repository, unless you list the repositories in{' '}
+                    <Field>repos</Field>.
+                </li>
+                <li>
+                    Set <Field>layout</Field> if the repositories don't follow the standard layout of trunk, branches
+                    and tags, and map Subversion usernames to Git authors with <Field>authors</Field>.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
 export const codeHostExternalServices: Record<string, AddExternalServiceOptions> = {
     github: GITHUB_DOTCOM,
     ghe: GITHUB_ENTERPRISE,
@@ -1247,6 +1302,8 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     ...(window.context?.experimentalFeatures?.perforce === 'enabled' ? { perforce: PERFORCE } : {}),
     ...(window.context?.experimentalFeatures?.jvmPackages === 'enabled' ? { jvmPackages: JVM_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.pagure === 'enabled' ? { pagure: PAGURE } : {}),
+    ...(window.context?.experimentalFeatures?.mercurial === 'enabled' ? { mercurial: MERCURIAL } : {}),
+    ...(window.context?.experimentalFeatures?.svn === 'enabled' ? { svn: SVN } : {}),
 }
 
 export const nonCodeHostExternalServices: Record<string, AddExternalServiceOptions> = {
@@ -1270,4 +1327,6 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.PERFORCE]: PERFORCE,
     [ExternalServiceKind.JVMPACKAGES]: JVM_PACKAGES,
     [ExternalServiceKind.PAGURE]: PAGURE,
+    [ExternalServiceKind.MERCURIAL]: MERCURIAL,
+    [ExternalServiceKind.SVN]: SVN,
 }
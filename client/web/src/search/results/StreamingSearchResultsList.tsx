@@ -1,4 +1,5 @@
 import * as H from 'history'
+import AccountIcon from 'mdi-react/AccountIcon'
 import AlphaSBoxIcon from 'mdi-react/AlphaSBoxIcon'
 import FileDocumentIcon from 'mdi-react/FileDocumentIcon'
 import FileIcon from 'mdi-react/FileIcon'
@@ -71,6 +72,12 @@ export const StreamingSearchResultsList: React.FunctionComponent<StreamingSearch
         if (item.type === 'content' || item.type === 'symbol') {
             return `file:${getMatchUrl(item)}`
         }
+        if (item.type === 'matchdiff') {
+            return `matchdiff:${getMatchUrl(item)}`
+        }
+        if (item.type === 'owner') {
+            return `owner:${item.repository}:${item.owner}`
+        }
         return getMatchUrl(item)
     }, [])
 
@@ -115,6 +122,24 @@ export const StreamingSearchResultsList: React.FunctionComponent<StreamingSearch
                             telemetryService={telemetryService}
                         />
                     )
+                case 'owner':
+                    return (
+                        <SearchResult
+                            icon={AccountIcon}
+                            result={result}
+                            repoName={result.repository}
+                            telemetryService={telemetryService}
+                        />
+                    )
+                case 'matchdiff':
+                    return (
+                        <SearchResult
+                            icon={FileDocumentIcon}
+                            result={result}
+                            repoName={result.repository}
+                            telemetryService={telemetryService}
+                        />
+                    )
             }
         },
         [
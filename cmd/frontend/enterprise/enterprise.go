@@ -18,20 +18,22 @@ import (
 // Services is a bag of HTTP handlers and factory functions that are registered by the
 // enterprise frontend setup hook.
 type Services struct {
-	GitHubWebhook             webhooks.Registerer
-	GitLabWebhook             http.Handler
-	BitbucketServerWebhook    http.Handler
-	NewCodeIntelUploadHandler NewCodeIntelUploadHandler
-	NewExecutorProxyHandler   NewExecutorProxyHandler
-	AuthzResolver             graphqlbackend.AuthzResolver
-	BatchChangesResolver      graphqlbackend.BatchChangesResolver
-	CodeIntelResolver         graphqlbackend.CodeIntelResolver
-	InsightsResolver          graphqlbackend.InsightsResolver
-	CodeMonitorsResolver      graphqlbackend.CodeMonitorsResolver
-	LicenseResolver           graphqlbackend.LicenseResolver
-	DotcomResolver            graphqlbackend.DotcomRootResolver
-	SearchContextsResolver    graphqlbackend.SearchContextsResolver
-	OrgRepositoryResolver     graphqlbackend.OrgRepositoryResolver
+	GitHubWebhook               webhooks.Registerer
+	GitLabWebhook               http.Handler
+	BitbucketServerWebhook      http.Handler
+	NewCodeIntelUploadHandler   NewCodeIntelUploadHandler
+	NewExecutorProxyHandler     NewExecutorProxyHandler
+	SearchExportDownloadHandler http.Handler
+	AuthzResolver               graphqlbackend.AuthzResolver
+	BatchChangesResolver        graphqlbackend.BatchChangesResolver
+	CodeIntelResolver           graphqlbackend.CodeIntelResolver
+	InsightsResolver            graphqlbackend.InsightsResolver
+	CodeMonitorsResolver        graphqlbackend.CodeMonitorsResolver
+	LicenseResolver             graphqlbackend.LicenseResolver
+	DotcomResolver              graphqlbackend.DotcomRootResolver
+	SearchContextsResolver      graphqlbackend.SearchContextsResolver
+	OrgRepositoryResolver       graphqlbackend.OrgRepositoryResolver
+	SearchExportsResolver       graphqlbackend.SearchExportsResolver
 }
 
 // NewCodeIntelUploadHandler creates a new handler for the LSIF upload endpoint. The
@@ -46,11 +48,12 @@ type NewExecutorProxyHandler func() http.Handler
 // DefaultServices creates a new Services value that has default implementations for all services.
 func DefaultServices() Services {
 	return Services{
-		GitHubWebhook:             registerFunc(func(webhook *webhooks.GitHubWebhook) {}),
-		GitLabWebhook:             makeNotFoundHandler("gitlab webhook"),
-		BitbucketServerWebhook:    makeNotFoundHandler("bitbucket server webhook"),
-		NewCodeIntelUploadHandler: func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
-		NewExecutorProxyHandler:   func() http.Handler { return makeNotFoundHandler("executor proxy") },
+		GitHubWebhook:               registerFunc(func(webhook *webhooks.GitHubWebhook) {}),
+		GitLabWebhook:               makeNotFoundHandler("gitlab webhook"),
+		BitbucketServerWebhook:      makeNotFoundHandler("bitbucket server webhook"),
+		NewCodeIntelUploadHandler:   func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
+		NewExecutorProxyHandler:     func() http.Handler { return makeNotFoundHandler("executor proxy") },
+		SearchExportDownloadHandler: makeNotFoundHandler("search export download"),
 	}
 }
 
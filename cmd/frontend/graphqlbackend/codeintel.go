@@ -32,6 +32,8 @@ type CodeIntelResolver interface {
 	PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *PreviewGitObjectFilterArgs) ([]GitObjectFilterPreviewResolver, error)
 	NodeResolvers() map[string]NodeByIDFunc
 	DocumentationSearch(ctx context.Context, args *DocumentationSearchArgs) (DocumentationSearchResultsResolver, error)
+	RepositoryStorageUsage(ctx context.Context, id graphql.ID) (CodeIntelligenceStorageUsageResolver, error)
+	OrganizationStorageUsage(ctx context.Context, id graphql.ID) (CodeIntelligenceStorageUsageResolver, error)
 }
 
 type LSIFUploadsQueryArgs struct {
@@ -145,6 +147,12 @@ type CodeIntelligenceCommitGraphResolver interface {
 	UpdatedAt(ctx context.Context) (*DateTime, error)
 }
 
+type CodeIntelligenceStorageUsageResolver interface {
+	StorageBytes() BigInt
+	UploadCount() int32
+	QuotaBytes() *BigInt
+}
+
 type GitBlobLSIFDataResolver interface {
 	GitTreeLSIFDataResolver
 	ToGitTreeLSIFData() (GitTreeLSIFDataResolver, bool)
@@ -155,6 +163,9 @@ type GitBlobLSIFDataResolver interface {
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	TypeDefinitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
+	IncomingCalls(ctx context.Context, args *LSIFQueryPositionArgs) ([]CallHierarchyCallResolver, error)
+	OutgoingCalls(ctx context.Context, args *LSIFQueryPositionArgs) ([]CallHierarchyCallResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 	Documentation(ctx context.Context, args *LSIFQueryPositionArgs) (DocumentationResolver, error)
 }
@@ -215,6 +226,11 @@ type LocationConnectionResolver interface {
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 }
 
+type CallHierarchyCallResolver interface {
+	Location(ctx context.Context) (LocationResolver, error)
+	CallSites(ctx context.Context) (LocationConnectionResolver, error)
+}
+
 type HoverResolver interface {
 	Markdown() Markdown
 	Range() RangeResolver
@@ -66,6 +66,21 @@ func (r *CommitSearchResultResolver) DiffPreview() *highlightedStringResolver {
 	return &highlightedStringResolver{*r.CommitMatch.DiffPreview}
 }
 
+func (r *CommitSearchResultResolver) Renames() []*fileRenameResolver {
+	out := make([]*fileRenameResolver, 0, len(r.CommitMatch.Renames))
+	for _, rename := range r.CommitMatch.Renames {
+		out = append(out, &fileRenameResolver{rename})
+	}
+	return out
+}
+
+type fileRenameResolver struct {
+	inner result.FileRename
+}
+
+func (r *fileRenameResolver) OldPath() string { return r.inner.OldPath }
+func (r *fileRenameResolver) NewPath() string { return r.inner.NewPath }
+
 func (r *CommitSearchResultResolver) Label() Markdown {
 	return Markdown(r.CommitMatch.Label())
 }
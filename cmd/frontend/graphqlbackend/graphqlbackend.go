@@ -353,6 +353,7 @@ func NewSchema(
 	dotcom DotcomRootResolver,
 	searchContexts SearchContextsResolver,
 	orgRepositoryResolver OrgRepositoryResolver,
+	searchExports SearchExportsResolver,
 ) (*graphql.Schema, error) {
 	resolver := newSchemaResolver(db)
 	schemas := []string{mainSchema}
@@ -432,6 +433,16 @@ func NewSchema(
 		schemas = append(schemas, orgSchema)
 	}
 
+	if searchExports != nil {
+		EnterpriseResolvers.searchExportsResolver = searchExports
+		resolver.SearchExportsResolver = searchExports
+		schemas = append(schemas, searchExportsSchema)
+		// Register NodeByID handlers.
+		for kind, res := range searchExports.NodeResolvers() {
+			resolver.nodeByIDFns[kind] = res
+		}
+	}
+
 	schemas = append(schemas, computeSchema)
 
 	return graphql.ParseSchema(
@@ -456,6 +467,7 @@ type schemaResolver struct {
 	DotcomRootResolver
 	SearchContextsResolver
 	OrgRepositoryResolver
+	SearchExportsResolver
 
 	db                database.DB
 	repoupdaterClient *repoupdater.Client
@@ -532,6 +544,7 @@ var EnterpriseResolvers = struct {
 	dotcomResolver         DotcomRootResolver
 	searchContextsResolver SearchContextsResolver
 	orgRepositoryResolver  OrgRepositoryResolver
+	searchExportsResolver  SearchExportsResolver
 }{}
 
 // DEPRECATED
@@ -13,11 +13,13 @@ type LocationResolver interface {
 	Range() *rangeResolver
 	URL(ctx context.Context) (string, error)
 	CanonicalURL() string
+	Precise() bool
 }
 
 type locationResolver struct {
 	resource *GitTreeEntryResolver
 	lspRange *lsp.Range
+	precise  bool
 }
 
 var _ LocationResolver = &locationResolver{}
@@ -29,6 +31,15 @@ func NewLocationResolver(resource *GitTreeEntryResolver, lspRange *lsp.Range) Lo
 	}
 }
 
+// NewPreciseLocationResolver creates a resolver for a location found by precise code intelligence.
+func NewPreciseLocationResolver(resource *GitTreeEntryResolver, lspRange *lsp.Range) LocationResolver {
+	return &locationResolver{
+		resource: resource,
+		lspRange: lspRange,
+		precise:  true,
+	}
+}
+
 func (r *locationResolver) Resource() *GitTreeEntryResolver { return r.resource }
 
 func (r *locationResolver) Range() *rangeResolver {
@@ -51,6 +62,8 @@ func (r *locationResolver) CanonicalURL() string {
 	return r.urlPath(url)
 }
 
+func (r *locationResolver) Precise() bool { return r.precise }
+
 func (r *locationResolver) urlPath(prefix string) string {
 	url := prefix
 	if r.lspRange != nil {
@@ -241,6 +241,11 @@ func (r *NodeResolver) ToInsightView() (InsightViewResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToSearchExport() (SearchExportResolver, bool) {
+	n, ok := r.Node.(SearchExportResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToWebhookLog() (*webhookLogResolver, bool) {
 	n, ok := r.Node.(*webhookLogResolver)
 	return n, ok
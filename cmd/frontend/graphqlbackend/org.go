@@ -215,6 +215,10 @@ func (o *OrgResolver) BatchChanges(ctx context.Context, args *ListBatchChangesAr
 	return EnterpriseResolvers.batchChangesResolver.BatchChanges(ctx, args)
 }
 
+func (o *OrgResolver) CodeIntelligenceStorageUsage(ctx context.Context) (CodeIntelligenceStorageUsageResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.OrganizationStorageUsage(ctx, o.ID())
+}
+
 func (r *schemaResolver) CreateOrganization(ctx context.Context, args *struct {
 	Name        string
 	DisplayName *string
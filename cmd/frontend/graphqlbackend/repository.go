@@ -348,6 +348,10 @@ func (r *RepositoryResolver) CodeIntelligenceCommitGraph(ctx context.Context) (C
 	return EnterpriseResolvers.codeIntelResolver.CommitGraph(ctx, r.ID())
 }
 
+func (r *RepositoryResolver) CodeIntelligenceStorageUsage(ctx context.Context) (CodeIntelligenceStorageUsageResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.RepositoryStorageUsage(ctx, r.ID())
+}
+
 func (r *RepositoryResolver) PreviewGitObjectFilter(ctx context.Context, args *PreviewGitObjectFilterArgs) ([]GitObjectFilterPreviewResolver, error) {
 	return EnterpriseResolvers.codeIntelResolver.PreviewGitObjectFilter(ctx, r.ID(), args)
 }
@@ -2,6 +2,7 @@ package graphqlbackend
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
@@ -10,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/query-runner/queryrunnerapi"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -67,6 +69,9 @@ func (r *schemaResolver) savedSearchByID(ctx context.Context, id graphql.ID) (*s
 			UserID:          ss.Config.UserID,
 			OrgID:           ss.Config.OrgID,
 			SlackWebhookURL: ss.Config.SlackWebhookURL,
+			NotifyWebhook:   ss.Config.NotifyWebhook,
+			WebhookURL:      ss.Config.WebhookURL,
+			WebhookSecret:   ss.Config.WebhookSecret,
 		},
 	}
 	return savedSearch, nil
@@ -108,6 +113,10 @@ func (r savedSearchResolver) Namespace(ctx context.Context) (*NamespaceResolver,
 
 func (r savedSearchResolver) SlackWebhookURL() *string { return r.s.SlackWebhookURL }
 
+func (r savedSearchResolver) NotifyWebhook() bool { return r.s.NotifyWebhook }
+
+func (r savedSearchResolver) WebhookURL() *string { return r.s.WebhookURL }
+
 func (r *schemaResolver) toSavedSearchResolver(entry types.SavedSearch) *savedSearchResolver {
 	return &savedSearchResolver{db: r.db, s: entry}
 }
@@ -158,6 +167,10 @@ func (r *schemaResolver) CreateSavedSearch(ctx context.Context, args *struct {
 	NotifySlack bool
 	OrgID       *graphql.ID
 	UserID      *graphql.ID
+
+	NotifyWebhook *bool
+	WebhookURL    *string
+	WebhookSecret *string
 }) (*savedSearchResolver, error) {
 	var userID, orgID *int32
 	// 🚨 SECURITY: Make sure the current user has permission to create a saved search for the specified user or org.
@@ -187,13 +200,21 @@ func (r *schemaResolver) CreateSavedSearch(ctx context.Context, args *struct {
 		return nil, errMissingPatternType
 	}
 
+	notifyWebhook := args.NotifyWebhook != nil && *args.NotifyWebhook
+	if err := validateWebhookURL(notifyWebhook, args.WebhookURL); err != nil {
+		return nil, err
+	}
+
 	ss, err := r.db.SavedSearches().Create(ctx, &types.SavedSearch{
-		Description: args.Description,
-		Query:       args.Query,
-		Notify:      args.NotifyOwner,
-		NotifySlack: args.NotifySlack,
-		UserID:      userID,
-		OrgID:       orgID,
+		Description:   args.Description,
+		Query:         args.Query,
+		Notify:        args.NotifyOwner,
+		NotifySlack:   args.NotifySlack,
+		UserID:        userID,
+		OrgID:         orgID,
+		NotifyWebhook: notifyWebhook,
+		WebhookURL:    args.WebhookURL,
+		WebhookSecret: args.WebhookSecret,
 	})
 	if err != nil {
 		return nil, err
@@ -210,6 +231,10 @@ func (r *schemaResolver) UpdateSavedSearch(ctx context.Context, args *struct {
 	NotifySlack bool
 	OrgID       *graphql.ID
 	UserID      *graphql.ID
+
+	NotifyWebhook *bool
+	WebhookURL    *string
+	WebhookSecret *string
 }) (*savedSearchResolver, error) {
 	var userID, orgID *int32
 	// 🚨 SECURITY: Make sure the current user has permission to update a saved search for the specified user or org.
@@ -244,14 +269,40 @@ func (r *schemaResolver) UpdateSavedSearch(ctx context.Context, args *struct {
 		return nil, errMissingPatternType
 	}
 
+	// Clients which don't know about webhooks omit their settings, which then
+	// keep their stored values.
+	notifyWebhook, webhookURL := args.NotifyWebhook, args.WebhookURL
+	if notifyWebhook == nil || webhookURL == nil {
+		existing, err := r.db.SavedSearches().GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		// 🚨 SECURITY: Make sure the current user has permission to read the stored settings of the saved search.
+		if err := checkSavedSearchAccess(ctx, r.db, existing.Config); err != nil {
+			return nil, err
+		}
+		if notifyWebhook == nil {
+			notifyWebhook = &existing.Config.NotifyWebhook
+		}
+		if webhookURL == nil {
+			webhookURL = existing.Config.WebhookURL
+		}
+	}
+	if err := validateWebhookURL(*notifyWebhook, webhookURL); err != nil {
+		return nil, err
+	}
+
 	ss, err := r.db.SavedSearches().Update(ctx, &types.SavedSearch{
-		ID:          id,
-		Description: args.Description,
-		Query:       args.Query,
-		Notify:      args.NotifyOwner,
-		NotifySlack: args.NotifySlack,
-		UserID:      userID,
-		OrgID:       orgID,
+		ID:            id,
+		Description:   args.Description,
+		Query:         args.Query,
+		Notify:        args.NotifyOwner,
+		NotifySlack:   args.NotifySlack,
+		UserID:        userID,
+		OrgID:         orgID,
+		NotifyWebhook: *notifyWebhook,
+		WebhookURL:    webhookURL,
+		WebhookSecret: args.WebhookSecret,
 	})
 	if err != nil {
 		return nil, err
@@ -296,4 +347,35 @@ func queryHasPatternType(query string) bool {
 	return patternType.Match([]byte(query))
 }
 
+// validateWebhookURL returns an error if notifyWebhook is true and webhookURL
+// is not an absolute HTTP(S) URL.
+// checkSavedSearchAccess returns an error if the current user is not allowed
+// to access the saved search with the given config.
+func checkSavedSearchAccess(ctx context.Context, db database.DB, config api.ConfigSavedQuery) error {
+	if config.UserID != nil {
+		return backend.CheckSiteAdminOrSameUser(ctx, db, *config.UserID)
+	}
+	if config.OrgID != nil {
+		return backend.CheckOrgAccessOrSiteAdmin(ctx, db, *config.OrgID)
+	}
+	return errors.New("no Org ID or User ID associated with saved search")
+}
+
+func validateWebhookURL(notifyWebhook bool, webhookURL *string) error {
+	if !notifyWebhook {
+		return nil
+	}
+	if webhookURL == nil || *webhookURL == "" {
+		return errors.New("a webhook URL is required to notify a webhook")
+	}
+	u, err := url.Parse(*webhookURL)
+	if err != nil {
+		return errors.Wrap(err, "invalid webhook URL")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("invalid webhook URL %q: must be an absolute http or https URL", *webhookURL)
+	}
+	return nil
+}
+
 var errMissingPatternType = errors.New("a `patternType:` filter is required in the query for all saved searches. `patternType` can be \"literal\", \"regexp\" or \"structural\"")
@@ -166,6 +166,10 @@ func TestCreateSavedSearch(t *testing.T) {
 		NotifySlack bool
 		OrgID       *graphql.ID
 		UserID      *graphql.ID
+
+		NotifyWebhook *bool
+		WebhookURL    *string
+		WebhookSecret *string
 	}{Description: "test query", Query: "test type:diff patternType:regexp", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err != nil {
 		t.Fatal(err)
@@ -194,6 +198,10 @@ func TestCreateSavedSearch(t *testing.T) {
 		NotifySlack bool
 		OrgID       *graphql.ID
 		UserID      *graphql.ID
+
+		NotifyWebhook *bool
+		WebhookURL    *string
+		WebhookSecret *string
 	}{Description: "test query", Query: "test type:diff", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err == nil {
 		t.Error("Expected error for createSavedSearch when query does not provide a patternType: field.")
@@ -208,6 +216,9 @@ func TestUpdateSavedSearch(t *testing.T) {
 	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true, ID: key}, nil)
 
 	ss := dbmock.NewMockSavedSearchStore()
+	ss.GetByIDFunc.SetDefaultReturn(&api.SavedQuerySpecAndConfig{
+		Config: api.ConfigSavedQuery{Key: "1", UserID: &key},
+	}, nil)
 	ss.UpdateFunc.SetDefaultHook(func(ctx context.Context, savedSearch *types.SavedSearch) (*types.SavedSearch, error) {
 		return &types.SavedSearch{
 			ID:          key,
@@ -233,6 +244,10 @@ func TestUpdateSavedSearch(t *testing.T) {
 		NotifySlack bool
 		OrgID       *graphql.ID
 		UserID      *graphql.ID
+
+		NotifyWebhook *bool
+		WebhookURL    *string
+		WebhookSecret *string
 	}{ID: marshalSavedSearchID(key), Description: "updated query description", Query: "test type:diff patternType:regexp", OrgID: nil, UserID: &userID})
 	if err != nil {
 		t.Fatal(err)
@@ -261,12 +276,62 @@ func TestUpdateSavedSearch(t *testing.T) {
 		NotifySlack bool
 		OrgID       *graphql.ID
 		UserID      *graphql.ID
+
+		NotifyWebhook *bool
+		WebhookURL    *string
+		WebhookSecret *string
 	}{ID: marshalSavedSearchID(key), Description: "updated query description", Query: "test type:diff", NotifyOwner: true, NotifySlack: false, OrgID: nil, UserID: &userID})
 	if err == nil {
 		t.Error("Expected error for updateSavedSearch when query does not provide a patternType: field.")
 	}
 }
 
+func TestUpdateSavedSearchKeepsWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	key := int32(1)
+	users := dbmock.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true, ID: key}, nil)
+
+	webhookURL := "https://example.com/hook"
+	ss := dbmock.NewMockSavedSearchStore()
+	ss.GetByIDFunc.SetDefaultReturn(&api.SavedQuerySpecAndConfig{
+		Config: api.ConfigSavedQuery{Key: "1", UserID: &key, NotifyWebhook: true, WebhookURL: &webhookURL},
+	}, nil)
+	ss.UpdateFunc.SetDefaultHook(func(ctx context.Context, savedSearch *types.SavedSearch) (*types.SavedSearch, error) {
+		return savedSearch, nil
+	})
+
+	db := dbmock.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.SavedSearchesFunc.SetDefaultReturn(ss)
+
+	// The web client omits the webhook settings when it updates a saved search.
+	userID := MarshalUserID(key)
+	_, err := (&schemaResolver{db: db}).UpdateSavedSearch(ctx, &struct {
+		ID          graphql.ID
+		Description string
+		Query       string
+		NotifyOwner bool
+		NotifySlack bool
+		OrgID       *graphql.ID
+		UserID      *graphql.ID
+
+		NotifyWebhook *bool
+		WebhookURL    *string
+		WebhookSecret *string
+	}{ID: marshalSavedSearchID(key), Description: "updated", Query: "test patternType:literal", UserID: &userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockrequire.Called(t, ss.UpdateFunc)
+	updated := ss.UpdateFunc.History()[0].Arg1
+	if !updated.NotifyWebhook || updated.WebhookURL == nil || *updated.WebhookURL != webhookURL {
+		t.Errorf("want stored webhook settings to be kept, got notifyWebhook=%v webhookURL=%v", updated.NotifyWebhook, updated.WebhookURL)
+	}
+}
+
 func TestDeleteSavedSearch(t *testing.T) {
 	ctx := context.Background()
 
@@ -305,3 +370,24 @@ func TestDeleteSavedSearch(t *testing.T) {
 
 	mockrequire.Called(t, ss.DeleteFunc)
 }
+
+func TestValidateWebhookURL(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	for _, tc := range []struct {
+		notifyWebhook bool
+		webhookURL    *string
+		wantErr       bool
+	}{
+		{notifyWebhook: false, webhookURL: nil},
+		{notifyWebhook: true, webhookURL: strPtr("https://alerts.example.com/hooks/sourcegraph")},
+		{notifyWebhook: true, webhookURL: nil, wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr(""), wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr("ftp://example.com"), wantErr: true},
+		{notifyWebhook: true, webhookURL: strPtr("/relative"), wantErr: true},
+	} {
+		err := validateWebhookURL(tc.notifyWebhook, tc.webhookURL)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("validateWebhookURL(%v, %v) got error %v, want error %v", tc.notifyWebhook, tc.webhookURL, err, tc.wantErr)
+		}
+	}
+}
@@ -48,3 +48,7 @@ var searchContextsSchema string
 // organization repositories.
 //go:embed org.graphql
 var orgSchema string
+
+// searchExportsSchema is the Search Exports raw graqhql schema.
+//go:embed search_exports.graphql
+var searchExportsSchema string
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/zoekt"
@@ -42,6 +43,10 @@ type SearchArgs struct {
 	// to make it visible in the browser.
 	Stream streaming.Sender
 
+	// Timeout if non-zero overrides the timeout of interactive searches. It
+	// is set by background jobs, such as search exports.
+	Timeout time.Duration
+
 	// For tests
 	Settings *schema.Settings
 }
@@ -109,7 +114,8 @@ func NewSearchImplementer(ctx context.Context, db database.DB, args *SearchArgs)
 			DefaultLimit:  defaultLimit,
 		},
 
-		stream: args.Stream,
+		stream:  args.Stream,
+		timeout: args.Timeout,
 
 		zoekt:        search.Indexed(),
 		searcherURLs: search.SearcherURLs(),
@@ -122,8 +128,8 @@ func (r *schemaResolver) Search(ctx context.Context, args *SearchArgs) (SearchIm
 	return NewSearchImplementer(ctx, r.db, args)
 }
 
-// detectSearchType returns the search type to perform ("regexp", or
-// "literal"). The search type derives from three sources: the version and
+// detectSearchType returns the search type to perform ("regexp", "literal",
+// "structural" or "fuzzy"). The search type derives from three sources: the version and
 // patternType parameters passed to the search endpoint (literal search is the
 // default in V2), and the `patternType:` filter in the input query string which
 // overrides the searchType, if present.
@@ -137,6 +143,8 @@ func detectSearchType(version string, patternType *string) (query.SearchType, er
 			searchType = query.SearchTypeRegex
 		case "structural":
 			searchType = query.SearchTypeStructural
+		case "fuzzy":
+			searchType = query.SearchTypeFuzzy
 		default:
 			return -1, errors.Errorf("unrecognized patternType: %v", patternType)
 		}
@@ -169,6 +177,8 @@ func overrideSearchType(input string, searchType query.SearchType) query.SearchT
 			searchType = query.SearchTypeLiteral
 		case "structural":
 			searchType = query.SearchTypeStructural
+		case "fuzzy":
+			searchType = query.SearchTypeFuzzy
 		}
 	})
 	return searchType
@@ -190,6 +200,9 @@ type searchResolver struct {
 	// stream if non-nil will send all search events we receive down it.
 	stream streaming.Sender
 
+	// timeout if non-zero overrides the timeout of interactive searches.
+	timeout time.Duration
+
 	// Cached resolveRepositories results. We use a pointer to the mutex so that we
 	// can copy the resolver, while sharing the mutex. If we didn't use a pointer,
 	// the mutex would lead to unexpected behaviour.
@@ -201,6 +214,14 @@ type searchResolver struct {
 	searcherURLs *endpoint.Map
 }
 
+// searchTimeout returns the timeout of the basic query q.
+func (r *searchResolver) searchTimeout(q query.Basic) time.Duration {
+	if r.timeout > 0 {
+		return r.timeout
+	}
+	return search.TimeoutDuration(q)
+}
+
 func (r *searchResolver) Inputs() run.SearchInputs {
 	return *r.SearchInputs
 }
@@ -315,7 +336,7 @@ func (r *searchResolver) suggestFilePaths(ctx context.Context, limit int) ([]Sea
 		SearcherURLs:    r.searcherURLs,
 	}
 
-	isEmpty := args.PatternInfo.Pattern == "" && args.PatternInfo.ExcludePattern == "" && len(args.PatternInfo.IncludePatterns) == 0
+	isEmpty := args.PatternInfo.Pattern == "" && args.PatternInfo.ExcludePattern == "" && len(args.PatternInfo.IncludePatterns) == 0 && len(args.PatternInfo.Languages) == 0 && len(args.PatternInfo.ExcludeLanguages) == 0
 	if isEmpty {
 		// Empty query isn't an error, but it has no results.
 		return nil, nil
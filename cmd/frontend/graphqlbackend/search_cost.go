@@ -0,0 +1,81 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/search/cost"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+)
+
+// admitSearch checks the estimated cost of the query against the search cost
+// budget of the user, if one is configured. Searches of internal actors and
+// searches exempted with cost.WithExempt are not charged. It returns a nil
+// decision if the
+// query runs as is. If the query is downgraded, admitSearch replaces r.Plan
+// with the downgraded plan. If the query is rejected, it returns an alert
+// and the query must not run.
+func (r *searchResolver) admitSearch(ctx context.Context) (*cost.Decision, *searchAlert, error) {
+	budget := cost.CurrentBudget()
+	if budget == nil {
+		return nil, nil, nil
+	}
+	a := actor.FromContext(ctx)
+	if a.IsInternal() || cost.IsExempt(ctx) {
+		return nil, nil, nil
+	}
+
+	estimated, err := cost.Estimate(r.Plan, r.countRepos(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decision, err := budget.Admit(ctx, a.UID, estimated)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch decision.Action {
+	case cost.ActionAdmit:
+		return nil, nil, nil
+	case cost.ActionDowngrade:
+		r.Plan = cost.Downgrade(r.Plan)
+		r.Query = r.Plan.ToParseTree()
+		downgraded, err := cost.Estimate(r.Plan, r.countRepos(ctx))
+		if err != nil {
+			return nil, nil, err
+		}
+		budget.Charge(a.UID, downgraded)
+	case cost.ActionReject:
+		return &decision, alertForCostBudget(decision), nil
+	}
+	return &decision, nil, nil
+}
+
+// countRepos returns a cost.RepoCounter which counts the repositories a basic
+// query searches. Predicates are ignored, since they can only narrow down the
+// repositories.
+func (r *searchResolver) countRepos(ctx context.Context) cost.RepoCounter {
+	return func(b query.Basic) (int, error) {
+		parameters := make([]query.Parameter, 0, len(b.Parameters))
+		for _, p := range b.Parameters {
+			if p.Annotation.Labels.IsSet(query.IsPredicate) {
+				continue
+			}
+			parameters = append(parameters, p)
+		}
+		q := b.MapParameters(parameters).ToParseTree()
+		resolver := searchrepos.Resolver{DB: r.db}
+		return resolver.Count(ctx, r.toRepoOptions(q, resolveRepositoriesOpts{}))
+	}
+}
+
+func alertForCostBudget(decision cost.Decision) *searchAlert {
+	return &searchAlert{
+		prometheusType: "cost_budget_exceeded",
+		title:          "Search cost budget exceeded",
+		description:    fmt.Sprintf("This query has an estimated cost of %d, but you only have %d left of your search budget of %d per minute. Try again in a minute or reduce the scope of your query, for example with `repo:`.", decision.Cost, decision.Remaining, decision.PerMinute),
+	}
+}
@@ -0,0 +1,48 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+)
+
+type SearchExportsResolver interface {
+	// Query
+	SearchExports(ctx context.Context, args *ListSearchExportsArgs) (SearchExportConnectionResolver, error)
+
+	// Mutations
+	CreateSearchExport(ctx context.Context, args *CreateSearchExportArgs) (SearchExportResolver, error)
+
+	NodeResolvers() map[string]NodeByIDFunc
+}
+
+type SearchExportConnectionResolver interface {
+	Nodes(ctx context.Context) ([]SearchExportResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type SearchExportResolver interface {
+	ID() graphql.ID
+	Query() string
+	Format() string
+	State() string
+	ResultCount() int32
+	FailureMessage() *string
+	CreatedAt() DateTime
+	StartedAt() *DateTime
+	FinishedAt() *DateTime
+	DownloadURL() *string
+}
+
+type ListSearchExportsArgs struct {
+	First int32
+	After *string
+}
+
+type CreateSearchExportArgs struct {
+	Query  string
+	Format string
+}
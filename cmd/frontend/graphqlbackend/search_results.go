@@ -33,8 +33,10 @@ import (
 	searchhoney "github.com/sourcegraph/sourcegraph/internal/honey/search"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/matchdiff"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/repos"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
@@ -191,6 +193,10 @@ func matchesToResolvers(db database.DB, matches []result.Match) []SearchResultRe
 				db:          db,
 				CommitMatch: *v,
 			})
+		case *result.OwnerMatch:
+			// Owner matches are only sent on the stream: resultsBatch
+			// rejects select:file.owners, so there is no resolver for them.
+			panic("unreachable: owner matches are not returned by batch searches")
 		}
 	}
 	return resolvers
@@ -331,8 +337,8 @@ loop:
 	for _, r := range sr.Matches {
 		r := r // shadow so it doesn't change in the goroutine
 		switch m := r.(type) {
-		case *result.RepoMatch:
-			// We don't care about repo results here.
+		case *result.RepoMatch, *result.OwnerMatch:
+			// We don't care about repo or owner results here.
 			continue
 		case *result.CommitMatch:
 			// Diff searches are cheap, because we implicitly have author date info.
@@ -396,7 +402,7 @@ func LogSearchLatency(ctx context.Context, db database.DB, si *run.SearchInputs,
 	resultTypes, _ := si.Query.StringValues(query.FieldType)
 	for _, typ := range resultTypes {
 		switch typ {
-		case "repo", "symbol", "diff", "commit":
+		case "repo", "symbol", "diff", "commit", "matchdiff":
 			types = append(types, typ)
 		case "path":
 			// Map type:path to file
@@ -405,6 +411,8 @@ func LogSearchLatency(ctx context.Context, db database.DB, si *run.SearchInputs,
 			switch {
 			case si.PatternType == query.SearchTypeStructural:
 				types = append(types, "structural")
+			case si.PatternType == query.SearchTypeFuzzy:
+				types = append(types, "fuzzy")
 			case si.PatternType == query.SearchTypeLiteral:
 				types = append(types, "literal")
 			case si.PatternType == query.SearchTypeRegex:
@@ -439,13 +447,16 @@ func LogSearchLatency(ctx context.Context, db database.DB, si *run.SearchInputs,
 			types = append(types, "regexp")
 		} else if q.IsStructural() {
 			types = append(types, "structural")
+		} else if q.IsFuzzy() {
+			types = append(types, "fuzzy")
 		} else if len(si.Query.Fields()["file"]) > 0 {
 			// No search pattern specified and file: is specified.
 			types = append(types, "file")
 		} else {
 			// No search pattern or file: is specified, assume repo.
 			// This includes accounting for searches of fields that
-			// specify repohasfile: and repohascommitafter:.
+			// specify repohasfile:, repohascommitafter:, repohastopic:
+			// and repohasdescription:.
 			types = append(types, "repo")
 		}
 	}
@@ -507,6 +518,8 @@ func (r *searchResolver) toRepoOptions(q query.Q, opts resolveRepositoriesOpts)
 	visibility := query.ParseVisibility(visibilityStr)
 
 	commitAfter, _ := q.StringValue(query.FieldRepoHasCommitAfter)
+	hasTopics, _ := q.StringValues(query.FieldRepoHasTopic)
+	hasDescriptionPatterns, _ := q.StringValues(query.FieldRepoHasDescription)
 	searchContextSpec, _ := q.StringValue(query.FieldContext)
 
 	var CacheLookup bool
@@ -516,25 +529,27 @@ func (r *searchResolver) toRepoOptions(q query.Q, opts resolveRepositoriesOpts)
 	}
 
 	return search.RepoOptions{
-		RepoFilters:       repoFilters,
-		MinusRepoFilters:  minusRepoFilters,
-		SearchContextSpec: searchContextSpec,
-		UserSettings:      r.UserSettings,
-		OnlyForks:         fork == query.Only,
-		NoForks:           fork == query.No,
-		OnlyArchived:      archived == query.Only,
-		NoArchived:        archived == query.No,
-		Visibility:        visibility,
-		CommitAfter:       commitAfter,
-		Query:             q,
-		Limit:             opts.limit,
-		CacheLookup:       CacheLookup,
+		RepoFilters:            repoFilters,
+		MinusRepoFilters:       minusRepoFilters,
+		SearchContextSpec:      searchContextSpec,
+		UserSettings:           r.UserSettings,
+		OnlyForks:              fork == query.Only,
+		NoForks:                fork == query.No,
+		OnlyArchived:           archived == query.Only,
+		NoArchived:             archived == query.No,
+		Visibility:             visibility,
+		CommitAfter:            commitAfter,
+		HasTopics:              hasTopics,
+		HasDescriptionPatterns: hasDescriptionPatterns,
+		Query:                  q,
+		Limit:                  opts.limit,
+		CacheLookup:            CacheLookup,
 	}
 }
 
 func withMode(args search.TextParameters, st query.SearchType) search.TextParameters {
 	isGlobalSearch := func() bool {
-		if st == query.SearchTypeStructural {
+		if st == query.SearchTypeStructural || st == query.SearchTypeFuzzy {
 			return false
 		}
 
@@ -560,7 +575,7 @@ func withMode(args search.TextParameters, st query.SearchType) search.TextParame
 
 	hasGlobalSearchResultType := args.ResultTypes.Has(result.TypeFile | result.TypePath | result.TypeSymbol)
 	isIndexedSearch := args.PatternInfo.Index != query.No
-	isEmpty := args.PatternInfo.Pattern == "" && args.PatternInfo.ExcludePattern == "" && len(args.PatternInfo.IncludePatterns) == 0
+	isEmpty := args.PatternInfo.Pattern == "" && args.PatternInfo.ExcludePattern == "" && len(args.PatternInfo.IncludePatterns) == 0 && len(args.PatternInfo.Languages) == 0 && len(args.PatternInfo.ExcludeLanguages) == 0
 	if isGlobalSearch() && isIndexedSearch && hasGlobalSearchResultType && !isEmpty {
 		args.Mode = search.ZoektGlobalSearch
 	}
@@ -609,14 +624,25 @@ func (r *searchResolver) toSearchInputs(q query.Q) (*search.TextParameters, []ru
 			forceResultTypes = result.TypeStructural
 		}
 	}
+	if r.PatternType == query.SearchTypeFuzzy {
+		if p.Pattern == "" {
+			// Like for structural search, fall back to literal search
+			// for searching repos and files if the pattern is empty.
+			r.PatternType = query.SearchTypeLiteral
+			p.IsFuzzy = false
+			forceResultTypes = result.Types(0)
+		} else {
+			forceResultTypes = result.TypeFuzzy
+		}
+	}
 
 	args := search.TextParameters{
 		PatternInfo: p,
 		Query:       q,
-		Timeout:     search.TimeoutDuration(b),
+		Timeout:     r.searchTimeout(b),
 
 		// UseFullDeadline if timeout: set or we are streaming.
-		UseFullDeadline: q.Timeout() != nil || q.Count() != nil || r.stream != nil,
+		UseFullDeadline: q.Timeout() != nil || q.Count() != nil || r.stream != nil || r.timeout > 0,
 
 		Zoekt:        r.zoekt,
 		SearcherURLs: r.searcherURLs,
@@ -728,6 +754,15 @@ func (r *searchResolver) toSearchInputs(q query.Q) (*search.TextParameters, []ru
 			})
 		}
 
+		if args.ResultTypes.Has(result.TypeMatchDiff) {
+			jobs = append(jobs, &matchdiff.MatchDiffSearch{
+				PatternInfo:     args.PatternInfo,
+				RepoOpts:        r.toRepoOptions(args.Query, resolveRepositoriesOpts{}),
+				SearcherURLs:    args.SearcherURLs,
+				UseFullDeadline: args.UseFullDeadline,
+			})
+		}
+
 		if r.PatternType == query.SearchTypeStructural && p.Pattern != "" {
 			typ := search.TextRequest
 			zoektQuery, err := search.QueryToZoektQuery(args.PatternInfo, typ)
@@ -758,6 +793,33 @@ func (r *searchResolver) toSearchInputs(q query.Q) (*search.TextParameters, []ru
 				OnMissingRepoRevs: zoektutil.MissingRepoRevStatus(r.stream),
 			})
 		}
+
+		if r.PatternType == query.SearchTypeFuzzy && p.Pattern != "" {
+			// Searcher asks Zoekt for candidate files itself, so the
+			// Zoekt arguments are only used to partition repositories.
+			zoektArgs := &search.ZoektParameters{
+				Typ:            search.TextRequest,
+				FileMatchLimit: args.PatternInfo.FileMatchLimit,
+				Select:         args.PatternInfo.Select,
+				Zoekt:          args.Zoekt,
+			}
+
+			searcherArgs := &search.SearcherParameters{
+				SearcherURLs:    args.SearcherURLs,
+				PatternInfo:     args.PatternInfo,
+				UseFullDeadline: args.UseFullDeadline,
+			}
+
+			jobs = append(jobs, &unindexed.FuzzySearch{
+				ZoektArgs:    zoektArgs,
+				SearcherArgs: searcherArgs,
+
+				NotSearcherOnly:   !searcherOnly,
+				UseIndex:          args.PatternInfo.Index,
+				ContainsRefGlobs:  query.ContainsRefGlobs(q),
+				OnMissingRepoRevs: zoektutil.MissingRepoRevStatus(r.stream),
+			})
+		}
 	}
 	return &args, jobs, nil
 }
@@ -836,7 +898,7 @@ func (r *searchResolver) evaluateAnd(ctx context.Context, q query.Basic) (*Searc
 	maxTryCount := 40000
 
 	// Set an overall timeout in addition to the timeouts that are set for leaf-requests.
-	ctx, cancel := context.WithTimeout(ctx, search.TimeoutDuration(q))
+	ctx, cancel := context.WithTimeout(ctx, r.searchTimeout(q))
 	defer cancel()
 
 	if count := q.GetCount(); count != "" {
@@ -1104,6 +1166,13 @@ func (r *searchResolver) resultsStreaming(ctx context.Context) (*SearchResultsRe
 	if sp, _ := r.Plan.ToParseTree().StringValue(query.FieldSelect); sp != "" {
 		// Ensure downstream events sent on the stream are processed by `select:`.
 		selectPath, _ := filter.SelectPathFromString(sp) // Invariant: error already checked
+		if selectsOwners(r.Plan) {
+			// Owners are resolved from the CODEOWNERS file of each
+			// repository after file matches are selected.
+			var done func()
+			r.stream, done = streaming.WithOwners(ctx, r.stream, codeowners.NewResolver().Owners)
+			defer done()
+		}
 		r.stream = streaming.WithSelect(r.stream, selectPath)
 	}
 	sr, err := r.resultsRecursive(ctx, r.Plan)
@@ -1111,6 +1180,20 @@ func (r *searchResolver) resultsStreaming(ctx context.Context) (*SearchResultsRe
 	return srr, err
 }
 
+// selectsOwners reports whether plan selects the code owners of file matches
+// (select:file.owners).
+func selectsOwners(plan query.Plan) bool {
+	sp, _ := plan.ToParseTree().StringValue(query.FieldSelect)
+	if sp == "" {
+		return false
+	}
+	selectPath, err := filter.SelectPathFromString(sp)
+	if err != nil {
+		return false
+	}
+	return len(selectPath) > 1 && selectPath.Root() == filter.File && selectPath[1] == "owners"
+}
+
 func (r *searchResolver) resultsToResolver(results *SearchResults) *SearchResultsResolver {
 	if results == nil {
 		results = &SearchResults{}
@@ -1124,10 +1207,34 @@ func (r *searchResolver) resultsToResolver(results *SearchResults) *SearchResult
 }
 
 func (r *searchResolver) Results(ctx context.Context) (*SearchResultsResolver, error) {
+	decision, alert, err := r.admitSearch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if alert != nil {
+		srr := r.resultsToResolver(alert.wrapResults())
+		srr.Stats.CostBudget = decision
+		return srr, nil
+	}
+	if decision != nil && r.stream != nil {
+		// Let the client know early that the query was queued or
+		// downgraded.
+		r.stream.Send(streaming.SearchEvent{Stats: streaming.Stats{CostBudget: decision}})
+	}
+
+	var srr *SearchResultsResolver
 	if r.stream == nil {
-		return r.resultsBatch(ctx)
+		if selectsOwners(r.Plan) {
+			return nil, errors.New("select:file.owners is only supported by the streaming search API")
+		}
+		srr, err = r.resultsBatch(ctx)
+	} else {
+		srr, err = r.resultsStreaming(ctx)
+	}
+	if srr != nil && decision != nil {
+		srr.Stats.CostBudget = decision
 	}
-	return r.resultsStreaming(ctx)
+	return srr, err
 }
 
 // DetermineStatusForLogs determines the final status of a search for logging
@@ -1527,7 +1634,7 @@ func withResultTypes(args search.TextParameters, forceTypes result.Types) search
 		}
 	}
 
-	if rts.Has(result.TypeFile) {
+	if rts.Has(result.TypeFile | result.TypeMatchDiff) {
 		args.PatternInfo.PatternMatchesContent = true
 	}
 
@@ -1723,6 +1830,10 @@ func (r *searchResolver) doResults(ctx context.Context, args *search.TextParamet
 			return waitGroup(true)
 		case "Structural":
 			return waitGroup(true)
+		case "Fuzzy":
+			return waitGroup(true)
+		case "MatchDiff":
+			return waitGroup(true)
 		default:
 			panic("unknown job name " + job.Name())
 		}
@@ -1856,6 +1967,10 @@ func compareSearchResults(left, right result.Match, exactFilePatterns map[string
 			return string(r.Name), "", nil
 		case *result.FileMatch:
 			return string(r.Repo.Name), r.Path, nil
+		case *result.MatchDiffMatch:
+			return string(r.Repo.Name), r.Path, nil
+		case *result.OwnerMatch:
+			return string(r.Repo.Name), r.Owner, nil
 		case *result.CommitMatch:
 			// Commits are relatively sorted by date, and after repo
 			// or path names. We use ~ as the key for repo and
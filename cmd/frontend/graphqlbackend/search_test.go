@@ -277,6 +277,7 @@ func testStringResult(result SearchSuggestionResolver) string {
 func TestDetectSearchType(t *testing.T) {
 	typeRegexp := "regexp"
 	typeLiteral := "literal"
+	typeFuzzy := "fuzzy"
 	testCases := []struct {
 		name        string
 		version     string
@@ -296,6 +297,8 @@ func TestDetectSearchType(t *testing.T) {
 		{"V2, override regex variant pattern type with single quotes", "V2", &typeLiteral, `patterntype:'regex'`, query.SearchTypeRegex},
 		{"V1, override literal pattern type", "V1", &typeRegexp, "patterntype:literal", query.SearchTypeLiteral},
 		{"V1, override literal pattern type, with case-insensitive query", "V1", &typeRegexp, "pAtTErNTypE:literal", query.SearchTypeLiteral},
+		{"V2, fuzzy pattern type", "V2", &typeFuzzy, "", query.SearchTypeFuzzy},
+		{"V2, override fuzzy pattern type", "V2", &typeLiteral, "patterntype:fuzzy", query.SearchTypeFuzzy},
 	}
 
 	for _, test := range testCases {
@@ -6,6 +6,7 @@ import (
 
 	sgapi "github.com/sourcegraph/sourcegraph/internal/api"
 	searchshared "github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/cost"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 )
@@ -67,6 +68,19 @@ func (p *progressAggregator) currentStats() api.ProgressStats {
 		SuggestedLimit:      suggestedLimit,
 		Trace:               p.Trace,
 		DisplayLimit:        p.DisplayLimit,
+		CostBudget:          costBudget(p.Stats.CostBudget),
+	}
+}
+
+func costBudget(decision *cost.Decision) *api.CostBudget {
+	if decision == nil || decision.Action == cost.ActionAdmit {
+		return nil
+	}
+	return &api.CostBudget{
+		Action:            string(decision.Action),
+		Cost:              int(decision.Cost),
+		Remaining:         int(decision.Remaining),
+		DelayMilliseconds: int(decision.Delay.Milliseconds()),
 	}
 }
 
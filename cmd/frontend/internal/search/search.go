@@ -27,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	searchhoney "github.com/sourcegraph/sourcegraph/internal/honey/search"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/search/cost"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/run"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -45,11 +46,34 @@ func StreamHandler(db database.DB) http.Handler {
 	}
 }
 
+// InternalStreamHandler is like StreamHandler, but also accepts a timeout
+// parameter which overrides the timeout of interactive searches, and does not
+// charge searches to the search cost budget of the user they are run for. It
+// must only be served by the internal API, for background jobs such as search
+// exports.
+func InternalStreamHandler(db database.DB) http.Handler {
+	return &streamHandler{
+		db:                  db,
+		newSearchResolver:   defaultNewSearchResolver,
+		flushTickerInternal: 100 * time.Millisecond,
+		pingTickerInterval:  5 * time.Second,
+		allowTimeout:        true,
+		exemptFromCost:      true,
+	}
+}
+
 type streamHandler struct {
 	db                  database.DB
 	newSearchResolver   func(context.Context, database.DB, *graphqlbackend.SearchArgs) (searchResolver, error)
 	flushTickerInternal time.Duration
 	pingTickerInterval  time.Duration
+
+	// allowTimeout is true if requests may override the search timeout.
+	allowTimeout bool
+
+	// exemptFromCost is true if searches are not charged to the search cost
+	// budget of the user.
+	exemptFromCost bool
 }
 
 func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -61,6 +85,13 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if args.Timeout != 0 && !h.allowTimeout {
+		http.Error(w, "the timeout parameter is not supported", http.StatusBadRequest)
+		return
+	}
+	if h.exemptFromCost {
+		ctx = cost.WithExempt(ctx)
+	}
 
 	tr, ctx := trace.New(ctx, "search.ServeStream", args.Query,
 		trace.Tag{Key: "version", Value: args.Version},
@@ -289,6 +320,7 @@ func (h *streamHandler) startSearch(ctx context.Context, a *args) (events <-chan
 		Query:       a.Query,
 		Version:     a.Version,
 		PatternType: strPtr(a.PatternType),
+		Timeout:     a.Timeout,
 
 		Stream: streaming.StreamFunc(func(event streaming.SearchEvent) {
 			eventsC <- event
@@ -341,6 +373,10 @@ type args struct {
 	DecorationLimit        int    // The initial number of files to decorate in the result set.
 	DecorationKind         string // The kind of decoration to apply (HTML highlighting, plaintext, etc.)
 	DecorationContextLines int    // The number of lines of context to include around lines with matches.
+
+	// Timeout if non-zero overrides the timeout of interactive searches, see
+	// InternalStreamHandler.
+	Timeout time.Duration
 }
 
 func parseURLQuery(q url.Values) (*args, error) {
@@ -379,6 +415,12 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.Errorf("decorationContextLines must be an integer, got %q: %w", decorationContextLines, err)
 	}
 
+	if timeout := get("timeout", ""); timeout != "" {
+		if a.Timeout, err = time.ParseDuration(timeout); err != nil || a.Timeout <= 0 {
+			return nil, errors.Errorf("timeout must be a positive duration, got %q", timeout)
+		}
+	}
+
 	return &a, nil
 }
 
@@ -423,6 +465,10 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo)
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v, repoCache)
+	case *result.MatchDiffMatch:
+		return fromMatchDiff(v, repoCache)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -570,6 +616,9 @@ func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.Sear
 		Content:    content,
 		Ranges:     ranges,
 	}
+	for _, r := range commit.Renames {
+		commitEvent.Renames = append(commitEvent.Renames, streamhttp.EventFileRename{OldPath: r.OldPath, NewPath: r.NewPath})
+	}
 
 	if r, ok := repoCache[commit.Repo.ID]; ok {
 		commitEvent.RepoStars = r.Stars
@@ -579,6 +628,56 @@ func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.Sear
 	return commitEvent
 }
 
+func fromOwner(om *result.OwnerMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventOwnerMatch {
+	ownerEvent := &streamhttp.EventOwnerMatch{
+		Type:         streamhttp.OwnerMatchType,
+		Owner:        om.Owner,
+		Repository:   string(om.Repo.Name),
+		RepositoryID: int32(om.Repo.ID),
+		Commit:       string(om.CommitID),
+	}
+
+	if r, ok := repoCache[om.Repo.ID]; ok {
+		ownerEvent.RepoStars = r.Stars
+		ownerEvent.RepoLastFetched = r.LastFetched
+	}
+
+	return ownerEvent
+}
+
+func fromMatchDiff(md *result.MatchDiffMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventMatchDiffMatch {
+	lineMatches := make([]streamhttp.EventMatchDiffLine, 0, len(md.Lines))
+	for _, l := range md.Lines {
+		lineMatches = append(lineMatches, streamhttp.EventMatchDiffLine{
+			Status:           string(l.Status),
+			Line:             l.Preview,
+			LineNumber:       l.LineNumber,
+			OffsetAndLengths: l.OffsetAndLengths,
+			OldPath:          l.OldPath,
+			OldLineNumber:    l.OldLineNumber,
+		})
+	}
+
+	matchDiffEvent := &streamhttp.EventMatchDiffMatch{
+		Type:         streamhttp.MatchDiffMatchType,
+		Path:         md.Path,
+		RepositoryID: int32(md.Repo.ID),
+		Repository:   string(md.Repo.Name),
+		Base:         md.Base,
+		Head:         md.Head,
+		BaseCommit:   string(md.BaseCommit),
+		HeadCommit:   string(md.HeadCommit),
+		LineMatches:  lineMatches,
+	}
+
+	if r, ok := repoCache[md.Repo.ID]; ok {
+		matchDiffEvent.RepoStars = r.Stars
+		matchDiffEvent.RepoLastFetched = r.LastFetched
+	}
+
+	return matchDiffEvent
+}
+
 // eventStreamOTHook returns a StatHook which logs to log.
 func eventStreamOTHook(log func(...otlog.Field)) func(streamhttp.WriterStat) {
 	return func(stat streamhttp.WriterStat) {
@@ -57,6 +57,40 @@ func TestServeStream_empty(t *testing.T) {
 	}
 }
 
+func TestServeStream_timeout(t *testing.T) {
+	for _, allowTimeout := range []bool{false, true} {
+		mock := &mockSearchResolver{
+			done: make(chan struct{}),
+		}
+		mock.Close()
+
+		var gotTimeout time.Duration
+		ts := httptest.NewServer(&streamHandler{
+			flushTickerInternal: 1 * time.Millisecond,
+			pingTickerInterval:  1 * time.Millisecond,
+			allowTimeout:        allowTimeout,
+			newSearchResolver: func(_ context.Context, _ database.DB, args *graphqlbackend.SearchArgs) (searchResolver, error) {
+				gotTimeout = args.Timeout
+				return mock, nil
+			}})
+
+		res, err := http.Get(ts.URL + "?q=test&timeout=1h")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		ts.Close()
+
+		if allowTimeout {
+			if res.StatusCode != 200 || gotTimeout != time.Hour {
+				t.Errorf("expected status 200 and timeout 1h, got %d and %s", res.StatusCode, gotTimeout)
+			}
+		} else if res.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", res.StatusCode)
+		}
+	}
+}
+
 // Ensures graphqlbackend matches the interface we expect
 func TestDefaultNewSearchResolver(t *testing.T) {
 	_, err := defaultNewSearchResolver(context.Background(), dbmock.NewMockDB(), &graphqlbackend.SearchArgs{
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -52,6 +53,13 @@ var (
 	syncRepoStateInterval        = env.MustGetDuration("SRC_REPOS_SYNC_STATE_INTERVAL", 10*time.Minute, "Interval between state syncs")
 	syncRepoStateBatchSize       = env.MustGetInt("SRC_REPOS_SYNC_STATE_BATCH_SIZE", 500, "Number of upserts to perform per batch")
 	syncRepoStateUpsertPerSecond = env.MustGetInt("SRC_REPOS_SYNC_STATE_UPSERT_PER_SEC", 500, "The number of upserted rows allowed per second across all gitserver instances")
+	reconcileReplicasInterval    = env.MustGetDuration("SRC_REPOS_RECONCILE_REPLICAS_INTERVAL", 10*time.Minute, "Interval between checks for missing repo replicas")
+	reconcileReplicasMaxClones   = env.MustGetInt("SRC_REPOS_RECONCILE_REPLICAS_MAX_CLONES", 100, "The maximum number of missing repo replicas cloned per check")
+	rebalanceEnabled, _          = strconv.ParseBool(env.Get("SRC_REPOS_REBALANCE", "false", "Transfer repos which move to another gitserver when the gitserver addresses change from their previous gitserver instead of recloning them"))
+	rebalanceInterval            = env.MustGetDuration("SRC_REPOS_REBALANCE_INTERVAL", 1*time.Minute, "Interval between checks for repos to transfer from other gitservers")
+	rebalancePerMinute           = env.MustGetInt("SRC_REPOS_REBALANCE_PER_MINUTE", 60, "The maximum number of repos transferred from other gitservers per minute")
+	maintenanceInterval          = env.MustGetDuration("SRC_REPOS_MAINTENANCE_INTERVAL", 5*time.Minute, "Interval between repo maintenance runs, which write commit-graphs, multi-pack-indexes and bitmaps")
+	maintenanceMaxRepos          = env.MustGetInt("SRC_REPOS_MAINTENANCE_MAX_REPOS", 50, "The maximum number of repos maintained per maintenance run")
 )
 
 func main() {
@@ -125,6 +133,7 @@ func main() {
 			return getVCSSyncer(ctx, externalServiceStore, repoStore, codeintelDB, repo)
 		},
 		Hostname:   hostname.Get(),
+		Rebalance:  rebalanceEnabled,
 		DB:         db,
 		CloneQueue: server.NewCloneQueue(list.New()),
 	}
@@ -149,6 +158,9 @@ func main() {
 	go debugserver.NewServerRoutine(ready).Start()
 	go gitserver.Janitor(janitorInterval)
 	go gitserver.SyncRepoState(syncRepoStateInterval, syncRepoStateBatchSize, syncRepoStateUpsertPerSecond)
+	go gitserver.ReconcileReplicas(reconcileReplicasInterval, reconcileReplicasMaxClones)
+	go gitserver.RebalanceRepos(rebalanceInterval, rebalancePerMinute)
+	go gitserver.MaintainRepos(maintenanceInterval, maintenanceMaxRepos)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -200,6 +212,25 @@ func main() {
 	gitserver.Stop()
 }
 
+// svnLayout returns the layout of a Subversion repository. Missing parts of
+// the configured layout default to the standard layout.
+func svnLayout(c *schema.SVNLayout) server.SVNLayout {
+	layout := server.StandardSVNLayout
+	if c == nil {
+		return layout
+	}
+	if c.Trunk != "" {
+		layout.Trunk = c.Trunk
+	}
+	if c.Branches != nil {
+		layout.Branches = c.Branches
+	}
+	if c.Tags != nil {
+		layout.Tags = c.Tags
+	}
+	return layout
+}
+
 func configureFusionClient(conn schema.PerforceConnection) server.FusionConfig {
 	// Set up default settings first
 	fc := server.FusionConfig{
@@ -326,6 +357,17 @@ func getVCSSyncer(ctx context.Context, externalServiceStore database.ExternalSer
 			Client:       c.P4Client,
 			FusionConfig: configureFusionClient(c),
 		}, nil
+	case extsvc.TypeMercurial:
+		return &server.HgRepoSyncer{}, nil
+	case extsvc.TypeSVN:
+		var c schema.SVNConnection
+		if err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+		return &server.SVNSyncer{
+			Layout:  svnLayout(c.Layout),
+			Authors: c.Authors,
+		}, nil
 	case extsvc.TypeJVMPackages:
 		var c schema.JVMPackagesConnection
 		if err := extractOptions(c); err != nil {
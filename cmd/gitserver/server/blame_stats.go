@@ -0,0 +1,404 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+const (
+	// blameStatsCacheDir is the directory in the git dir of a repository
+	// which caches blame statistics by tree hash.
+	blameStatsCacheDir = "sg_blamestats"
+
+	// blameStatsCacheSize is the number of blame statistics cached per
+	// repository. The least recently used ones are removed first.
+	blameStatsCacheSize = 20
+
+	// blameStatsConcurrency is the number of git blame commands which run
+	// concurrently for one request.
+	blameStatsConcurrency = 8
+)
+
+var blameStatsCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_blame_stats_cache_requests_total",
+	Help: "Number of blame statistics requests by whether they were served from the cache.",
+}, []string{"hit"})
+
+// handleBlameStats returns the number of lines each author changed last and
+// when they last changed them below a directory at a commit. Computing them
+// runs git blame on every file, so they are cached on disk by the hash of
+// the directory tree.
+func (s *Server) handleBlameStats(w http.ResponseWriter, r *http.Request) {
+	var req protocol.BlameStatsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "decoding body", http.StatusBadRequest)
+		log15.Error("handleBlameStats: decoding body", "error", err)
+		return
+	}
+	if err := checkSpecArgSafety(string(req.Commit)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dir := s.dir(protocol.NormalizeRepo(req.Repo))
+	if !repoCloned(dir) {
+		http.Error(w, "repository not found", http.StatusNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), conf.GitLongCommandTimeout())
+	defer cancel()
+
+	// Blame reads the blobs which are missing in partial clones.
+	var env []string
+	if isPartialClone(dir) {
+		var err error
+		if env, err = s.partialCloneEnv(ctx, req.Repo); err != nil {
+			http.Error(w, "getting remote URL", http.StatusInternalServerError)
+			log15.Error("handleBlameStats: getting remote URL", "repo", req.Repo, "error", err)
+			return
+		}
+	}
+
+	stats, err := blameStats(ctx, dir, env, req.Commit, req.Path)
+	if err != nil {
+		http.Error(w, "computing blame statistics", http.StatusInternalServerError)
+		log15.Error("handleBlameStats: computing blame statistics", "repo", req.Repo, "commit", req.Commit, "path", req.Path, "error", err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		log15.Error("handleBlameStats: sending response", "error", err)
+	}
+}
+
+// blameStats returns the blame statistics of the directory path at commit in
+// the repository at dir, from the cache if possible.
+func blameStats(ctx context.Context, dir GitDir, env []string, commit api.CommitID, dirPath string) (*protocol.BlameStatsResponse, error) {
+	dirPath = strings.Trim(path.Clean("/"+dirPath), "/")
+
+	treeHash, err := blameStatsTreeHash(ctx, dir, commit, dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if stats, err := readBlameStatsCache(dir, treeHash); err != nil {
+		log15.Warn("Reading cached blame statistics", "dir", dir, "tree", treeHash, "error", err)
+	} else if stats != nil {
+		blameStatsCacheRequests.WithLabelValues("true").Inc()
+		return stats, nil
+	}
+	blameStatsCacheRequests.WithLabelValues("false").Inc()
+
+	files, err := blameStatsFiles(ctx, dir, commit, dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	authors := make(map[authorKey]*protocol.AuthorBlameStats)
+
+	g, ctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, blameStatsConcurrency)
+	for _, file := range files {
+		file := file
+		g.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			defer func() { <-sem }()
+
+			fileAuthors, err := blameFileAuthors(ctx, dir, env, commit, file)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, a := range fileAuthors {
+				mergeAuthorBlameStats(authors, a)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	stats := &protocol.BlameStatsResponse{
+		TreeHash: treeHash,
+		Files:    len(files),
+		Authors:  make([]protocol.AuthorBlameStats, 0, len(authors)),
+	}
+	for _, a := range authors {
+		stats.Authors = append(stats.Authors, *a)
+	}
+	sort.Slice(stats.Authors, func(i, j int) bool {
+		ai, aj := stats.Authors[i], stats.Authors[j]
+		if ai.Lines != aj.Lines {
+			return ai.Lines > aj.Lines
+		}
+		if ai.Name != aj.Name {
+			return ai.Name < aj.Name
+		}
+		return ai.Email < aj.Email
+	})
+
+	if err := writeBlameStatsCache(dir, stats); err != nil {
+		log15.Warn("Caching blame statistics", "dir", dir, "tree", treeHash, "error", err)
+	}
+	return stats, nil
+}
+
+// blameStatsTreeHash returns the hash of the tree of the directory dirPath at
+// commit.
+func blameStatsTreeHash(ctx context.Context, dir GitDir, commit api.CommitID, dirPath string) (string, error) {
+	if dirPath == "" {
+		cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", string(commit)+"^{tree}")
+		dir.Set(cmd)
+		out, err := cmd.Output()
+		if err != nil {
+			return "", errors.Wrap(wrapCmdError(cmd, err), "resolving root tree")
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	// Without -r, ls-tree lists the entry of the directory itself.
+	cmd := exec.CommandContext(ctx, "git", "ls-tree", "-z", "--full-tree", string(commit), "--", dirPath)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(wrapCmdError(cmd, err), "resolving tree of %q", dirPath)
+	}
+	// Example: 040000 tree d00491fd7e5bb6fa28c517a0bb32b8b506539d4d	src
+	fields := strings.Fields(string(bytes.TrimRight(out, "\x00")))
+	if len(fields) < 3 || fields[1] != "tree" {
+		return "", errors.Errorf("%q is not a directory at %s", dirPath, commit)
+	}
+	return fields[2], nil
+}
+
+// blameStatsFiles returns the paths of the files below dirPath at commit
+// which git blame can read. Symlinks and submodules are left out.
+func blameStatsFiles(ctx context.Context, dir GitDir, commit api.CommitID, dirPath string) ([]string, error) {
+	args := []string{"ls-tree", "-r", "-z", "--full-tree", string(commit)}
+	if dirPath != "" {
+		args = append(args, "--", dirPath)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "listing files")
+	}
+
+	var files []string
+	for _, entry := range bytes.Split(out, []byte{0}) {
+		// Example: 100644 blob d00491fd7e5bb6fa28c517a0bb32b8b506539d4d	README.md
+		tab := bytes.IndexByte(entry, '\t')
+		if tab < 0 {
+			continue
+		}
+		fields := strings.Fields(string(entry[:tab]))
+		if len(fields) != 3 || fields[1] != "blob" || fields[0] == "120000" {
+			continue
+		}
+		files = append(files, string(entry[tab+1:]))
+	}
+	return files, nil
+}
+
+// blameFileAuthors runs git blame on the file at commit and returns the
+// number of lines each author changed last.
+func blameFileAuthors(ctx context.Context, dir GitDir, env []string, commit api.CommitID, file string) ([]protocol.AuthorBlameStats, error) {
+	cmd := exec.CommandContext(ctx, "git", "blame", "-w", "--incremental", string(commit), "--", file)
+	dir.Set(cmd)
+	cmd.Env = env
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(wrapCmdError(cmd, err), "blaming %q", file)
+	}
+	return parseIncrementalBlame(out)
+}
+
+// parseIncrementalBlame aggregates the output of git blame --incremental by
+// author. The output consists of groups of lines which were last changed by
+// the same commit. Each group starts with a line of the form "<commit>
+// <original line> <final line> <number of lines>", is followed by headers
+// and ends with a "filename" header. The headers describing a commit, like
+// "author", are only output in the first group of the commit.
+func parseIncrementalBlame(out []byte) ([]protocol.AuthorBlameStats, error) {
+	commits := make(map[string]*protocol.AuthorBlameStats)
+
+	var cur *protocol.AuthorBlameStats
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(make([]byte, 1024), 1<<20)
+	for sc.Scan() {
+		line := sc.Text()
+		if cur == nil {
+			fields := strings.Fields(line)
+			if len(fields) != 4 {
+				return nil, errors.Errorf("unexpected git blame group header %q", line)
+			}
+			n, err := strconv.Atoi(fields[3])
+			if err != nil {
+				return nil, errors.Errorf("unexpected git blame group header %q", line)
+			}
+			if cur = commits[fields[0]]; cur == nil {
+				cur = &protocol.AuthorBlameStats{}
+				commits[fields[0]] = cur
+			}
+			cur.Lines += n
+			continue
+		}
+
+		header := strings.SplitN(line, " ", 2)
+		if len(header) != 2 {
+			continue
+		}
+		switch header[0] {
+		case "author":
+			cur.Name = header[1]
+		case "author-mail":
+			cur.Email = strings.TrimSuffix(strings.TrimPrefix(header[1], "<"), ">")
+		case "author-time":
+			sec, err := strconv.ParseInt(header[1], 10, 64)
+			if err != nil {
+				return nil, errors.Errorf("unexpected git blame author-time %q", header[1])
+			}
+			cur.LastTouched = time.Unix(sec, 0).UTC()
+		case "filename":
+			cur = nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	authors := make(map[authorKey]*protocol.AuthorBlameStats)
+	for _, c := range commits {
+		mergeAuthorBlameStats(authors, *c)
+	}
+	res := make([]protocol.AuthorBlameStats, 0, len(authors))
+	for _, a := range authors {
+		res = append(res, *a)
+	}
+	return res, nil
+}
+
+type authorKey struct{ name, email string }
+
+// mergeAuthorBlameStats adds the lines of a to the statistics of the same
+// author in authors.
+func mergeAuthorBlameStats(authors map[authorKey]*protocol.AuthorBlameStats, a protocol.AuthorBlameStats) {
+	key := authorKey{a.Name, a.Email}
+	prev, ok := authors[key]
+	if !ok {
+		authors[key] = &a
+		return
+	}
+	prev.Lines += a.Lines
+	if a.LastTouched.After(prev.LastTouched) {
+		prev.LastTouched = a.LastTouched
+	}
+}
+
+func blameStatsCachePath(dir GitDir, treeHash string) string {
+	return dir.Path(blameStatsCacheDir, treeHash+".json")
+}
+
+// readBlameStatsCache returns the cached blame statistics of the tree, or nil
+// if they aren't cached.
+func readBlameStatsCache(dir GitDir, treeHash string) (*protocol.BlameStatsResponse, error) {
+	p := blameStatsCachePath(dir, treeHash)
+	b, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var stats protocol.BlameStatsResponse
+	if err := json.Unmarshal(b, &stats); err != nil {
+		return nil, err
+	}
+
+	// The modification time orders the cache entries by their last use.
+	now := time.Now()
+	_ = os.Chtimes(p, now, now)
+	return &stats, nil
+}
+
+// writeBlameStatsCache caches stats and removes the least recently used cache
+// entries of the repository beyond blameStatsCacheSize.
+func writeBlameStatsCache(dir GitDir, stats *protocol.BlameStatsResponse) error {
+	cacheDir := dir.Path(blameStatsCacheDir)
+	if err := os.MkdirAll(cacheDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that concurrent requests never read
+	// a partial entry.
+	f, err := os.CreateTemp(cacheDir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), blameStatsCachePath(dir, stats.TreeHash))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	entries, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+	if err != nil || len(entries) <= blameStatsCacheSize {
+		return err
+	}
+	modTimes := make(map[string]time.Time, len(entries))
+	for _, e := range entries {
+		if fi, err := os.Stat(e); err == nil {
+			modTimes[e] = fi.ModTime()
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return modTimes[entries[i]].After(modTimes[entries[j]]) })
+	for _, e := range entries[blameStatsCacheSize:] {
+		if err := os.Remove(e); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
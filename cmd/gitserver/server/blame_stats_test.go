@@ -0,0 +1,173 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestBlameStats(t *testing.T) {
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "example.com", "foo", "bar")
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	commit := func(name, email string, date time.Time, script string) {
+		t.Helper()
+		runCmd(t, repoDir, "sh", "-c", script)
+		runCmd(t, repoDir, "git", "add", "-A")
+		ts := strconv.FormatInt(date.Unix(), 10) + " +0000"
+		runCmdWithEnv(t, repoDir, []string{
+			"GIT_AUTHOR_NAME=" + name,
+			"GIT_AUTHOR_EMAIL=" + email,
+			"GIT_AUTHOR_DATE=" + ts,
+			"GIT_COMMITTER_NAME=" + name,
+			"GIT_COMMITTER_EMAIL=" + email,
+			"GIT_COMMITTER_DATE=" + ts,
+		}, "git", "commit", "-m", "commit")
+	}
+
+	alice := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	bob := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	runCmd(t, repoDir, "git", "init", ".")
+	commit("alice", "alice@example.com", alice, "mkdir -p src/a && printf '1\\n2\\n3\\n' > src/a/a.go && printf 'x\\n' > README")
+	commit("bob", "bob@example.com", bob, "printf '4\\n' >> src/a/a.go && printf 'b\\n' > src/b.go && ln -s a/a.go src/link")
+	commit("alice", "alice@example.com", alice.Add(24*time.Hour), "printf 'y\\n' >> README")
+
+	s := &Server{ReposDir: root}
+	h := s.Handler()
+
+	blameStats := func(t *testing.T, path string) protocol.BlameStatsResponse {
+		t.Helper()
+		body, err := json.Marshal(protocol.BlameStatsRequest{Repo: "example.com/foo/bar", Commit: "HEAD", Path: path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/blame-stats", bytes.NewReader(body)))
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+		}
+		var resp protocol.BlameStatsResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	got := blameStats(t, "src/")
+	want := protocol.BlameStatsResponse{
+		TreeHash: runCmd(t, repoDir, "git", "rev-parse", "HEAD:src")[:40],
+		Files:    2,
+		Authors: []protocol.AuthorBlameStats{
+			{Name: "alice", Email: "alice@example.com", Lines: 3, LastTouched: alice},
+			{Name: "bob", Email: "bob@example.com", Lines: 2, LastTouched: bob},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected blame stats (-want +got):\n%s", diff)
+	}
+
+	dir := GitDir(filepath.Join(repoDir, ".git"))
+	if _, err := os.Stat(blameStatsCachePath(dir, want.TreeHash)); err != nil {
+		t.Fatalf("expected blame stats to be cached: %s", err)
+	}
+
+	// Responses are served from the cache, which we check by changing it.
+	cached := want
+	cached.Files = 42
+	if err := writeBlameStatsCache(dir, &cached); err != nil {
+		t.Fatal(err)
+	}
+	if got := blameStats(t, "src"); got.Files != 42 {
+		t.Fatalf("expected the cached blame stats, got %+v", got)
+	}
+
+	got = blameStats(t, "")
+	if got.Files != 3 || len(got.Authors) != 2 || got.Authors[0].Lines != 5 || !got.Authors[0].LastTouched.Equal(alice.Add(24*time.Hour)) {
+		t.Fatalf("unexpected blame stats of the root directory: %+v", got)
+	}
+}
+
+func TestWriteBlameStatsCache(t *testing.T) {
+	dir := GitDir(t.TempDir())
+	for i := 0; i < blameStatsCacheSize; i++ {
+		stats := &protocol.BlameStatsResponse{TreeHash: strconv.Itoa(i)}
+		if err := writeBlameStatsCache(dir, stats); err != nil {
+			t.Fatal(err)
+		}
+		// Make the entries' last use distinguishable.
+		then := time.Now().Add(time.Duration(i-100) * time.Minute)
+		if err := os.Chtimes(blameStatsCachePath(dir, stats.TreeHash), then, then); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Reading an entry marks it as used.
+	if stats, err := readBlameStatsCache(dir, "0"); err != nil || stats == nil {
+		t.Fatalf("expected entry 0 to be cached, got %v, %v", stats, err)
+	}
+	if err := writeBlameStatsCache(dir, &protocol.BlameStatsResponse{TreeHash: "new"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := filepath.Glob(dir.Path(blameStatsCacheDir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != blameStatsCacheSize {
+		t.Fatalf("want %d cache entries, got %d", blameStatsCacheSize, len(entries))
+	}
+	for _, hash := range []string{"0", "new", strconv.Itoa(blameStatsCacheSize - 1)} {
+		if stats, _ := readBlameStatsCache(dir, hash); stats == nil {
+			t.Errorf("expected entry %s to be kept", hash)
+		}
+	}
+	if stats, _ := readBlameStatsCache(dir, "1"); stats != nil {
+		t.Error("expected the least recently used entry 1 to be removed")
+	}
+}
+
+func TestParseIncrementalBlame(t *testing.T) {
+	out := []byte(`4a5e1d2f8c3b4a5e1d2f8c3b4a5e1d2f8c3b4a5e 1 1 2
+author alice
+author-mail <alice@example.com>
+author-time 1609459200
+author-tz +0000
+summary first
+filename a.go
+9b8a7c6d5e4f9b8a7c6d5e4f9b8a7c6d5e4f9b8a 3 3 1
+author alice
+author-mail <alice@example.com>
+author-time 1612137600
+author-tz +0000
+summary second
+previous 4a5e1d2f8c3b4a5e1d2f8c3b4a5e1d2f8c3b4a5e a.go
+filename a.go
+4a5e1d2f8c3b4a5e1d2f8c3b4a5e1d2f8c3b4a5e 4 4 1
+filename a.go
+`)
+	authors, err := parseIncrementalBlame(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []protocol.AuthorBlameStats{{
+		Name:        "alice",
+		Email:       "alice@example.com",
+		Lines:       4,
+		LastTouched: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	if diff := cmp.Diff(want, authors); diff != "" {
+		t.Fatalf("unexpected authors (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,502 @@
+package server
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Git LFS stores large files outside of the repository and commits pointer
+// files referring to them instead. We download the objects of the pointers at
+// HEAD which the site configuration allows into the same location the git-lfs
+// client uses, and replace the pointers with them in tar archives. See
+// https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md and
+// https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md.
+
+const (
+	// lfsPointerMaxSize is the maximum size of LFS pointer files.
+	lfsPointerMaxSize = 1024
+	// lfsBatchSize is the number of objects requested from the batch API at
+	// once.
+	lfsBatchSize = 100
+
+	lfsMediaType = "application/vnd.git-lfs+json"
+)
+
+var (
+	// defaultLFSExtensions are the file extensions of the LFS objects we fetch
+	// unless configured otherwise.
+	defaultLFSExtensions = []string{".md", ".txt", ".json", ".yaml", ".yml", ".xml", ".csv", ".svg"}
+	// defaultLFSMaxFileSize is the maximum size of the LFS objects we fetch
+	// unless configured otherwise.
+	defaultLFSMaxFileSize int64 = 1024 * 1024
+
+	lfsPointerVersion = []byte("version https://git-lfs.github.com/spec/v1\n")
+)
+
+var lfsObjectsFetched = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_lfs_objects_fetched_total",
+	Help: "Number of Git LFS objects fetched from code hosts.",
+}, []string{"error"})
+
+// lfsDoer is the HTTP client LFS objects are downloaded with. Unlike
+// httpcli.ExternalDoer it doesn't cache responses, since the objects are
+// stored in the repository.
+var lfsDoer, _ = httpcli.NewFactory(
+	httpcli.NewMiddleware(
+		httpcli.ContextErrorMiddleware,
+		httpcli.HeadersMiddleware("User-Agent", "Sourcegraph-Bot"),
+	),
+	httpcli.ExternalTransportOpt,
+	httpcli.TracedTransportOpt,
+).Doer()
+
+// lfsPointer is a parsed LFS pointer file.
+type lfsPointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// parseLFSPointer parses b as an LFS pointer file. It returns false if b is not
+// a pointer to a SHA-256 object.
+func parseLFSPointer(b []byte) (lfsPointer, bool) {
+	var p lfsPointer
+	if len(b) > lfsPointerMaxSize || !bytes.HasPrefix(b, lfsPointerVersion) {
+		return p, false
+	}
+	size := int64(-1)
+	for _, line := range strings.Split(string(b[len(lfsPointerVersion):]), "\n") {
+		switch {
+		case strings.HasPrefix(line, "oid sha256:"):
+			p.OID = strings.TrimPrefix(line, "oid sha256:")
+		case strings.HasPrefix(line, "size "):
+			size, _ = strconv.ParseInt(strings.TrimPrefix(line, "size "), 10, 64)
+		}
+	}
+	if !isLFSOID(p.OID) || size < 0 {
+		return p, false
+	}
+	p.Size = size
+	return p, true
+}
+
+// isLFSOID returns true if oid is a SHA-256 hash. OIDs are used in paths, so
+// they must be checked before.
+func isLFSOID(oid string) bool {
+	_, err := hex.DecodeString(oid)
+	return err == nil && len(oid) == 2*sha256.Size
+}
+
+// lfsObjectPath returns the path of the LFS object oid in dir.
+func lfsObjectPath(dir GitDir, oid string) string {
+	return dir.Path("lfs", "objects", oid[0:2], oid[2:4], oid)
+}
+
+// hasLFSObject returns true if the object p points to is stored in dir.
+func hasLFSObject(dir GitDir, p lfsPointer) bool {
+	fi, err := os.Stat(lfsObjectPath(dir, p.OID))
+	return err == nil && fi.Mode().IsRegular() && fi.Size() == p.Size
+}
+
+// maybeFetchLFSObjects fetches the LFS objects of dir if Git LFS is enabled in
+// the site configuration. LFS objects are only searched if they were fetched,
+// so failing to fetch them doesn't fail the clone or update.
+func maybeFetchLFSObjects(ctx context.Context, remoteURL *vcs.URL, dir GitDir) {
+	c := conf.ExperimentalFeatures().GitLFS
+	if c == nil {
+		return
+	}
+	if err := fetchLFSObjects(ctx, remoteURL, dir, c); err != nil {
+		log15.Warn("failed to fetch LFS objects", "dir", dir, "error", newURLRedactor(remoteURL).redact(err.Error()))
+	}
+}
+
+// fetchLFSObjects downloads the LFS objects which are missing in dir of the
+// pointers at HEAD that match the extensions and size limit of c. Only
+// remotes accessed over HTTP(S) are supported.
+func fetchLFSObjects(ctx context.Context, remoteURL *vcs.URL, dir GitDir, c *schema.GitLFS) error {
+	if remoteURL.Scheme != "http" && remoteURL.Scheme != "https" {
+		return nil
+	}
+	// Listing the pointers of partial clones would fetch all the blobs they
+	// omit.
+	if isPartialClone(dir) {
+		return nil
+	}
+	if _, err := quickRevParseHead(dir); err != nil {
+		// Empty repository.
+		return nil
+	}
+
+	extensions := c.Extensions
+	if len(extensions) == 0 {
+		extensions = defaultLFSExtensions
+	}
+	maxSize := int64(c.MaxFileSize)
+	if maxSize <= 0 {
+		maxSize = defaultLFSMaxFileSize
+	}
+
+	pointers, err := lfsPointers(ctx, dir, extensions)
+	if err != nil {
+		return err
+	}
+	missing := pointers[:0]
+	seen := map[string]bool{}
+	for _, p := range pointers {
+		if p.Size <= maxSize && !seen[p.OID] && !hasLFSObject(dir, p) {
+			seen[p.OID] = true
+			missing = append(missing, p)
+		}
+	}
+
+	var errs *multierror.Error
+	for len(missing) > 0 {
+		batch := missing
+		if len(batch) > lfsBatchSize {
+			batch = batch[:lfsBatchSize]
+		}
+		missing = missing[len(batch):]
+
+		objects, err := lfsBatch(ctx, remoteURL, batch)
+		if err != nil {
+			return err
+		}
+		for _, o := range objects {
+			err := o.download(ctx, dir)
+			lfsObjectsFetched.WithLabelValues(strconv.FormatBool(err != nil)).Inc()
+			if err != nil {
+				errs = multierror.Append(errs, errors.Wrapf(err, "fetching LFS object %s", o.OID))
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// lfsPointers returns the LFS pointers at HEAD in dir of files with one of
+// extensions.
+func lfsPointers(ctx context.Context, dir GitDir, extensions []string) ([]lfsPointer, error) {
+	cmd := exec.CommandContext(ctx, "git", "ls-tree", "-r", "-z", "--long", "--full-tree", "HEAD")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, wrapCmdError(cmd, err)
+	}
+
+	var candidates bytes.Buffer
+	for _, entry := range bytes.Split(out, []byte{0}) {
+		// <mode> SP <type> SP <object> SP+ <size> TAB <path>
+		tab := bytes.IndexByte(entry, '\t')
+		if tab < 0 {
+			continue
+		}
+		fields := strings.Fields(string(entry[:tab]))
+		if len(fields) != 4 || fields[1] != "blob" || fields[0] == "120000" {
+			continue
+		}
+		if size, err := strconv.Atoi(fields[3]); err != nil || size > lfsPointerMaxSize {
+			continue
+		}
+		if !hasExtension(string(entry[tab+1:]), extensions) {
+			continue
+		}
+		candidates.WriteString(fields[2])
+		candidates.WriteByte('\n')
+	}
+	if candidates.Len() == 0 {
+		return nil, nil
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	dir.Set(cmd)
+	cmd.Stdin = &candidates
+	out, err = cmd.Output()
+	if err != nil {
+		return nil, wrapCmdError(cmd, err)
+	}
+
+	var pointers []lfsPointer
+	r := bufio.NewReader(bytes.NewReader(out))
+	for {
+		// <object> SP <type> SP <size> LF <contents> LF
+		header, err := r.ReadString('\n')
+		if err == io.EOF {
+			return pointers, nil
+		} else if err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, errors.Errorf("unexpected git cat-file output %q", header)
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, errors.Errorf("unexpected git cat-file output %q", header)
+		}
+		contents := make([]byte, size+1)
+		if _, err := io.ReadFull(r, contents); err != nil {
+			return nil, err
+		}
+		if p, ok := parseLFSPointer(contents[:size]); ok {
+			pointers = append(pointers, p)
+		}
+	}
+}
+
+// hasExtension returns true if the file name has one of extensions, ignoring
+// case.
+func hasExtension(name string, extensions []string) bool {
+	ext := filepath.Ext(name)
+	for _, e := range extensions {
+		if strings.EqualFold(ext, e) {
+			return true
+		}
+	}
+	return false
+}
+
+type lfsBatchRequest struct {
+	Operation string       `json:"operation"`
+	Transfers []string     `json:"transfers"`
+	Objects   []lfsPointer `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchObject struct {
+	OID     string `json:"oid"`
+	Size    int64  `json:"size"`
+	Actions struct {
+		Download *struct {
+			Href   string            `json:"href"`
+			Header map[string]string `json:"header"`
+		} `json:"download"`
+	} `json:"actions"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// lfsBatch requests the download actions of pointers from the LFS server of
+// remoteURL.
+func lfsBatch(ctx context.Context, remoteURL *vcs.URL, pointers []lfsPointer) ([]lfsBatchObject, error) {
+	u := remoteURL.URL
+	u.User = nil
+	if !strings.HasSuffix(u.Path, ".git") {
+		u.Path += ".git"
+	}
+	u.Path += "/info/lfs/objects/batch"
+
+	body, err := json.Marshal(lfsBatchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   pointers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+	if user := remoteURL.User; user != nil {
+		password, _ := user.Password()
+		req.SetBasicAuth(user.Username(), password)
+	}
+
+	resp, err := lfsDoer.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "LFS batch request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("LFS batch request: unexpected status %d", resp.StatusCode)
+	}
+
+	var batch lfsBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return nil, errors.Wrap(err, "decoding LFS batch response")
+	}
+	return batch.Objects, nil
+}
+
+// download downloads o into dir and verifies its contents.
+func (o *lfsBatchObject) download(ctx context.Context, dir GitDir) error {
+	if o.Error != nil {
+		return errors.Errorf("%d %s", o.Error.Code, o.Error.Message)
+	}
+	if !isLFSOID(o.OID) || o.Size < 0 {
+		return errors.New("invalid object")
+	}
+	if o.Actions.Download == nil {
+		return errors.New("no download action")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", o.Actions.Download.Href, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range o.Actions.Download.Header {
+		req.Header.Set(k, v)
+	}
+	resp, err := lfsDoer.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	path := lfsObjectPath(dir, o.OID)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), o.OID+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(resp.Body, o.Size+1))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if n != o.Size || hex.EncodeToString(h.Sum(nil)) != o.OID {
+		return errors.New("downloaded object does not match its pointer")
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// lfsTarWriter returns a writer which copies the tar archive written to it to
+// w, replacing the LFS pointers whose objects are stored in dir with the
+// objects. Close must be called once the archive is written, and returns an
+// error if the archive could not be read.
+func lfsTarWriter(dir GitDir, w io.Writer) io.WriteCloser {
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := resolveLFSPointers(dir, pr, w)
+		// Unblock the writer if we stopped reading early.
+		pr.CloseWithError(err)
+		done <- err
+	}()
+	return &lfsTarPipe{PipeWriter: pw, done: done}
+}
+
+type lfsTarPipe struct {
+	*io.PipeWriter
+	done chan error
+}
+
+func (p *lfsTarPipe) Close() error {
+	p.PipeWriter.Close()
+	return <-p.done
+}
+
+// resolveLFSPointers copies the tar archive r to w, replacing the LFS
+// pointers whose objects are stored in dir with the objects.
+func resolveLFSPointers(dir GitDir, r io.Reader, w io.Writer) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			// Consume the padding after the end of the archive.
+			if _, err := io.Copy(io.Discard, r); err != nil {
+				return err
+			}
+			return tw.Close()
+		} else if err != nil {
+			return err
+		}
+
+		if hdr.Typeflag != tar.TypeReg || hdr.Size > lfsPointerMaxSize {
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if p, ok := parseLFSPointer(contents); ok && hasLFSObject(dir, p) {
+			if err := writeLFSObject(tw, hdr, lfsObjectPath(dir, p.OID)); err == nil {
+				continue
+			} else if !os.IsNotExist(err) {
+				return err
+			}
+			// The object was removed since we checked, so we fall back to
+			// the pointer.
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(contents); err != nil {
+			return err
+		}
+	}
+}
+
+// writeLFSObject writes the entry hdr to tw with the contents of the LFS
+// object at path. Nothing is written if the object cannot be opened.
+func writeLFSObject(tw *tar.Writer, hdr *tar.Header, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	hdr.Size = fi.Size()
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// hasLFSObjects returns true if LFS objects were fetched into dir.
+func hasLFSObjects(dir GitDir) bool {
+	_, err := os.Stat(dir.Path("lfs", "objects"))
+	return err == nil
+}
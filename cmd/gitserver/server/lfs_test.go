@@ -0,0 +1,184 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func lfsPointerFile(contents string) (oid, pointer string) {
+	sum := sha256.Sum256([]byte(contents))
+	oid = hex.EncodeToString(sum[:])
+	return oid, fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", oid, len(contents))
+}
+
+func TestParseLFSPointer(t *testing.T) {
+	oid, pointer := lfsPointerFile("hello")
+	tests := []struct {
+		name    string
+		pointer string
+		want    lfsPointer
+		ok      bool
+	}{
+		{name: "pointer", pointer: pointer, want: lfsPointer{OID: oid, Size: 5}, ok: true},
+		{name: "extension keys", pointer: strings.Replace(pointer, "oid", "ext-0-foo sha256:abc\noid", 1), want: lfsPointer{OID: oid, Size: 5}, ok: true},
+		{name: "no pointer", pointer: "hello\n"},
+		{name: "no size", pointer: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\n"},
+		{name: "path in oid", pointer: "version https://git-lfs.github.com/spec/v1\noid sha256:../../../../etc/passwd\nsize 5\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, ok := parseLFSPointer([]byte(test.pointer))
+			if ok != test.ok || (ok && p != test.want) {
+				t.Errorf("got %+v, %t, want %+v, %t", p, ok, test.want, test.ok)
+			}
+		})
+	}
+}
+
+func TestFetchLFSObjects(t *testing.T) {
+	objects := map[string]string{}
+	pointer := func(contents string) string {
+		oid, pointer := lfsPointerFile(contents)
+		objects[oid] = contents
+		return pointer
+	}
+
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/foo/bar.git/info/lfs/objects/batch":
+			if user, pass, _ := r.BasicAuth(); user != "user" || pass != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var req lfsBatchRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Error(err)
+			}
+			var resp lfsBatchResponse
+			for _, p := range req.Objects {
+				requested = append(requested, objects[p.OID])
+				o := lfsBatchObject{OID: p.OID, Size: p.Size}
+				o.Actions.Download = &struct {
+					Href   string            `json:"href"`
+					Header map[string]string `json:"header"`
+				}{Href: "http://" + r.Host + "/objects/" + p.OID, Header: map[string]string{"X-Token": "token"}}
+				resp.Objects = append(resp.Objects, o)
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/objects/") && r.Header.Get("X-Token") == "token":
+			_, _ = io.WriteString(w, objects[strings.TrimPrefix(r.URL.Path, "/objects/")])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	repoDir := t.TempDir()
+	dir := GitDir(filepath.Join(repoDir, ".git"))
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, repoDir, name, arg...)
+	}
+	write := func(name, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoDir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd("git", "init", ".")
+	write("README.md", pointer("# readme\n"))
+	write("notes.TXT", pointer("notes\n"))
+	write("big.txt", pointer(strings.Repeat("big\n", 100)))
+	write("image.png", pointer("png"))
+	write("plain.md", "not in LFS\n")
+	cmd("git", "add", "-A")
+	cmd("git", "commit", "-m", "lfs")
+
+	remoteURL, err := vcs.ParseURL(strings.Replace(srv.URL, "http://", "http://user:secret@", 1) + "/foo/bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &schema.GitLFS{Extensions: []string{".md", ".txt"}, MaxFileSize: 100}
+	if err := fetchLFSObjects(context.Background(), remoteURL, dir, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"# readme\n", "notes\n"}, requested); diff != "" {
+		t.Errorf("unexpected requested objects (-want +got):\n%s", diff)
+	}
+	oid, _ := lfsPointerFile("notes\n")
+	if b, err := os.ReadFile(lfsObjectPath(dir, oid)); err != nil || string(b) != "notes\n" {
+		t.Errorf("expected the object to be stored, got %q, %v", b, err)
+	}
+
+	// Objects which were fetched already are not requested again.
+	requested = nil
+	if err := fetchLFSObjects(context.Background(), remoteURL, dir, c); err != nil {
+		t.Fatal(err)
+	}
+	if len(requested) != 0 {
+		t.Errorf("expected no objects to be requested, got %q", requested)
+	}
+
+	// Archives contain the objects instead of their pointers.
+	var archive bytes.Buffer
+	w := lfsTarWriter(dir, &archive)
+	archiveCmd := exec.Command("git", "archive", "--format=tar", "HEAD")
+	dir.Set(archiveCmd)
+	archiveCmd.Stdout = w
+	if err := archiveCmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{}
+	tr := tar.NewReader(&archive)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			files[hdr.Name] = string(b)
+		}
+	}
+	_, bigPointer := lfsPointerFile(strings.Repeat("big\n", 100))
+	_, pngPointer := lfsPointerFile("png")
+	want := map[string]string{
+		"README.md": "# readme\n",
+		"notes.TXT": "notes\n",
+		"big.txt":   bigPointer,
+		"image.png": pngPointer,
+		"plain.md":  "not in LFS\n",
+	}
+	if diff := cmp.Diff(want, files); diff != "" {
+		t.Errorf("unexpected archive (-want +got):\n%s", diff)
+	}
+}
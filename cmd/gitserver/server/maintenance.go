@@ -0,0 +1,329 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+const (
+	// gitConfigMaintenanceTime is the git config key which stores the time
+	// maintenance last ran on a repository.
+	gitConfigMaintenanceTime = "sourcegraph.maintenanceTimestamp"
+
+	// maintenanceMinInterval is the minimum time between two maintenance runs
+	// on a repository.
+	maintenanceMinInterval = time.Hour
+
+	// midxMinPacks is the number of packfiles from which we write a
+	// multi-pack-index.
+	midxMinPacks = 2
+
+	// repackMinPacks is the number of packfiles from which we repack all
+	// objects into a single packfile, even if the repository already has a
+	// reachability bitmap.
+	repackMinPacks = 50
+
+	// changedPriority is added to the priority of repositories which changed
+	// since their last maintenance. It is worth as much as this many packfiles.
+	changedPriority = 20
+)
+
+var (
+	maintenanceDue = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_maintenance_due",
+		Help: "Number of repos which were due for maintenance at the start of the last maintenance run",
+	})
+	maintenanceTaskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_gitserver_maintenance_task_duration_seconds",
+		Help:    "Duration of the maintenance tasks run on repos in seconds",
+		Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800},
+	}, []string{"task", "success"})
+)
+
+// maintenanceTask is a task which keeps reading a repository fast.
+type maintenanceTask string
+
+const (
+	// maintenanceRepack repacks all objects into a single packfile with a
+	// reachability bitmap, which makes counting the objects to send for a
+	// fetch fast.
+	maintenanceRepack maintenanceTask = "repack"
+	// maintenanceMultiPackIndex writes a multi-pack-index, which makes
+	// looking up objects in many packfiles fast.
+	maintenanceMultiPackIndex maintenanceTask = "multi-pack-index"
+	// maintenanceCommitGraph writes the commit-graph, which makes walking
+	// commits, for example in git log and commit search, fast.
+	maintenanceCommitGraph maintenanceTask = "commit-graph"
+)
+
+// args returns the git arguments which run the task.
+func (t maintenanceTask) args() [][]string {
+	switch t {
+	case maintenanceRepack:
+		return [][]string{{"repack", "-a", "-d", "--write-bitmap-index"}}
+	case maintenanceMultiPackIndex:
+		// expire deletes the packfiles whose objects are all contained in
+		// other packfiles.
+		return [][]string{{"multi-pack-index", "write"}, {"multi-pack-index", "expire"}}
+	case maintenanceCommitGraph:
+		return [][]string{{"commit-graph", "write", "--reachable", "--split"}}
+	}
+	return nil
+}
+
+// repoMaintenance is the state of a repository on disk which determines which
+// maintenance tasks are due.
+type repoMaintenance struct {
+	dir GitDir
+
+	// packs is the number of packfiles.
+	packs int
+
+	hasBitmap         bool
+	hasMultiPackIndex bool
+	hasCommitGraph    bool
+
+	// partial is true if the repository is a partial clone, which we don't
+	// repack.
+	partial bool
+
+	lastChanged     time.Time
+	lastMaintenance time.Time
+}
+
+// inspectMaintenance returns the maintenance state of the repository at dir.
+func inspectMaintenance(dir GitDir) (*repoMaintenance, error) {
+	packs, err := filepath.Glob(dir.Path("objects", "pack", "*.pack"))
+	if err != nil {
+		return nil, err
+	}
+	bitmaps, err := filepath.Glob(dir.Path("objects", "pack", "*.bitmap"))
+	if err != nil {
+		return nil, err
+	}
+
+	lastChanged, err := repoLastChanged(dir)
+	if err != nil {
+		return nil, err
+	}
+	lastMaintenance, err := getMaintenanceTime(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repoMaintenance{
+		dir:               dir,
+		packs:             len(packs),
+		hasBitmap:         len(bitmaps) > 0,
+		hasMultiPackIndex: fileExists(dir.Path("objects", "pack", "multi-pack-index")),
+		// Split commit-graphs are stored in a chain of files, others in a
+		// single file.
+		hasCommitGraph:  fileExists(dir.Path("objects", "info", "commit-graph")) || fileExists(dir.Path("objects", "info", "commit-graphs", "commit-graph-chain")),
+		partial:         isPartialClone(dir),
+		lastChanged:     lastChanged,
+		lastMaintenance: lastMaintenance,
+	}, nil
+}
+
+// changed returns true if the repository changed since its last maintenance.
+func (m *repoMaintenance) changed() bool {
+	return m.lastChanged.After(m.lastMaintenance)
+}
+
+// tasks returns the maintenance tasks which are due at now, in the order in
+// which they must run.
+func (m *repoMaintenance) tasks(now time.Time) []maintenanceTask {
+	if now.Sub(m.lastMaintenance) < maintenanceMinInterval || m.packs == 0 {
+		return nil
+	}
+
+	var tasks []maintenanceTask
+	packs := m.packs
+	if !m.partial && (!m.hasBitmap || packs >= repackMinPacks) {
+		tasks = append(tasks, maintenanceRepack)
+		packs = 1
+	}
+	if packs >= midxMinPacks && (!m.hasMultiPackIndex || m.changed()) {
+		tasks = append(tasks, maintenanceMultiPackIndex)
+	}
+	if !m.hasCommitGraph || m.changed() {
+		tasks = append(tasks, maintenanceCommitGraph)
+	}
+	return tasks
+}
+
+// priority returns the priority of maintaining the repository. Reading
+// repositories with many packfiles is slow, and repositories which changed
+// recently are likely to be read soon.
+func (m *repoMaintenance) priority() int {
+	p := m.packs
+	if m.changed() {
+		p += changedPriority
+	}
+	return p
+}
+
+// MaintainRepos runs maintenance tasks, such as writing commit-graphs,
+// multi-pack-indexes and reachability bitmaps, on the repos which need it
+// most and is expected to run in a background goroutine. At most maxRepos
+// repos are maintained per run.
+func (s *Server) MaintainRepos(interval time.Duration, maxRepos int) {
+	for {
+		if !conf.Get().DisableAutoGitUpdates {
+			if err := s.maintainRepos(time.Now(), maxRepos); err != nil {
+				log15.Error("Maintaining repos", "error", err)
+			}
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+func (s *Server) maintainRepos(now time.Time, maxRepos int) error {
+	ctx, cancel := s.serverContext()
+	defer cancel()
+
+	dirs, err := s.findGitDirs()
+	if err != nil {
+		return err
+	}
+
+	stats := protocol.RepoMaintenanceStats{}
+	type dueRepo struct {
+		*repoMaintenance
+		tasks []maintenanceTask
+	}
+	var due []dueRepo
+	for _, dir := range dirs {
+		m, err := inspectMaintenance(dir)
+		if err != nil {
+			log15.Warn("Inspecting repo for maintenance", "repo", dir, "error", err)
+			continue
+		}
+		stats.Packs += m.packs
+		if tasks := m.tasks(now); len(tasks) > 0 {
+			due = append(due, dueRepo{repoMaintenance: m, tasks: tasks})
+		}
+	}
+	stats.Due = len(due)
+	maintenanceDue.Set(float64(len(due)))
+
+	sort.SliceStable(due, func(i, j int) bool {
+		if pi, pj := due[i].priority(), due[j].priority(); pi != pj {
+			return pi > pj
+		}
+		return due[i].lastMaintenance.Before(due[j].lastMaintenance)
+	})
+	if maxRepos > 0 && len(due) > maxRepos {
+		due = due[:maxRepos]
+	}
+
+	for _, r := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		repo := s.name(r.dir)
+		err := runMaintenance(ctx, r.dir, r.tasks)
+		if err != nil {
+			stats.Failed++
+			log15.Error("Maintaining repo", "repo", repo, "tasks", r.tasks, "error", err)
+		}
+		stats.Maintained++
+
+		// The next run is due after maintenanceMinInterval even if this one
+		// failed, so that failing repos don't starve the others.
+		if err := setMaintenanceTime(r.dir, time.Now()); err != nil {
+			log15.Warn("Setting maintenance time", "repo", repo, "error", err)
+		}
+		s.setLastMaintenanceNonFatal(ctx, repo, r.dir, err)
+	}
+
+	stats.LastRunAt = time.Now()
+	s.maintenanceMu.Lock()
+	s.maintenanceStats = stats
+	s.maintenanceMu.Unlock()
+	return nil
+}
+
+// runMaintenance runs tasks on the repository at dir.
+func runMaintenance(ctx context.Context, dir GitDir, tasks []maintenanceTask) error {
+	ctx, cancel := context.WithTimeout(ctx, conf.GitLongCommandTimeout())
+	defer cancel()
+
+	for _, task := range tasks {
+		start := time.Now()
+		err := runMaintenanceTask(ctx, dir, task)
+		maintenanceTaskDuration.
+			WithLabelValues(string(task), strconv.FormatBool(err == nil)).
+			Observe(time.Since(start).Seconds())
+		if err != nil {
+			return errors.Wrapf(err, "running %s", task)
+		}
+	}
+	return nil
+}
+
+func runMaintenanceTask(ctx context.Context, dir GitDir, task maintenanceTask) error {
+	for _, args := range task.args() {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		dir.Set(cmd)
+		if output, err := runWith(ctx, cmd, false, nil); err != nil {
+			return errors.Wrapf(err, "git %s failed with output %q", strings.Join(args, " "), string(output))
+		}
+	}
+	return nil
+}
+
+func (s *Server) setLastMaintenanceNonFatal(ctx context.Context, name api.RepoName, dir GitDir, maintenanceErr error) {
+	if s.DB == nil {
+		return
+	}
+	var errString string
+	if maintenanceErr != nil {
+		errString = maintenanceErr.Error()
+	}
+	packs, _ := filepath.Glob(dir.Path("objects", "pack", "*.pack"))
+	if err := database.GitserverRepos(s.DB).SetLastMaintenance(ctx, name, len(packs), errString); err != nil {
+		log15.Warn("Setting last maintenance in DB", "error", err)
+	}
+}
+
+// getMaintenanceTime returns the time maintenance last ran on the repository
+// at dir, or the zero time if it never ran.
+func getMaintenanceTime(dir GitDir) (time.Time, error) {
+	value, err := gitConfigGet(dir, gitConfigMaintenanceTime)
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+	sec, err := strconv.ParseInt(strings.TrimSpace(value), 10, 0)
+	if err != nil {
+		// Treat a bad value like a missing one, which makes maintenance due.
+		return time.Time{}, nil
+	}
+	return time.Unix(sec, 0), nil
+}
+
+func setMaintenanceTime(dir GitDir, now time.Time) error {
+	return gitConfigSet(dir, gitConfigMaintenanceTime, strconv.FormatInt(now.Unix(), 10))
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
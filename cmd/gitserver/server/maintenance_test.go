@@ -0,0 +1,139 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRepoMaintenance_tasks(t *testing.T) {
+	now := time.Now()
+	lastMaintenance := now.Add(-2 * maintenanceMinInterval)
+
+	tests := []struct {
+		name string
+		m    repoMaintenance
+		want []maintenanceTask
+	}{
+		{
+			name: "never maintained",
+			m:    repoMaintenance{packs: 3},
+			want: []maintenanceTask{maintenanceRepack, maintenanceCommitGraph},
+		},
+		{
+			name: "maintained recently",
+			m:    repoMaintenance{packs: 3, lastMaintenance: now.Add(-time.Minute)},
+		},
+		{
+			name: "empty",
+			m:    repoMaintenance{},
+		},
+		{
+			name: "unchanged",
+			m: repoMaintenance{
+				packs:             3,
+				hasBitmap:         true,
+				hasMultiPackIndex: true,
+				hasCommitGraph:    true,
+				lastMaintenance:   lastMaintenance,
+			},
+		},
+		{
+			name: "changed",
+			m: repoMaintenance{
+				packs:             3,
+				hasBitmap:         true,
+				hasMultiPackIndex: true,
+				hasCommitGraph:    true,
+				lastChanged:       now,
+				lastMaintenance:   lastMaintenance,
+			},
+			want: []maintenanceTask{maintenanceMultiPackIndex, maintenanceCommitGraph},
+		},
+		{
+			name: "many packs",
+			m: repoMaintenance{
+				packs:             repackMinPacks,
+				hasBitmap:         true,
+				hasMultiPackIndex: true,
+				hasCommitGraph:    true,
+				lastMaintenance:   lastMaintenance,
+			},
+			want: []maintenanceTask{maintenanceRepack},
+		},
+		{
+			name: "partial clone",
+			m: repoMaintenance{
+				packs:           repackMinPacks,
+				partial:         true,
+				lastMaintenance: lastMaintenance,
+			},
+			want: []maintenanceTask{maintenanceMultiPackIndex, maintenanceCommitGraph},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.m.tasks(now); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want tasks %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMaintainRepos(t *testing.T) {
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "example.com", "foo", "bar")
+	dir := GitDir(filepath.Join(repoDir, ".git"))
+
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, repoDir, name, arg...)
+	}
+	makeSingleCommitRepo(cmd)
+	cmd("git", "repack", "-d")
+	cmd("sh", "-c", "echo bye world > bye.txt")
+	cmd("git", "add", "bye.txt")
+	cmd("git", "commit", "-m", "bye")
+	cmd("git", "repack", "-d")
+
+	m, err := inspectMaintenance(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.packs != 2 {
+		t.Fatalf("want 2 packs, got %d", m.packs)
+	}
+
+	s := &Server{ReposDir: root}
+	s.Handler() // Handler as a side-effect sets up Server
+	if err := s.maintainRepos(time.Now(), 10); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err = inspectMaintenance(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.packs != 1 || !m.hasBitmap || !m.hasCommitGraph {
+		t.Errorf("want a single pack with a bitmap and a commit-graph, got %+v", m)
+	}
+	if m.lastMaintenance.IsZero() {
+		t.Error("want the maintenance time to be set")
+	}
+	if tasks := m.tasks(time.Now()); len(tasks) != 0 {
+		t.Errorf("want no tasks due right after maintenance, got %v", tasks)
+	}
+
+	s.maintenanceMu.Lock()
+	stats := s.maintenanceStats
+	s.maintenanceMu.Unlock()
+	if stats.Due != 1 || stats.Maintained != 1 || stats.Failed != 0 || stats.LastRunAt.IsZero() {
+		t.Errorf("unexpected maintenance stats %+v", stats)
+	}
+}
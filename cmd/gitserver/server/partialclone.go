@@ -0,0 +1,328 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Partial clones are configured with the remote "origin" as their promisor
+// remote, which git fetches missing objects from. The URL of the remote is
+// never written to the git config since it may contain credentials. Instead
+// it is passed in the environment of every command which may need to fetch
+// missing objects, see promisorRemoteEnv.
+const partialCloneRemote = "origin"
+
+var (
+	partialCloneFetchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_gitserver_partial_clone_fetch_duration_seconds",
+		Help:    "Duration of fetching missing objects of partial clones in one batch in seconds.",
+		Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300},
+	}, []string{"caller", "error"})
+	partialCloneLazyFetches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_partial_clone_lazy_fetches_total",
+		Help: "Number of fetches git ran on demand for objects missing in partial clones.",
+	}, []string{"caller"})
+	partialCloneLazyFetchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_gitserver_partial_clone_lazy_fetch_command_duration_seconds",
+		Help:    "Duration of commands which fetched objects missing in partial clones on demand in seconds.",
+		Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300},
+	}, []string{"caller"})
+)
+
+var partialCloneFilters = conf.Cached(func() interface{} {
+	exp := conf.ExperimentalFeatures()
+	return buildPartialCloneFilters(exp.GitPartialClone)
+})
+
+func buildPartialCloneFilters(c []*schema.GitPartialCloneMapping) map[string]string {
+	filters := map[string]string{}
+	for _, mapping := range c {
+		filter := mapping.Filter
+		if filter == "" {
+			filter = "blob:none"
+		}
+		filters[mapping.DomainPath] = filter
+	}
+	return filters
+}
+
+// partialCloneFilter returns the object filter new clones of remoteURL are
+// cloned with, or the empty string if they are full clones. Repositories are
+// always cloned in full if the installed git doesn't support partial clones.
+func partialCloneFilter(remoteURL *vcs.URL) string {
+	filters := partialCloneFilters().(map[string]string)
+	if len(filters) == 0 || !gitSupportsPartialClones() {
+		return ""
+	}
+	return filters[path.Join(remoteURL.Host, remoteURL.Path)]
+}
+
+// minPartialCloneGitVersion is the oldest git which reads the
+// GIT_CONFIG_COUNT environment variables promisorRemoteEnv sets.
+var minPartialCloneGitVersion = [2]int{2, 31}
+
+var (
+	partialCloneSupportOnce sync.Once
+	partialCloneSupported   bool
+)
+
+// gitSupportsPartialClones returns true if the installed git is at least
+// minPartialCloneGitVersion.
+func gitSupportsPartialClones() bool {
+	partialCloneSupportOnce.Do(func() {
+		out, err := exec.Command("git", "version").Output()
+		if err != nil {
+			log15.Warn("failed to determine git version, cloning repositories in full", "error", err)
+			return
+		}
+		version, ok := parseGitVersion(string(out))
+		partialCloneSupported = ok && !versionLess(version, minPartialCloneGitVersion)
+		if !partialCloneSupported {
+			log15.Warn("git is too old for partial clones, cloning repositories in full",
+				"version", strings.TrimSpace(string(out)),
+				"required", strconv.Itoa(minPartialCloneGitVersion[0])+"."+strconv.Itoa(minPartialCloneGitVersion[1]))
+		}
+	})
+	return partialCloneSupported
+}
+
+var gitVersionPattern = regexp.MustCompile(`^git version (\d+)\.(\d+)`)
+
+// parseGitVersion returns the major and minor version of the output of
+// git version, e.g. "git version 2.26.3".
+func parseGitVersion(out string) ([2]int, bool) {
+	m := gitVersionPattern.FindStringSubmatch(strings.TrimSpace(out))
+	if m == nil {
+		return [2]int{}, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return [2]int{major, minor}, true
+}
+
+func versionLess(a, b [2]int) bool {
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+
+// configurePartialClone configures the repository at dir as a partial clone
+// whose missing objects are fetched from partialCloneRemote.
+func configurePartialClone(ctx context.Context, dir GitDir, filter string) error {
+	for _, kv := range [][2]string{
+		// Extensions require repository format version 1.
+		{"core.repositoryformatversion", "1"},
+		{"extensions.partialClone", partialCloneRemote},
+		{"remote." + partialCloneRemote + ".promisor", "true"},
+		{"remote." + partialCloneRemote + ".partialclonefilter", filter},
+	} {
+		cmd := exec.CommandContext(ctx, "git", "config", kv[0], kv[1])
+		dir.Set(cmd)
+		if err := cmd.Run(); err != nil {
+			return errors.Wrapf(wrapCmdError(cmd, err), "failed to set git config %s", kv[0])
+		}
+	}
+	return nil
+}
+
+// promisorRemoteEnv returns the environment variables which set the URL of
+// the promisor remote of a partial clone to remoteURL. Git passes them on to
+// the fetches it runs for missing objects.
+func promisorRemoteEnv(remoteURL *vcs.URL) []string {
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=remote." + partialCloneRemote + ".url",
+		"GIT_CONFIG_VALUE_0=" + remoteURL.String(),
+	}
+}
+
+// partialCloneCommandEnv returns the environment of commands which run in the
+// partial clone of remoteURL and may fetch missing objects.
+func partialCloneCommandEnv(remoteURL *vcs.URL) []string {
+	env := append(os.Environ(), remoteGitEnv(tlsExternal().(*tlsConfig))...)
+	return append(env, promisorRemoteEnv(remoteURL)...)
+}
+
+// promisorPacks returns the number of packs in dir which were fetched from
+// the promisor remote of a partial clone. Git marks each of them with a
+// .promisor file, so this is zero for full clones. Every fetch of missing
+// objects adds a pack.
+func promisorPacks(dir GitDir) int {
+	matches, _ := filepath.Glob(dir.Path("objects", "pack", "*.promisor"))
+	return len(matches)
+}
+
+// isPartialClone returns true if dir is a partial clone.
+func isPartialClone(dir GitDir) bool {
+	return promisorPacks(dir) > 0
+}
+
+// observeLazyFetches records the fetches of missing objects git ran on demand
+// while running a command which took duration, given the number of promisor
+// packs before the command ran.
+func observeLazyFetches(dir GitDir, caller string, packsBefore int, duration time.Duration) {
+	if n := promisorPacks(dir) - packsBefore; n > 0 {
+		partialCloneLazyFetches.WithLabelValues(caller).Add(float64(n))
+		partialCloneLazyFetchDuration.WithLabelValues(caller).Observe(duration.Seconds())
+	}
+}
+
+// missingObjects returns the objects of treeish which are missing in the
+// partial clone at dir. If paths are given, only objects below them are
+// returned.
+func missingObjects(ctx context.Context, dir GitDir, treeish string, paths []string) ([]string, error) {
+	// rev-list reports missing objects instead of fetching them.
+	cmd := exec.CommandContext(ctx, "git", "rev-list", "--objects", "--no-walk", "--missing=print", treeish)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "listing missing objects")
+	}
+
+	var missing []string
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		if line := sc.Text(); strings.HasPrefix(line, "?") {
+			missing = append(missing, line[1:])
+		}
+	}
+	if len(missing) == 0 || len(paths) == 0 {
+		return missing, nil
+	}
+
+	// rev-list doesn't know the paths of missing objects, so we intersect
+	// them with the objects below paths. ls-tree only reads trees, which are
+	// present in blobless clones.
+	cmd = exec.CommandContext(ctx, "git", append([]string{"ls-tree", "-r", treeish, "--"}, paths...)...)
+	dir.Set(cmd)
+	out, err = cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "listing objects")
+	}
+	wanted := make(map[string]struct{})
+	sc = bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		// Example: 100644 blob d00491fd7e5bb6fa28c517a0bb32b8b506539d4d	README.md
+		if fields := strings.Fields(sc.Text()); len(fields) >= 3 {
+			wanted[fields[2]] = struct{}{}
+		}
+	}
+	filtered := missing[:0]
+	for _, oid := range missing {
+		if _, ok := wanted[oid]; ok {
+			filtered = append(filtered, oid)
+		}
+	}
+	return filtered, nil
+}
+
+// fetchMissingObjects fetches the objects of treeish below paths which are
+// missing in the partial clone of repo at dir. Git would otherwise
+// fetch them on demand one at a time, which is slow for commands like git
+// archive which read many objects.
+func (s *Server) fetchMissingObjects(ctx context.Context, repo api.RepoName, dir GitDir, caller, treeish string, paths []string) (err error) {
+	missing, err := missingObjects(ctx, dir, treeish, paths)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	remoteURL, err := s.partialCloneRemoteURL(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	defer func() {
+		partialCloneFetchDuration.
+			WithLabelValues(caller, strconv.FormatBool(err != nil)).
+			Observe(time.Since(start).Seconds())
+	}()
+
+	// These are the arguments git uses for fetching missing objects, except
+	// for --no-write-fetch-head which older git doesn't support.
+	cmd := exec.CommandContext(ctx, "git",
+		"-c", "fetch.negotiationAlgorithm=noop",
+		"fetch", partialCloneRemote,
+		"--no-tags", "--recurse-submodules=no",
+		"--filter="+partialCloneFilterOf(dir), "--stdin")
+	dir.Set(cmd)
+	cmd.Stdin = strings.NewReader(strings.Join(missing, "\n") + "\n")
+	cmd.Env = partialCloneCommandEnv(remoteURL)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to fetch missing objects with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// partialCloneFilterOf returns the object filter the partial clone at dir was
+// cloned with, or the empty string if dir is a full clone.
+func partialCloneFilterOf(dir GitDir) string {
+	filter, _ := gitConfigGet(dir, "remote."+partialCloneRemote+".partialclonefilter")
+	return strings.TrimSpace(filter)
+}
+
+// partialCloneEnv returns the environment of commands which run in the
+// partial clone of repo and may fetch missing objects.
+func (s *Server) partialCloneEnv(ctx context.Context, repo api.RepoName) ([]string, error) {
+	remoteURL, err := s.partialCloneRemoteURL(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return partialCloneCommandEnv(remoteURL), nil
+}
+
+// remoteURLCacheTTL is how long partialCloneRemoteURL reuses the remote URL
+// of a repository before looking it up again, so that changes to the URL or
+// its credentials are picked up.
+const remoteURLCacheTTL = time.Minute
+
+type cachedRemoteURL struct {
+	url       *vcs.URL
+	timestamp time.Time
+}
+
+// partialCloneRemoteURL returns the remote URL of repo for fetching the
+// objects missing in its partial clone. Most commands which run in partial
+// clones need it, so it is cached to avoid asking the frontend for every
+// command.
+func (s *Server) partialCloneRemoteURL(ctx context.Context, repo api.RepoName) (*vcs.URL, error) {
+	s.remoteURLsMu.Lock()
+	cached, ok := s.remoteURLs[repo]
+	s.remoteURLsMu.Unlock()
+	if ok && time.Since(cached.timestamp) < remoteURLCacheTTL {
+		return cached.url, nil
+	}
+
+	remoteURL, err := s.getRemoteURL(actor.WithInternalActor(ctx), repo)
+	if err != nil {
+		return nil, err
+	}
+
+	s.remoteURLsMu.Lock()
+	if s.remoteURLs == nil {
+		s.remoteURLs = make(map[api.RepoName]cachedRemoteURL)
+	}
+	s.remoteURLs[repo] = cachedRemoteURL{url: remoteURL, timestamp: time.Now()}
+	s.remoteURLsMu.Unlock()
+
+	return remoteURL, nil
+}
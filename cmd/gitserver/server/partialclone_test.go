@@ -0,0 +1,188 @@
+package server
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPartialCloneFilter(t *testing.T) {
+	orig := partialCloneFilters
+	t.Cleanup(func() { partialCloneFilters = orig })
+	partialCloneFilters = func() interface{} {
+		return buildPartialCloneFilters([]*schema.GitPartialCloneMapping{
+			{DomainPath: "github.com/foo/monorepo"},
+			{DomainPath: "github.com/foo/assets", Filter: "blob:limit=1m"},
+		})
+	}
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://token@github.com/foo/monorepo", want: "blob:none"},
+		{url: "https://token@github.com/foo/assets", want: "blob:limit=1m"},
+		{url: "https://token@github.com/foo/other", want: ""},
+	}
+	for _, test := range tests {
+		remoteURL, _ := vcs.ParseURL(test.url)
+		if got := partialCloneFilter(remoteURL); got != test.want {
+			t.Errorf("URL %q: want filter %q, got %q", test.url, test.want, got)
+		}
+	}
+}
+
+func TestPartialClone(t *testing.T) {
+	orig := partialCloneFilters
+	t.Cleanup(func() { partialCloneFilters = orig })
+
+	ctx := context.Background()
+
+	remote := t.TempDir()
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	}
+	makeSingleCommitRepo(cmd)
+	cmd("sh", "-c", "mkdir docs && echo read me > docs/README")
+	cmd("git", "add", "docs")
+	cmd("git", "commit", "-m", "docs")
+	// The remote must allow fetching with filters and fetching objects by ID.
+	cmd("git", "config", "uploadpack.allowFilter", "true")
+	cmd("git", "config", "uploadpack.allowAnySHA1InWant", "true")
+
+	partialCloneFilters = func() interface{} {
+		return buildPartialCloneFilters([]*schema.GitPartialCloneMapping{{DomainPath: remote}})
+	}
+
+	remoteURL, err := vcs.ParseURL("file://" + remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpPath := filepath.Join(t.TempDir(), ".git")
+	dir := GitDir(tmpPath)
+	cloneCmd, err := (&GitRepoSyncer{}).CloneCommand(ctx, remoteURL, tmpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output, err := runWithRemoteOpts(ctx, cloneCmd, nil); err != nil {
+		t.Fatalf("clone failed: %s\nOutput: %s", err, output)
+	}
+
+	if !isPartialClone(dir) {
+		t.Fatal("expected a partial clone")
+	}
+	if filter := partialCloneFilterOf(dir); filter != "blob:none" {
+		t.Fatalf("want filter blob:none, got %q", filter)
+	}
+
+	missing, err := missingObjects(ctx, dir, "HEAD", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 2 {
+		t.Fatalf("want 2 missing blobs, got %v", missing)
+	}
+	missing, err = missingObjects(ctx, dir, "HEAD", []string{"docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 1 {
+		t.Fatalf("want 1 missing blob below docs, got %v", missing)
+	}
+
+	s := &Server{GetRemoteURLFunc: staticGetRemoteURL("file://" + remote)}
+	if err := s.fetchMissingObjects(ctx, api.RepoName("example.com/foo/bar"), dir, "test", "HEAD", nil); err != nil {
+		t.Fatal(err)
+	}
+	if missing, err = missingObjects(ctx, dir, "HEAD", nil); err != nil {
+		t.Fatal(err)
+	} else if len(missing) != 0 {
+		t.Fatalf("want no missing blobs after fetching them, got %v", missing)
+	}
+
+	// Fetches keep the clone partial.
+	cmd("sh", "-c", "echo new > new.txt")
+	cmd("git", "add", "new.txt")
+	cmd("git", "commit", "-m", "new")
+	if err := (&GitRepoSyncer{}).Fetch(ctx, remoteURL, dir); err != nil {
+		t.Fatal(err)
+	}
+	if missing, err = missingObjects(ctx, dir, "HEAD", nil); err != nil {
+		t.Fatal(err)
+	} else if len(missing) != 1 {
+		t.Fatalf("want the new blob to be missing, got %v", missing)
+	}
+
+	// Commands fetch missing objects on demand with the remote in their
+	// environment.
+	show := runCmdWithEnv(t, tmpPath, partialCloneCommandEnv(remoteURL), "git", "show", "HEAD:new.txt")
+	if strings.TrimSpace(show) != "new" {
+		t.Fatalf("unexpected content of new.txt: %q", show)
+	}
+}
+
+func runCmdWithEnv(t *testing.T, dir string, env []string, name string, arg ...string) string {
+	t.Helper()
+	c := exec.Command(name, arg...)
+	c.Dir = dir
+	c.Env = env
+	b, err := c.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s %s failed: %s\nOutput: %s", name, strings.Join(arg, " "), err, b)
+	}
+	return string(b)
+}
+
+func TestParseGitVersion(t *testing.T) {
+	tests := []struct {
+		out    string
+		want   [2]int
+		wantOK bool
+	}{
+		{out: "git version 2.26.3\n", want: [2]int{2, 26}, wantOK: true},
+		{out: "git version 2.31.0", want: [2]int{2, 31}, wantOK: true},
+		{out: "git version 2.24.3 (Apple Git-128)", want: [2]int{2, 24}, wantOK: true},
+		{out: "garbage", wantOK: false},
+	}
+	for _, test := range tests {
+		got, ok := parseGitVersion(test.out)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("%q: want %v, %v, got %v, %v", test.out, test.want, test.wantOK, got, ok)
+		}
+	}
+
+	if !versionLess([2]int{2, 26}, minPartialCloneGitVersion) || versionLess([2]int{2, 31}, minPartialCloneGitVersion) {
+		t.Errorf("unexpected comparison with minimum version %v", minPartialCloneGitVersion)
+	}
+}
+
+func TestPartialCloneRemoteURL(t *testing.T) {
+	calls := 0
+	s := &Server{
+		GetRemoteURLFunc: func(context.Context, api.RepoName) (string, error) {
+			calls++
+			return "https://github.com/foo/monorepo", nil
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		remoteURL, err := s.partialCloneRemoteURL(context.Background(), "github.com/foo/monorepo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := remoteURL.String(), "https://github.com/foo/monorepo"; got != want {
+			t.Errorf("want remote URL %q, got %q", want, got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("want the remote URL to be looked up once, got %d lookups", calls)
+	}
+}
@@ -223,7 +223,43 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	cmtHash := strings.TrimSpace(string(out))
 
 	// Move objects from tmpObjectsDir to repoObjectsDir.
-	err = filepath.Walk(tmpObjectsDir, func(path string, info fs.FileInfo, err error) error {
+	if err := moveObjects(tmpObjectsDir, repoObjectsDir); err != nil {
+		resp.SetError(repo, "", "", errors.Wrap(err, "copying git objects"))
+		return http.StatusInternalServerError, resp
+	}
+
+	if req.Push != nil {
+		var closeAgent func()
+		cmd, closeAgent, err = pushCmd(ctx, repoGitDir, remoteURL, req.Push, cmtHash, ref)
+		if err != nil {
+			resp.SetError(repo, "", "", err)
+			return http.StatusInternalServerError, resp
+		}
+		defer closeAgent()
+
+		if out, err = run(cmd, "pushing ref"); err != nil {
+			log15.Error("Failed to push", "ref", ref, "commit", cmtHash, "output", string(out))
+			return http.StatusInternalServerError, resp
+		}
+	}
+
+	resp.Rev = "refs/" + strings.TrimPrefix(ref, "refs/")
+
+	cmd = exec.CommandContext(ctx, "git", "update-ref", "--", ref, cmtHash)
+	cmd.Dir = repoGitDir
+
+	if out, err = run(cmd, "creating ref"); err != nil {
+		log15.Error("Failed to create ref for commit.", "ref", ref, "commit", cmtHash, "output", string(out))
+		return http.StatusInternalServerError, resp
+	}
+
+	return http.StatusOK, resp
+}
+
+// moveObjects moves the objects of a temporary repository from tmpObjectsDir
+// to repoObjectsDir.
+func moveObjects(tmpObjectsDir, repoObjectsDir string) error {
+	return filepath.Walk(tmpObjectsDir, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -245,57 +281,39 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 		}
 		return nil
 	})
-	if err != nil {
-		resp.SetError(repo, "", "", errors.Wrap(err, "copying git objects"))
-		return http.StatusInternalServerError, resp
-	}
-
-	if req.Push != nil {
-		cmd = exec.CommandContext(ctx, "git", "push", "--force", remoteURL.String(), fmt.Sprintf("%s:%s", cmtHash, ref))
-		cmd.Dir = repoGitDir
-
-		// If the protocol is SSH and a private key was given, we want to
-		// use it for communication with the code host.
-		if remoteURL.IsSSH() && req.Push.PrivateKey != "" && req.Push.Passphrase != "" {
-			// We set up an agent here, which sets up a socket that can be provided to
-			// SSH via the $SSH_AUTH_SOCK environment variable and the goroutine to drive
-			// it in the background.
-			// This is used to pass the private key to be used when pushing to the remote,
-			// without the need to store it on the disk.
-			agent, err := newSSHAgent([]byte(req.Push.PrivateKey), []byte(req.Push.Passphrase))
-			if err != nil {
-				resp.SetError(repo, "", "", errors.Wrap(err, "gitserver: error creating ssh-agent"))
-				return http.StatusInternalServerError, resp
-			}
-			go agent.Listen()
-			// Make sure we shut this down once we're done.
-			defer agent.Close()
-
-			cmd.Env = append(
-				os.Environ(),
-				[]string{
-					fmt.Sprintf("SSH_AUTH_SOCK=%s", agent.Socket()),
-				}...,
-			)
-		}
-
-		if out, err = run(cmd, "pushing ref"); err != nil {
-			log15.Error("Failed to push", "ref", ref, "commit", cmtHash, "output", string(out))
-			return http.StatusInternalServerError, resp
-		}
-	}
-
-	resp.Rev = "refs/" + strings.TrimPrefix(ref, "refs/")
+}
 
-	cmd = exec.CommandContext(ctx, "git", "update-ref", "--", ref, cmtHash)
+// pushCmd returns the command force pushing commit to ref of remoteURL. The
+// returned func must be called once the command has run.
+func pushCmd(ctx context.Context, repoGitDir string, remoteURL *vcs.URL, push *protocol.PushConfig, commit, ref string) (*exec.Cmd, func(), error) {
+	cmd := exec.CommandContext(ctx, "git", "push", "--force", remoteURL.String(), fmt.Sprintf("%s:%s", commit, ref))
 	cmd.Dir = repoGitDir
 
-	if out, err = run(cmd, "creating ref"); err != nil {
-		log15.Error("Failed to create ref for commit.", "ref", ref, "commit", cmtHash, "output", string(out))
-		return http.StatusInternalServerError, resp
+	// If the protocol is SSH and a private key was given, we want to
+	// use it for communication with the code host.
+	if remoteURL.IsSSH() && push.PrivateKey != "" && push.Passphrase != "" {
+		// We set up an agent here, which sets up a socket that can be provided to
+		// SSH via the $SSH_AUTH_SOCK environment variable and the goroutine to drive
+		// it in the background.
+		// This is used to pass the private key to be used when pushing to the remote,
+		// without the need to store it on the disk.
+		agent, err := newSSHAgent([]byte(push.PrivateKey), []byte(push.Passphrase))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "gitserver: error creating ssh-agent")
+		}
+		go agent.Listen()
+
+		cmd.Env = append(
+			os.Environ(),
+			[]string{
+				fmt.Sprintf("SSH_AUTH_SOCK=%s", agent.Socket()),
+			}...,
+		)
+		// Make sure we shut this down once we're done.
+		return cmd, func() { agent.Close() }, nil
 	}
 
-	return http.StatusOK, resp
+	return cmd, func() {}, nil
 }
 
 func cleanUpTmpRepo(path string) {
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+var (
+	rebalancePending = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_rebalance_pending",
+		Help: "Number of repos waiting to be transferred to this gitserver from the gitserver which owned them before",
+	})
+	rebalanceTransfers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_rebalance_transfers_total",
+		Help: "Incremented each time a repo transfer from another gitserver completes",
+	}, []string{"success"})
+)
+
+// RebalanceRepos transfers the repos which moved to this gitserver when the
+// gitserver addresses changed from the gitserver which owned them before,
+// instead of recloning them from the code host. It is expected to run in a
+// background goroutine. At most perMinute transfers are started per minute.
+func (s *Server) RebalanceRepos(interval time.Duration, perMinute int) {
+	if perMinute <= 0 {
+		perMinute = 1
+	}
+	limiter := rate.NewLimiter(rate.Limit(float64(perMinute)/60), 1)
+
+	for {
+		if s.Rebalance {
+			addrs := conf.Get().ServiceConnections().GitServers
+			if err := s.rebalanceRepos(addrs, limiter); err != nil {
+				log15.Error("Rebalancing repos", "error", err)
+			}
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+type repoTransfer struct {
+	repo api.RepoName
+	from string
+}
+
+func (s *Server) rebalanceRepos(addrs []string, limiter *rate.Limiter) error {
+	if s.DB == nil || len(addrs) == 0 {
+		return nil
+	}
+
+	ctx := s.ctx
+
+	// We collect all transfers before starting any, so that we don't keep the
+	// query open for the duration of the transfers.
+	var transfers []repoTransfer
+	err := database.GitserverRepos(s.DB).IterateRepoGitserverStatus(ctx, database.IterateRepoGitserverStatusOptions{}, func(repo types.RepoGitserverStatus) error {
+		from := s.rebalanceSourceFor(repo.Name, repo.GitserverRepo, addrs)
+		if from == "" || repoCloned(s.dir(repo.Name)) {
+			return nil
+		}
+		transfers = append(transfers, repoTransfer{repo: repo.Name, from: from})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rebalancePending.Set(float64(len(transfers)))
+	if len(transfers) > 0 {
+		log15.Info("rebalancing repos", "count", len(transfers))
+	}
+
+	for _, t := range transfers {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		_, err := s.cloneRepo(ctx, t.repo, &cloneOptions{Block: true, MigrateFrom: t.from})
+		if err != nil {
+			log15.Warn("Transferring repo from gitserver", "repo", t.repo, "from", t.from, "error", err)
+		}
+		rebalancePending.Dec()
+	}
+
+	return nil
+}
+
+// rebalanceSource returns the address of the gitserver repo should be
+// transferred from, or an empty string if it should be cloned from its code
+// host.
+func (s *Server) rebalanceSource(ctx context.Context, repo api.RepoName) string {
+	if !s.Rebalance || s.DB == nil {
+		return ""
+	}
+	gr, err := database.GitserverRepos(s.DB).GetByName(ctx, repo)
+	if err != nil {
+		return ""
+	}
+	return s.rebalanceSourceFor(repo, gr, conf.Get().ServiceConnections().GitServers)
+}
+
+// rebalanceSourceFor returns the address of the gitserver repo should be
+// transferred from given its gitserver_repos row. We transfer a repo to its
+// primary gitserver if another gitserver in addrs has cloned it, or if a
+// transfer from another gitserver was started before.
+func (s *Server) rebalanceSourceFor(repo api.RepoName, gr *types.GitserverRepo, addrs []string) string {
+	if gr == nil || len(addrs) == 0 {
+		return ""
+	}
+	if !s.hostnameMatch(gitserver.AddrForRepo(repo, addrs)) {
+		return ""
+	}
+
+	if gr.RebalanceFrom != "" {
+		for _, addr := range addrs {
+			if addr == gr.RebalanceFrom && !s.hostnameMatch(addr) {
+				return addr
+			}
+		}
+		return ""
+	}
+
+	if gr.CloneStatus != types.CloneStatusCloned || gr.ShardID == "" || gr.ShardID == s.Hostname {
+		return ""
+	}
+	for _, addr := range addrs {
+		if hostnameMatch(gr.ShardID, addr) {
+			return addr
+		}
+	}
+	// The gitserver which owned the repo is gone.
+	return ""
+}
+
+// peerRemoteURL returns the URL of repo on the git service of the gitserver
+// at addr.
+func peerRemoteURL(addr string, repo api.RepoName) (*vcs.URL, error) {
+	return vcs.ParseURL("http://" + addr + "/git/" + string(repo))
+}
+
+// peerCloneCommand returns the command which fetches all refs of a repo from
+// another gitserver into the bare repository at tmpPath.
+func peerCloneCommand(ctx context.Context, peerURL *vcs.URL, tmpPath string) (*exec.Cmd, error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "clone setup failed")
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "fetch", "--progress", peerURL.String(), "+refs/*:refs/*")
+	cmd.Dir = tmpPath
+	return cmd, nil
+}
+
+func (s *Server) setRebalanceStartedNonFatal(ctx context.Context, name api.RepoName, from string) {
+	if s.DB == nil {
+		return
+	}
+	if err := database.GitserverRepos(s.DB).SetRebalanceStarted(ctx, name, from); err != nil {
+		log15.Warn("Setting rebalance started in DB", "error", err)
+	}
+}
+
+func (s *Server) setRebalanceFinishedNonFatal(ctx context.Context, name api.RepoName) {
+	if s.DB == nil || !s.Rebalance {
+		return
+	}
+	if err := database.GitserverRepos(s.DB).SetRebalanceFinished(ctx, name); err != nil {
+		log15.Warn("Setting rebalance finished in DB", "error", err)
+	}
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestServer_rebalanceSourceFor(t *testing.T) {
+	addrs := []string{"gitserver-1:3178", "gitserver-2:3178", "gitserver-3:3178"}
+	repo := api.RepoName("repo1") // owned by gitserver-3
+
+	testCases := []struct {
+		name     string
+		hostname string
+		gr       *types.GitserverRepo
+		want     string
+	}{
+		{
+			name:     "cloned on previous owner",
+			hostname: "gitserver-3",
+			gr:       &types.GitserverRepo{ShardID: "gitserver-1", CloneStatus: types.CloneStatusCloned},
+			want:     "gitserver-1:3178",
+		},
+		{
+			name:     "not owner",
+			hostname: "gitserver-2",
+			gr:       &types.GitserverRepo{ShardID: "gitserver-1", CloneStatus: types.CloneStatusCloned},
+			want:     "",
+		},
+		{
+			name:     "not cloned on previous owner",
+			hostname: "gitserver-3",
+			gr:       &types.GitserverRepo{ShardID: "gitserver-1", CloneStatus: types.CloneStatusNotCloned},
+			want:     "",
+		},
+		{
+			name:     "already owned",
+			hostname: "gitserver-3",
+			gr:       &types.GitserverRepo{ShardID: "gitserver-3", CloneStatus: types.CloneStatusCloned},
+			want:     "",
+		},
+		{
+			name:     "previous owner is gone",
+			hostname: "gitserver-3",
+			gr:       &types.GitserverRepo{ShardID: "gitserver-4", CloneStatus: types.CloneStatusCloned},
+			want:     "",
+		},
+		{
+			name:     "transfer in progress",
+			hostname: "gitserver-3",
+			gr:       &types.GitserverRepo{ShardID: "gitserver-3", CloneStatus: types.CloneStatusCloning, RebalanceFrom: "gitserver-2:3178"},
+			want:     "gitserver-2:3178",
+		},
+		{
+			name:     "no row",
+			hostname: "gitserver-3",
+			want:     "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Server{Hostname: tc.hostname}
+			if got := s.rebalanceSourceFor(repo, tc.gr, addrs); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCloneRepo_FromGitserver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	remote := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+
+	cmd := func(dir, name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, dir, name, arg...)
+	}
+	wantCommit := makeSingleCommitRepo(func(name string, arg ...string) string {
+		return cmd(remote, name, arg...)
+	})
+
+	// The previous owner clones the repo from the code host.
+	from := makeTestServer(ctx, t.TempDir(), remote, nil)
+	if _, err := from.cloneRepo(ctx, repoName, &cloneOptions{Block: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/git/", http.StripPrefix("/git", from.gitServiceHandler()))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	// The new owner can't reach the code host, so the clone only succeeds if
+	// it is transferred from the previous owner.
+	to := makeTestServer(ctx, t.TempDir(), filepath.Join(remote, "does-not-exist"), nil)
+	if _, err := to.cloneRepo(ctx, repoName, &cloneOptions{Block: true, MigrateFrom: u.Host}); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := to.dir(repoName)
+	if gotCommit := cmd(filepath.Dir(string(dst)), "git", "rev-parse", "HEAD"); gotCommit != wantCommit {
+		t.Fatalf("failed to transfer repo: got HEAD %s, want %s", gotCommit, wantCommit)
+	}
+}
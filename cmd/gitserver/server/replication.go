@@ -0,0 +1,107 @@
+package server
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var reconcileReplicasCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_reconcile_replicas_total",
+	Help: "Incremented each time we check a repo this gitserver keeps a replica of",
+}, []string{"type"})
+
+// isSecondaryReplica returns true if this gitserver keeps a copy of repo but
+// isn't its primary. The primary owns the state of the repo in the
+// gitserver_repos table, so secondary replicas must not write it.
+func (s *Server) isSecondaryReplica(repo api.RepoName) bool {
+	factor := conf.GitReplicationFactor()
+	if factor <= 1 {
+		return false
+	}
+	return s.secondaryReplicaOf(repo, conf.Get().ServiceConnections().GitServers, factor)
+}
+
+func (s *Server) secondaryReplicaOf(repo api.RepoName, addrs []string, factor int) bool {
+	if len(addrs) == 0 {
+		return false
+	}
+	replicas := gitserver.ReplicaAddrsForRepo(repo, addrs, factor)
+	for _, addr := range replicas[1:] {
+		if s.hostnameMatch(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReconcileReplicas clones the repos this gitserver should keep a replica of
+// but which are missing on disk. It is expected to run in a background
+// goroutine. At most maxClones clones are started per run.
+func (s *Server) ReconcileReplicas(interval time.Duration, maxClones int) {
+	for {
+		addrs := conf.Get().ServiceConnections().GitServers
+		if factor := conf.GitReplicationFactor(); factor > 1 && len(addrs) > 1 {
+			if err := s.reconcileReplicas(addrs, factor, maxClones); err != nil {
+				log15.Error("Reconciling gitserver replicas", "error", err)
+			}
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// reconcileReplicas starts a clone of each repo which this gitserver is a
+// secondary replica of, if the primary has cloned it but we haven't.
+func (s *Server) reconcileReplicas(addrs []string, factor, maxClones int) error {
+	if s.DB == nil {
+		return nil
+	}
+
+	ctx := s.ctx
+	clones := 0
+	errStop := errors.New("reached maximum number of clones")
+
+	err := database.GitserverRepos(s.DB).IterateRepoGitserverStatus(ctx, database.IterateRepoGitserverStatusOptions{}, func(repo types.RepoGitserverStatus) error {
+		if !s.secondaryReplicaOf(repo.Name, addrs, factor) {
+			return nil
+		}
+
+		// We only replicate repos the primary has cloned. Until then the
+		// regular update scheduler takes care of cloning.
+		if repo.GitserverRepo == nil || repo.CloneStatus != types.CloneStatusCloned {
+			reconcileReplicasCounter.WithLabelValues("primary_not_cloned").Inc()
+			return nil
+		}
+
+		dir := s.dir(repo.Name)
+		if _, cloning := s.locker.Status(dir); cloning || repoCloned(dir) {
+			reconcileReplicasCounter.WithLabelValues("ok").Inc()
+			return nil
+		}
+
+		if clones >= maxClones {
+			return errStop
+		}
+		clones++
+
+		reconcileReplicasCounter.WithLabelValues("repair").Inc()
+		if _, err := s.cloneRepo(ctx, repo.Name, nil); err != nil {
+			log15.Warn("Repairing gitserver replica", "repo", repo.Name, "error", err)
+		}
+		return nil
+	})
+	if err == errStop {
+		return nil
+	}
+	return err
+}
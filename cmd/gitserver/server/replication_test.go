@@ -0,0 +1,35 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestServer_secondaryReplicaOf(t *testing.T) {
+	addrs := []string{"gitserver-1:3178", "gitserver-2:3178", "gitserver-3:3178"}
+	repo := api.RepoName("repo1") // primary is gitserver-3
+
+	testCases := []struct {
+		hostname string
+		factor   int
+		want     bool
+	}{
+		{hostname: "gitserver-3", factor: 2, want: false},
+		{hostname: "gitserver-1", factor: 2, want: true},
+		{hostname: "gitserver-2", factor: 2, want: false},
+		{hostname: "gitserver-2", factor: 3, want: true},
+		{hostname: "gitserver-1", factor: 1, want: false},
+	}
+
+	for _, tc := range testCases {
+		s := &Server{Hostname: tc.hostname}
+		if got := s.secondaryReplicaOf(repo, addrs, tc.factor); got != tc.want {
+			t.Errorf("%s with factor %d: got %v, want %v", tc.hostname, tc.factor, got, tc.want)
+		}
+	}
+
+	if (&Server{Hostname: "gitserver-1"}).secondaryReplicaOf(repo, nil, 2) {
+		t.Error("expected no replicas without gitserver addresses")
+	}
+}
@@ -113,8 +113,22 @@ func (s *Server) handleReposStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var stats protocol.ReposStats
+	if err := json.Unmarshal(b, &stats); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode %s: %v", reposStatsName, err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	// The maintenance state is kept in memory, since maintenance runs
+	// separately from the janitor which computes the other statistics.
+	s.maintenanceMu.Lock()
+	stats.Maintenance = s.maintenanceStats
+	s.maintenanceMu.Unlock()
+
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	_, _ = w.Write(b)
+	if err := json.NewEncoder(w).Encode(&stats); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
 
 func (s *Server) handleRepoCloneProgress(w http.ResponseWriter, r *http.Request) {
@@ -211,6 +211,11 @@ type Server struct {
 	// actual hostname but can also be overridden by the HOSTNAME environment variable.
 	Hostname string
 
+	// Rebalance enables transferring repos which moved to this gitserver
+	// from the gitserver which owned them before, instead of recloning them
+	// from the code host.
+	Rebalance bool
+
 	// shared db handle
 	DB dbutil.DB
 
@@ -242,8 +247,14 @@ type Server struct {
 	// per gitserver instance
 	rpsLimiter *rate.Limiter
 
+	remoteURLsMu sync.Mutex // protects remoteURLs
+	remoteURLs   map[api.RepoName]cachedRemoteURL
+
 	repoUpdateLocksMu sync.Mutex // protects the map below and also updates to locks.once
 	repoUpdateLocks   map[api.RepoName]*locks
+
+	maintenanceMu    sync.Mutex // protects maintenanceStats
+	maintenanceStats protocol.RepoMaintenanceStats
 }
 
 type locks struct {
@@ -355,6 +366,8 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/repo-update", s.handleRepoUpdate)
 	mux.HandleFunc("/getGitolitePhabricatorMetadata", s.handleGetGitolitePhabricatorMetadata)
 	mux.HandleFunc("/create-commit-from-patch", s.handleCreateCommitFromPatch)
+	mux.HandleFunc("/update-branch", s.handleUpdateBranch)
+	mux.HandleFunc("/blame-stats", s.handleBlameStats)
 	mux.HandleFunc("/ping", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
@@ -486,15 +499,19 @@ func (s *Server) cloneJobConsumer(ctx context.Context, jobs <-chan *cloneJob) {
 // hostnameMatch checks whether the hostname matches the given address.
 // If we don't find an exact match, we look at the initial prefix.
 func (s *Server) hostnameMatch(addr string) bool {
-	if !strings.HasPrefix(addr, s.Hostname) {
+	return hostnameMatch(s.Hostname, addr)
+}
+
+func hostnameMatch(hostname, addr string) bool {
+	if !strings.HasPrefix(addr, hostname) {
 		return false
 	}
-	if addr == s.Hostname {
+	if addr == hostname {
 		return true
 	}
-	// We know that s.Hostname is shorter than addr so we can safely check the next
+	// We know that hostname is shorter than addr so we can safely check the next
 	// char
-	next := addr[len(s.Hostname)]
+	next := addr[len(hostname)]
 	return next == '.' || next == ':'
 }
 
@@ -588,6 +605,13 @@ func (s *Server) syncRepoState(addrs []string, batchSize, perSecond int, fullSyn
 		cloned := repoCloned(dir)
 		_, cloning := s.locker.Status(dir)
 
+		if s.Rebalance && !cloned && !cloning && s.rebalanceSourceFor(repo.Name, repo.GitserverRepo, addrs) != "" {
+			// The repo will be transferred from the gitserver which owned it
+			// before. Until then shard_id points to that gitserver.
+			repoSyncStateCounter.WithLabelValues("rebalance_pending").Inc()
+			return nil
+		}
+
 		var shouldUpdate bool
 		if repo.GitserverRepo == nil {
 			repo.GitserverRepo = &types.GitserverRepo{
@@ -907,7 +931,24 @@ func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
 	req.Args = append(req.Args, treeish, "--")
 	req.Args = append(req.Args, paths...)
 
-	s.exec(w, r, req)
+	// git archive would fetch the objects missing in partial clones one at a
+	// time, so we fetch them in one batch first.
+	if dir := s.dir(protocol.NormalizeRepo(req.Repo)); repoCloned(dir) && isPartialClone(dir) {
+		if err := s.fetchMissingObjects(r.Context(), req.Repo, dir, "archive", treeish, paths); err != nil {
+			log15.Warn("failed to fetch missing objects for archive", "repo", repo, "treeish", treeish, "error", err)
+		}
+	}
+
+	// Searcher and symbols read tar archives, in which we replace the LFS
+	// pointers whose objects were fetched with the objects.
+	var stdoutFilter func(io.Writer) io.WriteCloser
+	if dir := s.dir(protocol.NormalizeRepo(req.Repo)); format == "tar" && hasLFSObjects(dir) {
+		stdoutFilter = func(w io.Writer) io.WriteCloser {
+			return lfsTarWriter(dir, w)
+		}
+	}
+
+	s.exec(w, r, req, stdoutFilter)
 }
 
 func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
@@ -928,6 +969,21 @@ func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
 		otlog.Int("limit", args.Limit),
 	)
 
+	// While the repository is transferred to this gitserver, the client
+	// searches the gitserver it is transferred from.
+	if repo := protocol.NormalizeRepo(args.Repo); !repoCloned(s.dir(repo)) {
+		if from := s.rebalanceSource(ctx, repo); from != "" {
+			cloneProgress, _ := s.cloneRepo(ctx, repo, nil)
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&protocol.NotFoundPayload{
+				CloneInProgress: true,
+				CloneProgress:   cloneProgress,
+				MovingFrom:      from,
+			})
+			return
+		}
+	}
+
 	searchStart := time.Now()
 	searchRunning.Inc()
 	defer searchRunning.Dec()
@@ -1061,6 +1117,17 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 			IncludeDiff: args.IncludeDiff,
 		}
 
+		// Diffs need the blobs which are missing in partial clones.
+		packs := promisorPacks(dir)
+		if packs > 0 && args.IncludeDiff {
+			if searcher.Env, err = s.partialCloneEnv(ctx, args.Repo); err != nil {
+				return err
+			}
+			defer func(start time.Time) {
+				observeLazyFetches(dir, "search", packs, time.Since(start))
+			}(time.Now())
+		}
+
 		return searcher.Search(ctx, func(match *protocol.CommitMatch) {
 			select {
 			case <-done:
@@ -1128,10 +1195,12 @@ func (s *Server) handleExec(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	s.exec(w, r, &req)
+	s.exec(w, r, &req, nil)
 }
 
-func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.ExecRequest) {
+// exec runs the git command req. If stdoutFilter is not nil, the output of
+// the command is written to the writer it returns for the response body.
+func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.ExecRequest, stdoutFilter func(io.Writer) io.WriteCloser) {
 	// Flush writes more aggressively than standard net/http so that clients
 	// with a context deadline see as much partial response body as possible.
 	if fw := newFlushingResponseWriter(w); fw != nil {
@@ -1254,6 +1323,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 			_ = json.NewEncoder(w).Encode(&protocol.NotFoundPayload{
 				CloneInProgress: true,
 				CloneProgress:   cloneProgress,
+				MovingFrom:      s.rebalanceSource(ctx, req.Repo),
 			})
 			return
 		}
@@ -1271,6 +1341,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 		_ = json.NewEncoder(w).Encode(&protocol.NotFoundPayload{
 			CloneInProgress: true,
 			CloneProgress:   cloneProgress,
+			MovingFrom:      s.rebalanceSource(ctx, req.Repo),
 		})
 		return
 	}
@@ -1316,8 +1387,15 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 		}
 	}
 
+	var stdout io.Writer = w
+	var filter io.WriteCloser
+	if stdoutFilter != nil {
+		filter = stdoutFilter(w)
+		stdout = filter
+	}
+
 	var stderrBuf bytes.Buffer
-	stdoutW := &writeCounter{w: w}
+	stdoutW := &writeCounter{w: stdout}
 	stderrW := &writeCounter{w: &limitWriter{W: &stderrBuf, N: 1024}}
 
 	cmdStart = time.Now()
@@ -1326,7 +1404,25 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	cmd.Stdout = stdoutW
 	cmd.Stderr = stderrW
 
-	exitStatus, execErr = runCommand(ctx, cmd)
+	// Git fetches the objects which are missing in partial clones on demand.
+	packs := promisorPacks(dir)
+	if packs > 0 {
+		if cmd.Env, execErr = s.partialCloneEnv(ctx, req.Repo); execErr != nil {
+			execErr = errors.Wrap(execErr, "configuring fetching of missing objects")
+		}
+	}
+
+	if execErr == nil {
+		exitStatus, execErr = runCommand(ctx, cmd)
+		if packs > 0 {
+			observeLazyFetches(dir, req.Args[0], packs, time.Since(cmdStart))
+		}
+	}
+	if filter != nil {
+		if err := filter.Close(); err != nil && execErr == nil {
+			execErr = errors.Wrap(err, "filtering output")
+		}
+	}
 
 	status = strconv.Itoa(exitStatus)
 	stdoutN = stdoutW.n
@@ -1500,14 +1596,14 @@ func (s *Server) p4exec(w http.ResponseWriter, r *http.Request, req *protocol.P4
 }
 
 func (s *Server) setLastError(ctx context.Context, name api.RepoName, error string) (err error) {
-	if s.DB == nil {
+	if s.DB == nil || s.isSecondaryReplica(name) {
 		return nil
 	}
 	return database.GitserverRepos(s.DB).SetLastError(ctx, name, error, s.Hostname)
 }
 
 func (s *Server) setLastFetched(ctx context.Context, name api.RepoName) error {
-	if s.DB == nil {
+	if s.DB == nil || s.isSecondaryReplica(name) {
 		return nil
 	}
 
@@ -1542,7 +1638,7 @@ func (s *Server) setLastErrorNonFatal(ctx context.Context, name api.RepoName, er
 }
 
 func (s *Server) setCloneStatus(ctx context.Context, name api.RepoName, status types.CloneStatus) (err error) {
-	if s.DB == nil {
+	if s.DB == nil || s.isSecondaryReplica(name) {
 		return nil
 	}
 	return database.GitserverRepos(s.DB).SetCloneStatus(ctx, name, status, s.Hostname)
@@ -1592,12 +1688,9 @@ type cloneOptions struct {
 	// Overwrite will overwrite the existing clone.
 	Overwrite bool
 
-	// MigrateFrom is the name of the gitserver instance which is the current owner of the
-	// repository. If this is a non-zero string, then gitserver will attempt to clone the repo from
-	// the current gitserver instance instead of the upstream repo URL of the external service.
-	//
-	// Once migration is complete for all repos in Sourcegraph, there is no need for this attribute
-	// and it should be removed.
+	// MigrateFrom is the address of the gitserver instance which is the current owner of the
+	// repository. If this is a non-zero string, then gitserver will clone the repo from the git
+	// service of that gitserver instance instead of the upstream repo URL of the external service.
 	MigrateFrom string
 }
 
@@ -1621,6 +1714,16 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 		return "", errors.Wrap(err, "get VCS syncer")
 	}
 
+	if opts == nil || opts.MigrateFrom == "" {
+		if from := s.rebalanceSource(ctx, repo); from != "" {
+			o := cloneOptions{MigrateFrom: from}
+			if opts != nil {
+				o.Block, o.Overwrite = opts.Block, opts.Overwrite
+			}
+			opts = &o
+		}
+	}
+
 	// We may be attempting to clone a private repo so we need an internal actor.
 	remoteURL, err := s.getRemoteURL(actor.WithInternalActor(ctx), repo)
 	if err != nil {
@@ -1638,13 +1741,31 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 	}
 	defer cancel()
 
-	if err = s.rpsLimiter.Wait(ctx); err != nil {
-		return "", err
+	if opts != nil && opts.MigrateFrom != "" {
+		peerURL, err := peerRemoteURL(opts.MigrateFrom, repo)
+		if err == nil {
+			err = (&GitRepoSyncer{}).IsCloneable(ctx, peerURL)
+		}
+		if err != nil {
+			log15.Warn("cannot transfer repo from gitserver, cloning from code host", "repo", repo, "from", opts.MigrateFrom, "error", err)
+			s.setRebalanceFinishedNonFatal(ctx, repo)
+			o := *opts
+			o.MigrateFrom = ""
+			opts = &o
+		}
 	}
 
-	if err := syncer.IsCloneable(ctx, remoteURL); err != nil {
-		redactedErr := newURLRedactor(remoteURL).redact(err.Error())
-		return "", errors.Errorf("error cloning repo: repo %s not cloneable: %s", repo, redactedErr)
+	// Transfers from other gitservers don't count against the code host rate
+	// limit.
+	if opts == nil || opts.MigrateFrom == "" {
+		if err = s.rpsLimiter.Wait(ctx); err != nil {
+			return "", err
+		}
+
+		if err := syncer.IsCloneable(ctx, remoteURL); err != nil {
+			redactedErr := newURLRedactor(remoteURL).redact(err.Error())
+			return "", errors.Errorf("error cloning repo: repo %s not cloneable: %s", repo, redactedErr)
+		}
 	}
 
 	// Mark this repo as currently being cloned. We have to check again if someone else isn't already
@@ -1695,11 +1816,18 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 	return "", nil
 }
 
-func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syncer VCSSyncer, lock *RepositoryLock, remoteURL *vcs.URL, opts *cloneOptions) error {
+func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syncer VCSSyncer, lock *RepositoryLock, remoteURL *vcs.URL, opts *cloneOptions) (err error) {
 	defer lock.Release()
 
-	if err := s.rpsLimiter.Wait(ctx); err != nil {
-		return err
+	var migrateFrom string
+	if opts != nil {
+		migrateFrom = opts.MigrateFrom
+	}
+
+	if migrateFrom == "" {
+		if err := s.rpsLimiter.Wait(ctx); err != nil {
+			return err
+		}
 	}
 
 	ctx, cancel2 := context.WithTimeout(ctx, conf.GitLongCommandTimeout())
@@ -1727,6 +1855,23 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 	tmpPath = filepath.Join(tmpPath, ".git")
 	tmp := GitDir(tmpPath)
 
+	// When transferring the repo from another gitserver, we clone from its git
+	// service and read HEAD from there.
+	cloneURL, headSyncer := remoteURL, syncer
+	if migrateFrom != "" {
+		if cloneURL, err = peerRemoteURL(migrateFrom, repo); err != nil {
+			return err
+		}
+		headSyncer = &GitRepoSyncer{}
+
+		// Record the transfer before setting the clone status, which
+		// overwrites the shard of the gitserver we transfer from.
+		s.setRebalanceStartedNonFatal(ctx, repo, migrateFrom)
+		defer func() {
+			rebalanceTransfers.WithLabelValues(strconv.FormatBool(err == nil)).Inc()
+		}()
+	}
+
 	// It may already be cloned
 	if !repoCloned(dir) {
 		s.setCloneStatusNonFatal(ctx, repo, types.CloneStatusCloning)
@@ -1736,7 +1881,12 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 		s.setCloneStatusNonFatal(context.Background(), repo, cloneStatus(repoCloned(dir), false))
 	}()
 
-	cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
+	var cmd *exec.Cmd
+	if migrateFrom != "" {
+		cmd, err = peerCloneCommand(ctx, cloneURL, tmpPath)
+	} else {
+		cmd, err = syncer.CloneCommand(ctx, remoteURL, tmpPath)
+	}
 	if err != nil {
 		return errors.Wrap(err, "get clone command")
 	}
@@ -1746,12 +1896,12 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 
 	// see issue #7322: skip LFS content in repositories with Git LFS configured
 	cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
-	log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath)
+	log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath, "from", migrateFrom)
 
 	pr, pw := io.Pipe()
 	defer pw.Close()
 
-	go readCloneProgress(newURLRedactor(remoteURL), lock, pr, repo)
+	go readCloneProgress(newURLRedactor(cloneURL), lock, pr, repo)
 
 	if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
 		return errors.Wrapf(err, "clone failed. Output: %s", string(output))
@@ -1763,11 +1913,17 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 
 	removeBadRefs(ctx, tmp)
 
-	if err := setHEAD(ctx, tmp, syncer, repo, remoteURL); err != nil {
+	if err := setHEAD(ctx, tmp, headSyncer, repo, cloneURL); err != nil {
 		log15.Error("Failed to ensure HEAD exists", "repo", repo, "error", err)
 		return errors.Wrap(err, "failed to ensure HEAD exists")
 	}
 
+	// Fetch the LFS objects of the initial clone rather than waiting for
+	// the first update.
+	if _, ok := syncer.(*GitRepoSyncer); ok {
+		maybeFetchLFSObjects(ctx, remoteURL, tmp)
+	}
+
 	if err := setRepositoryType(tmp, syncer.Type()); err != nil {
 		return errors.Wrap(err, `git config set "sourcegraph.type"`)
 	}
@@ -1802,6 +1958,7 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 	if err := s.setLastFetched(ctx, repo); err != nil {
 		log15.Warn("failed setting last fetch in DB", "repo", repo, "error", err)
 	}
+	s.setRebalanceFinishedNonFatal(ctx, repo)
 
 	log15.Info("repo cloned", "repo", repo)
 	repoClonedCounter.Inc()
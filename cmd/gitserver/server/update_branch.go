@@ -0,0 +1,308 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+func (s *Server) handleUpdateBranch(w http.ResponseWriter, r *http.Request) {
+	var req protocol.UpdateBranchRequest
+	var resp protocol.UpdateBranchResponse
+	var status int
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp.SetError("", "", "", errors.Wrap(err, "decoding UpdateBranchRequest"))
+		status = http.StatusBadRequest
+	} else {
+		status, resp = s.updateBranch(r.Context(), req)
+	}
+
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// updateBranch rebases or merges the branch with the head req.HeadCommit onto
+// req.BaseCommit in a temporary repository. If that succeeds, the new objects
+// are moved into the repository, the branch is pushed if requested and
+// req.TargetRef is updated. Otherwise the conflicting files are reported with
+// http.StatusConflict.
+func (s *Server) updateBranch(ctx context.Context, req protocol.UpdateBranchRequest) (int, protocol.UpdateBranchResponse) {
+	var resp protocol.UpdateBranchResponse
+
+	repo := string(protocol.NormalizeRepo(req.Repo))
+	dir := s.dir(protocol.NormalizeRepo(req.Repo))
+	if !repoCloned(dir) {
+		resp.SetError(repo, "", "", errors.New("gitserver: repo does not exist"))
+		return http.StatusInternalServerError, resp
+	}
+
+	if req.Strategy != protocol.UpdateBranchRebase && req.Strategy != protocol.UpdateBranchMerge {
+		resp.SetError(repo, "", "", errors.Errorf("gitserver: unknown update branch strategy %q", req.Strategy))
+		return http.StatusBadRequest, resp
+	}
+	if req.TargetRef == "" {
+		resp.SetError(repo, "", "", errors.New("gitserver: no target ref given"))
+		return http.StatusBadRequest, resp
+	}
+	for _, rev := range []api.CommitID{req.HeadCommit, req.BaseCommit} {
+		if err := checkSpecArgSafety(string(rev)); err != nil || rev == "" {
+			resp.SetError(repo, "", "", errors.Errorf("gitserver: invalid commit %q", rev))
+			return http.StatusBadRequest, resp
+		}
+	}
+
+	var (
+		remoteURL *vcs.URL
+		err       error
+	)
+	if req.Push != nil && req.Push.RemoteURL != "" {
+		remoteURL, err = vcs.ParseURL(req.Push.RemoteURL)
+	} else {
+		remoteURL, err = s.getRemoteURL(ctx, req.Repo)
+	}
+	if err != nil {
+		log15.Error("Failed to get remote URL", "ref", req.TargetRef, "err", err)
+		resp.SetError(repo, "", "", errors.Wrap(err, "repoRemoteURL"))
+		return http.StatusInternalServerError, resp
+	}
+
+	redactor := newURLRedactor(remoteURL)
+	defer func() {
+		if resp.Error != nil {
+			resp.Error.Command = redactor.redact(resp.Error.Command)
+			resp.Error.CombinedOutput = redactor.redact(resp.Error.CombinedOutput)
+			if resp.Error.InternalError != "" {
+				resp.Error.InternalError = redactor.redact(resp.Error.InternalError)
+			}
+		}
+	}()
+
+	tmpRepoDir, err := s.tempDir("update-branch-")
+	if err != nil {
+		resp.SetError(repo, "", "", errors.Wrap(err, "gitserver: make tmp repo"))
+		return http.StatusInternalServerError, resp
+	}
+	defer cleanUpTmpRepo(tmpRepoDir)
+
+	tmpObjectsDir := filepath.Join(tmpRepoDir, ".git", "objects")
+	repoObjectsDir := dir.Path("objects")
+
+	committerName := req.CommitInfo.CommitterName
+	if committerName == "" {
+		committerName = "Sourcegraph"
+	}
+	committerEmail := req.CommitInfo.CommitterEmail
+	if committerEmail == "" {
+		committerEmail = "support@sourcegraph.com"
+	}
+	authorName := req.CommitInfo.AuthorName
+	if authorName == "" {
+		authorName = committerName
+	}
+	authorEmail := req.CommitInfo.AuthorEmail
+	if authorEmail == "" {
+		authorEmail = committerEmail
+	}
+	env := append(os.Environ(),
+		"GIT_DIR="+filepath.Join(tmpRepoDir, ".git"),
+		"GIT_ALTERNATE_OBJECT_DIRECTORIES="+repoObjectsDir,
+		"GIT_COMMITTER_NAME="+committerName,
+		"GIT_COMMITTER_EMAIL="+committerEmail,
+	)
+	if req.Strategy == protocol.UpdateBranchMerge {
+		// Rebased commits keep their authors.
+		env = append(env, "GIT_AUTHOR_NAME="+authorName, "GIT_AUTHOR_EMAIL="+authorEmail)
+	}
+	if !req.CommitInfo.Date.IsZero() {
+		date := req.CommitInfo.Date.Format(time.RFC3339)
+		env = append(env, "GIT_COMMITTER_DATE="+date)
+		if req.Strategy == protocol.UpdateBranchMerge {
+			env = append(env, "GIT_AUTHOR_DATE="+date)
+		}
+	}
+
+	run := func(cmd *exec.Cmd, reason string) ([]byte, error) {
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			resp.SetError(repo, strings.Join(cmd.Args, " "), string(out), errors.Wrap(err, "gitserver: "+reason))
+		}
+		return out, err
+	}
+	tmpGit := func(args ...string) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = tmpRepoDir
+		cmd.Env = env
+		return cmd
+	}
+
+	if _, err := run(tmpGit("init", "-q"), "init tmp repo"); err != nil {
+		return http.StatusInternalServerError, resp
+	}
+	if out, err := run(tmpGit("reset", "-q", "--hard", string(req.HeadCommit)), "checking out head"); err != nil {
+		log15.Error("Failed to check out the head of the branch.", "ref", req.TargetRef, "head", req.HeadCommit, "output", string(out))
+		return http.StatusInternalServerError, resp
+	}
+
+	var cmd *exec.Cmd
+	if req.Strategy == protocol.UpdateBranchRebase {
+		cmd = tmpGit("rebase", "-q", string(req.BaseCommit))
+	} else {
+		args := []string{"merge", "-q", "--no-edit"}
+		if req.CommitInfo.Message != "" {
+			args = append(args, "-m", req.CommitInfo.Message)
+		}
+		cmd = tmpGit(append(args, string(req.BaseCommit))...)
+	}
+	if out, err := run(cmd, string(req.Strategy)+" onto base"); err != nil {
+		conflicts, cerr := updateBranchConflicts(tmpGit, req.Strategy)
+		if cerr != nil || len(conflicts) == 0 {
+			log15.Error("Failed to update branch.", "ref", req.TargetRef, "base", req.BaseCommit, "output", string(out), "err", cerr)
+			return http.StatusInternalServerError, resp
+		}
+		resp.Error = nil
+		resp.Conflicts = conflicts
+		return http.StatusConflict, resp
+	}
+
+	// We don't use 'run' here as we only want stdout
+	cmd = tmpGit("rev-parse", "HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		resp.SetError(repo, strings.Join(cmd.Args, " "), string(out), errors.Wrap(err, "gitserver: retrieving new commit id"))
+		return http.StatusInternalServerError, resp
+	}
+	head := strings.TrimSpace(string(out))
+
+	if err := moveObjects(tmpObjectsDir, repoObjectsDir); err != nil {
+		resp.SetError(repo, "", "", errors.Wrap(err, "copying git objects"))
+		return http.StatusInternalServerError, resp
+	}
+
+	ref := req.TargetRef
+	if req.Push != nil {
+		ref = ensureRefPrefix(ref)
+
+		cmd, closeAgent, err := pushCmd(ctx, string(dir), remoteURL, req.Push, head, ref)
+		if err != nil {
+			resp.SetError(repo, "", "", err)
+			return http.StatusInternalServerError, resp
+		}
+		defer closeAgent()
+
+		if out, err := run(cmd, "pushing ref"); err != nil {
+			log15.Error("Failed to push", "ref", ref, "commit", head, "output", string(out))
+			return http.StatusInternalServerError, resp
+		}
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "update-ref", "--", ref, head)
+	dir.Set(cmd)
+	if out, err := run(cmd, "updating ref"); err != nil {
+		log15.Error("Failed to update ref.", "ref", ref, "commit", head, "output", string(out))
+		return http.StatusInternalServerError, resp
+	}
+
+	resp.Rev = "refs/" + strings.TrimPrefix(ref, "refs/")
+	resp.Commit = api.CommitID(head)
+	return http.StatusOK, resp
+}
+
+// updateBranchConflicts returns the unmerged files of a stopped rebase or
+// merge run by tmpGit. A rebase stops at the first commit which conflicts, so
+// only the conflicts of that commit are returned.
+func updateBranchConflicts(tmpGit func(args ...string) *exec.Cmd, strategy protocol.UpdateBranchStrategy) ([]protocol.UpdateBranchConflict, error) {
+	out, err := tmpGit("ls-files", "-u", "-z").Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing unmerged files")
+	}
+
+	var commit api.CommitID
+	if strategy == protocol.UpdateBranchRebase {
+		out, err := tmpGit("rev-parse", "--verify", "REBASE_HEAD").Output()
+		if err != nil {
+			return nil, errors.Wrap(err, "resolving the conflicting commit")
+		}
+		commit = api.CommitID(bytes.TrimSpace(out))
+	}
+
+	stages, err := parseUnmergedFiles(out)
+	if err != nil {
+		return nil, err
+	}
+	conflicts := make([]protocol.UpdateBranchConflict, 0, len(stages))
+	for path, s := range stages {
+		conflicts = append(conflicts, protocol.UpdateBranchConflict{
+			Path:   path,
+			Kind:   conflictKind(s, strategy),
+			Commit: commit,
+		})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Path < conflicts[j].Path })
+	return conflicts, nil
+}
+
+// unmergedStages are the index stages of an unmerged file: the common
+// ancestor (stage 1), "ours" (stage 2) and "theirs" (stage 3).
+type unmergedStages [4]bool
+
+// parseUnmergedFiles parses the output of git ls-files -u -z into the stages
+// of each unmerged file.
+func parseUnmergedFiles(out []byte) (map[string]unmergedStages, error) {
+	files := map[string]unmergedStages{}
+	for _, entry := range bytes.Split(out, []byte{0}) {
+		if len(entry) == 0 {
+			continue
+		}
+		// <mode> SP <object> SP <stage> TAB <path>
+		tab := bytes.IndexByte(entry, '\t')
+		if tab < 2 || entry[tab-2] != ' ' || entry[tab-1] < '1' || entry[tab-1] > '3' {
+			return nil, errors.Errorf("unexpected unmerged file entry %q", entry)
+		}
+		path := string(entry[tab+1:])
+		s := files[path]
+		s[entry[tab-1]-'0'] = true
+		files[path] = s
+	}
+	return files, nil
+}
+
+// conflictKind classifies the conflict of a file with stages. When rebasing,
+// "ours" is the base the commits are replayed onto. When merging, "ours" is the
+// branch.
+func conflictKind(stages unmergedStages, strategy protocol.UpdateBranchStrategy) protocol.ConflictKind {
+	ours, theirs := stages[2], stages[3]
+	switch {
+	case ours && theirs && !stages[1]:
+		return protocol.ConflictAddAdd
+	case ours && !theirs:
+		if strategy == protocol.UpdateBranchRebase {
+			return protocol.ConflictDeletedInBranch
+		}
+		return protocol.ConflictDeletedInBase
+	case theirs && !ours:
+		if strategy == protocol.UpdateBranchRebase {
+			return protocol.ConflictDeletedInBase
+		}
+		return protocol.ConflictDeletedInBranch
+	default:
+		return protocol.ConflictContent
+	}
+}
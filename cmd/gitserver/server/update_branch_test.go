@@ -0,0 +1,179 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestUpdateBranch(t *testing.T) {
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "example.com", "foo", "bar")
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return strings.TrimSpace(runCmd(t, repoDir, name, arg...))
+	}
+	commit := func(script string) api.CommitID {
+		t.Helper()
+		cmd("sh", "-c", script)
+		cmd("git", "add", "-A")
+		cmd("git", "commit", "-m", script)
+		return api.CommitID(cmd("git", "rev-parse", "HEAD"))
+	}
+
+	cmd("git", "init", ".")
+	root0 := commit("printf '1\\n2\\n3\\n' > a.txt && echo b > b.txt")
+	base := commit("printf '1\\n2\\n3 base\\n' > a.txt && echo b base > b.txt")
+	cmd("git", "checkout", "-q", "-b", "feature", string(root0))
+	feature := commit("printf '1 feature\\n2\\n3\\n' > a.txt")
+	cmd("git", "checkout", "-q", "-b", "conflicting", string(root0))
+	conflicting := commit("printf '1\\n2\\n3 conflicting\\n' > a.txt && rm b.txt")
+
+	s := &Server{ReposDir: root, GetRemoteURLFunc: staticGetRemoteURL("https://example.com/foo/bar")}
+	h := s.Handler()
+
+	updateBranch := func(t *testing.T, req protocol.UpdateBranchRequest) (int, protocol.UpdateBranchResponse) {
+		t.Helper()
+		req.Repo = "example.com/foo/bar"
+		req.BaseCommit = base
+		req.CommitInfo = protocol.PatchCommitInfo{
+			Message:        "Merge base",
+			CommitterName:  "bot",
+			CommitterEmail: "bot@example.com",
+			Date:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		body, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/update-branch", bytes.NewReader(body)))
+		var resp protocol.UpdateBranchResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return w.Code, resp
+	}
+
+	for _, strategy := range []protocol.UpdateBranchStrategy{protocol.UpdateBranchRebase, protocol.UpdateBranchMerge} {
+		t.Run(string(strategy), func(t *testing.T) {
+			ref := "refs/heads/feature-" + string(strategy)
+			code, resp := updateBranch(t, protocol.UpdateBranchRequest{
+				HeadCommit: feature,
+				TargetRef:  ref,
+				Strategy:   strategy,
+			})
+			if code != http.StatusOK || resp.Error != nil || len(resp.Conflicts) != 0 {
+				t.Fatalf("unexpected response %d: %+v %v", code, resp, resp.Error)
+			}
+			if resp.Rev != ref {
+				t.Errorf("want rev %q, got %q", ref, resp.Rev)
+			}
+			if got := cmd("git", "rev-parse", ref); got != string(resp.Commit) {
+				t.Errorf("want %s to point to %s, got %s", ref, resp.Commit, got)
+			}
+			if got, want := cmd("git", "show", ref+":a.txt"), "1 feature\n2\n3 base"; got != want {
+				t.Errorf("unexpected a.txt %q, want %q", got, want)
+			}
+			cmd("git", "merge-base", "--is-ancestor", string(base), ref)
+
+			parents := strings.Fields(cmd("git", "log", "-1", "--format=%P", ref))
+			committer := cmd("git", "log", "-1", "--format=%cn %ce %ct", ref)
+			if committer != "bot bot@example.com 1609459200" {
+				t.Errorf("unexpected committer %q", committer)
+			}
+			switch strategy {
+			case protocol.UpdateBranchRebase:
+				if len(parents) != 1 || parents[0] != string(base) {
+					t.Errorf("want the rebased commit on top of the base, got parents %v", parents)
+				}
+				if author := cmd("git", "log", "-1", "--format=%an", ref); author != "a" {
+					t.Errorf("want the author to be kept, got %q", author)
+				}
+			case protocol.UpdateBranchMerge:
+				if len(parents) != 2 || parents[0] != string(feature) || parents[1] != string(base) {
+					t.Errorf("want a merge commit of the branch and the base, got parents %v", parents)
+				}
+				if msg := cmd("git", "log", "-1", "--format=%s", ref); msg != "Merge base" {
+					t.Errorf("unexpected merge commit message %q", msg)
+				}
+			}
+		})
+
+		t.Run(string(strategy)+" conflict", func(t *testing.T) {
+			ref := "refs/heads/conflicting-" + string(strategy)
+			code, resp := updateBranch(t, protocol.UpdateBranchRequest{
+				HeadCommit: conflicting,
+				TargetRef:  ref,
+				Strategy:   strategy,
+			})
+			if code != http.StatusConflict || resp.Error != nil {
+				t.Fatalf("unexpected response %d: %+v %v", code, resp, resp.Error)
+			}
+
+			var commit api.CommitID
+			if strategy == protocol.UpdateBranchRebase {
+				commit = conflicting
+			}
+			want := []protocol.UpdateBranchConflict{
+				{Path: "a.txt", Kind: protocol.ConflictContent, Commit: commit},
+				{Path: "b.txt", Kind: protocol.ConflictDeletedInBranch, Commit: commit},
+			}
+			if diff := cmp.Diff(want, resp.Conflicts); diff != "" {
+				t.Errorf("unexpected conflicts (-want +got):\n%s", diff)
+			}
+			c := exec.Command("git", "rev-parse", "--verify", "-q", ref)
+			c.Dir = repoDir
+			if out, err := c.Output(); err == nil {
+				t.Errorf("want %s not to be created, got %s", ref, out)
+			}
+		})
+	}
+
+	t.Run("invalid strategy", func(t *testing.T) {
+		code, resp := updateBranch(t, protocol.UpdateBranchRequest{
+			HeadCommit: feature,
+			TargetRef:  "refs/heads/feature",
+			Strategy:   "squash",
+		})
+		if code != http.StatusBadRequest || resp.Error == nil {
+			t.Fatalf("unexpected response %d: %+v", code, resp)
+		}
+	})
+}
+
+func TestConflictKind(t *testing.T) {
+	tests := []struct {
+		stages   unmergedStages
+		strategy protocol.UpdateBranchStrategy
+		want     protocol.ConflictKind
+	}{
+		{unmergedStages{1: true, 2: true, 3: true}, protocol.UpdateBranchRebase, protocol.ConflictContent},
+		{unmergedStages{2: true, 3: true}, protocol.UpdateBranchMerge, protocol.ConflictAddAdd},
+		{unmergedStages{1: true, 2: true}, protocol.UpdateBranchRebase, protocol.ConflictDeletedInBranch},
+		{unmergedStages{1: true, 2: true}, protocol.UpdateBranchMerge, protocol.ConflictDeletedInBase},
+		{unmergedStages{1: true, 3: true}, protocol.UpdateBranchRebase, protocol.ConflictDeletedInBase},
+		{unmergedStages{1: true, 3: true}, protocol.UpdateBranchMerge, protocol.ConflictDeletedInBranch},
+	}
+	for _, test := range tests {
+		if got := conflictKind(test.stages, test.strategy); got != test.want {
+			t.Errorf("conflictKind(%v, %s) = %s, want %s", test.stages, test.strategy, got, test.want)
+		}
+	}
+}
@@ -77,44 +77,70 @@ func (s *GitRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tm
 		return nil, errors.Wrapf(err, "clone setup failed")
 	}
 
-	cmd, _ = s.fetchCommand(ctx, remoteURL)
+	filter := partialCloneFilter(remoteURL)
+	if filter != "" {
+		if err := configurePartialClone(ctx, GitDir(tmpPath), filter); err != nil {
+			return nil, errors.Wrapf(err, "clone setup failed")
+		}
+	}
+
+	cmd, _ = s.fetchCommand(ctx, remoteURL, filter)
 	cmd.Dir = tmpPath
 	return cmd, nil
 }
 
-func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, configRemoteOpts bool) {
+// gitFetchRefspecs are the refspecs we fetch by default.
+var gitFetchRefspecs = []string{
+	// Normal git refs
+	"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
+	// GitHub pull requests
+	"+refs/pull/*:refs/pull/*",
+	// GitLab merge requests
+	"+refs/merge-requests/*:refs/merge-requests/*",
+	// Bitbucket pull requests
+	"+refs/pull-requests/*:refs/pull-requests/*",
+	// Gerrit changesets
+	"+refs/changes/*:refs/changes/*",
+	// Possibly deprecated refs for sourcegraph zap experiment?
+	"+refs/sourcegraph/*:refs/sourcegraph/*",
+}
+
+// fetchCommand returns the command which fetches from remoteURL. If filter is
+// not empty, the repository is a partial clone which only fetches the objects
+// the filter allows.
+func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL, filter string) (cmd *exec.Cmd, configRemoteOpts bool) {
 	configRemoteOpts = true
 	if customCmd := customFetchCmd(ctx, remoteURL); customCmd != nil {
 		cmd = customCmd
 		configRemoteOpts = false
+	} else if filter != "" {
+		refspecs := gitFetchRefspecs
+		if useRefspecOverrides() {
+			refspecs = refspecOverrides
+		}
+		// Git only fetches with a filter from the promisor remote, whose URL
+		// is passed in the environment.
+		cmd = exec.CommandContext(ctx, "git", append([]string{"fetch",
+			"--progress", "--prune", "--filter=" + filter, partialCloneRemote}, refspecs...)...)
+		cmd.Env = append(os.Environ(), promisorRemoteEnv(remoteURL)...)
 	} else if useRefspecOverrides() {
 		cmd = refspecOverridesFetchCmd(ctx, remoteURL)
 	} else {
-		cmd = exec.CommandContext(ctx, "git", "fetch",
-			"--progress", "--prune", remoteURL.String(),
-			// Normal git refs
-			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
-			// GitHub pull requests
-			"+refs/pull/*:refs/pull/*",
-			// GitLab merge requests
-			"+refs/merge-requests/*:refs/merge-requests/*",
-			// Bitbucket pull requests
-			"+refs/pull-requests/*:refs/pull-requests/*",
-			// Gerrit changesets
-			"+refs/changes/*:refs/changes/*",
-			// Possibly deprecated refs for sourcegraph zap experiment?
-			"+refs/sourcegraph/*:refs/sourcegraph/*")
+		cmd = exec.CommandContext(ctx, "git", append([]string{"fetch",
+			"--progress", "--prune", remoteURL.String()}, gitFetchRefspecs...)...)
 	}
 	return cmd, configRemoteOpts
 }
 
 // Fetch tries to fetch updates of a Git repository.
 func (s *GitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
-	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
+	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL, partialCloneFilterOf(dir))
 	dir.Set(cmd)
 	if output, err := runWith(ctx, cmd, configRemoteOpts, nil); err != nil {
 		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
 	}
+
+	maybeFetchLFSObjects(ctx, remoteURL, dir)
 	return nil
 }
 
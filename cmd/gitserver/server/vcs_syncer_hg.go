@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+// HgRepoSyncer is a syncer for Mercurial repositories. Mercurial history is
+// converted to Git with git-remote-hg, which exposes an hg repository as a Git
+// remote by way of git fast-import/fast-export. The bridge keeps the marks of
+// the changesets it has already converted inside the Git directory, so fetches
+// only convert the changesets which are new since the last fetch.
+//
+// hg bookmarks are converted to branches of the same name, named branches to
+// branches/<name> and hg tags to Git tags.
+type HgRepoSyncer struct{}
+
+var _ VCSSyncer = &HgRepoSyncer{}
+
+func (s *HgRepoSyncer) Type() string {
+	return "hg"
+}
+
+// decomposeHgRemoteURL returns the hg URL of remoteURL and the URL of the
+// remote git-remote-hg should fetch from.
+func decomposeHgRemoteURL(remoteURL *vcs.URL) (hgURL, gitURL string, err error) {
+	switch remoteURL.Scheme {
+	case "http", "https", "ssh":
+	default:
+		return "", "", errors.Errorf("unsupported scheme %q for Mercurial repository", remoteURL.Scheme)
+	}
+	hgURL = remoteURL.String()
+	return hgURL, "hg::" + hgURL, nil
+}
+
+// hgCommandEnv returns the environment hg and git-remote-hg are run with. It
+// disables user configuration which would change the output of hg and
+// prevents hg from prompting for credentials.
+func hgCommandEnv() []string {
+	return append(os.Environ(),
+		"HGPLAIN=1",
+		"HGENCODING=utf-8",
+	)
+}
+
+// IsCloneable checks to see if the Mercurial remote URL is cloneable.
+func (s *HgRepoSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	hgURL, _, err := decomposeHgRemoteURL(remoteURL)
+	if err != nil {
+		return errors.Wrap(err, "decompose")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "hg", "--noninteractive", "identify", "--id", hgURL)
+	cmd.Env = hgCommandEnv()
+
+	out, err := runWith(ctx, cmd, false, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Errorf("%s (output follows)\n\n%s", err, newURLRedactor(remoteURL).redact(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand returns the command to be executed for cloning a Mercurial
+// repository as a Git repository.
+func (s *HgRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (*exec.Cmd, error) {
+	_, gitURL, err := decomposeHgRemoteURL(remoteURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "decompose")
+	}
+
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "clone setup failed")
+	}
+
+	cmd = s.fetchCommand(ctx, gitURL)
+	cmd.Dir = tmpPath
+	return cmd, nil
+}
+
+func (s *HgRepoSyncer) fetchCommand(ctx context.Context, gitURL string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", "fetch", "--progress", "--prune", gitURL,
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
+	cmd.Env = hgCommandEnv()
+	return cmd
+}
+
+// Fetch tries to fetch updates of a Mercurial repository as a Git repository.
+func (s *HgRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
+	_, gitURL, err := decomposeHgRemoteURL(remoteURL)
+	if err != nil {
+		return errors.Wrap(err, "decompose")
+	}
+
+	cmd := s.fetchCommand(ctx, gitURL)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing Git remote
+// of a Mercurial repository.
+func (s *HgRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	// Remote info is encoded as in the current repository. setHEAD falls
+	// back to master, which git-remote-hg creates from the default branch.
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
@@ -0,0 +1,329 @@
+package server
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+// SVNLayout describes where trunk, branches and tags live in a Subversion
+// repository, relative to the repository.
+type SVNLayout struct {
+	// Trunk is imported as the master branch. "/" imports the repository
+	// root, which is useful for repositories without branches.
+	Trunk string
+	// Branches are the locations whose subdirectories are imported as
+	// branches.
+	Branches []string
+	// Tags are the locations whose subdirectories are imported as tags.
+	Tags []string
+}
+
+// StandardSVNLayout is the layout most Subversion repositories follow.
+var StandardSVNLayout = SVNLayout{
+	Trunk:    "trunk",
+	Branches: []string{"branches"},
+	Tags:     []string{"tags"},
+}
+
+// SVNSyncer is a syncer for Subversion repositories. Subversion history is
+// imported into Git with git svn. git svn records every revision it imports,
+// so each fetch only imports the revisions which are new, and a fetch which
+// is interrupted resumes from the last imported revision. Cloning only imports
+// the first svnCloneRevisions revisions, so that the history of large
+// repositories is imported by resumable fetches.
+type SVNSyncer struct {
+	// Layout is the layout of the repository.
+	Layout SVNLayout
+
+	// Authors maps Subversion usernames to the Git authors of imported
+	// commits in the form "Name <email>".
+	Authors map[string]string
+}
+
+var _ VCSSyncer = &SVNSyncer{}
+
+func (s *SVNSyncer) Type() string {
+	return "svn"
+}
+
+// Files written to the Git directory of Subversion repositories. They are
+// rewritten before every fetch, so that changes to the configuration are
+// picked up.
+const (
+	svnAuthorsFile = "sg_svn_authors"
+	svnAuthorsProg = "sg_svn_authors_prog"
+	svnAskPass     = "sg_svn_askpass"
+)
+
+// svnCloneRevisions is the number of revisions imported when cloning a
+// Subversion repository. A clone which is interrupted has to start over, so
+// the remaining revisions are imported by fetches.
+const svnCloneRevisions = 1000
+
+// decomposeSVNRemoteURL returns the credentials in remoteURL and the URL of
+// the repository without them. Subversion doesn't accept credentials in URLs.
+func decomposeSVNRemoteURL(remoteURL *vcs.URL) (username, password, svnURL string, err error) {
+	switch remoteURL.Scheme {
+	case "http", "https", "svn", "svn+ssh":
+	default:
+		return "", "", "", errors.Errorf("unsupported scheme %q for Subversion repository", remoteURL.Scheme)
+	}
+	password, _ = remoteURL.User.Password()
+	username = remoteURL.User.Username()
+
+	u := remoteURL.URL
+	u.User = nil
+	return username, password, u.String(), nil
+}
+
+// svnRemoteConfig returns the git config entries which configure git svn to
+// import the repository at svnURL with the given layout. Branches and tags
+// are imported directly into refs/heads and refs/tags. Branches and tags
+// from all but the first location are prefixed with their location, so that
+// they don't collide.
+func svnRemoteConfig(layout SVNLayout, svnURL string) [][2]string {
+	trunk := strings.Trim(layout.Trunk, "/")
+	config := [][2]string{
+		{"svn-remote.svn.url", strings.TrimSuffix(svnURL, "/")},
+		{"svn-remote.svn.fetch", trunk + ":refs/heads/master"},
+	}
+	add := func(key string, locations []string, refPrefix string) {
+		for i, location := range locations {
+			location = strings.Trim(location, "/")
+			if location == "" {
+				continue
+			}
+			ref := refPrefix
+			if i > 0 {
+				ref += location + "/"
+			}
+			config = append(config, [2]string{key, location + "/*:" + ref + "*"})
+		}
+	}
+	add("svn-remote.svn.branches", layout.Branches, "refs/heads/")
+	add("svn-remote.svn.tags", layout.Tags, "refs/tags/")
+	return config
+}
+
+// svnAuthors returns the content of the authors file of git svn.
+func svnAuthors(authors map[string]string) string {
+	lines := make([]string, 0, len(authors))
+	for user, author := range authors {
+		lines = append(lines, user+" = "+author+"\n")
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "")
+}
+
+// writeSVNHelpers writes the authors file and the helper programs git svn is
+// run with to dir.
+func (s *SVNSyncer) writeSVNHelpers(dir GitDir) error {
+	files := []struct {
+		name    string
+		content string
+		perm    os.FileMode
+	}{
+		{svnAuthorsFile, svnAuthors(s.Authors), 0600},
+		// git svn calls the authors program with the usernames which aren't
+		// in the authors file.
+		{svnAuthorsProg, "#!/bin/sh\nprintf '%s <%s>\\n' \"$1\" \"$1\"\n", 0700},
+		// git svn asks for passwords with GIT_ASKPASS. The password itself
+		// is only passed in the environment.
+		{svnAskPass, "#!/bin/sh\nprintf '%s\\n' \"$SRC_SVN_PASSWORD\"\n", 0700},
+	}
+	for _, f := range files {
+		if err := os.WriteFile(dir.Path(f.name), []byte(f.content), f.perm); err != nil {
+			return errors.Wrapf(err, "writing %s", f.name)
+		}
+	}
+	return nil
+}
+
+// removeStaleSVNLocks removes the index lock files an interrupted git svn
+// fetch leaves behind, which would make the next fetch fail.
+func removeStaleSVNLocks(dir GitDir) error {
+	err := filepath.WalkDir(dir.Path("svn"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && d.Name() == "index.lock" {
+			return os.Remove(path)
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// fetchCommand returns the git svn command which imports new revisions. If
+// maxRevision is not zero, only revisions up to maxRevision are imported.
+func (s *SVNSyncer) fetchCommand(ctx context.Context, dir GitDir, username, password string, maxRevision int) *exec.Cmd {
+	args := []string{"svn", "fetch"}
+	if maxRevision > 0 {
+		args = append(args, "--revision=0:"+strconv.Itoa(maxRevision))
+	}
+	if len(s.Authors) > 0 {
+		args = append(args, "--authors-file="+dir.Path(svnAuthorsFile), "--authors-prog="+dir.Path(svnAuthorsProg))
+	}
+	if username != "" {
+		args = append(args, "--username="+username)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(),
+		"GIT_ASKPASS="+dir.Path(svnAskPass),
+		"SRC_SVN_PASSWORD="+password,
+	)
+	return cmd
+}
+
+// svnInfoCommand returns the svn info command for svnURL with the given
+// extra arguments. The password is passed on stdin rather than as an
+// argument, so that it doesn't show up in the process list.
+func svnInfoCommand(ctx context.Context, username, password, svnURL string, extraArgs ...string) *exec.Cmd {
+	args := []string{"info", "--non-interactive", "--no-auth-cache"}
+	if username != "" {
+		args = append(args, "--username", username, "--password-from-stdin")
+	}
+	args = append(append(args, extraArgs...), svnURL)
+	cmd := exec.CommandContext(ctx, "svn", args...)
+	if username != "" {
+		cmd.Stdin = strings.NewReader(password + "\n")
+	}
+	return cmd
+}
+
+// runSVNInfo runs svn info for the Subversion repository at remoteURL and
+// returns its output.
+func runSVNInfo(ctx context.Context, remoteURL *vcs.URL, extraArgs ...string) ([]byte, error) {
+	username, password, svnURL, err := decomposeSVNRemoteURL(remoteURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "decompose")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	out, err := runWith(ctx, svnInfoCommand(ctx, username, password, svnURL, extraArgs...), false, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Errorf("%s (output follows)\n\n%s", err, newURLRedactor(remoteURL).redact(string(out)))
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// IsCloneable checks to see if the Subversion remote URL is cloneable.
+func (s *SVNSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	_, err := runSVNInfo(ctx, remoteURL)
+	return err
+}
+
+// latestSVNRevision returns the latest revision of the Subversion repository
+// at remoteURL.
+func latestSVNRevision(ctx context.Context, remoteURL *vcs.URL) (int, error) {
+	out, err := runSVNInfo(ctx, remoteURL, "--show-item=revision")
+	if err != nil {
+		return 0, err
+	}
+	rev, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		return 0, errors.Wrap(err, "parsing latest revision")
+	}
+	return rev, nil
+}
+
+// CloneCommand returns the command to be executed for cloning a Subversion
+// repository as a Git repository.
+func (s *SVNSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (*exec.Cmd, error) {
+	username, password, svnURL, err := decomposeSVNRemoteURL(remoteURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "decompose")
+	}
+
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "clone setup failed")
+	}
+
+	for _, kv := range svnRemoteConfig(s.Layout, svnURL) {
+		cmd := exec.CommandContext(ctx, "git", "config", "--add", kv[0], kv[1])
+		cmd.Dir = tmpPath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return nil, errors.Wrapf(err, "configuring git svn failed with output %q", newURLRedactor(remoteURL).redact(string(output)))
+		}
+	}
+
+	dir := GitDir(tmpPath)
+	if err := s.writeSVNHelpers(dir); err != nil {
+		return nil, errors.Wrap(err, "clone setup failed")
+	}
+
+	// Only import the first revisions of large repositories. Fetch imports
+	// the rest, and resumes if it is interrupted.
+	latest, err := latestSVNRevision(ctx, remoteURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving latest revision")
+	}
+	maxRevision := 0
+	if latest > svnCloneRevisions {
+		maxRevision = svnCloneRevisions
+	}
+
+	// Example: git svn fetch --authors-file=/tmp/clone-584194180/.git/sg_svn_authors
+	cmd = s.fetchCommand(ctx, dir, username, password, maxRevision)
+	cmd.Dir = tmpPath
+	return cmd, nil
+}
+
+// Fetch imports the new revisions of a Subversion repository.
+func (s *SVNSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
+	username, password, _, err := decomposeSVNRemoteURL(remoteURL)
+	if err != nil {
+		return errors.Wrap(err, "decompose")
+	}
+
+	if err := s.writeSVNHelpers(dir); err != nil {
+		return err
+	}
+	if err := removeStaleSVNLocks(dir); err != nil {
+		return errors.Wrap(err, "removing stale git svn locks")
+	}
+
+	cmd := s.fetchCommand(ctx, dir, username, password, 0)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing Git remote
+// of a Subversion repository.
+func (s *SVNSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	// Remote info is encoded as in the current repository. Trunk is imported
+	// as master, which setHEAD falls back to.
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
@@ -118,6 +118,11 @@ func notifySavedQueryWasCreatedOrUpdated(oldValue, newValue api.SavedQuerySpecAn
 				log15.Error("Failed to send unsubscribed Slack notification.", "recipient", removedRecipient, "error", err)
 			}
 		}
+		if removedRecipient.webhook {
+			if err := webhookNotifySubscription(ctx, removedRecipient, oldValue, webhookEventUnsubscribed); err != nil {
+				log15.Error("Failed to send unsubscribed webhook notification.", "recipient", removedRecipient, "error", err)
+			}
+		}
 	}
 	for _, addedRecipient := range addedRecipients {
 		if addedRecipient.email {
@@ -130,6 +135,11 @@ func notifySavedQueryWasCreatedOrUpdated(oldValue, newValue api.SavedQuerySpecAn
 				log15.Error("Failed to send subscribed Slack notification.", "recipient", addedRecipient, "error", err)
 			}
 		}
+		if addedRecipient.webhook {
+			if err := webhookNotifySubscription(ctx, addedRecipient, newValue, webhookEventSubscribed); err != nil {
+				log15.Error("Failed to send subscribed webhook notification.", "recipient", addedRecipient, "error", err)
+			}
+		}
 	}
 	return nil
 }
@@ -163,6 +173,10 @@ func serveTestNotification(w http.ResponseWriter, r *http.Request) {
 			writeError(w, errors.Errorf("error sending slack notifications to %s: %s", recipient.spec, err))
 			return
 		}
+		if err := webhookNotifySubscription(r.Context(), recipient, args.SavedSearch, webhookEventTest); err != nil {
+			writeError(w, errors.Errorf("error sending webhook notifications to %s: %s", recipient.spec, err))
+			return
+		}
 	}
 
 	log15.Info("saved query test notification sent", "spec", args.SavedSearch.Spec, "key", args.SavedSearch.Spec.Key)
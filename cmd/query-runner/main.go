@@ -152,7 +152,7 @@ func (e *executorT) run(ctx context.Context) error {
 // runQuery runs the given query if an appropriate amount of time has elapsed
 // since it last ran.
 func (e *executorT) runQuery(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSavedQuery) error {
-	if !query.Notify && !query.NotifySlack {
+	if !query.Notify && !query.NotifySlack && !query.NotifyWebhook {
 		// No need to run this query because there will be nobody to notify.
 		return nil
 	}
@@ -311,9 +311,11 @@ func notify(ctx context.Context, spec api.SavedQueryIDSpec, query api.ConfigSave
 		recipients: recipients,
 	}
 
-	// Send Slack and email notifications.
+	// Send Slack, email and webhook notifications. Webhook deliveries are
+	// last since they may be retried for a while.
 	n.slackNotify(ctx)
 	n.emailNotify(ctx)
+	n.webhookNotify(ctx)
 	return nil
 }
 
@@ -326,8 +328,9 @@ type notifier struct {
 }
 
 const (
-	utmSourceEmail = "saved-search-email"
-	utmSourceSlack = "saved-search-slack"
+	utmSourceEmail   = "saved-search-email"
+	utmSourceSlack   = "saved-search-slack"
+	utmSourceWebhook = "saved-search-webhook"
 )
 
 func searchURL(query, utmSource string) string {
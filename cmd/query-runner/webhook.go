@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// webhookEvent is the kind of a webhook notification. It is sent in the event
+// field of the payload and in the X-Sourcegraph-Event header.
+type webhookEvent string
+
+const (
+	webhookEventResults      webhookEvent = "results"
+	webhookEventSubscribed   webhookEvent = "subscribed"
+	webhookEventUnsubscribed webhookEvent = "unsubscribed"
+	webhookEventTest         webhookEvent = "test"
+)
+
+const (
+	webhookEventHeader     = "X-Sourcegraph-Event"
+	webhookSignatureHeader = "X-Sourcegraph-Signature"
+)
+
+// webhookPayload is the JSON body posted to the webhook URL of a saved search.
+type webhookPayload struct {
+	Event       webhookEvent       `json:"event"`
+	SavedSearch webhookSavedSearch `json:"savedSearch"`
+
+	// SearchURL links to the new results, or to the saved search for events
+	// other than results.
+	SearchURL string `json:"searchURL"`
+
+	// ApproximateResultCount and Results are only set for results events.
+	// Results are in the shape of the GraphQL search API, see
+	// gqlSearchQuery.
+	ApproximateResultCount string        `json:"approximateResultCount,omitempty"`
+	Results                []interface{} `json:"results,omitempty"`
+}
+
+type webhookSavedSearch struct {
+	Description string `json:"description"`
+	Query       string `json:"query"`
+}
+
+// Webhook deliveries are retried with an exponential backoff on network
+// errors, 429 and 5xx responses.
+const (
+	webhookMaxRetries     = 5
+	webhookRetryDelayBase = time.Second
+	webhookRetryDelayMax  = 30 * time.Second
+)
+
+var webhookAllowPrivateAddresses, _ = strconv.ParseBool(env.Get("QUERY_RUNNER_WEBHOOK_ALLOW_PRIVATE_ADDRESSES", "false", "Allow saved search webhooks to be posted to loopback, private and link-local addresses"))
+
+var webhookDoer, _ = newWebhookDoer(webhookAllowPrivateAddresses, httpcli.MaxRetries(webhookMaxRetries), webhookRetryDelayBase, webhookRetryDelayMax)
+
+func newWebhookDoer(allowPrivateAddresses bool, maxRetries int, delayBase, delayMax time.Duration) (httpcli.Doer, error) {
+	opts := []httpcli.Opt{}
+	if !allowPrivateAddresses {
+		opts = append(opts, publicAddressesOnlyOpt)
+	}
+	opts = append(opts,
+		httpcli.ExternalTransportOpt,
+		httpcli.NewErrorResilientTransportOpt(
+			httpcli.NewRetryPolicy(maxRetries),
+			httpcli.ExpJitterDelay(delayBase, delayMax),
+		),
+		httpcli.TracedTransportOpt,
+	)
+
+	return httpcli.NewFactory(
+		httpcli.NewMiddleware(
+			httpcli.ContextErrorMiddleware,
+			httpcli.HeadersMiddleware("User-Agent", "sourcegraph/query-runner"),
+		),
+		opts...,
+	).Doer()
+}
+
+// publicAddressesOnlyOpt restricts the transport of a client to connections to
+// public IP addresses. Saved searches can be created by any user, so webhooks
+// must not be able to reach services on the internal network. The address is
+// checked when connecting rather than when resolving the host name, which also
+// covers redirects and DNS rebinding.
+func publicAddressesOnlyOpt(cli *http.Client) error {
+	tr, ok := cli.Transport.(*http.Transport)
+	if cli.Transport == nil {
+		tr, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if !ok {
+		return errors.Errorf("http.Client.Transport is not an *http.Transport: %T", cli.Transport)
+	}
+
+	tr = tr.Clone()
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return errors.Errorf("webhook address %s is not a public IP address", host)
+			}
+			return nil
+		},
+	}
+	tr.DialContext = dialer.DialContext
+	cli.Transport = tr
+	return nil
+}
+
+// isPublicIP returns true if ip is a global unicast address outside of the
+// private, loopback, link-local and shared address space ranges.
+func isPublicIP(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+		return false
+	}
+	// 100.64.0.0/10 (carrier-grade NAT) is commonly used for internal
+	// networks, for example by Kubernetes and Tailscale.
+	if ip4 := ip.To4(); ip4 != nil && ip4[0] == 100 && ip4[1]&0xc0 == 64 {
+		return false
+	}
+	return true
+}
+
+func (n *notifier) webhookNotify(ctx context.Context) {
+	payload := &webhookPayload{
+		Event: webhookEventResults,
+		SavedSearch: webhookSavedSearch{
+			Description: n.query.Description,
+			Query:       n.query.Query,
+		},
+		SearchURL:              searchURL(n.newQuery, utmSourceWebhook),
+		ApproximateResultCount: n.results.Data.Search.Results.ApproximateResultCount,
+		Results:                n.results.Data.Search.Results.Results,
+	}
+	for _, recipient := range n.recipients {
+		if !recipient.webhook {
+			continue
+		}
+		if err := webhookNotify(ctx, recipient, n.query, payload); err != nil {
+			log15.Error("Failed to post webhook notification.", "recipient", recipient, "error", err)
+			continue
+		}
+		logEvent(0, "SavedSearchWebhookNotificationSent", string(webhookEventResults))
+	}
+}
+
+// webhookNotifySubscription notifies the webhook of query that it was
+// subscribed to or unsubscribed from the saved search, or sends a test
+// notification.
+func webhookNotifySubscription(ctx context.Context, recipient *recipient, query api.SavedQuerySpecAndConfig, event webhookEvent) error {
+	if !recipient.webhook {
+		return nil
+	}
+	payload := &webhookPayload{
+		Event: event,
+		SavedSearch: webhookSavedSearch{
+			Description: query.Config.Description,
+			Query:       query.Config.Query,
+		},
+		SearchURL: searchURL(query.Config.Query, utmSourceWebhook),
+	}
+	if err := webhookNotify(ctx, recipient, query.Config, payload); err != nil {
+		return err
+	}
+	logEvent(0, "SavedSearchWebhookNotificationSent", string(event))
+	return nil
+}
+
+func webhookNotify(ctx context.Context, recipient *recipient, query api.ConfigSavedQuery, payload *webhookPayload) error {
+	if query.WebhookURL == nil || *query.WebhookURL == "" {
+		return errors.Errorf("unable to send webhook notification because recipient (%s) has no webhook URL configured", recipient.spec)
+	}
+	var secret string
+	if query.WebhookSecret != nil {
+		secret = *query.WebhookSecret
+	}
+	return postWebhook(ctx, webhookDoer, *query.WebhookURL, secret, payload)
+}
+
+// postWebhook posts payload as JSON to url. If secret is non-empty, the
+// payload is signed with it, see signWebhookPayload.
+func postWebhook(ctx context.Context, doer httpcli.Doer, url, secret string, payload *webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshaling webhook payload")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "creating webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, string(payload.Event))
+	if secret != "" {
+		req.Header.Set(webhookSignatureHeader, signWebhookPayload(secret, body))
+	}
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "posting webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("webhook responded with status %d: %s", resp.StatusCode, msg)
+	}
+	return nil
+}
+
+// signWebhookPayload returns the value of the signature header for body: the
+// hex-encoded HMAC-SHA256 of body keyed by secret, prefixed with "sha256=".
+// Receivers verify a payload by computing the same HMAC over the raw request
+// body and comparing it in constant time.
+func signWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
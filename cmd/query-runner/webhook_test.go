@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPostWebhook(t *testing.T) {
+	const secret = "s3cr3t"
+
+	var (
+		attempts int
+		got      webhookPayload
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		if sig := r.Header.Get(webhookSignatureHeader); !hmac.Equal([]byte(sig), []byte(want)) {
+			t.Errorf("got signature %q, want %q", sig, want)
+		}
+		if event := r.Header.Get(webhookEventHeader); event != "results" {
+			t.Errorf("got event header %q, want %q", event, "results")
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer srv.Close()
+
+	doer, err := newWebhookDoer(true, 5, time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := &webhookPayload{
+		Event: webhookEventResults,
+		SavedSearch: webhookSavedSearch{
+			Description: "new TODOs",
+			Query:       "TODO type:diff patternType:literal",
+		},
+		SearchURL:              "https://sourcegraph.example.com/search?q=TODO",
+		ApproximateResultCount: "1",
+		Results:                []interface{}{map[string]interface{}{"__typename": "CommitSearchResult"}},
+	}
+	if err := postWebhook(context.Background(), doer, srv.URL, secret, payload); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Errorf("got %d attempts, want 3", attempts)
+	}
+	if diff := cmp.Diff(*payload, got); diff != "" {
+		t.Errorf("payload mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPostWebhook_clientError(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.Header.Get(webhookSignatureHeader) != "" {
+			t.Error("unexpected signature without a secret")
+		}
+		http.Error(w, "no such hook", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	doer, err := newWebhookDoer(true, 5, time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Client errors are not retried.
+	err = postWebhook(context.Background(), doer, srv.URL, "", &webhookPayload{Event: webhookEventTest})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
+
+func TestPostWebhook_privateAddress(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+	}))
+	defer srv.Close()
+
+	doer, err := newWebhookDoer(false, 0, time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = postWebhook(context.Background(), doer, srv.URL, "", &webhookPayload{Event: webhookEventTest})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 0 {
+		t.Errorf("got %d attempts, want 0", attempts)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for ip, want := range map[string]bool{
+		"93.184.216.34":        true,
+		"2606:2800:220:1::248": true,
+		"127.0.0.1":            false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"::1":                  false,
+		"fd00::1":              false,
+		"fe80::1":              false,
+	} {
+		if got := isPublicIP(net.ParseIP(ip)); got != want {
+			t.Errorf("isPublicIP(%s) = %t, want %t", ip, got, want)
+		}
+	}
+}
@@ -75,6 +75,12 @@ type PatternInfo struct {
 	// IsStructuralPat if true will treat the pattern as a Comby structural search pattern.
 	IsStructuralPat bool
 
+	// IsFuzzy if true will treat the pattern as a literal that may match
+	// with a bounded number of edits (insertions, deletions and
+	// substitutions of characters). The bound depends on the length of the
+	// pattern.
+	IsFuzzy bool
+
 	// IsWordMatch if true will only match the pattern at word boundaries.
 	IsWordMatch bool
 
@@ -119,6 +125,10 @@ type PatternInfo struct {
 	// Languages is the languages passed via the lang filters (e.g., "lang:c")
 	Languages []string
 
+	// ExcludeLanguages is the languages passed via the negated lang filters
+	// (e.g., "-lang:c"). Files are classified by their path and content.
+	ExcludeLanguages []string
+
 	// CombyRule is a rule that constrains matching for structural search.
 	// It only applies when IsStructuralPat is true.
 	// As a temporary measure, the expression `where "backcompat" == "backcompat"` acts as
@@ -144,6 +154,9 @@ func (p *PatternInfo) String() string {
 			args = append(args, "comby")
 		}
 	}
+	if p.IsFuzzy {
+		args = append(args, "fuzzy")
+	}
 	if p.IsWordMatch {
 		args = append(args, "word")
 	}
@@ -162,6 +175,9 @@ func (p *PatternInfo) String() string {
 	for _, lang := range p.Languages {
 		args = append(args, fmt.Sprintf("lang:%s", lang))
 	}
+	for _, lang := range p.ExcludeLanguages {
+		args = append(args, fmt.Sprintf("-lang:%s", lang))
+	}
 	if p.Select != "" {
 		args = append(args, fmt.Sprintf("select:%s", p.Select))
 	}
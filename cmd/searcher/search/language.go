@@ -0,0 +1,82 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+)
+
+// langMatcher reports whether a file is written in the languages of the lang:
+// and -lang: filters of a query. Languages are detected from the path and
+// content of a file by the same classifier as the language inventory of a
+// repository. This finds files which a file name pattern misses, such as
+// scripts with a shebang, and tells apart files with an extension shared by
+// several languages, such as .h.
+type langMatcher struct {
+	include []string
+	exclude []string
+}
+
+// compileLangMatcher returns a langMatcher for the languages include and
+// exclude, or nil if there are none.
+func compileLangMatcher(include, exclude []string) *langMatcher {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil
+	}
+	return &langMatcher{
+		include: canonicalLanguages(include),
+		exclude: canonicalLanguages(exclude),
+	}
+}
+
+// canonicalLanguages returns the go-enry names of languages, which may be
+// aliases like "golang".
+func canonicalLanguages(languages []string) []string {
+	names := make([]string, 0, len(languages))
+	for _, lang := range languages {
+		if name, ok := enry.GetLanguageByAlias(lang); ok {
+			lang = name
+		}
+		names = append(names, lang)
+	}
+	return names
+}
+
+// Match returns true if the file name matches all languages to include and
+// none of the languages to exclude. content is only called if the name of
+// the file does not determine its language. If content is nil, the file is
+// classified by its name alone. A nil langMatcher matches all files.
+func (m *langMatcher) Match(name string, content func() []byte) bool {
+	if m == nil {
+		return true
+	}
+	lang, safe := inventory.GetLanguageByName(name)
+	if !safe && content != nil {
+		lang = inventory.GetLanguage(name, content())
+	}
+	for _, include := range m.include {
+		if !strings.EqualFold(lang, include) {
+			return false
+		}
+	}
+	for _, exclude := range m.exclude {
+		if strings.EqualFold(lang, exclude) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *langMatcher) String() string {
+	if m == nil {
+		return ""
+	}
+	args := make([]string, 0, len(m.include)+len(m.exclude))
+	args = append(args, m.include...)
+	for _, lang := range m.exclude {
+		args = append(args, "-"+lang)
+	}
+	return strings.Join(args, ",")
+}
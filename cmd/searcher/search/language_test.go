@@ -0,0 +1,39 @@
+package search
+
+import "testing"
+
+func TestLangMatcher(t *testing.T) {
+	cases := []struct {
+		include, exclude []string
+		name, content    string
+		want             bool
+	}{
+		{name: "main.go", want: true},
+		{include: []string{"go"}, name: "main.go", want: true},
+		{include: []string{"golang"}, name: "main.go", want: true},
+		{include: []string{"go"}, name: "main.rs", want: false},
+		{exclude: []string{"go"}, name: "main.go", want: false},
+		{include: []string{"python"}, name: "bin/deploy", content: "#!/usr/bin/env python\nimport os\n", want: true},
+		{include: []string{"dockerfile"}, name: "Dockerfile.dev", content: "FROM alpine\n", want: true},
+		{include: []string{"c++"}, name: "foo.h", content: "#include <vector>\nclass Foo {\npublic:\n  std::vector<int> v;\n};\n", want: true},
+		{include: []string{"c"}, name: "foo.h", content: "#include <vector>\nclass Foo {\npublic:\n  std::vector<int> v;\n};\n", want: false},
+		{include: []string{"c"}, name: "foo.h", content: "#include <stdio.h>\nint foo(void);\n", want: true},
+	}
+	for _, c := range cases {
+		m := compileLangMatcher(c.include, c.exclude)
+		content := func() []byte { return []byte(c.content) }
+		if got := m.Match(c.name, content); got != c.want {
+			t.Errorf("%s.Match(%q) got %v, want %v", m, c.name, got, c.want)
+		}
+	}
+}
+
+func TestLangMatcher_nameOnly(t *testing.T) {
+	m := compileLangMatcher([]string{"go"}, nil)
+	if !m.Match("main.go", nil) {
+		t.Errorf("%s.Match(%q) got false, want true", m, "main.go")
+	}
+	if m.Match("bin/deploy", nil) {
+		t.Errorf("%s.Match(%q) got true, want false", m, "bin/deploy")
+	}
+}
@@ -142,6 +142,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 	span.SetTag("pattern", p.Pattern)
 	span.SetTag("isRegExp", strconv.FormatBool(p.IsRegExp))
 	span.SetTag("isStructuralPat", strconv.FormatBool(p.IsStructuralPat))
+	span.SetTag("isFuzzy", strconv.FormatBool(p.IsFuzzy))
 	span.SetTag("languages", p.Languages)
 	span.SetTag("isWordMatch", strconv.FormatBool(p.IsWordMatch))
 	span.SetTag("isCaseSensitive", strconv.FormatBool(p.IsCaseSensitive))
@@ -186,7 +187,7 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 		span.SetTag("deadlineHit", deadlineHit)
 		span.Finish()
 		if s.Log != nil {
-			s.Log.Debug("search request", "repo", p.Repo, "commit", p.Commit, "pattern", p.Pattern, "isRegExp", p.IsRegExp, "isStructuralPat", p.IsStructuralPat, "languages", p.Languages, "isWordMatch", p.IsWordMatch, "isCaseSensitive", p.IsCaseSensitive, "patternMatchesContent", p.PatternMatchesContent, "patternMatchesPath", p.PatternMatchesPath, "matches", sender.SentCount(), "code", code, "duration", time.Since(start), "indexerEndpoints", p.IndexerEndpoints, "err", err)
+			s.Log.Debug("search request", "repo", p.Repo, "commit", p.Commit, "pattern", p.Pattern, "isRegExp", p.IsRegExp, "isStructuralPat", p.IsStructuralPat, "isFuzzy", p.IsFuzzy, "languages", p.Languages, "isWordMatch", p.IsWordMatch, "isCaseSensitive", p.IsCaseSensitive, "patternMatchesContent", p.PatternMatchesContent, "patternMatchesPath", p.PatternMatchesPath, "matches", sender.SentCount(), "code", code, "duration", time.Since(start), "indexerEndpoints", p.IndexerEndpoints, "err", err)
 		}
 	}(time.Now())
 
@@ -196,6 +197,12 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 		return structuralSearchWithZoekt(ctx, p, sender)
 	}
 
+	// Short fuzzy patterns are split into pieces that match nearly every
+	// file, so we search the archive instead of asking Zoekt for candidates.
+	if p.IsFuzzy && p.Indexed && p.Pattern != "" && canPrefilterFuzzy(p.Pattern) {
+		return fuzzySearchWithZoekt(ctx, p, sender)
+	}
+
 	// Compile pattern before fetching from store incase it is bad.
 	var rg *readerGrep
 	if !p.IsStructuralPat {
@@ -254,12 +261,15 @@ func validateParams(p *protocol.Request) error {
 	if len(p.Commit) != 40 {
 		return errors.Errorf("Commit must be resolved (Commit=%q)", p.Commit)
 	}
-	if p.Pattern == "" && p.ExcludePattern == "" && len(p.IncludePatterns) == 0 {
+	if p.Pattern == "" && p.ExcludePattern == "" && len(p.IncludePatterns) == 0 && len(p.Languages) == 0 && len(p.ExcludeLanguages) == 0 {
 		return errors.New("At least one of pattern and include/exclude pattners must be non-empty")
 	}
 	if p.IsNegated && p.IsStructuralPat {
 		return errors.New("Negated patterns are not supported for structural searches")
 	}
+	if p.IsNegated && p.IsFuzzy {
+		return errors.New("Negated patterns are not supported for fuzzy searches")
+	}
 	return nil
 }
 
@@ -0,0 +1,308 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/google/zoekt"
+	zoektquery "github.com/google/zoekt/query"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/store"
+)
+
+// fuzzyMatcher finds approximate occurrences of a literal pattern on a single
+// line. An occurrence may differ from the pattern by up to maxEdits
+// insertions, deletions or substitutions of characters.
+//
+// fuzzyMatcher does not handle case insensitivity. Like for regular
+// expressions, readerGrep lowercases the pattern and input instead.
+type fuzzyMatcher struct {
+	pattern  []rune
+	maxEdits int
+
+	// pieces are maxEdits+1 disjoint substrings that make up pattern. Every
+	// edit touches at most one piece, so at least one piece appears unchanged
+	// in every occurrence of pattern. We use this to cheaply skip files and
+	// lines which cannot contain an occurrence.
+	pieces [][]byte
+}
+
+func newFuzzyMatcher(pattern string) *fuzzyMatcher {
+	maxEdits := fuzzyMaxEdits(pattern)
+	pieces := fuzzyPieces(pattern, maxEdits)
+	m := &fuzzyMatcher{
+		pattern:  []rune(pattern),
+		maxEdits: maxEdits,
+		pieces:   make([][]byte, 0, len(pieces)),
+	}
+	for _, piece := range pieces {
+		m.pieces = append(m.pieces, []byte(piece))
+	}
+	return m
+}
+
+// fuzzyMaxEdits returns the number of edits a fuzzy match of pattern may
+// have. It grows with the length of pattern, so that short patterns don't
+// match almost anything.
+func fuzzyMaxEdits(pattern string) int {
+	switch n := utf8.RuneCountInString(pattern); {
+	case n <= 2:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// fuzzyPieces splits pattern into maxEdits+1 substrings of about the same
+// length.
+func fuzzyPieces(pattern string, maxEdits int) []string {
+	runes := []rune(pattern)
+	n := maxEdits + 1
+	if n > len(runes) {
+		n = len(runes)
+	}
+	pieces := make([]string, 0, n)
+	start := 0
+	for i := 1; i <= n; i++ {
+		end := i * len(runes) / n
+		pieces = append(pieces, string(runes[start:end]))
+		start = end
+	}
+	return pieces
+}
+
+// minFuzzyPrefilterPieceLength is the minimum length in runes of the pieces
+// we ask Zoekt for. Zoekt indexes trigrams, and shorter pieces match almost
+// every file, so asking Zoekt for them is more expensive than searching the
+// archive.
+const minFuzzyPrefilterPieceLength = 3
+
+// canPrefilterFuzzy returns true if Zoekt can efficiently narrow down the
+// files which may contain an occurrence of pattern.
+func canPrefilterFuzzy(pattern string) bool {
+	for _, piece := range fuzzyPieces(pattern, fuzzyMaxEdits(pattern)) {
+		if utf8.RuneCountInString(piece) < minFuzzyPrefilterPieceLength {
+			return false
+		}
+	}
+	return true
+}
+
+// mayMatch returns false if b cannot contain an occurrence of the pattern.
+func (m *fuzzyMatcher) mayMatch(b []byte) bool {
+	for _, piece := range m.pieces {
+		if bytes.Contains(b, piece) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAllIndex returns the byte ranges of the best non-overlapping
+// occurrences of the pattern in b, in the style of regexp.FindAllIndex.
+// Occurrences do not span lines. If n >= 0, at most n ranges are returned.
+func (m *fuzzyMatcher) FindAllIndex(b []byte, n int) (locs [][]int) {
+	if len(m.pattern) == 0 || !m.mayMatch(b) {
+		return nil
+	}
+	for lineStart := 0; lineStart < len(b) && (n < 0 || len(locs) < n); {
+		lineEnd := bytes.IndexByte(b[lineStart:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(b)
+		} else {
+			lineEnd += lineStart
+		}
+		line := b[lineStart:lineEnd]
+		if m.mayMatch(line) {
+			for _, loc := range m.findLine(line) {
+				if n >= 0 && len(locs) == n {
+					break
+				}
+				locs = append(locs, []int{lineStart + loc[0], lineStart + loc[1]})
+			}
+		}
+		lineStart = lineEnd + 1
+	}
+	return locs
+}
+
+type fuzzyOccurrence struct {
+	start, end, edits int
+}
+
+// findLine returns the byte ranges of the best non-overlapping occurrences of
+// the pattern in line. It computes the edit distance between the pattern and
+// the substrings of line ending at each position with the dynamic programming
+// algorithm of Sellers, tracking where the best substring starts. Of several
+// overlapping occurrences, the one with the fewest edits is returned, and of
+// those the one closest in length to the pattern.
+func (m *fuzzyMatcher) findLine(line []byte) (locs [][]int) {
+	// offsets maps rune indexes in line to byte offsets.
+	offsets := make([]int, 0, len(line)+1)
+	text := make([]rune, 0, len(line))
+	for i, r := range string(line) {
+		offsets = append(offsets, i)
+		text = append(text, r)
+	}
+	offsets = append(offsets, len(line))
+
+	// dist[i] is the edit distance between pattern[:i] and the best substring
+	// of text ending at the current position, which starts at start[i].
+	size := len(m.pattern) + 1
+	dist, prevDist := make([]int, size), make([]int, size)
+	start, prevStart := make([]int, size), make([]int, size)
+	for i := range prevDist {
+		prevDist[i] = i
+	}
+
+	var best *fuzzyOccurrence
+	emit := func() {
+		if best != nil && best.end > best.start {
+			locs = append(locs, []int{offsets[best.start], offsets[best.end]})
+		}
+	}
+	for j, c := range text {
+		dist[0], start[0] = 0, j+1
+		for i := 1; i < size; i++ {
+			// Substitute (or match) pattern[i-1] with c.
+			d, s := prevDist[i-1], prevStart[i-1]
+			if m.pattern[i-1] != c {
+				d++
+			}
+			// Skip pattern[i-1]. On ties we prefer the longer substring.
+			if dist[i-1]+1 < d || (dist[i-1]+1 == d && start[i-1] < s) {
+				d, s = dist[i-1]+1, start[i-1]
+			}
+			// Skip c.
+			if prevDist[i]+1 < d || (prevDist[i]+1 == d && prevStart[i] < s) {
+				d, s = prevDist[i]+1, prevStart[i]
+			}
+			dist[i], start[i] = d, s
+		}
+
+		if edits := dist[size-1]; edits <= m.maxEdits {
+			o := fuzzyOccurrence{start: start[size-1], end: j + 1, edits: edits}
+			if best == nil || o.start >= best.end {
+				emit()
+				best = &o
+			} else if o.edits < best.edits || (o.edits == best.edits && m.lengthDiff(o) < m.lengthDiff(*best)) {
+				best = &o
+			}
+		}
+		dist, prevDist = prevDist, dist
+		start, prevStart = prevStart, start
+	}
+	emit()
+	return locs
+}
+
+// lengthDiff returns the difference in length between o and the pattern.
+func (m *fuzzyMatcher) lengthDiff(o fuzzyOccurrence) int {
+	d := o.end - o.start - len(m.pattern)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// fuzzyQuery returns a query for zoekt which matches all files that may
+// contain an occurrence of pattern. It should only be used for patterns for
+// which canPrefilterFuzzy returns true.
+func fuzzyQuery(args *search.TextPatternInfo) zoektquery.Q {
+	pieces := fuzzyPieces(args.Pattern, fuzzyMaxEdits(args.Pattern))
+	or := make([]zoektquery.Q, 0, len(pieces))
+	for _, piece := range pieces {
+		or = append(or, &zoektquery.Substring{
+			Pattern:       piece,
+			CaseSensitive: args.IsCaseSensitive,
+			Content:       true,
+		})
+	}
+	return zoektquery.NewOr(or...)
+}
+
+// fuzzySearchWithZoekt searches an indexed repository for fuzzy matches. Zoekt
+// returns the content of all files which contain a piece of the pattern, which
+// we then search for occurrences of the pattern.
+func fuzzySearchWithZoekt(ctx context.Context, p *protocol.Request, sender matchSender) (deadlineHit bool, err error) {
+	patternInfo := &search.TextPatternInfo{
+		Pattern:                      p.Pattern,
+		IsFuzzy:                      p.IsFuzzy,
+		IsCaseSensitive:              p.IsCaseSensitive,
+		FileMatchLimit:               int32(p.Limit),
+		IncludePatterns:              p.IncludePatterns,
+		ExcludePattern:               p.ExcludePattern,
+		PathPatternsAreCaseSensitive: p.PathPatternsAreCaseSensitive,
+		PatternMatchesContent:        p.PatternMatchesContent,
+		PatternMatchesPath:           p.PatternMatchesPath,
+		Languages:                    p.Languages,
+		ExcludeLanguages:             p.ExcludeLanguages,
+	}
+
+	// Zoekt only returns file contents, so we match paths against the
+	// include and exclude patterns in zoekt rather than with readerGrep.
+	// Zoekt matches lang: filters by file name only, so readerGrep
+	// classifies the returned files by their content.
+	rg, err := compile(&protocol.PatternInfo{
+		Pattern:          p.Pattern,
+		IsFuzzy:          true,
+		IsCaseSensitive:  p.IsCaseSensitive,
+		Languages:        p.Languages,
+		ExcludeLanguages: p.ExcludeLanguages,
+	})
+	if err != nil {
+		return false, badRequestError{err.Error()}
+	}
+
+	if p.Branch == "" {
+		p.Branch = "HEAD"
+	}
+	branchRepos := []zoektquery.BranchRepos{{Branch: p.Branch, Repos: roaring.BitmapOf(uint32(p.RepoID))}}
+	zoektMatches, limitHit, _, err := zoektSearch(ctx, patternInfo, branchRepos, time.Since, p.IndexerEndpoints, false, nil)
+	if err != nil {
+		return false, err
+	}
+	if limitHit {
+		// Zoekt did not return all candidate files, so we may miss
+		// occurrences.
+		sender.SetLimitHit()
+	}
+
+	if len(zoektMatches) == 0 {
+		return false, nil
+	}
+
+	return false, regexSearch(ctx, rg, zipFileFromMatches(zoektMatches), p.Limit, true, false, false, sender)
+}
+
+// zipFileFromMatches returns an in-memory store.ZipFile containing the
+// contents of fileMatches. Close must not be called on it.
+func zipFileFromMatches(fileMatches []zoekt.FileMatch) *store.ZipFile {
+	size := 0
+	for _, fm := range fileMatches {
+		size += len(fm.Content)
+	}
+	zf := &store.ZipFile{
+		Files: make([]store.SrcFile, 0, len(fileMatches)),
+		Data:  make([]byte, 0, size),
+	}
+	for _, fm := range fileMatches {
+		zf.Files = append(zf.Files, store.SrcFile{
+			Name: fm.FileName,
+			Off:  int64(len(zf.Data)),
+			Len:  int32(len(fm.Content)),
+		})
+		zf.Data = append(zf.Data, fm.Content...)
+		if len(fm.Content) > zf.MaxLen {
+			zf.MaxLen = len(fm.Content)
+		}
+	}
+	return zf
+}
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFuzzyMaxEdits(t *testing.T) {
+	cases := map[string]int{
+		"":        0,
+		"ab":      0,
+		"abc":     1,
+		"abcde":   1,
+		"abcdef":  2,
+		"ünïcödé": 2,
+	}
+	for pattern, want := range cases {
+		if got := fuzzyMaxEdits(pattern); got != want {
+			t.Errorf("fuzzyMaxEdits(%q) = %d, want %d", pattern, got, want)
+		}
+	}
+}
+
+func TestFuzzyPieces(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		maxEdits int
+		want     []string
+	}{
+		{"ab", 0, []string{"ab"}},
+		{"abcd", 1, []string{"ab", "cd"}},
+		{"abcdefg", 2, []string{"ab", "cd", "efg"}},
+		{"ab", 2, []string{"a", "b"}},
+		{"äöüß", 1, []string{"äö", "üß"}},
+	}
+	for _, c := range cases {
+		got := fuzzyPieces(c.pattern, c.maxEdits)
+		if diff := cmp.Diff(c.want, got); diff != "" {
+			t.Errorf("fuzzyPieces(%q, %d) mismatch (-want +got):\n%s", c.pattern, c.maxEdits, diff)
+		}
+	}
+}
+
+func TestCanPrefilterFuzzy(t *testing.T) {
+	cases := map[string]bool{
+		"ab":         false,
+		"abcd":       false,
+		"abcdefgh":   false,
+		"abcdefghi":  true,
+		"ünïcödéäöü": true,
+	}
+	for pattern, want := range cases {
+		if got := canPrefilterFuzzy(pattern); got != want {
+			t.Errorf("canPrefilterFuzzy(%q) = %t, want %t", pattern, got, want)
+		}
+	}
+}
+
+func TestFuzzyMatcher(t *testing.T) {
+	cases := []struct {
+		pattern string
+		input   string
+		want    []string
+	}{
+		{"hello", "hello world", []string{"hello"}},
+		{"hello", "helo world", []string{"helo"}},
+		{"hello", "hallo world", []string{"hallo"}},
+		{"hello", "heello world", []string{"heello"}},
+		{"hello", "hxxlo world", nil},
+		{"hello world", "helloworld", []string{"helloworld"}},
+		{"hello world", "hell wrld", []string{"hell wrld"}},
+		{"hello world", "hel wrl", nil},
+		{"abc", "xabcx abd", []string{"abc", "abd"}},
+		{"ab", "ab ac", []string{"ab"}},
+		{"hello", "hel\nlo", nil},
+		{"grüsse", "ein grusse", []string{"grusse"}},
+	}
+	for _, c := range cases {
+		m := newFuzzyMatcher(c.pattern)
+		var got []string
+		for _, loc := range m.FindAllIndex([]byte(c.input), -1) {
+			got = append(got, c.input[loc[0]:loc[1]])
+		}
+		if diff := cmp.Diff(c.want, got); diff != "" {
+			t.Errorf("FindAllIndex(%q, %q) mismatch (-want +got):\n%s", c.pattern, c.input, diff)
+		}
+	}
+}
@@ -57,11 +57,19 @@ type readerGrep struct {
 	// whether a file path matches (and should be searched).
 	matchPath pathmatch.PathMatcher
 
+	// matchLang is compiled from the lang: filters and reports whether a
+	// file is written in the requested languages. It is nil if there are no
+	// lang: filters.
+	matchLang *langMatcher
+
 	// literalSubstring is used to test if a file is worth considering for
 	// matches. literalSubstring is guaranteed to appear in any match found by
 	// re. It is the output of the longestLiteral function. It is only set if
 	// the regex has an empty LiteralPrefix.
 	literalSubstring []byte
+
+	// fuzzy is used instead of re to find matches for fuzzy patterns.
+	fuzzy *fuzzyMatcher
 }
 
 // compile returns a readerGrep for matching p.
@@ -69,8 +77,15 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 	var (
 		re               *regexp.Regexp
 		literalSubstring []byte
+		fuzzy            *fuzzyMatcher
 	)
-	if p.Pattern != "" {
+	if p.Pattern != "" && p.IsFuzzy {
+		pattern := []byte(p.Pattern)
+		if !p.IsCaseSensitive {
+			casetransform.BytesToLowerASCII(pattern, pattern)
+		}
+		fuzzy = newFuzzyMatcher(string(pattern))
+	} else if p.Pattern != "" {
 		expr := p.Pattern
 		if !p.IsRegExp {
 			expr = regexp.QuoteMeta(expr)
@@ -126,7 +141,9 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 		re:               re,
 		ignoreCase:       !p.IsCaseSensitive,
 		matchPath:        matchPath,
+		matchLang:        compileLangMatcher(p.Languages, p.ExcludeLanguages),
 		literalSubstring: literalSubstring,
+		fuzzy:            fuzzy,
 	}, nil
 }
 
@@ -137,13 +154,22 @@ func (rg *readerGrep) Copy() *readerGrep {
 		re:               rg.re,
 		ignoreCase:       rg.ignoreCase,
 		matchPath:        rg.matchPath,
+		matchLang:        rg.matchLang,
 		literalSubstring: rg.literalSubstring,
+		fuzzy:            rg.fuzzy,
 	}
 }
 
 // matchString returns whether rg's regexp pattern matches s. It is intended to be
 // used to match file paths.
 func (rg *readerGrep) matchString(s string) bool {
+	if rg.fuzzy != nil {
+		b := []byte(s)
+		if rg.ignoreCase {
+			casetransform.BytesToLowerASCII(b, b)
+		}
+		return len(rg.fuzzy.FindAllIndex(b, 1)) > 0
+	}
 	if rg.re == nil {
 		return true
 	}
@@ -187,7 +213,12 @@ func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile, limit int) (matc
 	}
 
 	// find limit+1 matches so we know whether we hit the limit
-	locs := rg.re.FindAllIndex(fileMatchBuf, limit+1)
+	var locs [][]int
+	if rg.fuzzy != nil {
+		locs = rg.fuzzy.FindAllIndex(fileMatchBuf, limit+1)
+	} else {
+		locs = rg.re.FindAllIndex(fileMatchBuf, limit+1)
+	}
 	lastStart := 0
 	lastLineNumber := 0
 	lastMatchIndex := 0
@@ -311,7 +342,11 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, limit i
 	if rg.re != nil {
 		span.SetTag("re", rg.re.String())
 	}
+	if rg.fuzzy != nil {
+		span.SetTag("fuzzy", string(rg.fuzzy.pattern))
+	}
 	span.SetTag("path", rg.matchPath.String())
+	span.SetTag("lang", rg.matchLang.String())
 	defer func() {
 		if err != nil {
 			ext.Error.Set(span, true)
@@ -341,11 +376,12 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, limit i
 		files   = zf.Files
 	)
 
-	if rg.re == nil || (patternMatchesPaths && !patternMatchesContent) {
+	if (rg.re == nil && rg.fuzzy == nil) || (patternMatchesPaths && !patternMatchesContent) {
 		// Fast path for only matching file paths (or with a nil pattern, which matches all files,
-		// so is effectively matching only on file paths).
+		// so is effectively matching only on file paths). Languages are classified by file name
+		// only, so that we don't read the content of every file.
 		for _, f := range files {
-			if match := rg.matchPath.MatchPath(f.Name) && rg.matchString(f.Name); match == !isPatternNegated {
+			if match := rg.matchPath.MatchPath(f.Name) && rg.matchLang.Match(f.Name, nil) && rg.matchString(f.Name); match == !isPatternNegated {
 				if ctx.Err() != nil {
 					return ctx.Err()
 				}
@@ -379,7 +415,7 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, limit i
 				filesmu.Unlock()
 
 				// decide whether to process, record that decision
-				if !rg.matchPath.MatchPath(f.Name) {
+				if !rg.matchPath.MatchPath(f.Name) || !rg.matchLang.Match(f.Name, func() []byte { return zf.DataFor(f) }) {
 					filesSkipped.Inc()
 					continue
 				}
@@ -295,6 +295,7 @@ func structuralSearchWithZoekt(ctx context.Context, p *protocol.Request, sender
 		PatternMatchesContent:        p.PatternMatchesContent,
 		PatternMatchesPath:           p.PatternMatchesPath,
 		Languages:                    p.Languages,
+		ExcludeLanguages:             p.ExcludeLanguages,
 	}
 
 	if p.Branch == "" {
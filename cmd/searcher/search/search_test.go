@@ -127,6 +127,17 @@ main.go:6:	fmt.Println("Hello world")
 `},
 
 		{protocol.PatternInfo{Pattern: "doesnotmatch"}, ""},
+
+		{protocol.PatternInfo{Pattern: "Println(\"Helo wrld", IsFuzzy: true}, `
+main.go:6:	fmt.Println("Hello world")
+`},
+		{protocol.PatternInfo{Pattern: "wirld", IsFuzzy: true, IncludePatterns: []string{"*.md"}}, `
+README.md:1:# Hello World
+README.md:3:Hello world example in go
+`},
+		{protocol.PatternInfo{Pattern: "wirld", IsFuzzy: true, IsCaseSensitive: true, IncludePatterns: []string{"*.md"}}, `
+README.md:3:Hello world example in go
+`},
 		{protocol.PatternInfo{Pattern: "", IsRegExp: false, IncludePatterns: []string{"\\.png"}, PathPatternsAreRegExps: true, PatternMatchesPath: true}, `
 milton.png
 `},
@@ -207,6 +218,15 @@ README.md
 abc.txt
 file++.plus
 milton.png
+`},
+
+		{protocol.PatternInfo{Pattern: "world", Languages: []string{"go"}}, `
+main.go:6:	fmt.Println("Hello world")
+`},
+
+		{protocol.PatternInfo{Pattern: "world", ExcludeLanguages: []string{"go"}}, `
+README.md:1:# Hello World
+README.md:3:Hello world example in go
 `},
 	}
 
@@ -12,6 +12,10 @@ type matchSender interface {
 	SentCount() int
 	Remaining() int
 	LimitHit() bool
+
+	// SetLimitHit records that some matches were dropped before they were
+	// sent, for example because the underlying search was incomplete.
+	SetLimitHit()
 }
 
 type limitedStreamCollector struct {
@@ -88,6 +92,12 @@ func (m *limitedStreamCollector) LimitHit() bool {
 	return m.limitHit
 }
 
+func (m *limitedStreamCollector) SetLimitHit() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.limitHit = true
+}
+
 type limitedStream struct {
 	cb        func(protocol.FileMatch)
 	mux       sync.Mutex
@@ -162,3 +172,9 @@ func (m *limitedStream) LimitHit() bool {
 	defer m.mux.Unlock()
 	return m.limitHit
 }
+
+func (m *limitedStream) SetLimitHit() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.limitHit = true
+}
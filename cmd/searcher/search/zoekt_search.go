@@ -47,17 +47,19 @@ func getZoektClient(indexerEndpoints []string) zoekt.Streamer {
 func HandleFilePathPatterns(query *search.TextPatternInfo) (zoektquery.Q, error) {
 	var and []zoektquery.Q
 
-	// Zoekt uses regular expressions for file paths.
+	// Zoekt uses regular expressions for file paths. Zoekt only classifies
+	// files by their name, so lang: filters are applied as file patterns.
 	// Unhandled cases: PathPatternsAreCaseSensitive and whitespace in file path patterns.
-	for _, p := range query.IncludePatterns {
+	includePatterns, excludePattern := query.FilePatterns()
+	for _, p := range includePatterns {
 		q, err := zoektutil.FileRe(p, query.IsCaseSensitive)
 		if err != nil {
 			return nil, err
 		}
 		and = append(and, q)
 	}
-	if query.ExcludePattern != "" {
-		q, err := zoektutil.FileRe(query.ExcludePattern, query.IsCaseSensitive)
+	if excludePattern != "" {
+		q, err := zoektutil.FileRe(excludePattern, query.IsCaseSensitive)
 		if err != nil {
 			return nil, err
 		}
@@ -89,6 +91,14 @@ func HandleFilePathPatterns(query *search.TextPatternInfo) (zoektquery.Q, error)
 }
 
 func buildQuery(args *search.TextPatternInfo, branchRepos []zoektquery.BranchRepos, filePathPatterns zoektquery.Q, shortcircuit bool) (zoektquery.Q, error) {
+	if args.IsFuzzy {
+		return zoektquery.NewAnd(
+			&zoektquery.BranchesRepos{List: branchRepos},
+			filePathPatterns,
+			fuzzyQuery(args),
+		), nil
+	}
+
 	regexString := comby.StructuralPatToRegexpQuery(args.Pattern, shortcircuit)
 	if len(regexString) == 0 {
 		return &zoektquery.Const{Value: true}, nil
@@ -49,7 +49,7 @@ func mustParseGraphQLSchema(t *testing.T, db dbutil.DB) *graphql.Schema {
 	t.Helper()
 
 	parseSchemaOnce.Do(func() {
-		parsedSchema, parseSchemaErr = graphqlbackend.NewSchema(database.NewDB(db), nil, nil, nil, NewResolver(db, clock), nil, nil, nil, nil, nil)
+		parsedSchema, parseSchemaErr = graphqlbackend.NewSchema(database.NewDB(db), nil, nil, nil, NewResolver(db, clock), nil, nil, nil, nil, nil, nil)
 	})
 	if parseSchemaErr != nil {
 		t.Fatal(parseSchemaErr)
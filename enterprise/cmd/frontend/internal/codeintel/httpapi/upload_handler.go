@@ -115,6 +115,7 @@ type UploadArgs struct {
 	RepositoryID      int
 	Indexer           string
 	AssociatedIndexID int
+	ParentUploadID    *int
 }
 
 type enqueuePayload struct {
@@ -134,6 +135,11 @@ type enqueuePayload struct {
 //   - POST `/upload?uploadId={id},index={i}`
 //   - POST `/upload?uploadId={id},done=true`
 //
+// Either sequence may supply a `parentUploadId` with the first request. Such an incremental upload
+// only needs to contain the documents that changed since the commit of the given (completed) upload
+// of the same repository. The data of all other documents is copied from the parent upload when the
+// upload is processed.
+//
 // See the functions the following functions for details on how each request is handled:
 //
 //   - handleEnqueueSinglePayload
@@ -151,6 +157,23 @@ func (h *UploadHandler) handleEnqueueErr(w http.ResponseWriter, r *http.Request,
 		AssociatedIndexID: getQueryInt(r, "associatedIndexId"),
 	}
 
+	if hasQuery(r, "parentUploadId") {
+		parentUploadID := getQueryInt(r, "parentUploadId")
+
+		parent, exists, err := h.dbStore.GetUploadByID(ctx, parentUploadID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists || parent.RepositoryID != repositoryID {
+			return nil, clientError("parent upload not found")
+		}
+		if parent.State != "completed" {
+			return nil, clientError("parent upload has not been processed")
+		}
+
+		uploadArgs.ParentUploadID = &parentUploadID
+	}
+
 	if !hasQuery(r, "multiPart") && !hasQuery(r, "uploadId") {
 		return h.handleEnqueueSinglePayload(r, uploadArgs)
 	}
@@ -217,6 +240,7 @@ func (h *UploadHandler) handleEnqueueSinglePayload(r *http.Request, uploadArgs U
 		RepositoryID:      uploadArgs.RepositoryID,
 		Indexer:           uploadArgs.Indexer,
 		AssociatedIndexID: &uploadArgs.AssociatedIndexID,
+		ParentUploadID:    uploadArgs.ParentUploadID,
 		State:             "uploading",
 		NumParts:          1,
 		UploadedParts:     []int{0},
@@ -257,6 +281,7 @@ func (h *UploadHandler) handleEnqueueMultipartSetup(r *http.Request, uploadArgs
 		RepositoryID:      uploadArgs.RepositoryID,
 		Indexer:           uploadArgs.Indexer,
 		AssociatedIndexID: &uploadArgs.AssociatedIndexID,
+		ParentUploadID:    uploadArgs.ParentUploadID,
 		State:             "uploading",
 		NumParts:          numParts,
 		UploadedParts:     nil,
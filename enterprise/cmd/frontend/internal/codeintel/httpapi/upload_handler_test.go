@@ -189,6 +189,72 @@ func TestHandleEnqueueSinglePayloadNoIndexerName(t *testing.T) {
 	}
 }
 
+func TestHandleEnqueueSinglePayloadWithParentUpload(t *testing.T) {
+	setupRepoMocks(t)
+
+	mockDBStore := NewMockDBStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	mockDBStore.InsertUploadFunc.SetDefaultReturn(42, nil)
+	mockDBStore.GetUploadByIDFunc.SetDefaultHook(func(ctx context.Context, id int) (store.Upload, bool, error) {
+		switch id {
+		case 40:
+			return store.Upload{ID: 40, RepositoryID: 50, State: "completed"}, true, nil
+		case 41:
+			return store.Upload{ID: 41, RepositoryID: 50, State: "queued"}, true, nil
+		default:
+			return store.Upload{}, false, nil
+		}
+	})
+
+	testCases := []struct {
+		parentUploadID     string
+		expectedStatusCode int
+	}{
+		{parentUploadID: "40", expectedStatusCode: http.StatusAccepted},
+		{parentUploadID: "41", expectedStatusCode: http.StatusBadRequest},
+		{parentUploadID: "43", expectedStatusCode: http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		testURL, err := url.Parse("http://test.com/upload")
+		if err != nil {
+			t.Fatalf("unexpected error constructing url: %s", err)
+		}
+		testURL.RawQuery = (url.Values{
+			"commit":         []string{testCommit},
+			"root":           []string{"proj/"},
+			"repository":     []string{"github.com/test/test"},
+			"indexerName":    []string{"lsif-go"},
+			"parentUploadId": []string{testCase.parentUploadID},
+		}).Encode()
+
+		w := httptest.NewRecorder()
+		r, err := http.NewRequest("POST", testURL.String(), bytes.NewReader([]byte("payload")))
+		if err != nil {
+			t.Fatalf("unexpected error constructing request: %s", err)
+		}
+
+		h := &UploadHandler{
+			dbStore:     mockDBStore,
+			uploadStore: mockUploadStore,
+		}
+		h.handleEnqueue(w, r)
+
+		if w.Code != testCase.expectedStatusCode {
+			t.Errorf("unexpected status code for parent upload %s. want=%d have=%d", testCase.parentUploadID, testCase.expectedStatusCode, w.Code)
+		}
+	}
+
+	if len(mockDBStore.InsertUploadFunc.History()) != 1 {
+		t.Errorf("unexpected number of InsertUpload calls. want=%d have=%d", 1, len(mockDBStore.InsertUploadFunc.History()))
+	} else if parentUploadID := mockDBStore.InsertUploadFunc.History()[0].Arg1.ParentUploadID; parentUploadID == nil || *parentUploadID != 40 {
+		t.Errorf("unexpected parent upload id. want=%d have=%v", 40, parentUploadID)
+	}
+}
+
 func TestHandleEnqueueMultipartSetup(t *testing.T) {
 	setupRepoMocks(t)
 
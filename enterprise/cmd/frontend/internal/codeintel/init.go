@@ -53,6 +53,7 @@ func newResolver(ctx context.Context, db database.DB, observationContext *observ
 	innerResolver := codeintelresolvers.NewResolver(
 		services.dbStore,
 		services.lsifStore,
+		newSearchClient(),
 		services.gitserverClient,
 		policyMatcher,
 		services.indexEnqueuer,
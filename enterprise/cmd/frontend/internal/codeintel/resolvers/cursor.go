@@ -15,6 +15,13 @@ type remoteCursor struct {
 	UploadBatchIDs []int `json:"uploadBatchIDs"`
 	// The location offset within the associated batch of uploads.
 	LocationOffset int `json:"locationOffset"`
+	// The identifiers of monikers that are probably referenced by an upload of a batch.
+	MatchedIdentifiers []string `json:"matchedIdentifiers,omitempty"`
+}
+
+// searchCursor is a location offset within the sorted result set of a search-based fallback.
+type searchCursor struct {
+	LocationOffset int `json:"locationOffset"`
 }
 
 type cursorAdjustedUpload struct {
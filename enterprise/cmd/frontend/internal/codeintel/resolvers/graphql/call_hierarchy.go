@@ -0,0 +1,50 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+)
+
+type CallHierarchyCallResolver struct {
+	location         gql.LocationResolver
+	callSites        []resolvers.AdjustedLocation
+	locationResolver *CachedLocationResolver
+}
+
+func NewCallHierarchyCallResolver(location gql.LocationResolver, callSites []resolvers.AdjustedLocation, locationResolver *CachedLocationResolver) gql.CallHierarchyCallResolver {
+	return &CallHierarchyCallResolver{
+		location:         location,
+		callSites:        callSites,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *CallHierarchyCallResolver) Location(ctx context.Context) (gql.LocationResolver, error) {
+	return r.location, nil
+}
+
+func (r *CallHierarchyCallResolver) CallSites(ctx context.Context) (gql.LocationConnectionResolver, error) {
+	return NewLocationConnectionResolver(r.callSites, nil, r.locationResolver), nil
+}
+
+// resolveCallHierarchyCalls creates a slice of CallHierarchyCallResolvers for the given list of adjusted
+// calls. The resulting list may be smaller than the input list as any call whose location has a commit
+// not known by gitserver will be skipped.
+func resolveCallHierarchyCalls(ctx context.Context, locationResolver *CachedLocationResolver, calls []resolvers.AdjustedCallHierarchyCall) ([]gql.CallHierarchyCallResolver, error) {
+	resolvedCalls := make([]gql.CallHierarchyCallResolver, 0, len(calls))
+	for _, call := range calls {
+		location, err := resolveLocation(ctx, locationResolver, call.Location)
+		if err != nil {
+			return nil, err
+		}
+		if location == nil {
+			continue
+		}
+
+		resolvedCalls = append(resolvedCalls, NewCallHierarchyCallResolver(location, call.CallSites, locationResolver))
+	}
+
+	return resolvedCalls, nil
+}
@@ -272,5 +272,8 @@ func resolveLocation(ctx context.Context, locationResolver *CachedLocationResolv
 	}
 
 	lspRange := convertRange(location.AdjustedRange)
-	return gql.NewLocationResolver(treeResolver, &lspRange), nil
+	if location.SearchBased {
+		return gql.NewLocationResolver(treeResolver, &lspRange), nil
+	}
+	return gql.NewPreciseLocationResolver(treeResolver, &lspRange), nil
 }
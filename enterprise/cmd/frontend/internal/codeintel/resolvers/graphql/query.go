@@ -122,6 +122,33 @@ func (r *QueryResolver) Implementations(ctx context.Context, args *gql.LSIFPaged
 	return NewLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
 }
 
+func (r *QueryResolver) TypeDefinitions(ctx context.Context, args *gql.LSIFQueryPositionArgs) (gql.LocationConnectionResolver, error) {
+	locations, err := r.resolver.TypeDefinitions(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLocationConnectionResolver(locations, nil, r.locationResolver), nil
+}
+
+func (r *QueryResolver) IncomingCalls(ctx context.Context, args *gql.LSIFQueryPositionArgs) ([]gql.CallHierarchyCallResolver, error) {
+	calls, err := r.resolver.IncomingCalls(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveCallHierarchyCalls(ctx, r.locationResolver, calls)
+}
+
+func (r *QueryResolver) OutgoingCalls(ctx context.Context, args *gql.LSIFQueryPositionArgs) ([]gql.CallHierarchyCallResolver, error) {
+	calls, err := r.resolver.OutgoingCalls(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveCallHierarchyCalls(ctx, r.locationResolver, calls)
+}
+
 func (r *QueryResolver) Hover(ctx context.Context, args *gql.LSIFQueryPositionArgs) (gql.HoverResolver, error) {
 	text, rx, exists, err := r.resolver.Hover(ctx, int(args.Line), int(args.Character))
 	if err != nil || !exists {
@@ -59,6 +59,44 @@ func TestDeleteLSIFUploadUnauthenticated(t *testing.T) {
 	}
 }
 
+func TestRepositoryStorageUsage(t *testing.T) {
+	db := new(dbtesting.MockDB)
+
+	t.Cleanup(func() {
+		database.Mocks.Users.GetByCurrentAuthUser = nil
+	})
+	database.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.RepositoryStorageUsageFunc.SetDefaultReturn(store.StorageUsage{StorageBytes: 5000000000, NumUploads: 12}, nil)
+
+	usage, err := NewResolver(db, mockResolver).RepositoryStorageUsage(context.Background(), gql.MarshalRepositoryID(50))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if val := mockResolver.RepositoryStorageUsageFunc.History()[0].Arg1; val != 50 {
+		t.Fatalf("unexpected repository id. want=%d have=%d", 50, val)
+	}
+	if val := usage.StorageBytes(); val.Int != 5000000000 {
+		t.Errorf("unexpected storage bytes. want=%d have=%d", 5000000000, val.Int)
+	}
+	if val := usage.UploadCount(); val != 12 {
+		t.Errorf("unexpected upload count. want=%d have=%d", 12, val)
+	}
+}
+
+func TestRepositoryStorageUsageUnauthenticated(t *testing.T) {
+	db := new(dbtesting.MockDB)
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(db, mockResolver).RepositoryStorageUsage(context.Background(), gql.MarshalRepositoryID(50)); err != backend.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", backend.ErrNotAuthenticated, err)
+	}
+}
+
 func TestDeleteLSIFIndex(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
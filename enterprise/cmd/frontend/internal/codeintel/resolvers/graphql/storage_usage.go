@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// 🚨 SECURITY: Only site admins may view storage usage
+func (r *Resolver) RepositoryStorageUsage(ctx context.Context, id graphql.ID) (gql.CodeIntelligenceStorageUsageResolver, error) {
+	if err := checkCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := gql.UnmarshalRepositoryID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := r.resolver.RepositoryStorageUsage(ctx, int(repositoryID))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStorageUsageResolver(usage, conf.CodeIntelStorageQuotaRepositoryBytes()), nil
+}
+
+// 🚨 SECURITY: Only site admins may view storage usage
+func (r *Resolver) OrganizationStorageUsage(ctx context.Context, id graphql.ID) (gql.CodeIntelligenceStorageUsageResolver, error) {
+	if err := checkCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	orgID, err := gql.UnmarshalOrgID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := r.resolver.OrganizationStorageUsage(ctx, int(orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStorageUsageResolver(usage, conf.CodeIntelStorageQuotaOrganizationBytes()), nil
+}
+
+type StorageUsageResolver struct {
+	usage store.StorageUsage
+	quota int64
+}
+
+// NewStorageUsageResolver returns a resolver for the given storage usage. A quota of zero denotes
+// that no quota is configured.
+func NewStorageUsageResolver(usage store.StorageUsage, quota int64) gql.CodeIntelligenceStorageUsageResolver {
+	return &StorageUsageResolver{
+		usage: usage,
+		quota: quota,
+	}
+}
+
+func (r *StorageUsageResolver) StorageBytes() gql.BigInt {
+	return gql.BigInt{Int: r.usage.StorageBytes}
+}
+
+func (r *StorageUsageResolver) UploadCount() int32 {
+	return int32(r.usage.NumUploads)
+}
+
+func (r *StorageUsageResolver) QuotaBytes() *gql.BigInt {
+	if r.quota == 0 {
+		return nil
+	}
+
+	return &gql.BigInt{Int: r.quota}
+}
@@ -46,6 +46,9 @@ type DBStore interface {
 	GetIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int) (store.IndexConfiguration, bool, error)
 	UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, data []byte) error
 	RepoIDsByGlobPatterns(ctx context.Context, patterns []string, limit, offset int) ([]int, int, error)
+	MarkUploadsAsQueried(ctx context.Context, ids []int) error
+	RepositoryStorageUsage(ctx context.Context, repositoryID int) (dbstore.StorageUsage, error)
+	OrganizationStorageUsage(ctx context.Context, orgID int) (dbstore.StorageUsage, error)
 }
 
 type LSIFStore interface {
@@ -55,6 +58,9 @@ type LSIFStore interface {
 	Definitions(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
 	References(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
 	Implementations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
+	TypeDefinitions(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
+	IncomingCalls(ctx context.Context, bundleID int, path string, line, character int) ([]lsifstore.CallHierarchyCall, error)
+	OutgoingCalls(ctx context.Context, bundleID int, path string, line, character int) ([]lsifstore.CallHierarchyCall, error)
 	Hover(ctx context.Context, bundleID int, path string, line, character int) (string, lsifstore.Range, bool, error)
 	Diagnostics(ctx context.Context, bundleID int, prefix string, limit, offset int) ([]lsifstore.Diagnostic, int, error)
 	MonikersByPosition(ctx context.Context, bundleID int, path string, line, character int) ([][]precise.MonikerData, error)
@@ -67,6 +73,10 @@ type LSIFStore interface {
 	DocumentationSearch(ctx context.Context, table, query string, repos []string) ([]precise.DocumentationSearchResult, error)
 }
 
+type SearchClient interface {
+	Search(ctx context.Context, query string) ([]SearchLocation, error)
+}
+
 type IndexEnqueuer interface {
 	QueueIndexes(ctx context.Context, repositoryID int, rev, configuration string, force bool) ([]dbstore.Index, error)
 	InferIndexConfiguration(ctx context.Context, repositoryID int) (*config.IndexConfiguration, error)
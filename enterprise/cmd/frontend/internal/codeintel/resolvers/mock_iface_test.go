@@ -92,6 +92,12 @@ type MockDBStore struct {
 	// MarkRepositoryAsDirtyFunc is an instance of a mock function object
 	// controlling the behavior of the method MarkRepositoryAsDirty.
 	MarkRepositoryAsDirtyFunc *DBStoreMarkRepositoryAsDirtyFunc
+	// MarkUploadsAsQueriedFunc is an instance of a mock function object
+	// controlling the behavior of the method MarkUploadsAsQueried.
+	MarkUploadsAsQueriedFunc *DBStoreMarkUploadsAsQueriedFunc
+	// OrganizationStorageUsageFunc is an instance of a mock function object
+	// controlling the behavior of the method OrganizationStorageUsage.
+	OrganizationStorageUsageFunc *DBStoreOrganizationStorageUsageFunc
 	// ReferenceIDsAndFiltersFunc is an instance of a mock function object
 	// controlling the behavior of the method ReferenceIDsAndFilters.
 	ReferenceIDsAndFiltersFunc *DBStoreReferenceIDsAndFiltersFunc
@@ -101,6 +107,9 @@ type MockDBStore struct {
 	// RepoNameFunc is an instance of a mock function object controlling the
 	// behavior of the method RepoName.
 	RepoNameFunc *DBStoreRepoNameFunc
+	// RepositoryStorageUsageFunc is an instance of a mock function object
+	// controlling the behavior of the method RepositoryStorageUsage.
+	RepositoryStorageUsageFunc *DBStoreRepositoryStorageUsageFunc
 	// UpdateConfigurationPolicyFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateConfigurationPolicy.
@@ -220,6 +229,16 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		MarkUploadsAsQueriedFunc: &DBStoreMarkUploadsAsQueriedFunc{
+			defaultHook: func(context.Context, []int) error {
+				return nil
+			},
+		},
+		OrganizationStorageUsageFunc: &DBStoreOrganizationStorageUsageFunc{
+			defaultHook: func(context.Context, int) (dbstore.StorageUsage, error) {
+				return dbstore.StorageUsage{}, nil
+			},
+		},
 		ReferenceIDsAndFiltersFunc: &DBStoreReferenceIDsAndFiltersFunc{
 			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error) {
 				return nil, 0, nil
@@ -235,6 +254,11 @@ func NewMockDBStore() *MockDBStore {
 				return "", nil
 			},
 		},
+		RepositoryStorageUsageFunc: &DBStoreRepositoryStorageUsageFunc{
+			defaultHook: func(context.Context, int) (dbstore.StorageUsage, error) {
+				return dbstore.StorageUsage{}, nil
+			},
+		},
 		UpdateConfigurationPolicyFunc: &DBStoreUpdateConfigurationPolicyFunc{
 			defaultHook: func(context.Context, dbstore.ConfigurationPolicy) error {
 				return nil
@@ -357,6 +381,16 @@ func NewStrictMockDBStore() *MockDBStore {
 				panic("unexpected invocation of MockDBStore.MarkRepositoryAsDirty")
 			},
 		},
+		MarkUploadsAsQueriedFunc: &DBStoreMarkUploadsAsQueriedFunc{
+			defaultHook: func(context.Context, []int) error {
+				panic("unexpected invocation of MockDBStore.MarkUploadsAsQueried")
+			},
+		},
+		OrganizationStorageUsageFunc: &DBStoreOrganizationStorageUsageFunc{
+			defaultHook: func(context.Context, int) (dbstore.StorageUsage, error) {
+				panic("unexpected invocation of MockDBStore.OrganizationStorageUsage")
+			},
+		},
 		ReferenceIDsAndFiltersFunc: &DBStoreReferenceIDsAndFiltersFunc{
 			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (dbstore.PackageReferenceScanner, int, error) {
 				panic("unexpected invocation of MockDBStore.ReferenceIDsAndFilters")
@@ -372,6 +406,11 @@ func NewStrictMockDBStore() *MockDBStore {
 				panic("unexpected invocation of MockDBStore.RepoName")
 			},
 		},
+		RepositoryStorageUsageFunc: &DBStoreRepositoryStorageUsageFunc{
+			defaultHook: func(context.Context, int) (dbstore.StorageUsage, error) {
+				panic("unexpected invocation of MockDBStore.RepositoryStorageUsage")
+			},
+		},
 		UpdateConfigurationPolicyFunc: &DBStoreUpdateConfigurationPolicyFunc{
 			defaultHook: func(context.Context, dbstore.ConfigurationPolicy) error {
 				panic("unexpected invocation of MockDBStore.UpdateConfigurationPolicy")
@@ -452,6 +491,12 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		MarkRepositoryAsDirtyFunc: &DBStoreMarkRepositoryAsDirtyFunc{
 			defaultHook: i.MarkRepositoryAsDirty,
 		},
+		MarkUploadsAsQueriedFunc: &DBStoreMarkUploadsAsQueriedFunc{
+			defaultHook: i.MarkUploadsAsQueried,
+		},
+		OrganizationStorageUsageFunc: &DBStoreOrganizationStorageUsageFunc{
+			defaultHook: i.OrganizationStorageUsage,
+		},
 		ReferenceIDsAndFiltersFunc: &DBStoreReferenceIDsAndFiltersFunc{
 			defaultHook: i.ReferenceIDsAndFilters,
 		},
@@ -461,6 +506,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		RepoNameFunc: &DBStoreRepoNameFunc{
 			defaultHook: i.RepoName,
 		},
+		RepositoryStorageUsageFunc: &DBStoreRepositoryStorageUsageFunc{
+			defaultHook: i.RepositoryStorageUsage,
+		},
 		UpdateConfigurationPolicyFunc: &DBStoreUpdateConfigurationPolicyFunc{
 			defaultHook: i.UpdateConfigurationPolicy,
 		},
@@ -2841,6 +2889,225 @@ func (c DBStoreMarkRepositoryAsDirtyFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBStoreMarkUploadsAsQueriedFunc describes the behavior when the
+// MarkUploadsAsQueried method of the parent MockDBStore instance is
+// invoked.
+type DBStoreMarkUploadsAsQueriedFunc struct {
+	defaultHook func(context.Context, []int) error
+	hooks       []func(context.Context, []int) error
+	history     []DBStoreMarkUploadsAsQueriedFuncCall
+	mutex       sync.Mutex
+}
+
+// MarkUploadsAsQueried delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) MarkUploadsAsQueried(v0 context.Context, v1 []int) error {
+	r0 := m.MarkUploadsAsQueriedFunc.nextHook()(v0, v1)
+	m.MarkUploadsAsQueriedFunc.appendCall(DBStoreMarkUploadsAsQueriedFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the MarkUploadsAsQueried
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreMarkUploadsAsQueriedFunc) SetDefaultHook(hook func(context.Context, []int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkUploadsAsQueried method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreMarkUploadsAsQueriedFunc) PushHook(hook func(context.Context, []int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreMarkUploadsAsQueriedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreMarkUploadsAsQueriedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []int) error {
+		return r0
+	})
+}
+
+func (f *DBStoreMarkUploadsAsQueriedFunc) nextHook() func(context.Context, []int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreMarkUploadsAsQueriedFunc) appendCall(r0 DBStoreMarkUploadsAsQueriedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreMarkUploadsAsQueriedFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreMarkUploadsAsQueriedFunc) History() []DBStoreMarkUploadsAsQueriedFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreMarkUploadsAsQueriedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreMarkUploadsAsQueriedFuncCall is an object that describes an
+// invocation of method MarkUploadsAsQueried on an instance of MockDBStore.
+type DBStoreMarkUploadsAsQueriedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreMarkUploadsAsQueriedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreMarkUploadsAsQueriedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// DBStoreOrganizationStorageUsageFunc describes the behavior when the
+// OrganizationStorageUsage method of the parent MockDBStore instance is
+// invoked.
+type DBStoreOrganizationStorageUsageFunc struct {
+	defaultHook func(context.Context, int) (dbstore.StorageUsage, error)
+	hooks       []func(context.Context, int) (dbstore.StorageUsage, error)
+	history     []DBStoreOrganizationStorageUsageFuncCall
+	mutex       sync.Mutex
+}
+
+// OrganizationStorageUsage delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) OrganizationStorageUsage(v0 context.Context, v1 int) (dbstore.StorageUsage, error) {
+	r0, r1 := m.OrganizationStorageUsageFunc.nextHook()(v0, v1)
+	m.OrganizationStorageUsageFunc.appendCall(DBStoreOrganizationStorageUsageFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// OrganizationStorageUsage method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreOrganizationStorageUsageFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.StorageUsage, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// OrganizationStorageUsage method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStoreOrganizationStorageUsageFunc) PushHook(hook func(context.Context, int) (dbstore.StorageUsage, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreOrganizationStorageUsageFunc) SetDefaultReturn(r0 dbstore.StorageUsage, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.StorageUsage, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreOrganizationStorageUsageFunc) PushReturn(r0 dbstore.StorageUsage, r1 error) {
+	f.PushHook(func(context.Context, int) (dbstore.StorageUsage, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreOrganizationStorageUsageFunc) nextHook() func(context.Context, int) (dbstore.StorageUsage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreOrganizationStorageUsageFunc) appendCall(r0 DBStoreOrganizationStorageUsageFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreOrganizationStorageUsageFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreOrganizationStorageUsageFunc) History() []DBStoreOrganizationStorageUsageFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreOrganizationStorageUsageFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreOrganizationStorageUsageFuncCall is an object that describes an
+// invocation of method OrganizationStorageUsage on an instance of
+// MockDBStore.
+type DBStoreOrganizationStorageUsageFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.StorageUsage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreOrganizationStorageUsageFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreOrganizationStorageUsageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreReferenceIDsAndFiltersFunc describes the behavior when the
 // ReferenceIDsAndFilters method of the parent MockDBStore instance is
 // invoked.
@@ -3194,37 +3461,36 @@ func (c DBStoreRepoNameFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// DBStoreUpdateConfigurationPolicyFunc describes the behavior when the
-// UpdateConfigurationPolicy method of the parent MockDBStore instance is
+// DBStoreRepositoryStorageUsageFunc describes the behavior when the
+// RepositoryStorageUsage method of the parent MockDBStore instance is
 // invoked.
-type DBStoreUpdateConfigurationPolicyFunc struct {
-	defaultHook func(context.Context, dbstore.ConfigurationPolicy) error
-	hooks       []func(context.Context, dbstore.ConfigurationPolicy) error
-	history     []DBStoreUpdateConfigurationPolicyFuncCall
+type DBStoreRepositoryStorageUsageFunc struct {
+	defaultHook func(context.Context, int) (dbstore.StorageUsage, error)
+	hooks       []func(context.Context, int) (dbstore.StorageUsage, error)
+	history     []DBStoreRepositoryStorageUsageFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateConfigurationPolicy delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockDBStore) UpdateConfigurationPolicy(v0 context.Context, v1 dbstore.ConfigurationPolicy) error {
-	r0 := m.UpdateConfigurationPolicyFunc.nextHook()(v0, v1)
-	m.UpdateConfigurationPolicyFunc.appendCall(DBStoreUpdateConfigurationPolicyFuncCall{v0, v1, r0})
-	return r0
+// RepositoryStorageUsage delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) RepositoryStorageUsage(v0 context.Context, v1 int) (dbstore.StorageUsage, error) {
+	r0, r1 := m.RepositoryStorageUsageFunc.nextHook()(v0, v1)
+	m.RepositoryStorageUsageFunc.appendCall(DBStoreRepositoryStorageUsageFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// UpdateConfigurationPolicy method of the parent MockDBStore instance is
+// RepositoryStorageUsage method of the parent MockDBStore instance is
 // invoked and the hook queue is empty.
-func (f *DBStoreUpdateConfigurationPolicyFunc) SetDefaultHook(hook func(context.Context, dbstore.ConfigurationPolicy) error) {
+func (f *DBStoreRepositoryStorageUsageFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.StorageUsage, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateConfigurationPolicy method of the parent MockDBStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *DBStoreUpdateConfigurationPolicyFunc) PushHook(hook func(context.Context, dbstore.ConfigurationPolicy) error) {
+// RepositoryStorageUsage method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreRepositoryStorageUsageFunc) PushHook(hook func(context.Context, int) (dbstore.StorageUsage, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3232,21 +3498,21 @@ func (f *DBStoreUpdateConfigurationPolicyFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreUpdateConfigurationPolicyFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, dbstore.ConfigurationPolicy) error {
-		return r0
+func (f *DBStoreRepositoryStorageUsageFunc) SetDefaultReturn(r0 dbstore.StorageUsage, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.StorageUsage, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreUpdateConfigurationPolicyFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, dbstore.ConfigurationPolicy) error {
-		return r0
+func (f *DBStoreRepositoryStorageUsageFunc) PushReturn(r0 dbstore.StorageUsage, r1 error) {
+	f.PushHook(func(context.Context, int) (dbstore.StorageUsage, error) {
+		return r0, r1
 	})
 }
 
-func (f *DBStoreUpdateConfigurationPolicyFunc) nextHook() func(context.Context, dbstore.ConfigurationPolicy) error {
+func (f *DBStoreRepositoryStorageUsageFunc) nextHook() func(context.Context, int) (dbstore.StorageUsage, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3259,74 +3525,186 @@ func (f *DBStoreUpdateConfigurationPolicyFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *DBStoreUpdateConfigurationPolicyFunc) appendCall(r0 DBStoreUpdateConfigurationPolicyFuncCall) {
+func (f *DBStoreRepositoryStorageUsageFunc) appendCall(r0 DBStoreRepositoryStorageUsageFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreUpdateConfigurationPolicyFuncCall
+// History returns a sequence of DBStoreRepositoryStorageUsageFuncCall
 // objects describing the invocations of this function.
-func (f *DBStoreUpdateConfigurationPolicyFunc) History() []DBStoreUpdateConfigurationPolicyFuncCall {
+func (f *DBStoreRepositoryStorageUsageFunc) History() []DBStoreRepositoryStorageUsageFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreUpdateConfigurationPolicyFuncCall, len(f.history))
+	history := make([]DBStoreRepositoryStorageUsageFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreUpdateConfigurationPolicyFuncCall is an object that describes an
-// invocation of method UpdateConfigurationPolicy on an instance of
+// DBStoreRepositoryStorageUsageFuncCall is an object that describes an
+// invocation of method RepositoryStorageUsage on an instance of
 // MockDBStore.
-type DBStoreUpdateConfigurationPolicyFuncCall struct {
+type DBStoreRepositoryStorageUsageFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 dbstore.ConfigurationPolicy
+	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 dbstore.StorageUsage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreUpdateConfigurationPolicyFuncCall) Args() []interface{} {
+func (c DBStoreRepositoryStorageUsageFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreUpdateConfigurationPolicyFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c DBStoreRepositoryStorageUsageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// DBStoreUpdateIndexConfigurationByRepositoryIDFunc describes the behavior
-// when the UpdateIndexConfigurationByRepositoryID method of the parent
-// MockDBStore instance is invoked.
-type DBStoreUpdateIndexConfigurationByRepositoryIDFunc struct {
-	defaultHook func(context.Context, int, []byte) error
-	hooks       []func(context.Context, int, []byte) error
-	history     []DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall
+// DBStoreUpdateConfigurationPolicyFunc describes the behavior when the
+// UpdateConfigurationPolicy method of the parent MockDBStore instance is
+// invoked.
+type DBStoreUpdateConfigurationPolicyFunc struct {
+	defaultHook func(context.Context, dbstore.ConfigurationPolicy) error
+	hooks       []func(context.Context, dbstore.ConfigurationPolicy) error
+	history     []DBStoreUpdateConfigurationPolicyFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateIndexConfigurationByRepositoryID delegates to the next hook
-// function in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockDBStore) UpdateIndexConfigurationByRepositoryID(v0 context.Context, v1 int, v2 []byte) error {
-	r0 := m.UpdateIndexConfigurationByRepositoryIDFunc.nextHook()(v0, v1, v2)
-	m.UpdateIndexConfigurationByRepositoryIDFunc.appendCall(DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall{v0, v1, v2, r0})
+// UpdateConfigurationPolicy delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockDBStore) UpdateConfigurationPolicy(v0 context.Context, v1 dbstore.ConfigurationPolicy) error {
+	r0 := m.UpdateConfigurationPolicyFunc.nextHook()(v0, v1)
+	m.UpdateConfigurationPolicyFunc.appendCall(DBStoreUpdateConfigurationPolicyFuncCall{v0, v1, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
-// UpdateIndexConfigurationByRepositoryID method of the parent MockDBStore
-// instance is invoked and the hook queue is empty.
-func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int, []byte) error) {
-	f.defaultHook = hook
+// UpdateConfigurationPolicy method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreUpdateConfigurationPolicyFunc) SetDefaultHook(hook func(context.Context, dbstore.ConfigurationPolicy) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateConfigurationPolicy method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStoreUpdateConfigurationPolicyFunc) PushHook(hook func(context.Context, dbstore.ConfigurationPolicy) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreUpdateConfigurationPolicyFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.ConfigurationPolicy) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreUpdateConfigurationPolicyFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, dbstore.ConfigurationPolicy) error {
+		return r0
+	})
+}
+
+func (f *DBStoreUpdateConfigurationPolicyFunc) nextHook() func(context.Context, dbstore.ConfigurationPolicy) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreUpdateConfigurationPolicyFunc) appendCall(r0 DBStoreUpdateConfigurationPolicyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreUpdateConfigurationPolicyFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreUpdateConfigurationPolicyFunc) History() []DBStoreUpdateConfigurationPolicyFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreUpdateConfigurationPolicyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreUpdateConfigurationPolicyFuncCall is an object that describes an
+// invocation of method UpdateConfigurationPolicy on an instance of
+// MockDBStore.
+type DBStoreUpdateConfigurationPolicyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 dbstore.ConfigurationPolicy
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreUpdateConfigurationPolicyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreUpdateConfigurationPolicyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// DBStoreUpdateIndexConfigurationByRepositoryIDFunc describes the behavior
+// when the UpdateIndexConfigurationByRepositoryID method of the parent
+// MockDBStore instance is invoked.
+type DBStoreUpdateIndexConfigurationByRepositoryIDFunc struct {
+	defaultHook func(context.Context, int, []byte) error
+	hooks       []func(context.Context, int, []byte) error
+	history     []DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIndexConfigurationByRepositoryID delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockDBStore) UpdateIndexConfigurationByRepositoryID(v0 context.Context, v1 int, v2 []byte) error {
+	r0 := m.UpdateIndexConfigurationByRepositoryIDFunc.nextHook()(v0, v1, v2)
+	m.UpdateIndexConfigurationByRepositoryIDFunc.appendCall(DBStoreUpdateIndexConfigurationByRepositoryIDFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateIndexConfigurationByRepositoryID method of the parent MockDBStore
+// instance is invoked and the hook queue is empty.
+func (f *DBStoreUpdateIndexConfigurationByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int, []byte) error) {
+	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
@@ -5762,9 +6140,15 @@ type MockLSIFStore struct {
 	// ImplementationsFunc is an instance of a mock function object
 	// controlling the behavior of the method Implementations.
 	ImplementationsFunc *LSIFStoreImplementationsFunc
+	// IncomingCallsFunc is an instance of a mock function object
+	// controlling the behavior of the method IncomingCalls.
+	IncomingCallsFunc *LSIFStoreIncomingCallsFunc
 	// MonikersByPositionFunc is an instance of a mock function object
 	// controlling the behavior of the method MonikersByPosition.
 	MonikersByPositionFunc *LSIFStoreMonikersByPositionFunc
+	// OutgoingCallsFunc is an instance of a mock function object
+	// controlling the behavior of the method OutgoingCalls.
+	OutgoingCallsFunc *LSIFStoreOutgoingCallsFunc
 	// PackageInformationFunc is an instance of a mock function object
 	// controlling the behavior of the method PackageInformation.
 	PackageInformationFunc *LSIFStorePackageInformationFunc
@@ -5777,6 +6161,9 @@ type MockLSIFStore struct {
 	// StencilFunc is an instance of a mock function object controlling the
 	// behavior of the method Stencil.
 	StencilFunc *LSIFStoreStencilFunc
+	// TypeDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method TypeDefinitions.
+	TypeDefinitionsFunc *LSIFStoreTypeDefinitionsFunc
 }
 
 // NewMockLSIFStore creates a new mock of the LSIFStore interface. All
@@ -5838,11 +6225,21 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return nil, 0, nil
 			},
 		},
+		IncomingCallsFunc: &LSIFStoreIncomingCallsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error) {
+				return nil, nil
+			},
+		},
 		MonikersByPositionFunc: &LSIFStoreMonikersByPositionFunc{
 			defaultHook: func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
 				return nil, nil
 			},
 		},
+		OutgoingCallsFunc: &LSIFStoreOutgoingCallsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error) {
+				return nil, nil
+			},
+		},
 		PackageInformationFunc: &LSIFStorePackageInformationFunc{
 			defaultHook: func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
 				return precise.PackageInformationData{}, false, nil
@@ -5863,6 +6260,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return nil, nil
 			},
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+				return nil, 0, nil
+			},
+		},
 	}
 }
 
@@ -5925,11 +6327,21 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.Implementations")
 			},
 		},
+		IncomingCallsFunc: &LSIFStoreIncomingCallsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error) {
+				panic("unexpected invocation of MockLSIFStore.IncomingCalls")
+			},
+		},
 		MonikersByPositionFunc: &LSIFStoreMonikersByPositionFunc{
 			defaultHook: func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
 				panic("unexpected invocation of MockLSIFStore.MonikersByPosition")
 			},
 		},
+		OutgoingCallsFunc: &LSIFStoreOutgoingCallsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error) {
+				panic("unexpected invocation of MockLSIFStore.OutgoingCalls")
+			},
+		},
 		PackageInformationFunc: &LSIFStorePackageInformationFunc{
 			defaultHook: func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
 				panic("unexpected invocation of MockLSIFStore.PackageInformation")
@@ -5950,6 +6362,11 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.Stencil")
 			},
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+				panic("unexpected invocation of MockLSIFStore.TypeDefinitions")
+			},
+		},
 	}
 }
 
@@ -5990,9 +6407,15 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		ImplementationsFunc: &LSIFStoreImplementationsFunc{
 			defaultHook: i.Implementations,
 		},
+		IncomingCallsFunc: &LSIFStoreIncomingCallsFunc{
+			defaultHook: i.IncomingCalls,
+		},
 		MonikersByPositionFunc: &LSIFStoreMonikersByPositionFunc{
 			defaultHook: i.MonikersByPosition,
 		},
+		OutgoingCallsFunc: &LSIFStoreOutgoingCallsFunc{
+			defaultHook: i.OutgoingCalls,
+		},
 		PackageInformationFunc: &LSIFStorePackageInformationFunc{
 			defaultHook: i.PackageInformation,
 		},
@@ -6005,6 +6428,9 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		StencilFunc: &LSIFStoreStencilFunc{
 			defaultHook: i.Stencil,
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: i.TypeDefinitions,
+		},
 	}
 }
 
@@ -7329,36 +7755,35 @@ func (c LSIFStoreImplementationsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// LSIFStoreMonikersByPositionFunc describes the behavior when the
-// MonikersByPosition method of the parent MockLSIFStore instance is
-// invoked.
-type LSIFStoreMonikersByPositionFunc struct {
-	defaultHook func(context.Context, int, string, int, int) ([][]precise.MonikerData, error)
-	hooks       []func(context.Context, int, string, int, int) ([][]precise.MonikerData, error)
-	history     []LSIFStoreMonikersByPositionFuncCall
+// LSIFStoreIncomingCallsFunc describes the behavior when the IncomingCalls
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreIncomingCallsFunc struct {
+	defaultHook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error)
+	hooks       []func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error)
+	history     []LSIFStoreIncomingCallsFuncCall
 	mutex       sync.Mutex
 }
 
-// MonikersByPosition delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockLSIFStore) MonikersByPosition(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([][]precise.MonikerData, error) {
-	r0, r1 := m.MonikersByPositionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.MonikersByPositionFunc.appendCall(LSIFStoreMonikersByPositionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+// IncomingCalls delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) IncomingCalls(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([]lsifstore.CallHierarchyCall, error) {
+	r0, r1 := m.IncomingCallsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.IncomingCallsFunc.appendCall(LSIFStoreIncomingCallsFuncCall{v0, v1, v2, v3, v4, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the MonikersByPosition
-// method of the parent MockLSIFStore instance is invoked and the hook queue
-// is empty.
-func (f *LSIFStoreMonikersByPositionFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([][]precise.MonikerData, error)) {
+// SetDefaultHook sets function that is called when the IncomingCalls method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreIncomingCallsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// MonikersByPosition method of the parent MockLSIFStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *LSIFStoreMonikersByPositionFunc) PushHook(hook func(context.Context, int, string, int, int) ([][]precise.MonikerData, error)) {
+// IncomingCalls method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreIncomingCallsFunc) PushHook(hook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -7366,21 +7791,21 @@ func (f *LSIFStoreMonikersByPositionFunc) PushHook(hook func(context.Context, in
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *LSIFStoreMonikersByPositionFunc) SetDefaultReturn(r0 [][]precise.MonikerData, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
+func (f *LSIFStoreIncomingCallsFunc) SetDefaultReturn(r0 []lsifstore.CallHierarchyCall, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *LSIFStoreMonikersByPositionFunc) PushReturn(r0 [][]precise.MonikerData, r1 error) {
-	f.PushHook(func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
+func (f *LSIFStoreIncomingCallsFunc) PushReturn(r0 []lsifstore.CallHierarchyCall, r1 error) {
+	f.PushHook(func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error) {
 		return r0, r1
 	})
 }
 
-func (f *LSIFStoreMonikersByPositionFunc) nextHook() func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
+func (f *LSIFStoreIncomingCallsFunc) nextHook() func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -7393,26 +7818,26 @@ func (f *LSIFStoreMonikersByPositionFunc) nextHook() func(context.Context, int,
 	return hook
 }
 
-func (f *LSIFStoreMonikersByPositionFunc) appendCall(r0 LSIFStoreMonikersByPositionFuncCall) {
+func (f *LSIFStoreIncomingCallsFunc) appendCall(r0 LSIFStoreIncomingCallsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of LSIFStoreMonikersByPositionFuncCall objects
+// History returns a sequence of LSIFStoreIncomingCallsFuncCall objects
 // describing the invocations of this function.
-func (f *LSIFStoreMonikersByPositionFunc) History() []LSIFStoreMonikersByPositionFuncCall {
+func (f *LSIFStoreIncomingCallsFunc) History() []LSIFStoreIncomingCallsFuncCall {
 	f.mutex.Lock()
-	history := make([]LSIFStoreMonikersByPositionFuncCall, len(f.history))
+	history := make([]LSIFStoreIncomingCallsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// LSIFStoreMonikersByPositionFuncCall is an object that describes an
-// invocation of method MonikersByPosition on an instance of MockLSIFStore.
-type LSIFStoreMonikersByPositionFuncCall struct {
+// LSIFStoreIncomingCallsFuncCall is an object that describes an invocation
+// of method IncomingCalls on an instance of MockLSIFStore.
+type LSIFStoreIncomingCallsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -7430,7 +7855,7 @@ type LSIFStoreMonikersByPositionFuncCall struct {
 	Arg4 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 [][]precise.MonikerData
+	Result0 []lsifstore.CallHierarchyCall
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -7438,46 +7863,46 @@ type LSIFStoreMonikersByPositionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c LSIFStoreMonikersByPositionFuncCall) Args() []interface{} {
+func (c LSIFStoreIncomingCallsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c LSIFStoreMonikersByPositionFuncCall) Results() []interface{} {
+func (c LSIFStoreIncomingCallsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// LSIFStorePackageInformationFunc describes the behavior when the
-// PackageInformation method of the parent MockLSIFStore instance is
+// LSIFStoreMonikersByPositionFunc describes the behavior when the
+// MonikersByPosition method of the parent MockLSIFStore instance is
 // invoked.
-type LSIFStorePackageInformationFunc struct {
-	defaultHook func(context.Context, int, string, string) (precise.PackageInformationData, bool, error)
-	hooks       []func(context.Context, int, string, string) (precise.PackageInformationData, bool, error)
-	history     []LSIFStorePackageInformationFuncCall
+type LSIFStoreMonikersByPositionFunc struct {
+	defaultHook func(context.Context, int, string, int, int) ([][]precise.MonikerData, error)
+	hooks       []func(context.Context, int, string, int, int) ([][]precise.MonikerData, error)
+	history     []LSIFStoreMonikersByPositionFuncCall
 	mutex       sync.Mutex
 }
 
-// PackageInformation delegates to the next hook function in the queue and
+// MonikersByPosition delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockLSIFStore) PackageInformation(v0 context.Context, v1 int, v2 string, v3 string) (precise.PackageInformationData, bool, error) {
-	r0, r1, r2 := m.PackageInformationFunc.nextHook()(v0, v1, v2, v3)
-	m.PackageInformationFunc.appendCall(LSIFStorePackageInformationFuncCall{v0, v1, v2, v3, r0, r1, r2})
-	return r0, r1, r2
+func (m *MockLSIFStore) MonikersByPosition(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([][]precise.MonikerData, error) {
+	r0, r1 := m.MonikersByPositionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.MonikersByPositionFunc.appendCall(LSIFStoreMonikersByPositionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the PackageInformation
+// SetDefaultHook sets function that is called when the MonikersByPosition
 // method of the parent MockLSIFStore instance is invoked and the hook queue
 // is empty.
-func (f *LSIFStorePackageInformationFunc) SetDefaultHook(hook func(context.Context, int, string, string) (precise.PackageInformationData, bool, error)) {
+func (f *LSIFStoreMonikersByPositionFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([][]precise.MonikerData, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// PackageInformation method of the parent MockLSIFStore instance invokes
+// MonikersByPosition method of the parent MockLSIFStore instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *LSIFStorePackageInformationFunc) PushHook(hook func(context.Context, int, string, string) (precise.PackageInformationData, bool, error)) {
+func (f *LSIFStoreMonikersByPositionFunc) PushHook(hook func(context.Context, int, string, int, int) ([][]precise.MonikerData, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -7485,21 +7910,21 @@ func (f *LSIFStorePackageInformationFunc) PushHook(hook func(context.Context, in
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *LSIFStorePackageInformationFunc) SetDefaultReturn(r0 precise.PackageInformationData, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
-		return r0, r1, r2
+func (f *LSIFStoreMonikersByPositionFunc) SetDefaultReturn(r0 [][]precise.MonikerData, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *LSIFStorePackageInformationFunc) PushReturn(r0 precise.PackageInformationData, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
-		return r0, r1, r2
+func (f *LSIFStoreMonikersByPositionFunc) PushReturn(r0 [][]precise.MonikerData, r1 error) {
+	f.PushHook(func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
+		return r0, r1
 	})
 }
 
-func (f *LSIFStorePackageInformationFunc) nextHook() func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
+func (f *LSIFStoreMonikersByPositionFunc) nextHook() func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -7512,26 +7937,26 @@ func (f *LSIFStorePackageInformationFunc) nextHook() func(context.Context, int,
 	return hook
 }
 
-func (f *LSIFStorePackageInformationFunc) appendCall(r0 LSIFStorePackageInformationFuncCall) {
+func (f *LSIFStoreMonikersByPositionFunc) appendCall(r0 LSIFStoreMonikersByPositionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of LSIFStorePackageInformationFuncCall objects
+// History returns a sequence of LSIFStoreMonikersByPositionFuncCall objects
 // describing the invocations of this function.
-func (f *LSIFStorePackageInformationFunc) History() []LSIFStorePackageInformationFuncCall {
+func (f *LSIFStoreMonikersByPositionFunc) History() []LSIFStoreMonikersByPositionFuncCall {
 	f.mutex.Lock()
-	history := make([]LSIFStorePackageInformationFuncCall, len(f.history))
+	history := make([]LSIFStoreMonikersByPositionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// LSIFStorePackageInformationFuncCall is an object that describes an
-// invocation of method PackageInformation on an instance of MockLSIFStore.
-type LSIFStorePackageInformationFuncCall struct {
+// LSIFStoreMonikersByPositionFuncCall is an object that describes an
+// invocation of method MonikersByPosition on an instance of MockLSIFStore.
+type LSIFStoreMonikersByPositionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -7543,33 +7968,270 @@ type LSIFStorePackageInformationFuncCall struct {
 	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 string
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 precise.PackageInformationData
+	Result0 [][]precise.MonikerData
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 bool
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c LSIFStorePackageInformationFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c LSIFStoreMonikersByPositionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c LSIFStorePackageInformationFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c LSIFStoreMonikersByPositionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// LSIFStoreRangesFunc describes the behavior when the Ranges method of the
-// parent MockLSIFStore instance is invoked.
-type LSIFStoreRangesFunc struct {
+// LSIFStoreOutgoingCallsFunc describes the behavior when the OutgoingCalls
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreOutgoingCallsFunc struct {
+	defaultHook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error)
+	hooks       []func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error)
+	history     []LSIFStoreOutgoingCallsFuncCall
+	mutex       sync.Mutex
+}
+
+// OutgoingCalls delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) OutgoingCalls(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([]lsifstore.CallHierarchyCall, error) {
+	r0, r1 := m.OutgoingCallsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.OutgoingCallsFunc.appendCall(LSIFStoreOutgoingCallsFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the OutgoingCalls method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreOutgoingCallsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// OutgoingCalls method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreOutgoingCallsFunc) PushHook(hook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreOutgoingCallsFunc) SetDefaultReturn(r0 []lsifstore.CallHierarchyCall, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreOutgoingCallsFunc) PushReturn(r0 []lsifstore.CallHierarchyCall, r1 error) {
+	f.PushHook(func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreOutgoingCallsFunc) nextHook() func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreOutgoingCallsFunc) appendCall(r0 LSIFStoreOutgoingCallsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreOutgoingCallsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreOutgoingCallsFunc) History() []LSIFStoreOutgoingCallsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreOutgoingCallsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreOutgoingCallsFuncCall is an object that describes an invocation
+// of method OutgoingCalls on an instance of MockLSIFStore.
+type LSIFStoreOutgoingCallsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.CallHierarchyCall
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreOutgoingCallsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreOutgoingCallsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LSIFStorePackageInformationFunc describes the behavior when the
+// PackageInformation method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStorePackageInformationFunc struct {
+	defaultHook func(context.Context, int, string, string) (precise.PackageInformationData, bool, error)
+	hooks       []func(context.Context, int, string, string) (precise.PackageInformationData, bool, error)
+	history     []LSIFStorePackageInformationFuncCall
+	mutex       sync.Mutex
+}
+
+// PackageInformation delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) PackageInformation(v0 context.Context, v1 int, v2 string, v3 string) (precise.PackageInformationData, bool, error) {
+	r0, r1, r2 := m.PackageInformationFunc.nextHook()(v0, v1, v2, v3)
+	m.PackageInformationFunc.appendCall(LSIFStorePackageInformationFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the PackageInformation
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStorePackageInformationFunc) SetDefaultHook(hook func(context.Context, int, string, string) (precise.PackageInformationData, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PackageInformation method of the parent MockLSIFStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LSIFStorePackageInformationFunc) PushHook(hook func(context.Context, int, string, string) (precise.PackageInformationData, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStorePackageInformationFunc) SetDefaultReturn(r0 precise.PackageInformationData, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStorePackageInformationFunc) PushReturn(r0 precise.PackageInformationData, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStorePackageInformationFunc) nextHook() func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStorePackageInformationFunc) appendCall(r0 LSIFStorePackageInformationFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStorePackageInformationFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStorePackageInformationFunc) History() []LSIFStorePackageInformationFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStorePackageInformationFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStorePackageInformationFuncCall is an object that describes an
+// invocation of method PackageInformation on an instance of MockLSIFStore.
+type LSIFStorePackageInformationFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 precise.PackageInformationData
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStorePackageInformationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStorePackageInformationFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// LSIFStoreRangesFunc describes the behavior when the Ranges method of the
+// parent MockLSIFStore instance is invoked.
+type LSIFStoreRangesFunc struct {
 	defaultHook func(context.Context, int, string, int, int) ([]lsifstore.CodeIntelligenceRange, error)
 	hooks       []func(context.Context, int, string, int, int) ([]lsifstore.CodeIntelligenceRange, error)
 	history     []LSIFStoreRangesFuncCall
@@ -7921,6 +8583,133 @@ func (c LSIFStoreStencilFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LSIFStoreTypeDefinitionsFunc describes the behavior when the
+// TypeDefinitions method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreTypeDefinitionsFunc struct {
+	defaultHook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)
+	hooks       []func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)
+	history     []LSIFStoreTypeDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// TypeDefinitions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) TypeDefinitions(v0 context.Context, v1 int, v2 string, v3 int, v4 int, v5 int, v6 int) ([]lsifstore.Location, int, error) {
+	r0, r1, r2 := m.TypeDefinitionsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.TypeDefinitionsFunc.appendCall(LSIFStoreTypeDefinitionsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the TypeDefinitions
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreTypeDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TypeDefinitions method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreTypeDefinitionsFunc) PushHook(hook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreTypeDefinitionsFunc) SetDefaultReturn(r0 []lsifstore.Location, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreTypeDefinitionsFunc) PushReturn(r0 []lsifstore.Location, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStoreTypeDefinitionsFunc) nextHook() func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreTypeDefinitionsFunc) appendCall(r0 LSIFStoreTypeDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreTypeDefinitionsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreTypeDefinitionsFunc) History() []LSIFStoreTypeDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreTypeDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreTypeDefinitionsFuncCall is an object that describes an
+// invocation of method TypeDefinitions on an instance of MockLSIFStore.
+type LSIFStoreTypeDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreTypeDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreTypeDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // MockRepoUpdaterClient is a mock implementation of the RepoUpdaterClient
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
@@ -8079,3 +8868,156 @@ func (c RepoUpdaterClientEnqueueRepoUpdateFuncCall) Args() []interface{} {
 func (c RepoUpdaterClientEnqueueRepoUpdateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// MockSearchClient is a mock implementation of the SearchClient interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockSearchClient struct {
+	// SearchFunc is an instance of a mock function object controlling the
+	// behavior of the method Search.
+	SearchFunc *SearchClientSearchFunc
+}
+
+// NewMockSearchClient creates a new mock of the SearchClient interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockSearchClient() *MockSearchClient {
+	return &MockSearchClient{
+		SearchFunc: &SearchClientSearchFunc{
+			defaultHook: func(context.Context, string) ([]SearchLocation, error) {
+				return nil, nil
+			},
+		},
+	}
+}
+
+// NewStrictMockSearchClient creates a new mock of the SearchClient
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockSearchClient() *MockSearchClient {
+	return &MockSearchClient{
+		SearchFunc: &SearchClientSearchFunc{
+			defaultHook: func(context.Context, string) ([]SearchLocation, error) {
+				panic("unexpected invocation of MockSearchClient.Search")
+			},
+		},
+	}
+}
+
+// NewMockSearchClientFrom creates a new mock of the MockSearchClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockSearchClientFrom(i SearchClient) *MockSearchClient {
+	return &MockSearchClient{
+		SearchFunc: &SearchClientSearchFunc{
+			defaultHook: i.Search,
+		},
+	}
+}
+
+// SearchClientSearchFunc describes the behavior when the Search method of
+// the parent MockSearchClient instance is invoked.
+type SearchClientSearchFunc struct {
+	defaultHook func(context.Context, string) ([]SearchLocation, error)
+	hooks       []func(context.Context, string) ([]SearchLocation, error)
+	history     []SearchClientSearchFuncCall
+	mutex       sync.Mutex
+}
+
+// Search delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockSearchClient) Search(v0 context.Context, v1 string) ([]SearchLocation, error) {
+	r0, r1 := m.SearchFunc.nextHook()(v0, v1)
+	m.SearchFunc.appendCall(SearchClientSearchFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Search method of the
+// parent MockSearchClient instance is invoked and the hook queue is empty.
+func (f *SearchClientSearchFunc) SetDefaultHook(hook func(context.Context, string) ([]SearchLocation, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Search method of the parent MockSearchClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *SearchClientSearchFunc) PushHook(hook func(context.Context, string) ([]SearchLocation, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *SearchClientSearchFunc) SetDefaultReturn(r0 []SearchLocation, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) ([]SearchLocation, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *SearchClientSearchFunc) PushReturn(r0 []SearchLocation, r1 error) {
+	f.PushHook(func(context.Context, string) ([]SearchLocation, error) {
+		return r0, r1
+	})
+}
+
+func (f *SearchClientSearchFunc) nextHook() func(context.Context, string) ([]SearchLocation, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *SearchClientSearchFunc) appendCall(r0 SearchClientSearchFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of SearchClientSearchFuncCall objects
+// describing the invocations of this function.
+func (f *SearchClientSearchFunc) History() []SearchClientSearchFuncCall {
+	f.mutex.Lock()
+	history := make([]SearchClientSearchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// SearchClientSearchFuncCall is an object that describes an invocation of
+// method Search on an instance of MockSearchClient.
+type SearchClientSearchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []SearchLocation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c SearchClientSearchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c SearchClientSearchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -67,6 +67,9 @@ type MockResolver struct {
 	// object controlling the behavior of the method
 	// InferredIndexConfiguration.
 	InferredIndexConfigurationFunc *ResolverInferredIndexConfigurationFunc
+	// OrganizationStorageUsageFunc is an instance of a mock function object
+	// controlling the behavior of the method OrganizationStorageUsage.
+	OrganizationStorageUsageFunc *ResolverOrganizationStorageUsageFunc
 	// PreviewGitObjectFilterFunc is an instance of a mock function object
 	// controlling the behavior of the method PreviewGitObjectFilter.
 	PreviewGitObjectFilterFunc *ResolverPreviewGitObjectFilterFunc
@@ -80,6 +83,9 @@ type MockResolver struct {
 	// object controlling the behavior of the method
 	// QueueAutoIndexJobsForRepo.
 	QueueAutoIndexJobsForRepoFunc *ResolverQueueAutoIndexJobsForRepoFunc
+	// RepositoryStorageUsageFunc is an instance of a mock function object
+	// controlling the behavior of the method RepositoryStorageUsage.
+	RepositoryStorageUsageFunc *ResolverRepositoryStorageUsageFunc
 	// UpdateConfigurationPolicyFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateConfigurationPolicy.
@@ -172,6 +178,11 @@ func NewMockResolver() *MockResolver {
 				return nil, false, nil
 			},
 		},
+		OrganizationStorageUsageFunc: &ResolverOrganizationStorageUsageFunc{
+			defaultHook: func(context.Context, int) (dbstore.StorageUsage, error) {
+				return dbstore.StorageUsage{}, nil
+			},
+		},
 		PreviewGitObjectFilterFunc: &ResolverPreviewGitObjectFilterFunc{
 			defaultHook: func(context.Context, int, dbstore.GitObjectType, string) (map[string][]string, error) {
 				return nil, nil
@@ -192,6 +203,11 @@ func NewMockResolver() *MockResolver {
 				return nil, nil
 			},
 		},
+		RepositoryStorageUsageFunc: &ResolverRepositoryStorageUsageFunc{
+			defaultHook: func(context.Context, int) (dbstore.StorageUsage, error) {
+				return dbstore.StorageUsage{}, nil
+			},
+		},
 		UpdateConfigurationPolicyFunc: &ResolverUpdateConfigurationPolicyFunc{
 			defaultHook: func(context.Context, dbstore.ConfigurationPolicy) error {
 				return nil
@@ -289,6 +305,11 @@ func NewStrictMockResolver() *MockResolver {
 				panic("unexpected invocation of MockResolver.InferredIndexConfiguration")
 			},
 		},
+		OrganizationStorageUsageFunc: &ResolverOrganizationStorageUsageFunc{
+			defaultHook: func(context.Context, int) (dbstore.StorageUsage, error) {
+				panic("unexpected invocation of MockResolver.OrganizationStorageUsage")
+			},
+		},
 		PreviewGitObjectFilterFunc: &ResolverPreviewGitObjectFilterFunc{
 			defaultHook: func(context.Context, int, dbstore.GitObjectType, string) (map[string][]string, error) {
 				panic("unexpected invocation of MockResolver.PreviewGitObjectFilter")
@@ -309,6 +330,11 @@ func NewStrictMockResolver() *MockResolver {
 				panic("unexpected invocation of MockResolver.QueueAutoIndexJobsForRepo")
 			},
 		},
+		RepositoryStorageUsageFunc: &ResolverRepositoryStorageUsageFunc{
+			defaultHook: func(context.Context, int) (dbstore.StorageUsage, error) {
+				panic("unexpected invocation of MockResolver.RepositoryStorageUsage")
+			},
+		},
 		UpdateConfigurationPolicyFunc: &ResolverUpdateConfigurationPolicyFunc{
 			defaultHook: func(context.Context, dbstore.ConfigurationPolicy) error {
 				panic("unexpected invocation of MockResolver.UpdateConfigurationPolicy")
@@ -376,6 +402,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		InferredIndexConfigurationFunc: &ResolverInferredIndexConfigurationFunc{
 			defaultHook: i.InferredIndexConfiguration,
 		},
+		OrganizationStorageUsageFunc: &ResolverOrganizationStorageUsageFunc{
+			defaultHook: i.OrganizationStorageUsage,
+		},
 		PreviewGitObjectFilterFunc: &ResolverPreviewGitObjectFilterFunc{
 			defaultHook: i.PreviewGitObjectFilter,
 		},
@@ -388,6 +417,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		QueueAutoIndexJobsForRepoFunc: &ResolverQueueAutoIndexJobsForRepoFunc{
 			defaultHook: i.QueueAutoIndexJobsForRepo,
 		},
+		RepositoryStorageUsageFunc: &ResolverRepositoryStorageUsageFunc{
+			defaultHook: i.RepositoryStorageUsage,
+		},
 		UpdateConfigurationPolicyFunc: &ResolverUpdateConfigurationPolicyFunc{
 			defaultHook: i.UpdateConfigurationPolicy,
 		},
@@ -2075,6 +2107,118 @@ func (c ResolverInferredIndexConfigurationFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// ResolverOrganizationStorageUsageFunc describes the behavior when the
+// OrganizationStorageUsage method of the parent MockResolver instance is
+// invoked.
+type ResolverOrganizationStorageUsageFunc struct {
+	defaultHook func(context.Context, int) (dbstore.StorageUsage, error)
+	hooks       []func(context.Context, int) (dbstore.StorageUsage, error)
+	history     []ResolverOrganizationStorageUsageFuncCall
+	mutex       sync.Mutex
+}
+
+// OrganizationStorageUsage delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) OrganizationStorageUsage(v0 context.Context, v1 int) (dbstore.StorageUsage, error) {
+	r0, r1 := m.OrganizationStorageUsageFunc.nextHook()(v0, v1)
+	m.OrganizationStorageUsageFunc.appendCall(ResolverOrganizationStorageUsageFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// OrganizationStorageUsage method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverOrganizationStorageUsageFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.StorageUsage, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// OrganizationStorageUsage method of the parent MockResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverOrganizationStorageUsageFunc) PushHook(hook func(context.Context, int) (dbstore.StorageUsage, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverOrganizationStorageUsageFunc) SetDefaultReturn(r0 dbstore.StorageUsage, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.StorageUsage, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverOrganizationStorageUsageFunc) PushReturn(r0 dbstore.StorageUsage, r1 error) {
+	f.PushHook(func(context.Context, int) (dbstore.StorageUsage, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverOrganizationStorageUsageFunc) nextHook() func(context.Context, int) (dbstore.StorageUsage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverOrganizationStorageUsageFunc) appendCall(r0 ResolverOrganizationStorageUsageFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverOrganizationStorageUsageFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverOrganizationStorageUsageFunc) History() []ResolverOrganizationStorageUsageFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverOrganizationStorageUsageFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverOrganizationStorageUsageFuncCall is an object that describes an
+// invocation of method OrganizationStorageUsage on an instance of
+// MockResolver.
+type ResolverOrganizationStorageUsageFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.StorageUsage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverOrganizationStorageUsageFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverOrganizationStorageUsageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ResolverPreviewGitObjectFilterFunc describes the behavior when the
 // PreviewGitObjectFilter method of the parent MockResolver instance is
 // invoked.
@@ -2543,6 +2687,117 @@ func (c ResolverQueueAutoIndexJobsForRepoFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ResolverRepositoryStorageUsageFunc describes the behavior when the
+// RepositoryStorageUsage method of the parent MockResolver instance is
+// invoked.
+type ResolverRepositoryStorageUsageFunc struct {
+	defaultHook func(context.Context, int) (dbstore.StorageUsage, error)
+	hooks       []func(context.Context, int) (dbstore.StorageUsage, error)
+	history     []ResolverRepositoryStorageUsageFuncCall
+	mutex       sync.Mutex
+}
+
+// RepositoryStorageUsage delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) RepositoryStorageUsage(v0 context.Context, v1 int) (dbstore.StorageUsage, error) {
+	r0, r1 := m.RepositoryStorageUsageFunc.nextHook()(v0, v1)
+	m.RepositoryStorageUsageFunc.appendCall(ResolverRepositoryStorageUsageFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// RepositoryStorageUsage method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverRepositoryStorageUsageFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.StorageUsage, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepositoryStorageUsage method of the parent MockResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverRepositoryStorageUsageFunc) PushHook(hook func(context.Context, int) (dbstore.StorageUsage, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverRepositoryStorageUsageFunc) SetDefaultReturn(r0 dbstore.StorageUsage, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.StorageUsage, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverRepositoryStorageUsageFunc) PushReturn(r0 dbstore.StorageUsage, r1 error) {
+	f.PushHook(func(context.Context, int) (dbstore.StorageUsage, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverRepositoryStorageUsageFunc) nextHook() func(context.Context, int) (dbstore.StorageUsage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverRepositoryStorageUsageFunc) appendCall(r0 ResolverRepositoryStorageUsageFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverRepositoryStorageUsageFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverRepositoryStorageUsageFunc) History() []ResolverRepositoryStorageUsageFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverRepositoryStorageUsageFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverRepositoryStorageUsageFuncCall is an object that describes an
+// invocation of method RepositoryStorageUsage on an instance of
+// MockResolver.
+type ResolverRepositoryStorageUsageFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.StorageUsage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverRepositoryStorageUsageFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverRepositoryStorageUsageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ResolverUpdateConfigurationPolicyFunc describes the behavior when the
 // UpdateConfigurationPolicy method of the parent MockResolver instance is
 // invoked.
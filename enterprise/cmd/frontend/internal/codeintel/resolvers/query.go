@@ -10,11 +10,14 @@ import (
 
 // AdjustedLocation is a path and range pair from within a particular upload. The adjusted commit
 // denotes the target commit for which the location was adjusted (the originally requested commit).
+// Search-based locations were found by a text search rather than from an upload; their dump only
+// identifies the repository and commit of the match.
 type AdjustedLocation struct {
 	Dump           store.Dump
 	Path           string
 	AdjustedCommit string
 	AdjustedRange  lsifstore.Range
+	SearchBased    bool
 }
 
 // AdjustedDiagnostic is a diagnostic from within a particular upload. The adjusted commit denotes
@@ -38,6 +41,13 @@ type AdjustedCodeIntelligenceRange struct {
 	DocumentationPathID string
 }
 
+// AdjustedCallHierarchyCall pairs the definition of a caller or a callee with the locations of the
+// calls between them. The locations have been adjusted to fit the target (originally requested) commit.
+type AdjustedCallHierarchyCall struct {
+	Location  AdjustedLocation
+	CallSites []AdjustedLocation
+}
+
 func (a *AdjustedCodeIntelligenceRange) ToDocumentation() *Documentation {
 	if a.DocumentationPathID == "" {
 		return nil
@@ -55,6 +65,9 @@ type QueryResolver interface {
 	Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
 	References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
 	Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+	TypeDefinitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
+	IncomingCalls(ctx context.Context, line, character int) ([]AdjustedCallHierarchyCall, error)
+	OutgoingCalls(ctx context.Context, line, character int) ([]AdjustedCallHierarchyCall, error)
 	Hover(ctx context.Context, line, character int) (string, lsifstore.Range, bool, error)
 	Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error)
 	DocumentationPage(ctx context.Context, pathID string) (*precise.DocumentationPageData, error)
@@ -71,6 +84,7 @@ type Documentation struct {
 type queryResolver struct {
 	dbStore             DBStore
 	lsifStore           LSIFStore
+	searchClient        SearchClient
 	cachedCommitChecker *cachedCommitChecker
 	positionAdjuster    PositionAdjuster
 	repositoryID        int
@@ -87,6 +101,7 @@ type queryResolver struct {
 func NewQueryResolver(
 	dbStore DBStore,
 	lsifStore LSIFStore,
+	searchClient SearchClient,
 	cachedCommitChecker *cachedCommitChecker,
 	positionAdjuster PositionAdjuster,
 	repositoryID int,
@@ -95,12 +110,13 @@ func NewQueryResolver(
 	uploads []store.Dump,
 	operations *operations,
 ) QueryResolver {
-	return newQueryResolver(dbStore, lsifStore, cachedCommitChecker, positionAdjuster, repositoryID, commit, path, uploads, operations)
+	return newQueryResolver(dbStore, lsifStore, searchClient, cachedCommitChecker, positionAdjuster, repositoryID, commit, path, uploads, operations)
 }
 
 func newQueryResolver(
 	dbStore DBStore,
 	lsifStore LSIFStore,
+	searchClient SearchClient,
 	cachedCommitChecker *cachedCommitChecker,
 	positionAdjuster PositionAdjuster,
 	repositoryID int,
@@ -121,6 +137,7 @@ func newQueryResolver(
 	return &queryResolver{
 		dbStore:             dbStore,
 		lsifStore:           lsifStore,
+		searchClient:        searchClient,
 		cachedCommitChecker: cachedCommitChecker,
 		positionAdjuster:    positionAdjuster,
 		operations:          operations,
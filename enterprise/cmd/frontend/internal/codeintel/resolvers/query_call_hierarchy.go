@@ -0,0 +1,101 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+const slowCallHierarchyRequestThreshold = time.Second
+
+// IncomingCalls returns the functions calling the function at the given position, along with
+// the locations of the calls within each caller.
+func (r *queryResolver) IncomingCalls(ctx context.Context, line, character int) (_ []AdjustedCallHierarchyCall, err error) {
+	ctx, _, endObservation := observeResolver(ctx, &err, "IncomingCalls", r.operations.incomingCalls, slowCallHierarchyRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("numUploads", len(r.uploads)),
+			log.String("uploads", uploadIDsToString(r.uploads)),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer endObservation()
+
+	return r.calls(ctx, line, character, "IncomingCalls", r.lsifStore.IncomingCalls)
+}
+
+// OutgoingCalls returns the functions called by the function at the given position, along with
+// the locations of the calls within the function at the given position.
+func (r *queryResolver) OutgoingCalls(ctx context.Context, line, character int) (_ []AdjustedCallHierarchyCall, err error) {
+	ctx, _, endObservation := observeResolver(ctx, &err, "OutgoingCalls", r.operations.outgoingCalls, slowCallHierarchyRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("numUploads", len(r.uploads)),
+			log.String("uploads", uploadIDsToString(r.uploads)),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer endObservation()
+
+	return r.calls(ctx, line, character, "OutgoingCalls", r.lsifStore.OutgoingCalls)
+}
+
+type callsFunc func(ctx context.Context, bundleID int, path string, line, character int) ([]lsifstore.CallHierarchyCall, error)
+
+// calls returns the adjusted calls of the first visible upload for which the given function
+// returns a non-empty result. Call hierarchies are resolved within a single index.
+func (r *queryResolver) calls(ctx context.Context, line, character int, name string, f callsFunc) ([]AdjustedCallHierarchyCall, error) {
+	adjustedUploads, err := r.adjustUploads(ctx, line, character)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range adjustedUploads {
+		calls, err := f(
+			ctx,
+			adjustedUploads[i].Upload.ID,
+			adjustedUploads[i].AdjustedPathInBundle,
+			adjustedUploads[i].AdjustedPosition.Line,
+			adjustedUploads[i].AdjustedPosition.Character,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore."+name)
+		}
+		if len(calls) == 0 {
+			continue
+		}
+
+		// Adjust the locations back to the appropriate range in the target commits.
+		adjustedCalls := make([]AdjustedCallHierarchyCall, 0, len(calls))
+		for _, call := range calls {
+			adjustedLocation, err := r.adjustLocation(ctx, r.uploadCache[call.Location.DumpID], call.Location)
+			if err != nil {
+				return nil, err
+			}
+			adjustedCallSites, err := r.adjustLocations(ctx, call.CallSites)
+			if err != nil {
+				return nil, err
+			}
+
+			adjustedCalls = append(adjustedCalls, AdjustedCallHierarchyCall{
+				Location:  adjustedLocation,
+				CallSites: adjustedCallSites,
+			})
+		}
+
+		return adjustedCalls, nil
+	}
+
+	return nil, nil
+}
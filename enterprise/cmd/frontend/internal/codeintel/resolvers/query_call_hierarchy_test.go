@@ -0,0 +1,128 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestIncomingCalls(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	calls := []lsifstore.CallHierarchyCall{
+		{
+			Location: lsifstore.Location{DumpID: 51, Path: "a.go", Range: testRange1},
+			CallSites: []lsifstore.Location{
+				{DumpID: 51, Path: "a.go", Range: testRange2},
+				{DumpID: 51, Path: "a.go", Range: testRange3},
+			},
+		},
+		{
+			Location:  lsifstore.Location{DumpID: 51, Path: "b.go", Range: testRange4},
+			CallSites: []lsifstore.Location{{DumpID: 51, Path: "b.go", Range: testRange5}},
+		},
+	}
+	mockLSIFStore.IncomingCallsFunc.PushReturn(nil, nil)
+	mockLSIFStore.IncomingCallsFunc.PushReturn(calls, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+		{ID: 52, Commit: "deadbeef", Root: "sub3/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		nil,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	adjustedCalls, err := resolver.IncomingCalls(context.Background(), 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+
+	expectedCalls := []AdjustedCallHierarchyCall{
+		{
+			Location: AdjustedLocation{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+			CallSites: []AdjustedLocation{
+				{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
+				{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3},
+			},
+		},
+		{
+			Location: AdjustedLocation{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4},
+			CallSites: []AdjustedLocation{
+				{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange5},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, adjustedCalls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+}
+
+func TestOutgoingCalls(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	calls := []lsifstore.CallHierarchyCall{
+		{
+			Location:  lsifstore.Location{DumpID: 50, Path: "b.go", Range: testRange1},
+			CallSites: []lsifstore.Location{{DumpID: 50, Path: "a.go", Range: testRange2}},
+		},
+	}
+	mockLSIFStore.OutgoingCallsFunc.PushReturn(calls, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		nil,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	adjustedCalls, err := resolver.OutgoingCalls(context.Background(), 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedCalls := []AdjustedCallHierarchyCall{
+		{
+			Location: AdjustedLocation{Dump: uploads[0], Path: "sub1/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+			CallSites: []AdjustedLocation{
+				{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, adjustedCalls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	if history := mockLSIFStore.OutgoingCallsFunc.History(); len(history) != 1 {
+		t.Errorf("unexpected number of calls to lsifStore.OutgoingCalls. want=%d have=%d", 1, len(history))
+	}
+}
@@ -37,6 +37,7 @@ func TestDefinitions(t *testing.T) {
 	resolver := newQueryResolver(
 		mockDBStore,
 		mockLSIFStore,
+		nil,
 		newCachedCommitChecker(mockGitserverClient),
 		mockPositionAdjuster,
 		42,
@@ -115,6 +116,7 @@ func TestDefinitionsRemote(t *testing.T) {
 	resolver := newQueryResolver(
 		mockDBStore,
 		mockLSIFStore,
+		nil,
 		newCachedCommitChecker(mockGitserverClient),
 		mockPositionAdjuster,
 		42,
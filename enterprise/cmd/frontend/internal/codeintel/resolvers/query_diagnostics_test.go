@@ -39,6 +39,7 @@ func TestDiagnostics(t *testing.T) {
 	resolver := newQueryResolver(
 		mockDBStore,
 		mockLSIFStore,
+		nil,
 		newCachedCommitChecker(mockGitserverClient),
 		mockPositionAdjuster,
 		42,
@@ -126,13 +126,37 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 			locations = append(locations, remoteLocations...)
 
 			if !hasMore {
-				cursor.Phase = "done"
+				// No more remote results, move on to phase 3 if we can search
+				if r.searchClient != nil {
+					cursor.Phase = "search"
+				} else {
+					cursor.Phase = "done"
+				}
 				break
 			}
 		}
 	}
 
-	traceLog(log.Int("numLocations", len(locations)))
+	// Phase 3: Gather "search-based" locations for monikers that are not referenced by any upload via
+	// a text search over repositories without precise results. These locations are not adjusted, as
+	// they are already relative to the commit at which they were found.
+	var searchLocations []AdjustedLocation
+	if cursor.Phase == "search" && len(locations) < limit {
+		var hasMore bool
+		searchLocations, hasMore, err = r.pageSearchLocations(ctx, cursor.OrderedMonikers, cursor.RemoteCursor.MatchedIdentifiers, &cursor.SearchCursor, limit-len(locations), traceLog)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if !hasMore {
+			cursor.Phase = "done"
+		}
+	}
+
+	traceLog(
+		log.Int("numLocations", len(locations)),
+		log.Int("numSearchLocations", len(searchLocations)),
+	)
 
 	// Adjust the locations back to the appropriate range in the target commits. This adjusts
 	// locations within the repository the user is browsing so that it appears all references
@@ -149,7 +173,7 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 		nextCursor = encodeReferencesCursor(cursor)
 	}
 
-	return adjustedLocations, nextCursor, nil
+	return append(adjustedLocations, searchLocations...), nextCursor, nil
 }
 
 // ErrConcurrentModification occurs when a page of a references request cannot be resolved as
@@ -284,11 +308,17 @@ func (r *queryResolver) pageRemoteLocations(
 			ignoreIDs = append(ignoreIDs, adjustedUpload.Upload.ID)
 		}
 
+		matchedIdentifiers := map[string]struct{}{}
+		for _, identifier := range cursor.MatchedIdentifiers {
+			matchedIdentifiers[identifier] = struct{}{}
+		}
+
 		// Find the next batch of indexes to perform a moniker search over
 		referenceUploadIDs, recordsScanned, totalRecords, err := r.uploadIDsWithReferences(
 			ctx,
 			orderedMonikers,
 			ignoreIDs,
+			matchedIdentifiers,
 			maximumIndexesPerMonikerSearch,
 			cursor.UploadOffset,
 			traceLog,
@@ -298,6 +328,11 @@ func (r *queryResolver) pageRemoteLocations(
 		}
 
 		cursor.UploadBatchIDs = referenceUploadIDs
+		cursor.MatchedIdentifiers = cursor.MatchedIdentifiers[:0]
+		for identifier := range matchedIdentifiers {
+			cursor.MatchedIdentifiers = append(cursor.MatchedIdentifiers, identifier)
+		}
+		sort.Strings(cursor.MatchedIdentifiers)
 		cursor.UploadOffset += recordsScanned
 
 		if cursor.UploadOffset >= totalRecords {
@@ -312,6 +347,10 @@ func (r *queryResolver) pageRemoteLocations(
 		return nil, false, err
 	}
 
+	// Remote uploads answering this query are in use too, so they should not be evicted
+	// before the uploads of the repository being queried
+	markUploadsAsQueried(r.dbStore, monikerSearchUploads)
+
 	// Perform the moniker search
 	locations, totalCount, err := r.monikerLocations(ctx, monikerSearchUploads, orderedMonikers, lsifDataTable, limit, cursor.LocationOffset)
 	if err != nil {
@@ -385,11 +424,14 @@ func rangeContainsPosition(r lsifstore.Range, pos lsifstore.Position) bool {
 // will not return uploads for commits which are unknown to gitserver, nor will it return uploads which
 // are listed in the given ignored identifier slice. This method also returns the number of records
 // scanned (but possibly filtered out from the return slice) from the database (the offset for the
-// subsequent request) and the total number of records in the database.
+// subsequent request) and the total number of records in the database. If a non-nil matched identifiers
+// map is given, the identifiers of the monikers probably imported or implemented by any returned upload
+// are added to it.
 func (r *queryResolver) uploadIDsWithReferences(
 	ctx context.Context,
 	orderedMonikers []precise.QualifiedMonikerData,
 	ignoreIDs []int,
+	matchedIdentifiers map[string]struct{},
 	limit int,
 	offset int,
 	traceLog observation.TraceLogger,
@@ -422,7 +464,7 @@ func (r *queryResolver) uploadIDsWithReferences(
 		}
 		recordsScanned++
 
-		if _, ok := filtered[packageReference.DumpID]; ok {
+		if _, ok := filtered[packageReference.DumpID]; ok && matchedIdentifiers == nil {
 			// This index includes a definition so we can skip testing the filters here. The index
 			// will be included in the moniker search regardless if it contains additional references.
 			continue
@@ -437,13 +479,19 @@ func (r *queryResolver) uploadIDsWithReferences(
 		// implements. We test this bloom filter to greatly reduce the number of remote indexes over
 		// which we need to search.
 
-		ok, err := testFilter(packageReference.Filter, orderedMonikers)
+		identifiers, err := filterIdentifiers(packageReference.Filter, orderedMonikers)
 		if err != nil {
 			return nil, 0, 0, err
 		}
-		if ok {
+		if len(identifiers) > 0 {
 			// Probably imports or implements at least one of the monikers' identifiers
 			filtered[packageReference.DumpID] = struct{}{}
+
+			if matchedIdentifiers != nil {
+				for _, identifier := range identifiers {
+					matchedIdentifiers[identifier] = struct{}{}
+				}
+			}
 		}
 	}
 
@@ -461,21 +509,22 @@ func (r *queryResolver) uploadIDsWithReferences(
 	return flattened, recordsScanned, totalCount, nil
 }
 
-// testFilter returns true if the set underlying the given encoded bloom filter probably includes any of
-// the given monikers.
-func testFilter(filter []byte, orderedMonikers []precise.QualifiedMonikerData) (bool, error) {
+// filterIdentifiers returns the identifiers of the given monikers that the set underlying the given
+// encoded bloom filter probably includes.
+func filterIdentifiers(filter []byte, orderedMonikers []precise.QualifiedMonikerData) ([]string, error) {
 	includesIdentifier, err := bloomfilter.Decode(filter)
 	if err != nil {
-		return false, errors.Wrap(err, "bloomfilter.Decode")
+		return nil, errors.Wrap(err, "bloomfilter.Decode")
 	}
 
+	var identifiers []string
 	for _, moniker := range orderedMonikers {
 		if includesIdentifier(moniker.Identifier) {
-			return true, nil
+			identifiers = append(identifiers, moniker.Identifier)
 		}
 	}
 
-	return false, nil
+	return identifiers, nil
 }
 
 // uploadsByIDs returns a slice of uploads with the given identifiers. This method will not return a
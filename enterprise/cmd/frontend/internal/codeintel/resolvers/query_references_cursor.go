@@ -15,6 +15,7 @@ type referencesCursor struct {
 	Phase           string                         `json:"phase"`
 	LocalCursor     localCursor                    `json:"localCursor"`
 	RemoteCursor    remoteCursor                   `json:"remoteCursor"`
+	SearchCursor    searchCursor                   `json:"searchCursor"`
 }
 
 // decodeReferencesCursor is the inverse of encodeCursor. If the given encoded string is empty, then
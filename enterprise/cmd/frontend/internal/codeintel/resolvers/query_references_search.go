@@ -0,0 +1,195 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// SearchLocation is the range of a text search match within a repository at a specific commit.
+type SearchLocation struct {
+	RepositoryID   int
+	RepositoryName string
+	Commit         string
+	Path           string
+	Range          lsifstore.Range
+}
+
+// MaximumSearchBasedReferences is the maximum number of locations requested from a single search
+// for the references of a moniker identifier.
+const MaximumSearchBasedReferences = 500
+
+// pageSearchLocations returns a slice of the (search-based) result set denoted by the given cursor
+// fulfilled by searching for the identifiers of the given monikers that are not probably referenced
+// by any upload. Repositories with uploads that already supplied precise results are excluded from
+// the search. The given cursor will be adjusted to reflect the offset required to resolve the next
+// page of results. If there are no more pages left in the result set, a false-valued flag is returned.
+func (r *queryResolver) pageSearchLocations(
+	ctx context.Context,
+	orderedMonikers []precise.QualifiedMonikerData,
+	matchedIdentifiers []string,
+	cursor *searchCursor,
+	limit int,
+	traceLog observation.TraceLogger,
+) ([]AdjustedLocation, bool, error) {
+	definitionUploads, err := r.definitionUploads(ctx, orderedMonikers)
+	if err != nil {
+		return nil, false, err
+	}
+
+	excludedRepositoryNames := make([]string, 0, len(r.uploads)+len(definitionUploads))
+	for _, upload := range r.uploads {
+		excludedRepositoryNames = append(excludedRepositoryNames, upload.RepositoryName)
+	}
+	for _, upload := range definitionUploads {
+		excludedRepositoryNames = append(excludedRepositoryNames, upload.RepositoryName)
+	}
+
+	queries := searchBasedReferencesQueries(orderedMonikers, matchedIdentifiers, excludedRepositoryNames)
+	traceLog(log.Int("pageSearchLocations.numQueries", len(queries)))
+
+	var searchLocations []SearchLocation
+	for _, query := range queries {
+		locations, err := r.searchClient.Search(ctx, query)
+		if err != nil {
+			return nil, false, err
+		}
+
+		searchLocations = append(searchLocations, locations...)
+	}
+
+	// Sort the locations so that offsets into the result set are stable between requests
+	searchLocations = sortAndDeduplicateSearchLocations(searchLocations)
+	traceLog(log.Int("pageSearchLocations.numLocations", len(searchLocations)))
+
+	if cursor.LocationOffset >= len(searchLocations) {
+		return nil, false, nil
+	}
+
+	page := searchLocations[cursor.LocationOffset:]
+	if len(page) > limit {
+		page = page[:limit]
+	}
+	cursor.LocationOffset += len(page)
+
+	adjustedLocations := make([]AdjustedLocation, 0, len(page))
+	for _, location := range page {
+		adjustedLocations = append(adjustedLocations, AdjustedLocation{
+			Dump: dbstore.Dump{
+				RepositoryID:   location.RepositoryID,
+				RepositoryName: location.RepositoryName,
+				Commit:         location.Commit,
+			},
+			Path:           location.Path,
+			AdjustedCommit: location.Commit,
+			AdjustedRange:  location.Range,
+			SearchBased:    true,
+		})
+	}
+
+	return adjustedLocations, cursor.LocationOffset < len(searchLocations), nil
+}
+
+// searchBasedReferencesQueries returns the search queries used to find the references of each of the
+// given monikers whose identifier is not in the given list of matched identifiers. Each query matches
+// the symbol name of the moniker identifier as a whole word within repositories containing the name of
+// the moniker's package, excluding the repositories with the given names. Monikers without package
+// information or without a symbol name are skipped.
+func searchBasedReferencesQueries(orderedMonikers []precise.QualifiedMonikerData, matchedIdentifiers, excludedRepositoryNames []string) []string {
+	matched := make(map[string]struct{}, len(matchedIdentifiers))
+	for _, identifier := range matchedIdentifiers {
+		matched[identifier] = struct{}{}
+	}
+
+	excluded := make(map[string]struct{}, len(excludedRepositoryNames))
+	excludedPatterns := make([]string, 0, len(excludedRepositoryNames))
+	for _, name := range excludedRepositoryNames {
+		if _, ok := excluded[name]; ok || name == "" {
+			continue
+		}
+		excluded[name] = struct{}{}
+		excludedPatterns = append(excludedPatterns, fmt.Sprintf("-repo:^%s$", regexp.QuoteMeta(name)))
+	}
+	sort.Strings(excludedPatterns)
+
+	var queries []string
+	seen := map[string]struct{}{}
+	for _, moniker := range orderedMonikers {
+		if _, ok := matched[moniker.Identifier]; ok {
+			continue
+		}
+
+		symbolName := identifierSymbolName(moniker.Identifier)
+		if symbolName == "" || moniker.Name == "" {
+			continue
+		}
+
+		terms := append([]string{fmt.Sprintf("repo:contains.content(%s)", regexp.QuoteMeta(moniker.Name))}, excludedPatterns...)
+		terms = append(terms,
+			"type:file",
+			"patterntype:regexp",
+			"case:yes",
+			fmt.Sprintf("count:%d", MaximumSearchBasedReferences),
+			fmt.Sprintf(`\b%s\b`, regexp.QuoteMeta(symbolName)),
+		)
+
+		query := strings.Join(terms, " ")
+		if _, ok := seen[query]; ok {
+			continue
+		}
+		seen[query] = struct{}{}
+		queries = append(queries, query)
+	}
+
+	return queries
+}
+
+var identifierPattern = regexp.MustCompile(`[A-Za-z_$][A-Za-z0-9_$]*`)
+
+// identifierSymbolName returns the name of the symbol denoted by the given moniker identifier. Moniker
+// identifiers are formatted differently by each indexer, but generally end with the name of the symbol
+// they describe (e.g. `github.com/foo/bar:Baz.Qux` or `com/foo/Bar#qux().`), so we use the last word of
+// the identifier.
+func identifierSymbolName(identifier string) string {
+	matches := identifierPattern.FindAllString(identifier, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+
+	return matches[len(matches)-1]
+}
+
+// sortAndDeduplicateSearchLocations returns the given locations ordered by repository, path, and range
+// with duplicates removed. The input slice is modified in-place.
+func sortAndDeduplicateSearchLocations(locations []SearchLocation) []SearchLocation {
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].RepositoryName != locations[j].RepositoryName {
+			return locations[i].RepositoryName < locations[j].RepositoryName
+		}
+		if locations[i].Path != locations[j].Path {
+			return locations[i].Path < locations[j].Path
+		}
+		if locations[i].Range.Start.Line != locations[j].Range.Start.Line {
+			return locations[i].Range.Start.Line < locations[j].Range.Start.Line
+		}
+		return locations[i].Range.Start.Character < locations[j].Range.Start.Character
+	})
+
+	deduplicated := locations[:0]
+	for _, location := range locations {
+		if len(deduplicated) == 0 || location != deduplicated[len(deduplicated)-1] {
+			deduplicated = append(deduplicated, location)
+		}
+	}
+
+	return deduplicated
+}
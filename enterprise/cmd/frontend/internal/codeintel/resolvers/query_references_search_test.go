@@ -0,0 +1,79 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestSearchBasedReferencesQueries(t *testing.T) {
+	monikers := []precise.QualifiedMonikerData{
+		{
+			MonikerData:            precise.MonikerData{Kind: "import", Scheme: "gomod", Identifier: "github.com/test/lib:Pad"},
+			PackageInformationData: precise.PackageInformationData{Name: "github.com/test/lib", Version: "v0.1.0"},
+		},
+		{
+			MonikerData:            precise.MonikerData{Kind: "export", Scheme: "gomod", Identifier: "github.com/test/lib:Pad"},
+			PackageInformationData: precise.PackageInformationData{Name: "github.com/test/lib", Version: "v0.1.0"},
+		},
+		{
+			MonikerData:            precise.MonikerData{Kind: "import", Scheme: "semanticdb", Identifier: "com/test/Lib#pad()."},
+			PackageInformationData: precise.PackageInformationData{Name: "maven/com.test/lib", Version: "1.0"},
+		},
+		{
+			MonikerData:            precise.MonikerData{Kind: "import", Scheme: "npm", Identifier: "lib:leftPad"},
+			PackageInformationData: precise.PackageInformationData{Name: "left-pad", Version: "1.3.0"},
+		},
+		{
+			MonikerData: precise.MonikerData{Kind: "import", Scheme: "npm", Identifier: "lib:rightPad"},
+		},
+	}
+
+	queries := searchBasedReferencesQueries(monikers, []string{"lib:leftPad"}, []string{"github.com/test/b", "github.com/test/a", "github.com/test/b"})
+
+	expectedQueries := []string{
+		`repo:contains.content(github\.com/test/lib) -repo:^github\.com/test/a$ -repo:^github\.com/test/b$ type:file patterntype:regexp case:yes count:500 \bPad\b`,
+		`repo:contains.content(maven/com\.test/lib) -repo:^github\.com/test/a$ -repo:^github\.com/test/b$ type:file patterntype:regexp case:yes count:500 \bpad\b`,
+	}
+	if diff := cmp.Diff(expectedQueries, queries); diff != "" {
+		t.Errorf("unexpected queries (-want +got):\n%s", diff)
+	}
+}
+
+func TestIdentifierSymbolName(t *testing.T) {
+	testCases := map[string]string{
+		"github.com/foo/bar:Baz.Qux": "Qux",
+		"com/foo/Bar#qux().":         "qux",
+		"lib/pad:leftPad":            "leftPad",
+		"$scope:$el":                 "$el",
+		"::":                         "",
+	}
+
+	for identifier, expected := range testCases {
+		if name := identifierSymbolName(identifier); name != expected {
+			t.Errorf("unexpected symbol name for %q. want=%q have=%q", identifier, expected, name)
+		}
+	}
+}
+
+func TestSortAndDeduplicateSearchLocations(t *testing.T) {
+	locations := []SearchLocation{
+		{RepositoryName: "b", Path: "a.go", Range: testRange1},
+		{RepositoryName: "a", Path: "b.go", Range: testRange2},
+		{RepositoryName: "a", Path: "b.go", Range: testRange1},
+		{RepositoryName: "b", Path: "a.go", Range: testRange1},
+		{RepositoryName: "a", Path: "a.go", Range: testRange3},
+	}
+
+	expectedLocations := []SearchLocation{
+		{RepositoryName: "a", Path: "a.go", Range: testRange3},
+		{RepositoryName: "a", Path: "b.go", Range: testRange1},
+		{RepositoryName: "a", Path: "b.go", Range: testRange2},
+		{RepositoryName: "b", Path: "a.go", Range: testRange1},
+	}
+	if diff := cmp.Diff(expectedLocations, sortAndDeduplicateSearchLocations(locations)); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+}
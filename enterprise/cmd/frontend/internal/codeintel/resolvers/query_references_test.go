@@ -44,6 +44,7 @@ func TestReferences(t *testing.T) {
 	resolver := newQueryResolver(
 		mockDBStore,
 		mockLSIFStore,
+		nil,
 		newCachedCommitChecker(mockGitserverClient),
 		mockPositionAdjuster,
 		42,
@@ -165,6 +166,7 @@ func TestReferencesRemote(t *testing.T) {
 	resolver := newQueryResolver(
 		mockDBStore,
 		mockLSIFStore,
+		nil,
 		newCachedCommitChecker(mockGitserverClient),
 		mockPositionAdjuster,
 		42,
@@ -239,12 +241,87 @@ func TestReferencesRemote(t *testing.T) {
 	}
 }
 
+func TestReferencesSearchBased(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockSearchClient := NewMockSearchClient()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	// Empty result set (prevents nil pointer as scanner is always non-nil)
+	mockDBStore.ReferenceIDsAndFiltersFunc.PushReturn(dbstore.PackageReferenceScannerFromSlice(), 0, nil)
+
+	moniker := precise.MonikerData{Kind: "import", Scheme: "gomod", Identifier: "github.com/test/lib:Pad", PackageInformationID: "51"}
+	mockLSIFStore.MonikersByPositionFunc.PushReturn([][]precise.MonikerData{{moniker}}, nil)
+	mockLSIFStore.PackageInformationFunc.PushReturn(precise.PackageInformationData{Name: "github.com/test/lib", Version: "v0.1.0"}, true, nil)
+	mockLSIFStore.ReferencesFunc.PushReturn([]lsifstore.Location{{DumpID: 50, Path: "a.go", Range: testRange1}}, 1, nil)
+
+	searchLocations := []SearchLocation{
+		{RepositoryID: 43, RepositoryName: "github.com/test/c", Commit: "cafebabe", Path: "c.go", Range: testRange3},
+		{RepositoryID: 44, RepositoryName: "github.com/test/b", Commit: "deadc0de", Path: "b.go", Range: testRange2},
+	}
+	mockSearchClient.SearchFunc.SetDefaultHook(func(ctx context.Context, query string) ([]SearchLocation, error) {
+		return append([]SearchLocation(nil), searchLocations...), nil
+	})
+
+	uploads := []dbstore.Dump{
+		{ID: 50, RepositoryID: 42, RepositoryName: "github.com/test/app", Commit: "deadbeef", Root: "sub1/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		mockSearchClient,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+
+	adjustedLocations, cursor, err := resolver.References(context.Background(), 10, 20, 2, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying references: %s", err)
+	}
+	if cursor == "" {
+		t.Fatalf("expected a cursor for the next page")
+	}
+
+	nextAdjustedLocations, cursor, err := resolver.References(context.Background(), 10, 20, 2, cursor)
+	if err != nil {
+		t.Fatalf("unexpected error querying references: %s", err)
+	}
+	if cursor != "" {
+		t.Fatalf("unexpected cursor %q", cursor)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+		{Dump: dbstore.Dump{RepositoryID: 44, RepositoryName: "github.com/test/b", Commit: "deadc0de"}, Path: "b.go", AdjustedCommit: "deadc0de", AdjustedRange: testRange2, SearchBased: true},
+		{Dump: dbstore.Dump{RepositoryID: 43, RepositoryName: "github.com/test/c", Commit: "cafebabe"}, Path: "c.go", AdjustedCommit: "cafebabe", AdjustedRange: testRange3, SearchBased: true},
+	}
+	if diff := cmp.Diff(expectedLocations, append(adjustedLocations, nextAdjustedLocations...)); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockSearchClient.SearchFunc.History(); len(history) != 2 {
+		t.Fatalf("unexpected call count for searchClient.Search. want=%d have=%d", 2, len(history))
+	} else {
+		expectedQuery := `repo:contains.content(github\.com/test/lib) -repo:^github\.com/test/app$ type:file patterntype:regexp case:yes count:500 \bPad\b`
+		if history[0].Arg1 != expectedQuery {
+			t.Errorf("unexpected query. want=%q have=%q", expectedQuery, history[0].Arg1)
+		}
+	}
+}
+
 func TestIgnoredIDs(t *testing.T) {
 	mockDBStore := NewMockDBStore()
 
 	resolver := newQueryResolver(
 		mockDBStore,
 		NewMockLSIFStore(),
+		nil,
 		newCachedCommitChecker(NewMockGitserverClient()),
 		noopPositionAdjuster(),
 		42,
@@ -269,6 +346,7 @@ func TestIgnoredIDs(t *testing.T) {
 			context.Background(),
 			[]precise.QualifiedMonikerData{{MonikerData: precise.MonikerData{Identifier: "padLeft"}}},
 			ignoreIDs,
+			nil,
 			10,
 			0,
 			func(fields ...log.Field) {},
@@ -2,8 +2,10 @@ package resolvers
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/inconshreveable/log15"
 	"github.com/opentracing/opentracing-go/log"
 
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -46,6 +48,8 @@ type Resolver interface {
 	PreviewRepositoryFilter(ctx context.Context, patterns []string, limit, offset int) (_ []int, totalCount int, repositoryMatchLimit *int, _ error)
 	PreviewGitObjectFilter(ctx context.Context, repositoryID int, gitObjectType dbstore.GitObjectType, pattern string) (map[string][]string, error)
 	DocumentationSearch(ctx context.Context, query string, repos []string) ([]precise.DocumentationSearchResult, error)
+	RepositoryStorageUsage(ctx context.Context, repositoryID int) (store.StorageUsage, error)
+	OrganizationStorageUsage(ctx context.Context, orgID int) (store.StorageUsage, error)
 
 	UploadConnectionResolver(opts store.GetUploadsOptions) *UploadsResolver
 	IndexConnectionResolver(opts store.GetIndexesOptions) *IndexesResolver
@@ -55,6 +59,7 @@ type Resolver interface {
 type resolver struct {
 	dbStore         DBStore
 	lsifStore       LSIFStore
+	searchClient    SearchClient
 	gitserverClient GitserverClient
 	policyMatcher   *policies.Matcher
 	indexEnqueuer   IndexEnqueuer
@@ -66,18 +71,20 @@ type resolver struct {
 func NewResolver(
 	dbStore DBStore,
 	lsifStore LSIFStore,
+	searchClient SearchClient,
 	gitserverClient GitserverClient,
 	policyMatcher *policies.Matcher,
 	indexEnqueuer IndexEnqueuer,
 	hunkCache HunkCache,
 	observationContext *observation.Context,
 ) Resolver {
-	return newResolver(dbStore, lsifStore, gitserverClient, policyMatcher, indexEnqueuer, hunkCache, observationContext)
+	return newResolver(dbStore, lsifStore, searchClient, gitserverClient, policyMatcher, indexEnqueuer, hunkCache, observationContext)
 }
 
 func newResolver(
 	dbStore DBStore,
 	lsifStore LSIFStore,
+	searchClient SearchClient,
 	gitserverClient GitserverClient,
 	policyMatcher *policies.Matcher,
 	indexEnqueuer IndexEnqueuer,
@@ -87,6 +94,7 @@ func newResolver(
 	return &resolver{
 		dbStore:         dbStore,
 		lsifStore:       lsifStore,
+		searchClient:    searchClient,
 		gitserverClient: gitserverClient,
 		policyMatcher:   policyMatcher,
 		indexEnqueuer:   indexEnqueuer,
@@ -142,6 +150,14 @@ func (r *resolver) QueueAutoIndexJobsForRepo(ctx context.Context, repositoryID i
 	return r.indexEnqueuer.QueueIndexes(ctx, repositoryID, rev, configuration, true)
 }
 
+func (r *resolver) RepositoryStorageUsage(ctx context.Context, repositoryID int) (store.StorageUsage, error) {
+	return r.dbStore.RepositoryStorageUsage(ctx, repositoryID)
+}
+
+func (r *resolver) OrganizationStorageUsage(ctx context.Context, orgID int) (store.StorageUsage, error) {
+	return r.dbStore.OrganizationStorageUsage(ctx, orgID)
+}
+
 const slowQueryResolverRequestThreshold = time.Second
 
 // QueryResolver determines the set of dumps that can answer code intel queries for the
@@ -175,9 +191,12 @@ func (r *resolver) QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataA
 		return nil, err
 	}
 
+	markUploadsAsQueried(r.dbStore, dumps)
+
 	return NewQueryResolver(
 		r.dbStore,
 		r.lsifStore,
+		r.searchClient,
 		cachedCommitChecker,
 		NewPositionAdjuster(args.Repo, string(args.Commit), r.hunkCache),
 		int(args.Repo.ID),
@@ -188,6 +207,63 @@ func (r *resolver) QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataA
 	), nil
 }
 
+// markUploadsAsQueriedInterval is how long the uses of uploads are collected before they are
+// recorded in a single update.
+const markUploadsAsQueriedInterval = 5 * time.Second
+
+// markUploadsAsQueriedTimeout bounds the background update made by markUploadsAsQueried.
+const markUploadsAsQueriedTimeout = 10 * time.Second
+
+// queriedUploads holds the identifiers of the uploads used since the last update, per store.
+var queriedUploads = struct {
+	sync.Mutex
+	pending map[DBStore]map[int]struct{}
+}{pending: map[DBStore]map[int]struct{}{}}
+
+// markUploadsAsQueried records the use of the given uploads so that the least recently queried
+// uploads are the first to be evicted when a repository or organization exceeds its storage
+// quota. Uses are batched and recorded in the background every markUploadsAsQueriedInterval.
+// Failures are only logged, as they must not fail or slow down the code intelligence queries
+// that used the uploads.
+func markUploadsAsQueried(dbStore DBStore, dumps []store.Dump) {
+	if len(dumps) == 0 {
+		return
+	}
+
+	queriedUploads.Lock()
+	defer queriedUploads.Unlock()
+
+	pending, ok := queriedUploads.pending[dbStore]
+	if !ok {
+		pending = map[int]struct{}{}
+		queriedUploads.pending[dbStore] = pending
+		time.AfterFunc(markUploadsAsQueriedInterval, func() { flushQueriedUploads(dbStore) })
+	}
+	for _, dump := range dumps {
+		pending[dump.ID] = struct{}{}
+	}
+}
+
+// flushQueriedUploads records the uses of uploads collected by markUploadsAsQueried.
+func flushQueriedUploads(dbStore DBStore) {
+	queriedUploads.Lock()
+	pending := queriedUploads.pending[dbStore]
+	delete(queriedUploads.pending, dbStore)
+	queriedUploads.Unlock()
+
+	uploadIDs := make([]int, 0, len(pending))
+	for id := range pending {
+		uploadIDs = append(uploadIDs, id)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), markUploadsAsQueriedTimeout)
+	defer cancel()
+
+	if err := dbStore.MarkUploadsAsQueried(ctx, uploadIDs); err != nil {
+		log15.Warn("Failed to mark uploads as queried", "uploadIDs", uploadIDs, "error", err)
+	}
+}
+
 func (r *resolver) GetConfigurationPolicies(ctx context.Context, opts store.GetConfigurationPoliciesOptions) ([]store.ConfigurationPolicy, int, error) {
 	return r.dbStore.GetConfigurationPolicies(ctx, opts)
 }
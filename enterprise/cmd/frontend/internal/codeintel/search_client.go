@@ -0,0 +1,107 @@
+package codeintel
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	codeintelresolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+)
+
+// searchClient runs searches for the search-based fallback of code intelligence queries through the
+// streaming search API of the frontend.
+type searchClient struct {
+	frontendInternalURL string
+}
+
+var _ codeintelresolvers.SearchClient = &searchClient{}
+
+func newSearchClient() *searchClient {
+	return &searchClient{frontendInternalURL: internalapi.Client.URL + "/.internal"}
+}
+
+const internalSearchClientUserAgent = "Code intelligence search-based fallback"
+
+// Search returns the ranges of every content match of the given query. The search is run on behalf of
+// the user in the given context. No search is run for unauthenticated users, as the internal API would
+// otherwise not restrict the search to the repositories they can access.
+func (c *searchClient) Search(ctx context.Context, query string) (_ []codeintelresolvers.SearchLocation, err error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, nil
+	}
+
+	req, err := streamhttp.NewRequest(c.frontendInternalURL, query)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", internalSearchClientUserAgent)
+	req.Header.Set("X-Sourcegraph-User-ID", a.UIDString())
+
+	resp, err := httpcli.InternalClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("search failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var locations []codeintelresolvers.SearchLocation
+	dec := streamhttp.FrontendStreamDecoder{
+		OnMatches: func(matches []streamhttp.EventMatch) {
+			for _, match := range matches {
+				if contentMatch, ok := match.(*streamhttp.EventContentMatch); ok {
+					locations = append(locations, searchLocationsFromContentMatch(contentMatch)...)
+				}
+			}
+		},
+		OnError: func(ee *streamhttp.EventError) {
+			err = errors.New(ee.Message)
+		},
+	}
+	if decErr := dec.ReadAll(resp.Body); decErr != nil {
+		return nil, decErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+// searchLocationsFromContentMatch returns a location for each matched range of the given content
+// match. Matches without a resolved commit are skipped as they cannot be linked to.
+func searchLocationsFromContentMatch(match *streamhttp.EventContentMatch) []codeintelresolvers.SearchLocation {
+	if match.Commit == "" {
+		return nil
+	}
+
+	var locations []codeintelresolvers.SearchLocation
+	for _, lineMatch := range match.LineMatches {
+		for _, offsetAndLength := range lineMatch.OffsetAndLengths {
+			start := lsifstore.Position{Line: int(lineMatch.LineNumber), Character: int(offsetAndLength[0])}
+			end := lsifstore.Position{Line: int(lineMatch.LineNumber), Character: int(offsetAndLength[0] + offsetAndLength[1])}
+
+			locations = append(locations, codeintelresolvers.SearchLocation{
+				RepositoryID:   int(match.RepositoryID),
+				RepositoryName: match.Repository,
+				Commit:         match.Commit,
+				Path:           match.Path,
+				Range:          lsifstore.Range{Start: start, End: end},
+			})
+		}
+	}
+
+	return locations
+}
@@ -0,0 +1,33 @@
+package searchexport
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchexport/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexport"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
+)
+
+func Init(ctx context.Context, db database.DB, _ conftypes.UnifiedWatchable, _ *oobmigration.Runner, enterpriseServices *enterprise.Services, observationContext *observation.Context) error {
+	// Exports are written by the worker to the bucket of code intelligence
+	// uploads, so we read them with the same configuration.
+	uploadStoreConfig := &uploadstore.Config{}
+	uploadStoreConfig.Load()
+	if err := uploadStoreConfig.Validate(); err != nil {
+		return err
+	}
+	uploadStore, err := uploadstore.CreateLazy(ctx, uploadStoreConfig, observationContext)
+	if err != nil {
+		return err
+	}
+
+	store := searchexport.NewStore(db)
+	enterpriseServices.SearchExportsResolver = resolvers.NewResolver(db, store)
+	enterpriseServices.SearchExportDownloadHandler = resolvers.NewDownloadHandler(db, store, uploadStore)
+	return nil
+}
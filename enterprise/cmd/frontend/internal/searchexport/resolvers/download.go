@@ -0,0 +1,65 @@
+package resolvers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexport"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// NewDownloadHandler returns an HTTP handler that serves the exported file of
+// a completed search export.
+func NewDownloadHandler(db database.DB, store *searchexport.Store, uploadStore uploadstore.Store) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			http.Error(w, "invalid search export id", http.StatusBadRequest)
+			return
+		}
+
+		job, err := store.GetByID(ctx, id)
+		if err == searchexport.ErrNotFound {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// 🚨 SECURITY: Only site admins and the user who created an export
+		// may download it. We respond with 404 so the existence of other
+		// users' exports is not revealed.
+		if err := backend.CheckSiteAdminOrSameUser(ctx, db, job.UserID); err != nil {
+			http.Error(w, searchexport.ErrNotFound.Error(), http.StatusNotFound)
+			return
+		}
+
+		if job.State != "completed" || job.ObjectKey == "" {
+			http.Error(w, "search export has not completed", http.StatusConflict)
+			return
+		}
+
+		rc, err := uploadStore.Get(ctx, job.ObjectKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer rc.Close()
+
+		w.Header().Set("Content-Type", job.Format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=search-export-%d.%s", job.ID, job.Format))
+		if _, err := io.Copy(w, rc); err != nil {
+			log15.Warn("failed to write search export", "id", job.ID, "error", err)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexport"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+const searchExportKind = "SearchExport"
+
+func marshalSearchExportID(id int64) graphql.ID {
+	return relay.MarshalID(searchExportKind, id)
+}
+
+func unmarshalSearchExportID(id graphql.ID) (exportID int64, err error) {
+	err = relay.UnmarshalSpec(id, &exportID)
+	return
+}
+
+// NewResolver returns a new SearchExportsResolver that uses the given store.
+func NewResolver(db database.DB, store *searchexport.Store) graphqlbackend.SearchExportsResolver {
+	return &Resolver{db: db, store: store}
+}
+
+type Resolver struct {
+	db    database.DB
+	store *searchexport.Store
+}
+
+func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
+	return map[string]graphqlbackend.NodeByIDFunc{
+		searchExportKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.searchExportByID(ctx, id)
+		},
+	}
+}
+
+func (r *Resolver) searchExportByID(ctx context.Context, id graphql.ID) (*searchExportResolver, error) {
+	exportID, err := unmarshalSearchExportID(id)
+	if err != nil {
+		return nil, err
+	}
+	job, err := r.store.GetByID(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only site admins and the user who created an export may
+	// see it, as its query and results are private to that user.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.db, job.UserID); err != nil {
+		return nil, err
+	}
+	return &searchExportResolver{job: job}, nil
+}
+
+func (r *Resolver) SearchExports(ctx context.Context, args *graphqlbackend.ListSearchExportsArgs) (graphqlbackend.SearchExportConnectionResolver, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+	if args.First < 0 {
+		return nil, errors.New("first must not be negative")
+	}
+
+	var after int64
+	if args.After != nil {
+		var err error
+		if after, err = unmarshalSearchExportID(graphql.ID(*args.After)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Request one extra to determine if there are more pages.
+	jobs, err := r.store.List(ctx, searchexport.ListOpts{
+		UserID: a.UID,
+		First:  int(args.First) + 1,
+		After:  after,
+	})
+	if err != nil {
+		return nil, err
+	}
+	totalCount, err := r.store.Count(ctx, a.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNextPage := false
+	if len(jobs) > int(args.First) {
+		hasNextPage = true
+		jobs = jobs[:args.First]
+	}
+
+	nodes := make([]graphqlbackend.SearchExportResolver, 0, len(jobs))
+	for _, job := range jobs {
+		nodes = append(nodes, &searchExportResolver{job: job})
+	}
+	return &searchExportConnectionResolver{nodes: nodes, totalCount: totalCount, hasNextPage: hasNextPage}, nil
+}
+
+func (r *Resolver) CreateSearchExport(ctx context.Context, args *graphqlbackend.CreateSearchExportArgs) (graphqlbackend.SearchExportResolver, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	format, err := searchexport.ParseFormat(args.Format)
+	if err != nil {
+		return nil, err
+	}
+	// Reject invalid queries early, rather than failing the export in the
+	// background.
+	if _, err := query.Pipeline(query.Init(args.Query, query.SearchTypeLiteral)); err != nil {
+		return nil, err
+	}
+
+	job, err := r.store.Create(ctx, a.UID, args.Query, format)
+	if err != nil {
+		return nil, err
+	}
+	return &searchExportResolver{job: job}, nil
+}
+
+type searchExportConnectionResolver struct {
+	nodes       []graphqlbackend.SearchExportResolver
+	totalCount  int
+	hasNextPage bool
+}
+
+func (r *searchExportConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.SearchExportResolver, error) {
+	return r.nodes, nil
+}
+
+func (r *searchExportConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	return int32(r.totalCount), nil
+}
+
+func (r *searchExportConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	if !r.hasNextPage || len(r.nodes) == 0 {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	return graphqlutil.NextPageCursor(string(r.nodes[len(r.nodes)-1].ID())), nil
+}
+
+type searchExportResolver struct {
+	job *searchexport.Job
+}
+
+func (r *searchExportResolver) ID() graphql.ID {
+	return marshalSearchExportID(r.job.ID)
+}
+
+func (r *searchExportResolver) Query() string {
+	return r.job.Query
+}
+
+func (r *searchExportResolver) Format() string {
+	switch r.job.Format {
+	case searchexport.FormatCSV:
+		return "CSV"
+	default:
+		return "JSONL"
+	}
+}
+
+func (r *searchExportResolver) State() string {
+	switch r.job.State {
+	case "processing":
+		return "PROCESSING"
+	case "completed":
+		return "COMPLETED"
+	case "errored":
+		return "ERRORED"
+	case "failed":
+		return "FAILED"
+	default:
+		return "QUEUED"
+	}
+}
+
+func (r *searchExportResolver) ResultCount() int32 {
+	return int32(r.job.ResultCount)
+}
+
+func (r *searchExportResolver) FailureMessage() *string {
+	return r.job.FailureMessage
+}
+
+func (r *searchExportResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.job.CreatedAt}
+}
+
+func (r *searchExportResolver) StartedAt() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.job.StartedAt)
+}
+
+func (r *searchExportResolver) FinishedAt() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.job.FinishedAt)
+}
+
+func (r *searchExportResolver) DownloadURL() *string {
+	if r.job.State != "completed" || r.job.ObjectKey == "" {
+		return nil
+	}
+	url := downloadURL(r.job.ID)
+	return &url
+}
+
+// downloadURL returns the path of the HTTP API endpoint that serves the
+// exported file of the export with the given ID.
+func downloadURL(id int64) string {
+	return fmt.Sprintf("/.api/search/exports/%d", id)
+}
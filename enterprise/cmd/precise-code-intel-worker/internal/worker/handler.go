@@ -16,6 +16,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -118,6 +119,19 @@ func (h *handler) handle(ctx context.Context, upload store.Upload, traceLog obse
 
 	traceLog(log.Bool("defaultBranch", isDefaultBranch))
 
+	// Determine the upload on which this upload is based, if any. The documents of the parent upload
+	// that are not replaced by this upload are copied into the resulting dump.
+	parent, err := resolveParentUpload(ctx, h.dbStore, h.gitserverClient, upload)
+	if err != nil {
+		return false, err
+	}
+	if parent != nil {
+		traceLog(
+			log.Int("parentUploadID", parent.upload.ID),
+			log.Int("numChangedPaths", len(parent.changedPaths)),
+		)
+	}
+
 	getChildren := func(ctx context.Context, dirnames []string) (map[string][]string, error) {
 		directoryChildren, err := h.gitserverClient.DirectoryChildren(ctx, upload.RepositoryID, upload.Commit, dirnames)
 		if err != nil {
@@ -134,7 +148,7 @@ func (h *handler) handle(ctx context.Context, upload store.Upload, traceLog obse
 
 		// Note: this is writing to a different database than the block below, so we need to use a
 		// different transaction context (managed by the writeData function).
-		if err := writeData(ctx, h.lsifStore, upload, repo, isDefaultBranch, groupedBundleData, traceLog); err != nil {
+		if err := writeData(ctx, h.lsifStore, upload, parent, repo, isDefaultBranch, groupedBundleData, traceLog); err != nil {
 			if isUniqueConstraintViolation(err) {
 				// If this is a unique constraint violation, then we've previously processed this same
 				// upload record up to this point, but failed to perform the transaction below. We can
@@ -184,6 +198,13 @@ func (h *handler) handle(ctx context.Context, upload store.Upload, traceLog obse
 			if err := tx.UpdatePackageReferences(ctx, upload.ID, groupedBundleData.PackageReferences); err != nil {
 				return errors.Wrap(err, "store.UpdatePackageReferences")
 			}
+			if parent != nil {
+				// Packages provided and referenced by unchanged documents of the parent upload are
+				// not present in this upload's data.
+				if err := tx.InheritPackages(ctx, parent.upload.ID, upload.ID); err != nil {
+					return errors.Wrap(err, "store.InheritPackages")
+				}
+			}
 
 			// When inserting a new completed upload record, update the reference counts both to it from
 			// existing uploads, as well as the reference counts to all of this new upload's dependencies.
@@ -280,8 +301,14 @@ func withUploadData(ctx context.Context, uploadStore uploadstore.Store, id int,
 	return nil
 }
 
-// writeData transactionally writes the given grouped bundle data into the given LSIF store.
-func writeData(ctx context.Context, lsifStore LSIFStore, upload store.Upload, repo *types.Repo, isDefaultBranch bool, groupedBundleData *precise.GroupedBundleDataChans, traceLog observation.TraceLogger) (err error) {
+// maxResultChunkGenerations is the maximum number of uploads whose result chunks are stored together
+// in a single dump. Every incremental upload copies all result chunks of its parent, including those
+// referring to replaced documents, so the chain of incremental uploads must end in a full upload.
+const maxResultChunkGenerations = 10
+
+// writeData transactionally writes the given grouped bundle data into the given LSIF store. If a parent
+// upload is given, the data of the parent that is not replaced by the given data is copied as well.
+func writeData(ctx context.Context, lsifStore LSIFStore, upload store.Upload, parent *parentUpload, repo *types.Repo, isDefaultBranch bool, groupedBundleData *precise.GroupedBundleDataChans, traceLog observation.TraceLogger) (err error) {
 	// Upsert values used for documentation search that have high contention. We do this with the raw LSIF store
 	// instead of in the transaction below because the rows being upserted tend to have heavy contention.
 	repositoryNameID, languageNameID, err := lsifStore.WriteDocumentationSearchPrework(ctx, upload, repo, isDefaultBranch)
@@ -295,21 +322,70 @@ func writeData(ctx context.Context, lsifStore LSIFStore, upload store.Upload, re
 	}
 	defer func() { err = tx.Done(err) }()
 
-	if err := tx.WriteMeta(ctx, upload.ID, groupedBundleData.Meta); err != nil {
+	// The identifiers and result chunks of this upload follow those of the parent (if any) so that the
+	// copied data and the new data can be stored side-by-side in the same dump.
+	var parentGenerations []lsifstore.ResultChunkGeneration
+	if parent != nil {
+		if parentGenerations, err = tx.ResultChunkGenerations(ctx, parent.upload.ID); err != nil {
+			return errors.Wrap(err, "store.ResultChunkGenerations")
+		}
+		if len(parentGenerations) == 0 {
+			return errors.Errorf("parent upload %d was processed before incremental uploads were supported; a full upload is required", parent.upload.ID)
+		}
+		if len(parentGenerations) >= maxResultChunkGenerations {
+			return errors.Errorf("parent upload %d is based on %d earlier uploads; a full upload is required", parent.upload.ID, len(parentGenerations)-1)
+		}
+	}
+	idOffset, resultChunkOffset := 0, 0
+	if n := len(parentGenerations); n > 0 {
+		idOffset = parentGenerations[n-1].IDBound
+		resultChunkOffset = parentGenerations[n-1].ResultChunkOffset + parentGenerations[n-1].NumResultChunks
+	}
+	shifter := newIDShifter(idOffset, resultChunkOffset)
+
+	meta := groupedBundleData.Meta
+	meta.NumResultChunks += resultChunkOffset
+	if err := tx.WriteMeta(ctx, upload.ID, meta); err != nil {
 		return errors.Wrap(err, "store.WriteMeta")
 	}
-	count, err := tx.WriteDocuments(ctx, upload.ID, groupedBundleData.Documents)
+	count, err := tx.WriteDocuments(ctx, upload.ID, shifter.shiftDocuments(groupedBundleData.Documents))
 	if err != nil {
 		return errors.Wrap(err, "store.WriteDocuments")
 	}
 	traceLog(log.Uint32("numDocuments", count))
 
-	count, err = tx.WriteResultChunks(ctx, upload.ID, groupedBundleData.ResultChunks)
+	count, err = tx.WriteResultChunks(ctx, upload.ID, shifter.shiftResultChunks(groupedBundleData.ResultChunks))
 	if err != nil {
 		return errors.Wrap(err, "store.WriteResultChunks")
 	}
 	traceLog(log.Uint32("numResultChunks", count))
 
+	generation, err := shifter.generation(groupedBundleData.Meta.NumResultChunks)
+	if err != nil {
+		return err
+	}
+	if err := tx.WriteResultChunkGenerations(ctx, upload.ID, append(parentGenerations, generation)); err != nil {
+		return errors.Wrap(err, "store.WriteResultChunkGenerations")
+	}
+
+	var replaced []string
+	if parent != nil {
+		replaced = replacedPaths(parent.changedPaths, shifter.documentPaths())
+		traceLog(log.Int("numReplacedPaths", len(replaced)))
+
+		count, err = tx.CopyDocuments(ctx, parent.upload.ID, upload.ID, replaced)
+		if err != nil {
+			return errors.Wrap(err, "store.CopyDocuments")
+		}
+		traceLog(log.Uint32("numCopiedDocuments", count))
+
+		count, err = tx.CopyResultChunks(ctx, parent.upload.ID, upload.ID)
+		if err != nil {
+			return errors.Wrap(err, "store.CopyResultChunks")
+		}
+		traceLog(log.Uint32("numCopiedResultChunks", count))
+	}
+
 	count, err = tx.WriteDefinitions(ctx, upload.ID, groupedBundleData.Definitions)
 	if err != nil {
 		return errors.Wrap(err, "store.WriteDefinitions")
@@ -328,6 +404,22 @@ func writeData(ctx context.Context, lsifStore LSIFStore, upload store.Upload, re
 	}
 	traceLog(log.Uint32("numImplementations", count))
 
+	if parent != nil {
+		// Only monikers attached to replaced documents or to the documents of this upload are
+		// recomputed; the rows of all other monikers are copied from the parent as-is.
+		for _, table := range []struct{ tableName, logField string }{
+			{"definitions", "numCopiedDefinitions"},
+			{"references", "numCopiedReferences"},
+			{"implementations", "numCopiedImplementations"},
+		} {
+			count, err = tx.CopyMonikerLocations(ctx, table.tableName, parent.upload.ID, upload.ID, replaced)
+			if err != nil {
+				return errors.Wrap(err, "store.CopyMonikerLocations")
+			}
+			traceLog(log.Uint32(table.logField, count))
+		}
+	}
+
 	count, err = tx.WriteDocumentationPages(ctx, upload, repo, isDefaultBranch, groupedBundleData.DocumentationPages, repositoryNameID, languageNameID)
 	if err != nil {
 		return errors.Wrap(err, "store.WriteDocumentationPages")
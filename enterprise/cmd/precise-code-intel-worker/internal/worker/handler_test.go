@@ -14,6 +14,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
 	uploadstoremocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -158,6 +159,147 @@ func TestHandle(t *testing.T) {
 	}
 }
 
+func TestHandleIncremental(t *testing.T) {
+	setupRepoMocks(t)
+
+	parentUploadID := 41
+	upload := dbstore.Upload{
+		ID:             42,
+		Root:           "root/",
+		Commit:         "deadbeef",
+		RepositoryID:   50,
+		Indexer:        "lsif-go",
+		ParentUploadID: &parentUploadID,
+	}
+
+	mockWorkerStore := NewMockWorkerStore()
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := NewMockGitserverClient()
+
+	// Set default transaction behavior
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+
+	// Set default transaction behavior
+	mockLSIFStore.TransactFunc.SetDefaultReturn(mockLSIFStore, nil)
+	mockLSIFStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+
+	// Give correlation package a valid input dump
+	mockUploadStore.GetFunc.SetDefaultHook(copyTestDump)
+
+	// Allowlist all files in dump
+	gitserverClient.DirectoryChildrenFunc.SetDefaultReturn(map[string][]string{
+		"":     {"root"},
+		"root": {"root/foo.go", "root/bar.go"},
+	}, nil)
+	gitserverClient.CommitDateFunc.SetDefaultReturn("deadbeef", time.Now(), true, nil)
+
+	// Parent upload and the files changed since its commit
+	mockDBStore.GetUploadByIDFunc.SetDefaultReturn(dbstore.Upload{
+		ID:           41,
+		Root:         "root/",
+		Commit:       "cafebabe",
+		RepositoryID: 50,
+		Indexer:      "lsif-go",
+		State:        "completed",
+	}, true, nil)
+	gitserverClient.ChangedFilesFunc.SetDefaultReturn([]string{"root/baz.go", "other/foo.go"}, nil)
+	parentGenerations := []lsifstore.ResultChunkGeneration{
+		{IDOffset: 0, IDBound: 100, ResultChunkOffset: 0, NumResultChunks: 3},
+	}
+	mockLSIFStore.ResultChunkGenerationsFunc.SetDefaultReturn(parentGenerations, nil)
+
+	// Consume written documents and result chunks so that their paths and identifiers are recorded
+	mockLSIFStore.WriteDocumentsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, documents chan precise.KeyedDocumentData) (count uint32, _ error) {
+		for range documents {
+			count++
+		}
+		return count, nil
+	})
+	mockLSIFStore.WriteResultChunksFunc.SetDefaultHook(func(ctx context.Context, bundleID int, resultChunks chan precise.IndexedResultChunkData) (count uint32, _ error) {
+		for range resultChunks {
+			count++
+		}
+		return count, nil
+	})
+
+	handler := &handler{
+		dbStore:         mockDBStore,
+		workerStore:     mockWorkerStore,
+		lsifStore:       mockLSIFStore,
+		uploadStore:     mockUploadStore,
+		gitserverClient: gitserverClient,
+	}
+
+	requeued, err := handler.handle(context.Background(), upload, func(fields ...log.Field) {})
+	if err != nil {
+		t.Fatalf("unexpected error handling upload: %s", err)
+	} else if requeued {
+		t.Errorf("unexpected requeue")
+	}
+
+	if calls := gitserverClient.ChangedFilesFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of ChangedFiles calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg2 != "cafebabe" || calls[0].Arg3 != "deadbeef" {
+		t.Errorf("unexpected ChangedFiles commits. want=%s..%s have=%s..%s", "cafebabe", "deadbeef", calls[0].Arg2, calls[0].Arg3)
+	}
+
+	if calls := mockLSIFStore.WriteResultChunkGenerationsFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of WriteResultChunkGenerations calls. want=%d have=%d", 1, len(calls))
+	} else if generations := calls[0].Arg2; len(generations) != 2 {
+		t.Errorf("unexpected number of generations. want=%d have=%d", 2, len(generations))
+	} else if diff := cmp.Diff(parentGenerations[0], generations[0]); diff != "" {
+		t.Errorf("unexpected parent generation (-want +got):\n%s", diff)
+	} else if generations[1].IDOffset != 100 || generations[1].ResultChunkOffset != 3 {
+		t.Errorf("unexpected generation offsets. want=(%d, %d) have=(%d, %d)", 100, 3, generations[1].IDOffset, generations[1].ResultChunkOffset)
+	} else if calls := mockLSIFStore.WriteMetaFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of WriteMeta calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg2.NumResultChunks != 3+generations[1].NumResultChunks {
+		t.Errorf("unexpected number of result chunks. want=%d have=%d", 3+generations[1].NumResultChunks, calls[0].Arg2.NumResultChunks)
+	}
+
+	if calls := mockLSIFStore.CopyDocumentsFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of CopyDocuments calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != 41 || calls[0].Arg2 != 42 {
+		t.Errorf("unexpected CopyDocuments bundles. want=(%d, %d) have=(%d, %d)", 41, 42, calls[0].Arg1, calls[0].Arg2)
+	} else if diff := cmp.Diff([]string{"bar.go", "baz.go", "foo.go"}, calls[0].Arg3); diff != "" {
+		t.Errorf("unexpected excluded paths (-want +got):\n%s", diff)
+	}
+	if calls := mockLSIFStore.CopyResultChunksFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of CopyResultChunks calls. want=%d have=%d", 1, len(calls))
+	}
+	if calls := mockLSIFStore.CopyMonikerLocationsFunc.History(); len(calls) != 3 {
+		t.Errorf("unexpected number of CopyMonikerLocations calls. want=%d have=%d", 3, len(calls))
+	}
+
+	if calls := mockDBStore.InheritPackagesFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of InheritPackages calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != 41 || calls[0].Arg2 != 42 {
+		t.Errorf("unexpected InheritPackages uploads. want=(%d, %d) have=(%d, %d)", 41, 42, calls[0].Arg1, calls[0].Arg2)
+	}
+}
+
+func TestWriteDataGenerationLimit(t *testing.T) {
+	mockLSIFStore := NewMockLSIFStore()
+	mockLSIFStore.TransactFunc.SetDefaultReturn(mockLSIFStore, nil)
+	mockLSIFStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	mockLSIFStore.ResultChunkGenerationsFunc.SetDefaultReturn(make([]lsifstore.ResultChunkGeneration, maxResultChunkGenerations), nil)
+
+	parent := &parentUpload{upload: dbstore.Upload{ID: 41}}
+	err := writeData(context.Background(), mockLSIFStore, dbstore.Upload{ID: 42}, parent, &types.Repo{ID: 50}, true, &precise.GroupedBundleDataChans{}, func(fields ...log.Field) {})
+	if err == nil {
+		t.Fatalf("unexpected nil error writing data")
+	} else if !strings.Contains(err.Error(), "a full upload is required") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls := mockLSIFStore.WriteMetaFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected number of WriteMeta calls. want=%d have=%d", 0, len(calls))
+	}
+}
+
 func TestHandleError(t *testing.T) {
 	setupRepoMocks(t)
 
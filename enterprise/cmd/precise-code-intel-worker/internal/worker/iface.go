@@ -28,6 +28,8 @@ type DBStore interface {
 	DeleteOverlappingDumps(ctx context.Context, repositoryID int, commit, root, indexer string) error
 	InsertDependencySyncingJob(ctx context.Context, uploadID int) (jobID int, err error)
 	UpdateCommitedAt(ctx context.Context, dumpID int, committedAt time.Time) error
+	GetUploadByID(ctx context.Context, id int) (dbstore.Upload, bool, error)
+	InheritPackages(ctx context.Context, parentUploadID, uploadID int) error
 }
 
 type DBStoreShim struct {
@@ -61,6 +63,11 @@ type LSIFStore interface {
 	WriteDocumentationPathInfo(ctx context.Context, bundleID int, documentation chan *precise.DocumentationPathInfoData) (count uint32, err error)
 	WriteDocumentationMappings(ctx context.Context, bundleID int, mappings chan precise.DocumentationMapping) (count uint32, err error)
 	WriteDocumentationSearchPrework(ctx context.Context, upload dbstore.Upload, repo *types.Repo, isDefaultBranch bool) (int, int, error)
+	ResultChunkGenerations(ctx context.Context, bundleID int) ([]lsifstore.ResultChunkGeneration, error)
+	WriteResultChunkGenerations(ctx context.Context, bundleID int, generations []lsifstore.ResultChunkGeneration) error
+	CopyDocuments(ctx context.Context, sourceBundleID, targetBundleID int, excludedPaths []string) (count uint32, err error)
+	CopyResultChunks(ctx context.Context, sourceBundleID, targetBundleID int) (count uint32, err error)
+	CopyMonikerLocations(ctx context.Context, tableName string, sourceBundleID, targetBundleID int, replacedPaths []string) (count uint32, err error)
 }
 
 type LSIFStoreShim struct {
@@ -81,4 +88,5 @@ type GitserverClient interface {
 	CommitDate(ctx context.Context, repositoryID int, commit string) (string, time.Time, bool, error)
 	ResolveRevision(ctx context.Context, repositoryID int, versionString string) (api.CommitID, error)
 	DefaultBranchContains(ctx context.Context, repositoryID int, commit string) (bool, error)
+	ChangedFiles(ctx context.Context, repositoryID int, fromCommit, toCommit string) ([]string, error)
 }
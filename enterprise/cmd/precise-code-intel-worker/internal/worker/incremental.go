@@ -0,0 +1,240 @@
+package worker
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// parentUpload describes the completed upload on which an incremental upload is based, along with
+// the paths of the parent's documents that are no longer valid at the commit of the incremental
+// upload.
+type parentUpload struct {
+	upload       store.Upload
+	changedPaths []string
+}
+
+// resolveParentUpload returns the parent of the given incremental upload and the root-relative paths
+// of the files that changed between the commits of the parent and the given upload. This method
+// returns nil for uploads that do not declare a parent.
+func resolveParentUpload(ctx context.Context, dbStore DBStore, gitserverClient GitserverClient, upload store.Upload) (*parentUpload, error) {
+	if upload.ParentUploadID == nil {
+		return nil, nil
+	}
+
+	// The parent upload was visible to the user that submitted this upload when it was enqueued
+	// (see the upload handler). We look it up with an internal actor so that repository permissions
+	// do not hide it from the worker.
+	parent, exists, err := dbStore.GetUploadByID(actor.WithInternalActor(ctx), *upload.ParentUploadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.GetUploadByID")
+	}
+	if !exists || parent.State != "completed" {
+		return nil, errors.Errorf("parent upload %d does not exist or has been deleted; a full upload is required", *upload.ParentUploadID)
+	}
+	if parent.RepositoryID != upload.RepositoryID || parent.Root != upload.Root || parent.Indexer != upload.Indexer {
+		return nil, errors.Errorf("parent upload %d does not share the repository, root, and indexer of this upload", parent.ID)
+	}
+
+	paths, err := gitserverClient.ChangedFiles(ctx, upload.RepositoryID, parent.Commit, upload.Commit)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserverClient.ChangedFiles")
+	}
+
+	return &parentUpload{
+		upload:       parent,
+		changedPaths: rootRelativePaths(paths, upload.Root),
+	}, nil
+}
+
+// rootRelativePaths returns the given repository-relative paths that lie within the given root,
+// relative to that root.
+func rootRelativePaths(paths []string, root string) []string {
+	relativePaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if strings.HasPrefix(path, root) {
+			relativePaths = append(relativePaths, strings.TrimPrefix(path, root))
+		}
+	}
+
+	return relativePaths
+}
+
+// idShifter rewrites the range and result set identifiers of documents and result chunks so that
+// they do not collide with the identifiers of the documents and result chunks copied from a parent
+// upload. It also records the largest (unshifted) identifier and the paths of the documents it has
+// seen so that the generation written by this upload can be described once all data has been written.
+//
+// Hover, documentation, moniker, and package information identifiers are only referenced from within
+// the same document and do not need to be rewritten.
+type idShifter struct {
+	idOffset          int
+	resultChunkOffset int
+
+	m     sync.Mutex
+	maxID int
+	paths []string
+	err   error
+}
+
+func newIDShifter(idOffset, resultChunkOffset int) *idShifter {
+	return &idShifter{
+		idOffset:          idOffset,
+		resultChunkOffset: resultChunkOffset,
+	}
+}
+
+// generation returns the result chunk generation describing the data written through this shifter.
+// This method returns an error if an identifier could not be rewritten.
+func (s *idShifter) generation(numResultChunks int) (lsifstore.ResultChunkGeneration, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.err != nil {
+		return lsifstore.ResultChunkGeneration{}, s.err
+	}
+
+	return lsifstore.ResultChunkGeneration{
+		IDOffset:          s.idOffset,
+		IDBound:           s.idOffset + s.maxID + 1,
+		ResultChunkOffset: s.resultChunkOffset,
+		NumResultChunks:   numResultChunks,
+	}, nil
+}
+
+// documentPaths returns the paths of the documents written through this shifter.
+func (s *idShifter) documentPaths() []string {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return append([]string(nil), s.paths...)
+}
+
+// shiftDocuments returns a channel that mirrors the given channel with rewritten range and result
+// set identifiers.
+func (s *idShifter) shiftDocuments(documents chan precise.KeyedDocumentData) chan precise.KeyedDocumentData {
+	ch := make(chan precise.KeyedDocumentData)
+
+	go func() {
+		defer close(ch)
+
+		for document := range documents {
+			ch <- s.shiftDocument(document)
+		}
+	}()
+
+	return ch
+}
+
+// shiftResultChunks returns a channel that mirrors the given channel with rewritten range and result
+// set identifiers and result chunk indexes.
+func (s *idShifter) shiftResultChunks(resultChunks chan precise.IndexedResultChunkData) chan precise.IndexedResultChunkData {
+	ch := make(chan precise.IndexedResultChunkData)
+
+	go func() {
+		defer close(ch)
+
+		for resultChunk := range resultChunks {
+			ch <- s.shiftResultChunk(resultChunk)
+		}
+	}()
+
+	return ch
+}
+
+func (s *idShifter) shiftDocument(document precise.KeyedDocumentData) precise.KeyedDocumentData {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.paths = append(s.paths, document.Path)
+
+	ranges := make(map[precise.ID]precise.RangeData, len(document.Document.Ranges))
+	for id, r := range document.Document.Ranges {
+		r.DefinitionResultID = s.shiftID(r.DefinitionResultID)
+		r.ReferenceResultID = s.shiftID(r.ReferenceResultID)
+		r.ImplementationResultID = s.shiftID(r.ImplementationResultID)
+		r.TypeDefinitionResultID = s.shiftID(r.TypeDefinitionResultID)
+		ranges[s.shiftID(id)] = r
+	}
+	document.Document.Ranges = ranges
+
+	return document
+}
+
+func (s *idShifter) shiftResultChunk(resultChunk precise.IndexedResultChunkData) precise.IndexedResultChunkData {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	documentIDRangeIDs := make(map[precise.ID][]precise.DocumentIDRangeID, len(resultChunk.ResultChunk.DocumentIDRangeIDs))
+	for id, pairs := range resultChunk.ResultChunk.DocumentIDRangeIDs {
+		shiftedPairs := make([]precise.DocumentIDRangeID, 0, len(pairs))
+		for _, pair := range pairs {
+			shiftedPairs = append(shiftedPairs, precise.DocumentIDRangeID{
+				DocumentID: pair.DocumentID,
+				RangeID:    s.shiftID(pair.RangeID),
+			})
+		}
+
+		documentIDRangeIDs[s.shiftID(id)] = shiftedPairs
+	}
+
+	return precise.IndexedResultChunkData{
+		Index: s.resultChunkOffset + resultChunk.Index,
+		ResultChunk: precise.ResultChunkData{
+			DocumentPaths:      resultChunk.ResultChunk.DocumentPaths,
+			DocumentIDRangeIDs: documentIDRangeIDs,
+		},
+	}
+}
+
+// shiftID returns the given identifier increased by the identifier offset. Empty identifiers denote
+// the absence of a value and are returned unchanged. This method must be called while holding the
+// shifter's lock.
+func (s *idShifter) shiftID(id precise.ID) precise.ID {
+	if id == "" {
+		return id
+	}
+
+	value, err := strconv.Atoi(string(id))
+	if err != nil {
+		// Identifiers are always integers after correlation
+		if s.err == nil {
+			s.err = errors.Errorf("unexpected non-integer identifier %q", id)
+		}
+		return id
+	}
+	if value > s.maxID {
+		s.maxID = value
+	}
+
+	return precise.ID(strconv.Itoa(s.idOffset + value))
+}
+
+// replacedPaths returns the paths of the parent's documents whose data must not be copied into the
+// incremental upload: those changed between the two commits and those supplied by the upload itself.
+func replacedPaths(changedPaths, documentPaths []string) []string {
+	pathMap := make(map[string]struct{}, len(changedPaths)+len(documentPaths))
+	for _, path := range changedPaths {
+		pathMap[path] = struct{}{}
+	}
+	for _, path := range documentPaths {
+		pathMap[path] = struct{}{}
+	}
+
+	paths := make([]string, 0, len(pathMap))
+	for path := range pathMap {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
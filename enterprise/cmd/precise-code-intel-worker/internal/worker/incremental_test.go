@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestIDShifter(t *testing.T) {
+	shifter := newIDShifter(100, 3)
+
+	document := shifter.shiftDocument(precise.KeyedDocumentData{
+		Path: "foo.go",
+		Document: precise.DocumentData{
+			Ranges: map[precise.ID]precise.RangeData{
+				"1": {DefinitionResultID: "7", ReferenceResultID: "8", HoverResultID: "9", MonikerIDs: []precise.ID{"10"}},
+				"2": {ReferenceResultID: "8"},
+			},
+		},
+	})
+	expectedDocument := precise.KeyedDocumentData{
+		Path: "foo.go",
+		Document: precise.DocumentData{
+			Ranges: map[precise.ID]precise.RangeData{
+				"101": {DefinitionResultID: "107", ReferenceResultID: "108", HoverResultID: "9", MonikerIDs: []precise.ID{"10"}},
+				"102": {ReferenceResultID: "108"},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDocument, document); diff != "" {
+		t.Errorf("unexpected document (-want +got):\n%s", diff)
+	}
+
+	resultChunk := shifter.shiftResultChunk(precise.IndexedResultChunkData{
+		Index: 1,
+		ResultChunk: precise.ResultChunkData{
+			DocumentPaths:      map[precise.ID]string{"3": "foo.go"},
+			DocumentIDRangeIDs: map[precise.ID][]precise.DocumentIDRangeID{"8": {{DocumentID: "3", RangeID: "1"}, {DocumentID: "3", RangeID: "2"}}},
+		},
+	})
+	expectedResultChunk := precise.IndexedResultChunkData{
+		Index: 4,
+		ResultChunk: precise.ResultChunkData{
+			DocumentPaths:      map[precise.ID]string{"3": "foo.go"},
+			DocumentIDRangeIDs: map[precise.ID][]precise.DocumentIDRangeID{"108": {{DocumentID: "3", RangeID: "101"}, {DocumentID: "3", RangeID: "102"}}},
+		},
+	}
+	if diff := cmp.Diff(expectedResultChunk, resultChunk); diff != "" {
+		t.Errorf("unexpected result chunk (-want +got):\n%s", diff)
+	}
+
+	generation, err := shifter.generation(2)
+	if err != nil {
+		t.Fatalf("unexpected error describing generation: %s", err)
+	}
+	expectedGeneration := lsifstore.ResultChunkGeneration{IDOffset: 100, IDBound: 109, ResultChunkOffset: 3, NumResultChunks: 2}
+	if diff := cmp.Diff(expectedGeneration, generation); diff != "" {
+		t.Errorf("unexpected generation (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"foo.go"}, shifter.documentPaths()); diff != "" {
+		t.Errorf("unexpected document paths (-want +got):\n%s", diff)
+	}
+}
+
+func TestReplacedPaths(t *testing.T) {
+	changedPaths := rootRelativePaths([]string{"root/a.go", "root/sub/b.go", "other/c.go"}, "root/")
+	if diff := cmp.Diff([]string{"a.go", "sub/b.go"}, changedPaths); diff != "" {
+		t.Errorf("unexpected changed paths (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"a.go", "d.go", "sub/b.go"}, replacedPaths(changedPaths, []string{"d.go", "a.go"})); diff != "" {
+		t.Errorf("unexpected replaced paths (-want +got):\n%s", diff)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	dbstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	lsifstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	types "github.com/sourcegraph/sourcegraph/internal/types"
@@ -25,9 +26,15 @@ type MockDBStore struct {
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *DBStoreDoneFunc
+	// GetUploadByIDFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadByID.
+	GetUploadByIDFunc *DBStoreGetUploadByIDFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *DBStoreHandleFunc
+	// InheritPackagesFunc is an instance of a mock function object
+	// controlling the behavior of the method InheritPackages.
+	InheritPackagesFunc *DBStoreInheritPackagesFunc
 	// InsertDependencySyncingJobFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// InsertDependencySyncingJob.
@@ -72,11 +79,21 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		GetUploadByIDFunc: &DBStoreGetUploadByIDFunc{
+			defaultHook: func(context.Context, int) (dbstore.Upload, bool, error) {
+				return dbstore.Upload{}, false, nil
+			},
+		},
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
 				return nil
 			},
 		},
+		InheritPackagesFunc: &DBStoreInheritPackagesFunc{
+			defaultHook: func(context.Context, int, int) error {
+				return nil
+			},
+		},
 		InsertDependencySyncingJobFunc: &DBStoreInsertDependencySyncingJobFunc{
 			defaultHook: func(context.Context, int) (int, error) {
 				return 0, nil
@@ -139,11 +156,21 @@ func NewStrictMockDBStore() *MockDBStore {
 				panic("unexpected invocation of MockDBStore.Done")
 			},
 		},
+		GetUploadByIDFunc: &DBStoreGetUploadByIDFunc{
+			defaultHook: func(context.Context, int) (dbstore.Upload, bool, error) {
+				panic("unexpected invocation of MockDBStore.GetUploadByID")
+			},
+		},
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
 				panic("unexpected invocation of MockDBStore.Handle")
 			},
 		},
+		InheritPackagesFunc: &DBStoreInheritPackagesFunc{
+			defaultHook: func(context.Context, int, int) error {
+				panic("unexpected invocation of MockDBStore.InheritPackages")
+			},
+		},
 		InsertDependencySyncingJobFunc: &DBStoreInsertDependencySyncingJobFunc{
 			defaultHook: func(context.Context, int) (int, error) {
 				panic("unexpected invocation of MockDBStore.InsertDependencySyncingJob")
@@ -202,9 +229,15 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		DoneFunc: &DBStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		GetUploadByIDFunc: &DBStoreGetUploadByIDFunc{
+			defaultHook: i.GetUploadByID,
+		},
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
+		InheritPackagesFunc: &DBStoreInheritPackagesFunc{
+			defaultHook: i.InheritPackages,
+		},
 		InsertDependencySyncingJobFunc: &DBStoreInsertDependencySyncingJobFunc{
 			defaultHook: i.InsertDependencySyncingJob,
 		},
@@ -454,6 +487,118 @@ func (c DBStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBStoreGetUploadByIDFunc describes the behavior when the GetUploadByID
+// method of the parent MockDBStore instance is invoked.
+type DBStoreGetUploadByIDFunc struct {
+	defaultHook func(context.Context, int) (dbstore.Upload, bool, error)
+	hooks       []func(context.Context, int) (dbstore.Upload, bool, error)
+	history     []DBStoreGetUploadByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadByID delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDBStore) GetUploadByID(v0 context.Context, v1 int) (dbstore.Upload, bool, error) {
+	r0, r1, r2 := m.GetUploadByIDFunc.nextHook()(v0, v1)
+	m.GetUploadByIDFunc.appendCall(DBStoreGetUploadByIDFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetUploadByID method
+// of the parent MockDBStore instance is invoked and the hook queue is
+// empty.
+func (f *DBStoreGetUploadByIDFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.Upload, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadByID method of the parent MockDBStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBStoreGetUploadByIDFunc) PushHook(hook func(context.Context, int) (dbstore.Upload, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreGetUploadByIDFunc) SetDefaultReturn(r0 dbstore.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreGetUploadByIDFunc) PushReturn(r0 dbstore.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (dbstore.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DBStoreGetUploadByIDFunc) nextHook() func(context.Context, int) (dbstore.Upload, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreGetUploadByIDFunc) appendCall(r0 DBStoreGetUploadByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreGetUploadByIDFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetUploadByIDFunc) History() []DBStoreGetUploadByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreGetUploadByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreGetUploadByIDFuncCall is an object that describes an invocation of
+// method GetUploadByID on an instance of MockDBStore.
+type DBStoreGetUploadByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreGetUploadByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreGetUploadByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // DBStoreHandleFunc describes the behavior when the Handle method of the
 // parent MockDBStore instance is invoked.
 type DBStoreHandleFunc struct {
@@ -553,6 +698,115 @@ func (c DBStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBStoreInheritPackagesFunc describes the behavior when the
+// InheritPackages method of the parent MockDBStore instance is invoked.
+type DBStoreInheritPackagesFunc struct {
+	defaultHook func(context.Context, int, int) error
+	hooks       []func(context.Context, int, int) error
+	history     []DBStoreInheritPackagesFuncCall
+	mutex       sync.Mutex
+}
+
+// InheritPackages delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) InheritPackages(v0 context.Context, v1 int, v2 int) error {
+	r0 := m.InheritPackagesFunc.nextHook()(v0, v1, v2)
+	m.InheritPackagesFunc.appendCall(DBStoreInheritPackagesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the InheritPackages
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreInheritPackagesFunc) SetDefaultHook(hook func(context.Context, int, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InheritPackages method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreInheritPackagesFunc) PushHook(hook func(context.Context, int, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreInheritPackagesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreInheritPackagesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int) error {
+		return r0
+	})
+}
+
+func (f *DBStoreInheritPackagesFunc) nextHook() func(context.Context, int, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreInheritPackagesFunc) appendCall(r0 DBStoreInheritPackagesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreInheritPackagesFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreInheritPackagesFunc) History() []DBStoreInheritPackagesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreInheritPackagesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreInheritPackagesFuncCall is an object that describes an invocation
+// of method InheritPackages on an instance of MockDBStore.
+type DBStoreInheritPackagesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreInheritPackagesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreInheritPackagesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBStoreInsertDependencySyncingJobFunc describes the behavior when the
 // InsertDependencySyncingJob method of the parent MockDBStore instance is
 // invoked.
@@ -1534,6 +1788,9 @@ func (c DBStoreWithFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/worker)
 // used for unit testing.
 type MockGitserverClient struct {
+	// ChangedFilesFunc is an instance of a mock function object controlling
+	// the behavior of the method ChangedFiles.
+	ChangedFilesFunc *GitserverClientChangedFilesFunc
 	// CommitDateFunc is an instance of a mock function object controlling
 	// the behavior of the method CommitDate.
 	CommitDateFunc *GitserverClientCommitDateFunc
@@ -1553,6 +1810,11 @@ type MockGitserverClient struct {
 // overwritten.
 func NewMockGitserverClient() *MockGitserverClient {
 	return &MockGitserverClient{
+		ChangedFilesFunc: &GitserverClientChangedFilesFunc{
+			defaultHook: func(context.Context, int, string, string) ([]string, error) {
+				return nil, nil
+			},
+		},
 		CommitDateFunc: &GitserverClientCommitDateFunc{
 			defaultHook: func(context.Context, int, string) (string, time.Time, bool, error) {
 				return "", time.Time{}, false, nil
@@ -1580,6 +1842,11 @@ func NewMockGitserverClient() *MockGitserverClient {
 // interface. All methods panic on invocation, unless overwritten.
 func NewStrictMockGitserverClient() *MockGitserverClient {
 	return &MockGitserverClient{
+		ChangedFilesFunc: &GitserverClientChangedFilesFunc{
+			defaultHook: func(context.Context, int, string, string) ([]string, error) {
+				panic("unexpected invocation of MockGitserverClient.ChangedFiles")
+			},
+		},
 		CommitDateFunc: &GitserverClientCommitDateFunc{
 			defaultHook: func(context.Context, int, string) (string, time.Time, bool, error) {
 				panic("unexpected invocation of MockGitserverClient.CommitDate")
@@ -1608,6 +1875,9 @@ func NewStrictMockGitserverClient() *MockGitserverClient {
 // overwritten.
 func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
 	return &MockGitserverClient{
+		ChangedFilesFunc: &GitserverClientChangedFilesFunc{
+			defaultHook: i.ChangedFiles,
+		},
 		CommitDateFunc: &GitserverClientCommitDateFunc{
 			defaultHook: i.CommitDate,
 		},
@@ -1623,35 +1893,36 @@ func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
 	}
 }
 
-// GitserverClientCommitDateFunc describes the behavior when the CommitDate
-// method of the parent MockGitserverClient instance is invoked.
-type GitserverClientCommitDateFunc struct {
-	defaultHook func(context.Context, int, string) (string, time.Time, bool, error)
-	hooks       []func(context.Context, int, string) (string, time.Time, bool, error)
-	history     []GitserverClientCommitDateFuncCall
+// GitserverClientChangedFilesFunc describes the behavior when the
+// ChangedFiles method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientChangedFilesFunc struct {
+	defaultHook func(context.Context, int, string, string) ([]string, error)
+	hooks       []func(context.Context, int, string, string) ([]string, error)
+	history     []GitserverClientChangedFilesFuncCall
 	mutex       sync.Mutex
 }
 
-// CommitDate delegates to the next hook function in the queue and stores
+// ChangedFiles delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockGitserverClient) CommitDate(v0 context.Context, v1 int, v2 string) (string, time.Time, bool, error) {
-	r0, r1, r2, r3 := m.CommitDateFunc.nextHook()(v0, v1, v2)
-	m.CommitDateFunc.appendCall(GitserverClientCommitDateFuncCall{v0, v1, v2, r0, r1, r2, r3})
-	return r0, r1, r2, r3
+func (m *MockGitserverClient) ChangedFiles(v0 context.Context, v1 int, v2 string, v3 string) ([]string, error) {
+	r0, r1 := m.ChangedFilesFunc.nextHook()(v0, v1, v2, v3)
+	m.ChangedFilesFunc.appendCall(GitserverClientChangedFilesFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CommitDate method of
-// the parent MockGitserverClient instance is invoked and the hook queue is
-// empty.
-func (f *GitserverClientCommitDateFunc) SetDefaultHook(hook func(context.Context, int, string) (string, time.Time, bool, error)) {
+// SetDefaultHook sets function that is called when the ChangedFiles method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientChangedFilesFunc) SetDefaultHook(hook func(context.Context, int, string, string) ([]string, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CommitDate method of the parent MockGitserverClient instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *GitserverClientCommitDateFunc) PushHook(hook func(context.Context, int, string) (string, time.Time, bool, error)) {
+// ChangedFiles method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientChangedFilesFunc) PushHook(hook func(context.Context, int, string, string) ([]string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1659,21 +1930,21 @@ func (f *GitserverClientCommitDateFunc) PushHook(hook func(context.Context, int,
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *GitserverClientCommitDateFunc) SetDefaultReturn(r0 string, r1 time.Time, r2 bool, r3 error) {
-	f.SetDefaultHook(func(context.Context, int, string) (string, time.Time, bool, error) {
-		return r0, r1, r2, r3
+func (f *GitserverClientChangedFilesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string) ([]string, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *GitserverClientCommitDateFunc) PushReturn(r0 string, r1 time.Time, r2 bool, r3 error) {
-	f.PushHook(func(context.Context, int, string) (string, time.Time, bool, error) {
-		return r0, r1, r2, r3
+func (f *GitserverClientChangedFilesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int, string, string) ([]string, error) {
+		return r0, r1
 	})
 }
 
-func (f *GitserverClientCommitDateFunc) nextHook() func(context.Context, int, string) (string, time.Time, bool, error) {
+func (f *GitserverClientChangedFilesFunc) nextHook() func(context.Context, int, string, string) ([]string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1686,26 +1957,26 @@ func (f *GitserverClientCommitDateFunc) nextHook() func(context.Context, int, st
 	return hook
 }
 
-func (f *GitserverClientCommitDateFunc) appendCall(r0 GitserverClientCommitDateFuncCall) {
+func (f *GitserverClientChangedFilesFunc) appendCall(r0 GitserverClientChangedFilesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of GitserverClientCommitDateFuncCall objects
+// History returns a sequence of GitserverClientChangedFilesFuncCall objects
 // describing the invocations of this function.
-func (f *GitserverClientCommitDateFunc) History() []GitserverClientCommitDateFuncCall {
+func (f *GitserverClientChangedFilesFunc) History() []GitserverClientChangedFilesFuncCall {
 	f.mutex.Lock()
-	history := make([]GitserverClientCommitDateFuncCall, len(f.history))
+	history := make([]GitserverClientChangedFilesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// GitserverClientCommitDateFuncCall is an object that describes an
-// invocation of method CommitDate on an instance of MockGitserverClient.
-type GitserverClientCommitDateFuncCall struct {
+// GitserverClientChangedFilesFuncCall is an object that describes an
+// invocation of method ChangedFiles on an instance of MockGitserverClient.
+type GitserverClientChangedFilesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -1715,48 +1986,163 @@ type GitserverClientCommitDateFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
+	Result0 []string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 time.Time
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 bool
-	// Result3 is the value of the 4th result returned from this method
-	// invocation.
-	Result3 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitserverClientCommitDateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c GitserverClientChangedFilesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitserverClientCommitDateFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+func (c GitserverClientChangedFilesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// GitserverClientDefaultBranchContainsFunc describes the behavior when the
-// DefaultBranchContains method of the parent MockGitserverClient instance
-// is invoked.
-type GitserverClientDefaultBranchContainsFunc struct {
-	defaultHook func(context.Context, int, string) (bool, error)
-	hooks       []func(context.Context, int, string) (bool, error)
-	history     []GitserverClientDefaultBranchContainsFuncCall
+// GitserverClientCommitDateFunc describes the behavior when the CommitDate
+// method of the parent MockGitserverClient instance is invoked.
+type GitserverClientCommitDateFunc struct {
+	defaultHook func(context.Context, int, string) (string, time.Time, bool, error)
+	hooks       []func(context.Context, int, string) (string, time.Time, bool, error)
+	history     []GitserverClientCommitDateFuncCall
 	mutex       sync.Mutex
 }
 
-// DefaultBranchContains delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockGitserverClient) DefaultBranchContains(v0 context.Context, v1 int, v2 string) (bool, error) {
-	r0, r1 := m.DefaultBranchContainsFunc.nextHook()(v0, v1, v2)
-	m.DefaultBranchContainsFunc.appendCall(GitserverClientDefaultBranchContainsFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+// CommitDate delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) CommitDate(v0 context.Context, v1 int, v2 string) (string, time.Time, bool, error) {
+	r0, r1, r2, r3 := m.CommitDateFunc.nextHook()(v0, v1, v2)
+	m.CommitDateFunc.appendCall(GitserverClientCommitDateFuncCall{v0, v1, v2, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the CommitDate method of
+// the parent MockGitserverClient instance is invoked and the hook queue is
+// empty.
+func (f *GitserverClientCommitDateFunc) SetDefaultHook(hook func(context.Context, int, string) (string, time.Time, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitDate method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientCommitDateFunc) PushHook(hook func(context.Context, int, string) (string, time.Time, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *GitserverClientCommitDateFunc) SetDefaultReturn(r0 string, r1 time.Time, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, int, string) (string, time.Time, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *GitserverClientCommitDateFunc) PushReturn(r0 string, r1 time.Time, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, int, string) (string, time.Time, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *GitserverClientCommitDateFunc) nextHook() func(context.Context, int, string) (string, time.Time, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientCommitDateFunc) appendCall(r0 GitserverClientCommitDateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientCommitDateFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientCommitDateFunc) History() []GitserverClientCommitDateFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientCommitDateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientCommitDateFuncCall is an object that describes an
+// invocation of method CommitDate on an instance of MockGitserverClient.
+type GitserverClientCommitDateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 time.Time
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientCommitDateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientCommitDateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// GitserverClientDefaultBranchContainsFunc describes the behavior when the
+// DefaultBranchContains method of the parent MockGitserverClient instance
+// is invoked.
+type GitserverClientDefaultBranchContainsFunc struct {
+	defaultHook func(context.Context, int, string) (bool, error)
+	hooks       []func(context.Context, int, string) (bool, error)
+	history     []GitserverClientDefaultBranchContainsFuncCall
+	mutex       sync.Mutex
+}
+
+// DefaultBranchContains delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockGitserverClient) DefaultBranchContains(v0 context.Context, v1 int, v2 string) (bool, error) {
+	r0, r1 := m.DefaultBranchContainsFunc.nextHook()(v0, v1, v2)
+	m.DefaultBranchContainsFunc.appendCall(GitserverClientDefaultBranchContainsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
@@ -2094,9 +2480,21 @@ func (c GitserverClientResolveRevisionFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/worker)
 // used for unit testing.
 type MockLSIFStore struct {
+	// CopyDocumentsFunc is an instance of a mock function object
+	// controlling the behavior of the method CopyDocuments.
+	CopyDocumentsFunc *LSIFStoreCopyDocumentsFunc
+	// CopyMonikerLocationsFunc is an instance of a mock function object
+	// controlling the behavior of the method CopyMonikerLocations.
+	CopyMonikerLocationsFunc *LSIFStoreCopyMonikerLocationsFunc
+	// CopyResultChunksFunc is an instance of a mock function object
+	// controlling the behavior of the method CopyResultChunks.
+	CopyResultChunksFunc *LSIFStoreCopyResultChunksFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *LSIFStoreDoneFunc
+	// ResultChunkGenerationsFunc is an instance of a mock function object
+	// controlling the behavior of the method ResultChunkGenerations.
+	ResultChunkGenerationsFunc *LSIFStoreResultChunkGenerationsFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *LSIFStoreTransactFunc
@@ -2130,6 +2528,10 @@ type MockLSIFStore struct {
 	// WriteReferencesFunc is an instance of a mock function object
 	// controlling the behavior of the method WriteReferences.
 	WriteReferencesFunc *LSIFStoreWriteReferencesFunc
+	// WriteResultChunkGenerationsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// WriteResultChunkGenerations.
+	WriteResultChunkGenerationsFunc *LSIFStoreWriteResultChunkGenerationsFunc
 	// WriteResultChunksFunc is an instance of a mock function object
 	// controlling the behavior of the method WriteResultChunks.
 	WriteResultChunksFunc *LSIFStoreWriteResultChunksFunc
@@ -2139,11 +2541,31 @@ type MockLSIFStore struct {
 // methods return zero values for all results, unless overwritten.
 func NewMockLSIFStore() *MockLSIFStore {
 	return &MockLSIFStore{
+		CopyDocumentsFunc: &LSIFStoreCopyDocumentsFunc{
+			defaultHook: func(context.Context, int, int, []string) (uint32, error) {
+				return 0, nil
+			},
+		},
+		CopyMonikerLocationsFunc: &LSIFStoreCopyMonikerLocationsFunc{
+			defaultHook: func(context.Context, string, int, int, []string) (uint32, error) {
+				return 0, nil
+			},
+		},
+		CopyResultChunksFunc: &LSIFStoreCopyResultChunksFunc{
+			defaultHook: func(context.Context, int, int) (uint32, error) {
+				return 0, nil
+			},
+		},
 		DoneFunc: &LSIFStoreDoneFunc{
 			defaultHook: func(error) error {
 				return nil
 			},
 		},
+		ResultChunkGenerationsFunc: &LSIFStoreResultChunkGenerationsFunc{
+			defaultHook: func(context.Context, int) ([]lsifstore.ResultChunkGeneration, error) {
+				return nil, nil
+			},
+		},
 		TransactFunc: &LSIFStoreTransactFunc{
 			defaultHook: func(context.Context) (LSIFStore, error) {
 				return nil, nil
@@ -2194,6 +2616,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return 0, nil
 			},
 		},
+		WriteResultChunkGenerationsFunc: &LSIFStoreWriteResultChunkGenerationsFunc{
+			defaultHook: func(context.Context, int, []lsifstore.ResultChunkGeneration) error {
+				return nil
+			},
+		},
 		WriteResultChunksFunc: &LSIFStoreWriteResultChunksFunc{
 			defaultHook: func(context.Context, int, chan precise.IndexedResultChunkData) (uint32, error) {
 				return 0, nil
@@ -2206,11 +2633,31 @@ func NewMockLSIFStore() *MockLSIFStore {
 // methods panic on invocation, unless overwritten.
 func NewStrictMockLSIFStore() *MockLSIFStore {
 	return &MockLSIFStore{
+		CopyDocumentsFunc: &LSIFStoreCopyDocumentsFunc{
+			defaultHook: func(context.Context, int, int, []string) (uint32, error) {
+				panic("unexpected invocation of MockLSIFStore.CopyDocuments")
+			},
+		},
+		CopyMonikerLocationsFunc: &LSIFStoreCopyMonikerLocationsFunc{
+			defaultHook: func(context.Context, string, int, int, []string) (uint32, error) {
+				panic("unexpected invocation of MockLSIFStore.CopyMonikerLocations")
+			},
+		},
+		CopyResultChunksFunc: &LSIFStoreCopyResultChunksFunc{
+			defaultHook: func(context.Context, int, int) (uint32, error) {
+				panic("unexpected invocation of MockLSIFStore.CopyResultChunks")
+			},
+		},
 		DoneFunc: &LSIFStoreDoneFunc{
 			defaultHook: func(error) error {
 				panic("unexpected invocation of MockLSIFStore.Done")
 			},
 		},
+		ResultChunkGenerationsFunc: &LSIFStoreResultChunkGenerationsFunc{
+			defaultHook: func(context.Context, int) ([]lsifstore.ResultChunkGeneration, error) {
+				panic("unexpected invocation of MockLSIFStore.ResultChunkGenerations")
+			},
+		},
 		TransactFunc: &LSIFStoreTransactFunc{
 			defaultHook: func(context.Context) (LSIFStore, error) {
 				panic("unexpected invocation of MockLSIFStore.Transact")
@@ -2261,6 +2708,11 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.WriteReferences")
 			},
 		},
+		WriteResultChunkGenerationsFunc: &LSIFStoreWriteResultChunkGenerationsFunc{
+			defaultHook: func(context.Context, int, []lsifstore.ResultChunkGeneration) error {
+				panic("unexpected invocation of MockLSIFStore.WriteResultChunkGenerations")
+			},
+		},
 		WriteResultChunksFunc: &LSIFStoreWriteResultChunksFunc{
 			defaultHook: func(context.Context, int, chan precise.IndexedResultChunkData) (uint32, error) {
 				panic("unexpected invocation of MockLSIFStore.WriteResultChunks")
@@ -2269,47 +2721,409 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 	}
 }
 
-// NewMockLSIFStoreFrom creates a new mock of the MockLSIFStore interface.
-// All methods delegate to the given implementation, unless overwritten.
-func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
-	return &MockLSIFStore{
-		DoneFunc: &LSIFStoreDoneFunc{
-			defaultHook: i.Done,
-		},
-		TransactFunc: &LSIFStoreTransactFunc{
-			defaultHook: i.Transact,
-		},
-		WriteDefinitionsFunc: &LSIFStoreWriteDefinitionsFunc{
-			defaultHook: i.WriteDefinitions,
-		},
-		WriteDocumentationMappingsFunc: &LSIFStoreWriteDocumentationMappingsFunc{
-			defaultHook: i.WriteDocumentationMappings,
-		},
-		WriteDocumentationPagesFunc: &LSIFStoreWriteDocumentationPagesFunc{
-			defaultHook: i.WriteDocumentationPages,
-		},
-		WriteDocumentationPathInfoFunc: &LSIFStoreWriteDocumentationPathInfoFunc{
-			defaultHook: i.WriteDocumentationPathInfo,
-		},
-		WriteDocumentationSearchPreworkFunc: &LSIFStoreWriteDocumentationSearchPreworkFunc{
-			defaultHook: i.WriteDocumentationSearchPrework,
-		},
-		WriteDocumentsFunc: &LSIFStoreWriteDocumentsFunc{
-			defaultHook: i.WriteDocuments,
-		},
-		WriteImplementationsFunc: &LSIFStoreWriteImplementationsFunc{
-			defaultHook: i.WriteImplementations,
-		},
-		WriteMetaFunc: &LSIFStoreWriteMetaFunc{
-			defaultHook: i.WriteMeta,
-		},
-		WriteReferencesFunc: &LSIFStoreWriteReferencesFunc{
-			defaultHook: i.WriteReferences,
-		},
-		WriteResultChunksFunc: &LSIFStoreWriteResultChunksFunc{
-			defaultHook: i.WriteResultChunks,
-		},
+// NewMockLSIFStoreFrom creates a new mock of the MockLSIFStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
+	return &MockLSIFStore{
+		CopyDocumentsFunc: &LSIFStoreCopyDocumentsFunc{
+			defaultHook: i.CopyDocuments,
+		},
+		CopyMonikerLocationsFunc: &LSIFStoreCopyMonikerLocationsFunc{
+			defaultHook: i.CopyMonikerLocations,
+		},
+		CopyResultChunksFunc: &LSIFStoreCopyResultChunksFunc{
+			defaultHook: i.CopyResultChunks,
+		},
+		DoneFunc: &LSIFStoreDoneFunc{
+			defaultHook: i.Done,
+		},
+		ResultChunkGenerationsFunc: &LSIFStoreResultChunkGenerationsFunc{
+			defaultHook: i.ResultChunkGenerations,
+		},
+		TransactFunc: &LSIFStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		WriteDefinitionsFunc: &LSIFStoreWriteDefinitionsFunc{
+			defaultHook: i.WriteDefinitions,
+		},
+		WriteDocumentationMappingsFunc: &LSIFStoreWriteDocumentationMappingsFunc{
+			defaultHook: i.WriteDocumentationMappings,
+		},
+		WriteDocumentationPagesFunc: &LSIFStoreWriteDocumentationPagesFunc{
+			defaultHook: i.WriteDocumentationPages,
+		},
+		WriteDocumentationPathInfoFunc: &LSIFStoreWriteDocumentationPathInfoFunc{
+			defaultHook: i.WriteDocumentationPathInfo,
+		},
+		WriteDocumentationSearchPreworkFunc: &LSIFStoreWriteDocumentationSearchPreworkFunc{
+			defaultHook: i.WriteDocumentationSearchPrework,
+		},
+		WriteDocumentsFunc: &LSIFStoreWriteDocumentsFunc{
+			defaultHook: i.WriteDocuments,
+		},
+		WriteImplementationsFunc: &LSIFStoreWriteImplementationsFunc{
+			defaultHook: i.WriteImplementations,
+		},
+		WriteMetaFunc: &LSIFStoreWriteMetaFunc{
+			defaultHook: i.WriteMeta,
+		},
+		WriteReferencesFunc: &LSIFStoreWriteReferencesFunc{
+			defaultHook: i.WriteReferences,
+		},
+		WriteResultChunkGenerationsFunc: &LSIFStoreWriteResultChunkGenerationsFunc{
+			defaultHook: i.WriteResultChunkGenerations,
+		},
+		WriteResultChunksFunc: &LSIFStoreWriteResultChunksFunc{
+			defaultHook: i.WriteResultChunks,
+		},
+	}
+}
+
+// LSIFStoreCopyDocumentsFunc describes the behavior when the CopyDocuments
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreCopyDocumentsFunc struct {
+	defaultHook func(context.Context, int, int, []string) (uint32, error)
+	hooks       []func(context.Context, int, int, []string) (uint32, error)
+	history     []LSIFStoreCopyDocumentsFuncCall
+	mutex       sync.Mutex
+}
+
+// CopyDocuments delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) CopyDocuments(v0 context.Context, v1 int, v2 int, v3 []string) (uint32, error) {
+	r0, r1 := m.CopyDocumentsFunc.nextHook()(v0, v1, v2, v3)
+	m.CopyDocumentsFunc.appendCall(LSIFStoreCopyDocumentsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CopyDocuments method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreCopyDocumentsFunc) SetDefaultHook(hook func(context.Context, int, int, []string) (uint32, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CopyDocuments method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreCopyDocumentsFunc) PushHook(hook func(context.Context, int, int, []string) (uint32, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreCopyDocumentsFunc) SetDefaultReturn(r0 uint32, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int, []string) (uint32, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreCopyDocumentsFunc) PushReturn(r0 uint32, r1 error) {
+	f.PushHook(func(context.Context, int, int, []string) (uint32, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreCopyDocumentsFunc) nextHook() func(context.Context, int, int, []string) (uint32, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreCopyDocumentsFunc) appendCall(r0 LSIFStoreCopyDocumentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreCopyDocumentsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreCopyDocumentsFunc) History() []LSIFStoreCopyDocumentsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreCopyDocumentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreCopyDocumentsFuncCall is an object that describes an invocation
+// of method CopyDocuments on an instance of MockLSIFStore.
+type LSIFStoreCopyDocumentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 uint32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreCopyDocumentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreCopyDocumentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LSIFStoreCopyMonikerLocationsFunc describes the behavior when the
+// CopyMonikerLocations method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreCopyMonikerLocationsFunc struct {
+	defaultHook func(context.Context, string, int, int, []string) (uint32, error)
+	hooks       []func(context.Context, string, int, int, []string) (uint32, error)
+	history     []LSIFStoreCopyMonikerLocationsFuncCall
+	mutex       sync.Mutex
+}
+
+// CopyMonikerLocations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) CopyMonikerLocations(v0 context.Context, v1 string, v2 int, v3 int, v4 []string) (uint32, error) {
+	r0, r1 := m.CopyMonikerLocationsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CopyMonikerLocationsFunc.appendCall(LSIFStoreCopyMonikerLocationsFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CopyMonikerLocations
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreCopyMonikerLocationsFunc) SetDefaultHook(hook func(context.Context, string, int, int, []string) (uint32, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CopyMonikerLocations method of the parent MockLSIFStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LSIFStoreCopyMonikerLocationsFunc) PushHook(hook func(context.Context, string, int, int, []string) (uint32, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreCopyMonikerLocationsFunc) SetDefaultReturn(r0 uint32, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int, []string) (uint32, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreCopyMonikerLocationsFunc) PushReturn(r0 uint32, r1 error) {
+	f.PushHook(func(context.Context, string, int, int, []string) (uint32, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreCopyMonikerLocationsFunc) nextHook() func(context.Context, string, int, int, []string) (uint32, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreCopyMonikerLocationsFunc) appendCall(r0 LSIFStoreCopyMonikerLocationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreCopyMonikerLocationsFuncCall
+// objects describing the invocations of this function.
+func (f *LSIFStoreCopyMonikerLocationsFunc) History() []LSIFStoreCopyMonikerLocationsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreCopyMonikerLocationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreCopyMonikerLocationsFuncCall is an object that describes an
+// invocation of method CopyMonikerLocations on an instance of
+// MockLSIFStore.
+type LSIFStoreCopyMonikerLocationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 uint32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreCopyMonikerLocationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreCopyMonikerLocationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LSIFStoreCopyResultChunksFunc describes the behavior when the
+// CopyResultChunks method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreCopyResultChunksFunc struct {
+	defaultHook func(context.Context, int, int) (uint32, error)
+	hooks       []func(context.Context, int, int) (uint32, error)
+	history     []LSIFStoreCopyResultChunksFuncCall
+	mutex       sync.Mutex
+}
+
+// CopyResultChunks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) CopyResultChunks(v0 context.Context, v1 int, v2 int) (uint32, error) {
+	r0, r1 := m.CopyResultChunksFunc.nextHook()(v0, v1, v2)
+	m.CopyResultChunksFunc.appendCall(LSIFStoreCopyResultChunksFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CopyResultChunks
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreCopyResultChunksFunc) SetDefaultHook(hook func(context.Context, int, int) (uint32, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CopyResultChunks method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreCopyResultChunksFunc) PushHook(hook func(context.Context, int, int) (uint32, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreCopyResultChunksFunc) SetDefaultReturn(r0 uint32, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int) (uint32, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreCopyResultChunksFunc) PushReturn(r0 uint32, r1 error) {
+	f.PushHook(func(context.Context, int, int) (uint32, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreCopyResultChunksFunc) nextHook() func(context.Context, int, int) (uint32, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
 	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreCopyResultChunksFunc) appendCall(r0 LSIFStoreCopyResultChunksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreCopyResultChunksFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreCopyResultChunksFunc) History() []LSIFStoreCopyResultChunksFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreCopyResultChunksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreCopyResultChunksFuncCall is an object that describes an
+// invocation of method CopyResultChunks on an instance of MockLSIFStore.
+type LSIFStoreCopyResultChunksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 uint32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreCopyResultChunksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreCopyResultChunksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
 // LSIFStoreDoneFunc describes the behavior when the Done method of the
@@ -2414,6 +3228,118 @@ func (c LSIFStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// LSIFStoreResultChunkGenerationsFunc describes the behavior when the
+// ResultChunkGenerations method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreResultChunkGenerationsFunc struct {
+	defaultHook func(context.Context, int) ([]lsifstore.ResultChunkGeneration, error)
+	hooks       []func(context.Context, int) ([]lsifstore.ResultChunkGeneration, error)
+	history     []LSIFStoreResultChunkGenerationsFuncCall
+	mutex       sync.Mutex
+}
+
+// ResultChunkGenerations delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) ResultChunkGenerations(v0 context.Context, v1 int) ([]lsifstore.ResultChunkGeneration, error) {
+	r0, r1 := m.ResultChunkGenerationsFunc.nextHook()(v0, v1)
+	m.ResultChunkGenerationsFunc.appendCall(LSIFStoreResultChunkGenerationsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ResultChunkGenerations method of the parent MockLSIFStore instance is
+// invoked and the hook queue is empty.
+func (f *LSIFStoreResultChunkGenerationsFunc) SetDefaultHook(hook func(context.Context, int) ([]lsifstore.ResultChunkGeneration, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ResultChunkGenerations method of the parent MockLSIFStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LSIFStoreResultChunkGenerationsFunc) PushHook(hook func(context.Context, int) ([]lsifstore.ResultChunkGeneration, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreResultChunkGenerationsFunc) SetDefaultReturn(r0 []lsifstore.ResultChunkGeneration, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]lsifstore.ResultChunkGeneration, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreResultChunkGenerationsFunc) PushReturn(r0 []lsifstore.ResultChunkGeneration, r1 error) {
+	f.PushHook(func(context.Context, int) ([]lsifstore.ResultChunkGeneration, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreResultChunkGenerationsFunc) nextHook() func(context.Context, int) ([]lsifstore.ResultChunkGeneration, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreResultChunkGenerationsFunc) appendCall(r0 LSIFStoreResultChunkGenerationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreResultChunkGenerationsFuncCall
+// objects describing the invocations of this function.
+func (f *LSIFStoreResultChunkGenerationsFunc) History() []LSIFStoreResultChunkGenerationsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreResultChunkGenerationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreResultChunkGenerationsFuncCall is an object that describes an
+// invocation of method ResultChunkGenerations on an instance of
+// MockLSIFStore.
+type LSIFStoreResultChunkGenerationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.ResultChunkGeneration
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreResultChunkGenerationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreResultChunkGenerationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LSIFStoreTransactFunc describes the behavior when the Transact method of
 // the parent MockLSIFStore instance is invoked.
 type LSIFStoreTransactFunc struct {
@@ -3556,6 +4482,119 @@ func (c LSIFStoreWriteReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LSIFStoreWriteResultChunkGenerationsFunc describes the behavior when the
+// WriteResultChunkGenerations method of the parent MockLSIFStore instance
+// is invoked.
+type LSIFStoreWriteResultChunkGenerationsFunc struct {
+	defaultHook func(context.Context, int, []lsifstore.ResultChunkGeneration) error
+	hooks       []func(context.Context, int, []lsifstore.ResultChunkGeneration) error
+	history     []LSIFStoreWriteResultChunkGenerationsFuncCall
+	mutex       sync.Mutex
+}
+
+// WriteResultChunkGenerations delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) WriteResultChunkGenerations(v0 context.Context, v1 int, v2 []lsifstore.ResultChunkGeneration) error {
+	r0 := m.WriteResultChunkGenerationsFunc.nextHook()(v0, v1, v2)
+	m.WriteResultChunkGenerationsFunc.appendCall(LSIFStoreWriteResultChunkGenerationsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// WriteResultChunkGenerations method of the parent MockLSIFStore instance
+// is invoked and the hook queue is empty.
+func (f *LSIFStoreWriteResultChunkGenerationsFunc) SetDefaultHook(hook func(context.Context, int, []lsifstore.ResultChunkGeneration) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WriteResultChunkGenerations method of the parent MockLSIFStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LSIFStoreWriteResultChunkGenerationsFunc) PushHook(hook func(context.Context, int, []lsifstore.ResultChunkGeneration) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreWriteResultChunkGenerationsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []lsifstore.ResultChunkGeneration) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreWriteResultChunkGenerationsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []lsifstore.ResultChunkGeneration) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreWriteResultChunkGenerationsFunc) nextHook() func(context.Context, int, []lsifstore.ResultChunkGeneration) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreWriteResultChunkGenerationsFunc) appendCall(r0 LSIFStoreWriteResultChunkGenerationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// LSIFStoreWriteResultChunkGenerationsFuncCall objects describing the
+// invocations of this function.
+func (f *LSIFStoreWriteResultChunkGenerationsFunc) History() []LSIFStoreWriteResultChunkGenerationsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreWriteResultChunkGenerationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreWriteResultChunkGenerationsFuncCall is an object that describes
+// an invocation of method WriteResultChunkGenerations on an instance of
+// MockLSIFStore.
+type LSIFStoreWriteResultChunkGenerationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []lsifstore.ResultChunkGeneration
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreWriteResultChunkGenerationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreWriteResultChunkGenerationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // LSIFStoreWriteResultChunksFunc describes the behavior when the
 // WriteResultChunks method of the parent MockLSIFStore instance is invoked.
 type LSIFStoreWriteResultChunksFunc struct {
@@ -33,6 +33,9 @@ type DBStore interface {
 	DeleteSourcedCommits(ctx context.Context, repositoryID int, commit string, now time.Time) (int, int, error)
 	SelectPoliciesForRepositoryMembershipUpdate(ctx context.Context, batchSize int) (configurationPolicies []dbstore.ConfigurationPolicy, err error)
 	UpdateReposMatchingPatterns(ctx context.Context, patterns []string, policyID int, repositoryMatchLimit *int) (err error)
+	SelectUploadsWithoutStorageSize(ctx context.Context, limit int) ([]int, error)
+	UpdateUploadStorageSizes(ctx context.Context, sizes map[int]int64) error
+	SelectUploadsExceedingStorageQuota(ctx context.Context, repositoryQuota, organizationQuota int64, limit int) ([]int, error)
 }
 
 type DBStoreShim struct {
@@ -55,6 +58,7 @@ type LSIFStore interface {
 	Clear(ctx context.Context, bundleIDs ...int) error
 	DeleteOldPublicSearchRecords(ctx context.Context, minimumTimeSinceLastCheck time.Duration, limit int) (int, error)
 	DeleteOldPrivateSearchRecords(ctx context.Context, minimumTimeSinceLastCheck time.Duration, limit int) (int, error)
+	UploadStorageSizes(ctx context.Context, bundleIDs []int) (map[int]int64, error)
 }
 
 type LSIFStoreShim struct {
@@ -61,6 +61,14 @@ type MockDBStore struct {
 	// function object controlling the behavior of the method
 	// SelectRepositoriesForRetentionScan.
 	SelectRepositoriesForRetentionScanFunc *DBStoreSelectRepositoriesForRetentionScanFunc
+	// SelectUploadsExceedingStorageQuotaFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// SelectUploadsExceedingStorageQuota.
+	SelectUploadsExceedingStorageQuotaFunc *DBStoreSelectUploadsExceedingStorageQuotaFunc
+	// SelectUploadsWithoutStorageSizeFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// SelectUploadsWithoutStorageSize.
+	SelectUploadsWithoutStorageSizeFunc *DBStoreSelectUploadsWithoutStorageSizeFunc
 	// SoftDeleteExpiredUploadsFunc is an instance of a mock function object
 	// controlling the behavior of the method SoftDeleteExpiredUploads.
 	SoftDeleteExpiredUploadsFunc *DBStoreSoftDeleteExpiredUploadsFunc
@@ -80,6 +88,9 @@ type MockDBStore struct {
 	// UpdateUploadRetentionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateUploadRetention.
 	UpdateUploadRetentionFunc *DBStoreUpdateUploadRetentionFunc
+	// UpdateUploadStorageSizesFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateUploadStorageSizes.
+	UpdateUploadStorageSizesFunc *DBStoreUpdateUploadStorageSizesFunc
 }
 
 // NewMockDBStore creates a new mock of the DBStore interface. All methods
@@ -151,6 +162,16 @@ func NewMockDBStore() *MockDBStore {
 				return nil, nil
 			},
 		},
+		SelectUploadsExceedingStorageQuotaFunc: &DBStoreSelectUploadsExceedingStorageQuotaFunc{
+			defaultHook: func(context.Context, int64, int64, int) ([]int, error) {
+				return nil, nil
+			},
+		},
+		SelectUploadsWithoutStorageSizeFunc: &DBStoreSelectUploadsWithoutStorageSizeFunc{
+			defaultHook: func(context.Context, int) ([]int, error) {
+				return nil, nil
+			},
+		},
 		SoftDeleteExpiredUploadsFunc: &DBStoreSoftDeleteExpiredUploadsFunc{
 			defaultHook: func(context.Context) (int, error) {
 				return 0, nil
@@ -181,6 +202,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		UpdateUploadStorageSizesFunc: &DBStoreUpdateUploadStorageSizesFunc{
+			defaultHook: func(context.Context, map[int]int64) error {
+				return nil
+			},
+		},
 	}
 }
 
@@ -253,6 +279,16 @@ func NewStrictMockDBStore() *MockDBStore {
 				panic("unexpected invocation of MockDBStore.SelectRepositoriesForRetentionScan")
 			},
 		},
+		SelectUploadsExceedingStorageQuotaFunc: &DBStoreSelectUploadsExceedingStorageQuotaFunc{
+			defaultHook: func(context.Context, int64, int64, int) ([]int, error) {
+				panic("unexpected invocation of MockDBStore.SelectUploadsExceedingStorageQuota")
+			},
+		},
+		SelectUploadsWithoutStorageSizeFunc: &DBStoreSelectUploadsWithoutStorageSizeFunc{
+			defaultHook: func(context.Context, int) ([]int, error) {
+				panic("unexpected invocation of MockDBStore.SelectUploadsWithoutStorageSize")
+			},
+		},
 		SoftDeleteExpiredUploadsFunc: &DBStoreSoftDeleteExpiredUploadsFunc{
 			defaultHook: func(context.Context) (int, error) {
 				panic("unexpected invocation of MockDBStore.SoftDeleteExpiredUploads")
@@ -283,6 +319,11 @@ func NewStrictMockDBStore() *MockDBStore {
 				panic("unexpected invocation of MockDBStore.UpdateUploadRetention")
 			},
 		},
+		UpdateUploadStorageSizesFunc: &DBStoreUpdateUploadStorageSizesFunc{
+			defaultHook: func(context.Context, map[int]int64) error {
+				panic("unexpected invocation of MockDBStore.UpdateUploadStorageSizes")
+			},
+		},
 	}
 }
 
@@ -329,6 +370,12 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		SelectRepositoriesForRetentionScanFunc: &DBStoreSelectRepositoriesForRetentionScanFunc{
 			defaultHook: i.SelectRepositoriesForRetentionScan,
 		},
+		SelectUploadsExceedingStorageQuotaFunc: &DBStoreSelectUploadsExceedingStorageQuotaFunc{
+			defaultHook: i.SelectUploadsExceedingStorageQuota,
+		},
+		SelectUploadsWithoutStorageSizeFunc: &DBStoreSelectUploadsWithoutStorageSizeFunc{
+			defaultHook: i.SelectUploadsWithoutStorageSize,
+		},
 		SoftDeleteExpiredUploadsFunc: &DBStoreSoftDeleteExpiredUploadsFunc{
 			defaultHook: i.SoftDeleteExpiredUploads,
 		},
@@ -347,6 +394,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		UpdateUploadRetentionFunc: &DBStoreUpdateUploadRetentionFunc{
 			defaultHook: i.UpdateUploadRetention,
 		},
+		UpdateUploadStorageSizesFunc: &DBStoreUpdateUploadStorageSizesFunc{
+			defaultHook: i.UpdateUploadStorageSizes,
+		},
 	}
 }
 
@@ -1805,6 +1855,238 @@ func (c DBStoreSelectRepositoriesForRetentionScanFuncCall) Results() []interface
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// DBStoreSelectUploadsExceedingStorageQuotaFunc describes the behavior when
+// the SelectUploadsExceedingStorageQuota method of the parent MockDBStore
+// instance is invoked.
+type DBStoreSelectUploadsExceedingStorageQuotaFunc struct {
+	defaultHook func(context.Context, int64, int64, int) ([]int, error)
+	hooks       []func(context.Context, int64, int64, int) ([]int, error)
+	history     []DBStoreSelectUploadsExceedingStorageQuotaFuncCall
+	mutex       sync.Mutex
+}
+
+// SelectUploadsExceedingStorageQuota delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockDBStore) SelectUploadsExceedingStorageQuota(v0 context.Context, v1 int64, v2 int64, v3 int) ([]int, error) {
+	r0, r1 := m.SelectUploadsExceedingStorageQuotaFunc.nextHook()(v0, v1, v2, v3)
+	m.SelectUploadsExceedingStorageQuotaFunc.appendCall(DBStoreSelectUploadsExceedingStorageQuotaFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// SelectUploadsExceedingStorageQuota method of the parent MockDBStore
+// instance is invoked and the hook queue is empty.
+func (f *DBStoreSelectUploadsExceedingStorageQuotaFunc) SetDefaultHook(hook func(context.Context, int64, int64, int) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SelectUploadsExceedingStorageQuota method of the parent MockDBStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *DBStoreSelectUploadsExceedingStorageQuotaFunc) PushHook(hook func(context.Context, int64, int64, int) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreSelectUploadsExceedingStorageQuotaFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreSelectUploadsExceedingStorageQuotaFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, int64, int64, int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreSelectUploadsExceedingStorageQuotaFunc) nextHook() func(context.Context, int64, int64, int) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreSelectUploadsExceedingStorageQuotaFunc) appendCall(r0 DBStoreSelectUploadsExceedingStorageQuotaFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// DBStoreSelectUploadsExceedingStorageQuotaFuncCall objects describing the
+// invocations of this function.
+func (f *DBStoreSelectUploadsExceedingStorageQuotaFunc) History() []DBStoreSelectUploadsExceedingStorageQuotaFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreSelectUploadsExceedingStorageQuotaFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreSelectUploadsExceedingStorageQuotaFuncCall is an object that
+// describes an invocation of method SelectUploadsExceedingStorageQuota on
+// an instance of MockDBStore.
+type DBStoreSelectUploadsExceedingStorageQuotaFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreSelectUploadsExceedingStorageQuotaFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreSelectUploadsExceedingStorageQuotaFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// DBStoreSelectUploadsWithoutStorageSizeFunc describes the behavior when
+// the SelectUploadsWithoutStorageSize method of the parent MockDBStore
+// instance is invoked.
+type DBStoreSelectUploadsWithoutStorageSizeFunc struct {
+	defaultHook func(context.Context, int) ([]int, error)
+	hooks       []func(context.Context, int) ([]int, error)
+	history     []DBStoreSelectUploadsWithoutStorageSizeFuncCall
+	mutex       sync.Mutex
+}
+
+// SelectUploadsWithoutStorageSize delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockDBStore) SelectUploadsWithoutStorageSize(v0 context.Context, v1 int) ([]int, error) {
+	r0, r1 := m.SelectUploadsWithoutStorageSizeFunc.nextHook()(v0, v1)
+	m.SelectUploadsWithoutStorageSizeFunc.appendCall(DBStoreSelectUploadsWithoutStorageSizeFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// SelectUploadsWithoutStorageSize method of the parent MockDBStore instance
+// is invoked and the hook queue is empty.
+func (f *DBStoreSelectUploadsWithoutStorageSizeFunc) SetDefaultHook(hook func(context.Context, int) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SelectUploadsWithoutStorageSize method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStoreSelectUploadsWithoutStorageSizeFunc) PushHook(hook func(context.Context, int) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreSelectUploadsWithoutStorageSizeFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreSelectUploadsWithoutStorageSizeFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreSelectUploadsWithoutStorageSizeFunc) nextHook() func(context.Context, int) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreSelectUploadsWithoutStorageSizeFunc) appendCall(r0 DBStoreSelectUploadsWithoutStorageSizeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// DBStoreSelectUploadsWithoutStorageSizeFuncCall objects describing the
+// invocations of this function.
+func (f *DBStoreSelectUploadsWithoutStorageSizeFunc) History() []DBStoreSelectUploadsWithoutStorageSizeFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreSelectUploadsWithoutStorageSizeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreSelectUploadsWithoutStorageSizeFuncCall is an object that
+// describes an invocation of method SelectUploadsWithoutStorageSize on an
+// instance of MockDBStore.
+type DBStoreSelectUploadsWithoutStorageSizeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreSelectUploadsWithoutStorageSizeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreSelectUploadsWithoutStorageSizeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreSoftDeleteExpiredUploadsFunc describes the behavior when the
 // SoftDeleteExpiredUploads method of the parent MockDBStore instance is
 // invoked.
@@ -2478,6 +2760,115 @@ func (c DBStoreUpdateUploadRetentionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBStoreUpdateUploadStorageSizesFunc describes the behavior when the
+// UpdateUploadStorageSizes method of the parent MockDBStore instance is
+// invoked.
+type DBStoreUpdateUploadStorageSizesFunc struct {
+	defaultHook func(context.Context, map[int]int64) error
+	hooks       []func(context.Context, map[int]int64) error
+	history     []DBStoreUpdateUploadStorageSizesFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateUploadStorageSizes delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) UpdateUploadStorageSizes(v0 context.Context, v1 map[int]int64) error {
+	r0 := m.UpdateUploadStorageSizesFunc.nextHook()(v0, v1)
+	m.UpdateUploadStorageSizesFunc.appendCall(DBStoreUpdateUploadStorageSizesFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateUploadStorageSizes method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreUpdateUploadStorageSizesFunc) SetDefaultHook(hook func(context.Context, map[int]int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateUploadStorageSizes method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStoreUpdateUploadStorageSizesFunc) PushHook(hook func(context.Context, map[int]int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreUpdateUploadStorageSizesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, map[int]int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreUpdateUploadStorageSizesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, map[int]int64) error {
+		return r0
+	})
+}
+
+func (f *DBStoreUpdateUploadStorageSizesFunc) nextHook() func(context.Context, map[int]int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreUpdateUploadStorageSizesFunc) appendCall(r0 DBStoreUpdateUploadStorageSizesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreUpdateUploadStorageSizesFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreUpdateUploadStorageSizesFunc) History() []DBStoreUpdateUploadStorageSizesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreUpdateUploadStorageSizesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreUpdateUploadStorageSizesFuncCall is an object that describes an
+// invocation of method UpdateUploadStorageSizes on an instance of
+// MockDBStore.
+type DBStoreUpdateUploadStorageSizesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 map[int]int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreUpdateUploadStorageSizesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreUpdateUploadStorageSizesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockLSIFStore is a mock implementation of the LSIFStore interface (from
 // the package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/codeintel/janitor)
@@ -2500,6 +2891,9 @@ type MockLSIFStore struct {
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *LSIFStoreTransactFunc
+	// UploadStorageSizesFunc is an instance of a mock function object
+	// controlling the behavior of the method UploadStorageSizes.
+	UploadStorageSizesFunc *LSIFStoreUploadStorageSizesFunc
 }
 
 // NewMockLSIFStore creates a new mock of the LSIFStore interface. All
@@ -2531,6 +2925,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return nil, nil
 			},
 		},
+		UploadStorageSizesFunc: &LSIFStoreUploadStorageSizesFunc{
+			defaultHook: func(context.Context, []int) (map[int]int64, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -2563,6 +2962,11 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.Transact")
 			},
 		},
+		UploadStorageSizesFunc: &LSIFStoreUploadStorageSizesFunc{
+			defaultHook: func(context.Context, []int) (map[int]int64, error) {
+				panic("unexpected invocation of MockLSIFStore.UploadStorageSizes")
+			},
+		},
 	}
 }
 
@@ -2585,6 +2989,9 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		TransactFunc: &LSIFStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
+		UploadStorageSizesFunc: &LSIFStoreUploadStorageSizesFunc{
+			defaultHook: i.UploadStorageSizes,
+		},
 	}
 }
 
@@ -3139,6 +3546,116 @@ func (c LSIFStoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LSIFStoreUploadStorageSizesFunc describes the behavior when the
+// UploadStorageSizes method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreUploadStorageSizesFunc struct {
+	defaultHook func(context.Context, []int) (map[int]int64, error)
+	hooks       []func(context.Context, []int) (map[int]int64, error)
+	history     []LSIFStoreUploadStorageSizesFuncCall
+	mutex       sync.Mutex
+}
+
+// UploadStorageSizes delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) UploadStorageSizes(v0 context.Context, v1 []int) (map[int]int64, error) {
+	r0, r1 := m.UploadStorageSizesFunc.nextHook()(v0, v1)
+	m.UploadStorageSizesFunc.appendCall(LSIFStoreUploadStorageSizesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UploadStorageSizes
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreUploadStorageSizesFunc) SetDefaultHook(hook func(context.Context, []int) (map[int]int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UploadStorageSizes method of the parent MockLSIFStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LSIFStoreUploadStorageSizesFunc) PushHook(hook func(context.Context, []int) (map[int]int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreUploadStorageSizesFunc) SetDefaultReturn(r0 map[int]int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int) (map[int]int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreUploadStorageSizesFunc) PushReturn(r0 map[int]int64, r1 error) {
+	f.PushHook(func(context.Context, []int) (map[int]int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreUploadStorageSizesFunc) nextHook() func(context.Context, []int) (map[int]int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreUploadStorageSizesFunc) appendCall(r0 LSIFStoreUploadStorageSizesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreUploadStorageSizesFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreUploadStorageSizesFunc) History() []LSIFStoreUploadStorageSizesFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreUploadStorageSizesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreUploadStorageSizesFuncCall is an object that describes an
+// invocation of method UploadStorageSizes on an instance of MockLSIFStore.
+type LSIFStoreUploadStorageSizesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreUploadStorageSizesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreUploadStorageSizesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockPolicyMatcher is a mock implementation of the PolicyMatcher interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/codeintel/janitor)
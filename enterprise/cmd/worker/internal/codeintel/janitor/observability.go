@@ -12,6 +12,7 @@ type metrics struct {
 	numUploadsScanned               prometheus.Counter
 	numCommitsScanned               prometheus.Counter
 	numUploadsExpired               prometheus.Counter
+	numUploadsEvicted               prometheus.Counter
 	numUploadRecordsRemoved         prometheus.Counter
 	numIndexRecordsRemoved          prometheus.Counter
 	numUploadsPurged                prometheus.Counter
@@ -60,6 +61,10 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		"src_codeintel_background_upload_records_expired_total",
 		"The number of codeintel upload records marked as expired.",
 	)
+	numUploadsEvicted := counter(
+		"src_codeintel_background_upload_records_evicted_total",
+		"The number of codeintel upload records marked as expired to enforce storage quotas.",
+	)
 	numUploadRecordsRemoved := counter(
 		"src_codeintel_background_upload_records_removed_total",
 		"The number of codeintel upload records removed.",
@@ -129,6 +134,7 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		numUploadsScanned:               numUploadsScanned,
 		numCommitsScanned:               numCommitsScanned,
 		numUploadsExpired:               numUploadsExpired,
+		numUploadsEvicted:               numUploadsEvicted,
 		numUploadRecordsRemoved:         numUploadRecordsRemoved,
 		numIndexRecordsRemoved:          numIndexRecordsRemoved,
 		numUploadsPurged:                numUploadsPurged,
@@ -0,0 +1,67 @@
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type storageQuotaEnforcer struct {
+	dbStore   DBStore
+	batchSize int
+	metrics   *metrics
+}
+
+var _ goroutine.Handler = &storageQuotaEnforcer{}
+var _ goroutine.ErrorHandler = &storageQuotaEnforcer{}
+
+// NewStorageQuotaEnforcer returns a background routine that periodically marks the least recently
+// queried uploads of repositories and organizations whose code intelligence data exceeds the storage
+// quotas configured in the site configuration as expired.
+//
+// Expired records with no dependents will be removed by the expiredUploadDeleter.
+func NewStorageQuotaEnforcer(dbStore DBStore, batchSize int, interval time.Duration, metrics *metrics) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, &storageQuotaEnforcer{
+		dbStore:   dbStore,
+		batchSize: batchSize,
+		metrics:   metrics,
+	})
+}
+
+var (
+	repositoryStorageQuota   = conf.CodeIntelStorageQuotaRepositoryBytes
+	organizationStorageQuota = conf.CodeIntelStorageQuotaOrganizationBytes
+)
+
+func (e *storageQuotaEnforcer) Handle(ctx context.Context) error {
+	repositoryQuota, organizationQuota := repositoryStorageQuota(), organizationStorageQuota()
+	if repositoryQuota == 0 && organizationQuota == 0 {
+		return nil
+	}
+
+	ids, err := e.dbStore.SelectUploadsExceedingStorageQuota(ctx, repositoryQuota, organizationQuota, e.batchSize)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.SelectUploadsExceedingStorageQuota")
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := e.dbStore.UpdateUploadRetention(ctx, nil, ids); err != nil {
+		return errors.Wrap(err, "dbstore.UpdateUploadRetention")
+	}
+
+	log15.Debug("Evicted codeintel uploads exceeding storage quota", "count", len(ids))
+	e.metrics.numUploadsEvicted.Add(float64(len(ids)))
+	return nil
+}
+
+func (e *storageQuotaEnforcer) HandleError(err error) {
+	e.metrics.numErrors.Inc()
+	log15.Error("Failed to enforce codeintel storage quotas", "error", err)
+}
@@ -0,0 +1,67 @@
+package janitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestStorageQuotaEnforcer(t *testing.T) {
+	setStorageQuotas(t, 1000, 5000)
+
+	dbStore := NewMockDBStore()
+	dbStore.SelectUploadsExceedingStorageQuotaFunc.SetDefaultReturn([]int{3, 5, 8}, nil)
+
+	enforcer := &storageQuotaEnforcer{
+		dbStore:   dbStore,
+		batchSize: 100,
+		metrics:   newMetrics(&observation.TestContext),
+	}
+	if err := enforcer.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error from handle: %s", err)
+	}
+
+	if history := dbStore.SelectUploadsExceedingStorageQuotaFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to SelectUploadsExceedingStorageQuota. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg1 != 1000 || history[0].Arg2 != 5000 || history[0].Arg3 != 100 {
+		t.Errorf("unexpected arguments to SelectUploadsExceedingStorageQuota: %v", history[0].Args()[1:])
+	}
+
+	if history := dbStore.UpdateUploadRetentionFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to UpdateUploadRetention. want=%d have=%d", 1, len(history))
+	} else if diff := cmp.Diff([]int{3, 5, 8}, history[0].Arg2); diff != "" {
+		t.Errorf("unexpected expired upload identifiers (-want +got):\n%s", diff)
+	}
+}
+
+func TestStorageQuotaEnforcerNoQuotas(t *testing.T) {
+	setStorageQuotas(t, 0, 0)
+
+	dbStore := NewMockDBStore()
+	enforcer := &storageQuotaEnforcer{
+		dbStore:   dbStore,
+		batchSize: 100,
+		metrics:   newMetrics(&observation.TestContext),
+	}
+	if err := enforcer.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error from handle: %s", err)
+	}
+
+	if history := dbStore.SelectUploadsExceedingStorageQuotaFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected number of calls to SelectUploadsExceedingStorageQuota. want=%d have=%d", 0, len(history))
+	}
+}
+
+func setStorageQuotas(t *testing.T, repositoryQuota, organizationQuota int64) {
+	t.Cleanup(func() {
+		repositoryStorageQuota = conf.CodeIntelStorageQuotaRepositoryBytes
+		organizationStorageQuota = conf.CodeIntelStorageQuotaOrganizationBytes
+	})
+
+	repositoryStorageQuota = func() int64 { return repositoryQuota }
+	organizationStorageQuota = func() int64 { return organizationQuota }
+}
@@ -0,0 +1,59 @@
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type uploadSizer struct {
+	dbStore   DBStore
+	lsifStore LSIFStore
+	batchSize int
+	metrics   *metrics
+}
+
+var _ goroutine.Handler = &uploadSizer{}
+var _ goroutine.ErrorHandler = &uploadSizer{}
+
+// NewUploadSizer returns a background routine that periodically measures the size of the
+// data of completed uploads in the codeintel database. These sizes are used to enforce the
+// storage quotas of repositories and organizations in this package's storageQuotaEnforcer.
+func NewUploadSizer(dbStore DBStore, lsifStore LSIFStore, batchSize int, interval time.Duration, metrics *metrics) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, &uploadSizer{
+		dbStore:   dbStore,
+		lsifStore: lsifStore,
+		batchSize: batchSize,
+		metrics:   metrics,
+	})
+}
+
+func (s *uploadSizer) Handle(ctx context.Context) error {
+	ids, err := s.dbStore.SelectUploadsWithoutStorageSize(ctx, s.batchSize)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.SelectUploadsWithoutStorageSize")
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	sizes, err := s.lsifStore.UploadStorageSizes(ctx, ids)
+	if err != nil {
+		return errors.Wrap(err, "lsifstore.UploadStorageSizes")
+	}
+
+	if err := s.dbStore.UpdateUploadStorageSizes(ctx, sizes); err != nil {
+		return errors.Wrap(err, "dbstore.UpdateUploadStorageSizes")
+	}
+
+	return nil
+}
+
+func (s *uploadSizer) HandleError(err error) {
+	s.metrics.numErrors.Inc()
+	log15.Error("Failed to measure the size of codeintel uploads", "error", err)
+}
@@ -0,0 +1,39 @@
+package janitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestUploadSizer(t *testing.T) {
+	dbStore := NewMockDBStore()
+	dbStore.SelectUploadsWithoutStorageSizeFunc.SetDefaultReturn([]int{1, 2, 3}, nil)
+	lsifStore := NewMockLSIFStore()
+	lsifStore.UploadStorageSizesFunc.SetDefaultReturn(map[int]int64{1: 100, 2: 0, 3: 250}, nil)
+
+	sizer := &uploadSizer{
+		dbStore:   dbStore,
+		lsifStore: lsifStore,
+		batchSize: 50,
+		metrics:   newMetrics(&observation.TestContext),
+	}
+	if err := sizer.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error from handle: %s", err)
+	}
+
+	if history := lsifStore.UploadStorageSizesFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to UploadStorageSizes. want=%d have=%d", 1, len(history))
+	} else if diff := cmp.Diff([]int{1, 2, 3}, history[0].Arg1); diff != "" {
+		t.Errorf("unexpected bundle identifiers (-want +got):\n%s", diff)
+	}
+
+	if history := dbStore.UpdateUploadStorageSizesFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to UpdateUploadStorageSizes. want=%d have=%d", 1, len(history))
+	} else if diff := cmp.Diff(map[int]int64{1: 100, 2: 0, 3: 250}, history[0].Arg1); diff != "" {
+		t.Errorf("unexpected storage sizes (-want +got):\n%s", diff)
+	}
+}
@@ -25,6 +25,8 @@ type janitorConfig struct {
 	ConfigurationPolicyMembershipBatchSize              int
 	DocumentationSearchCurrentMinimumTimeSinceLastCheck time.Duration
 	DocumentationSearchCurrentBatchSize                 int
+	UploadSizeBatchSize                                 int
+	StorageQuotaBatchSize                               int
 
 	MetricsConfig *executorqueue.Config
 }
@@ -47,6 +49,8 @@ func (c *janitorConfig) Load() {
 	c.ConfigurationPolicyMembershipBatchSize = c.GetInt("PRECISE_CODE_INTEL_CONFIGURATION_POLICY_MEMBERSHIP_BATCH_SIZE", "100", "The maximum number of policy configurations to update repository membership for at a time.")
 	c.DocumentationSearchCurrentMinimumTimeSinceLastCheck = c.GetInterval("PRECISE_CODE_INTEL_DOCUMENTATION_SEARCH_CURRENT_MINIMUM_TIME_SINCE_LAST_CHECK", "24h", "The minimum time the documentation search current janitor will re-check records for a unique search key.")
 	c.DocumentationSearchCurrentBatchSize = c.GetInt("PRECISE_CODE_INTEL_DOCUMENTATION_SEARCH_CURRENT_BATCH_SIZE", "100", "The maximum number of unique search keys to clean up at a time.")
+	c.UploadSizeBatchSize = c.GetInt("PRECISE_CODE_INTEL_UPLOAD_SIZE_BATCH_SIZE", "100", "The maximum number of uploads to measure the storage size of at a time.")
+	c.StorageQuotaBatchSize = c.GetInt("PRECISE_CODE_INTEL_STORAGE_QUOTA_BATCH_SIZE", "100", "The maximum number of uploads to expire at a time when enforcing storage quotas.")
 
 	c.MetricsConfig = executorqueue.InitMetricsConfig()
 	c.MetricsConfig.Load()
@@ -0,0 +1,31 @@
+package searchexport
+
+import (
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+type config struct {
+	env.BaseConfig
+
+	// UploadStoreConfig configures the blob store exports are written to.
+	// Exports share the bucket of code intelligence uploads and expire with
+	// the same TTL.
+	UploadStoreConfig *uploadstore.Config
+}
+
+var configInst = &config{}
+
+func (c *config) Load() {
+	c.UploadStoreConfig = &uploadstore.Config{}
+	c.UploadStoreConfig.Load()
+}
+
+func (c *config) Validate() error {
+	var errs *multierror.Error
+	errs = multierror.Append(errs, c.BaseConfig.Validate())
+	errs = multierror.Append(errs, c.UploadStoreConfig.Validate())
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,57 @@
+package searchexport
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/workerdb"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchexport"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+type exportJob struct{}
+
+// NewExportJob returns a job that runs queued search exports.
+func NewExportJob() job.Job {
+	return &exportJob{}
+}
+
+func (j *exportJob) Config() []env.Config {
+	return []env.Config{configInst}
+}
+
+func (j *exportJob) Routines(ctx context.Context) ([]goroutine.BackgroundRoutine, error) {
+	observationContext := &observation.Context{
+		Logger:     log15.Root(),
+		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+
+	db, err := workerdb.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	uploadStore, err := uploadstore.CreateLazy(context.Background(), configInst.UploadStoreConfig, observationContext)
+	if err != nil {
+		return nil, err
+	}
+
+	store := searchexport.NewStore(db)
+	workerStore := searchexport.NewWorkerStore(basestore.NewHandleWithDB(db, sql.TxOptions{}), observationContext)
+
+	return []goroutine.BackgroundRoutine{
+		searchexport.NewWorker(context.Background(), store, workerStore, uploadStore, observationContext),
+		searchexport.NewResetter(workerStore, observationContext),
+	}, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/batches"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/codeintel"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/executors"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/searchexport"
 	eiauthz "github.com/sourcegraph/sourcegraph/enterprise/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -38,6 +39,7 @@ func main() {
 		"insights-job":             insights.NewInsightsJob(),
 		"batches-janitor":          batches.NewJanitorJob(),
 		"executors-janitor":        executors.NewJanitorJob(),
+		"search-export":            searchexport.NewExportJob(),
 	})
 }
 
@@ -528,6 +528,32 @@ func (c *Client) ListFiles(ctx context.Context, repositoryID int, commit string,
 	return matching, nil
 }
 
+// ChangedFiles returns the repository-relative paths of the files that were added, modified, or deleted between
+// the two given commits of a repository. Renamed files are reported as a deletion of the old path and an
+// addition of the new path.
+func (c *Client) ChangedFiles(ctx context.Context, repositoryID int, fromCommit, toCommit string) (_ []string, err error) {
+	ctx, endObservation := c.operations.changedFiles.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("fromCommit", fromCommit),
+		log.String("toCommit", toCommit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	out, err := c.execResolveRevGitCommand(ctx, repositoryID, toCommit, "diff", "--name-only", "--no-renames", "-z", fromCommit, toCommit, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, path := range strings.Split(out, "\x00") {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
 // ResolveRevision returns the absolute commit for a commit-ish spec.
 func (c *Client) ResolveRevision(ctx context.Context, repositoryID int, versionString string) (commitID api.CommitID, err error) {
 	ctx, endObservation := c.operations.resolveRevision.With(ctx, &err, observation.Args{LogFields: []log.Field{
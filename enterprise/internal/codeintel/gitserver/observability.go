@@ -11,6 +11,7 @@ import (
 )
 
 type operations struct {
+	changedFiles          *observation.Operation
 	commitDate            *observation.Operation
 	commitExists          *observation.Operation
 	commitGraph           *observation.Operation
@@ -48,6 +49,7 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
+		changedFiles:          op("ChangedFiles"),
 		commitDate:            op("CommitDate"),
 		commitExists:          op("CommitExists"),
 		commitGraph:           op("CommitGraph"),
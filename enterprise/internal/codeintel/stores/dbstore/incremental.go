@@ -0,0 +1,154 @@
+package dbstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/bloomfilter"
+)
+
+// InheritPackages copies the packages and package references of the given parent upload that are not
+// already present on the given upload. References to a package declared by both uploads are merged so
+// that the resulting filter contains the identifiers of both. This method should be called after the
+// packages and package references of the upload itself have been written.
+func (s *Store) InheritPackages(ctx context.Context, parentUploadID, uploadID int) (err error) {
+	ctx, traceLog, endObservation := s.operations.inheritPackages.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("parentUploadID", parentUploadID),
+		log.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(inheritPackagesQuery, uploadID, parentUploadID, uploadID)); err != nil {
+		return err
+	}
+
+	// Merge the filters of references declared by both uploads before copying the parent's
+	// remaining references. The copy below skips any reference already present on the upload.
+	sharedReferences, err := scanSharedReferences(tx.Query(ctx, sqlf.Sprintf(sharedReferencesQuery, uploadID, parentUploadID)))
+	if err != nil {
+		return err
+	}
+	traceLog(log.Int("numSharedReferences", len(sharedReferences)))
+
+	for _, reference := range sharedReferences {
+		filter, err := unionFilters(reference.filter, reference.parentFilter)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec(ctx, sqlf.Sprintf(updateReferenceFilterQuery, filter, reference.id)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(inheritReferencesQuery, uploadID, parentUploadID, uploadID))
+}
+
+const inheritPackagesQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/incremental.go:InheritPackages
+INSERT INTO lsif_packages (dump_id, scheme, name, version)
+SELECT %s, p.scheme, p.name, p.version
+FROM lsif_packages p
+WHERE
+	p.dump_id = %s AND
+	NOT EXISTS (
+		SELECT 1
+		FROM lsif_packages c
+		WHERE
+			c.dump_id = %s AND
+			c.scheme = p.scheme AND
+			c.name = p.name AND
+			c.version IS NOT DISTINCT FROM p.version
+	)
+`
+
+const sharedReferencesQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/incremental.go:InheritPackages
+SELECT c.id, c.filter, p.filter
+FROM lsif_references c
+JOIN lsif_references p ON
+	p.scheme = c.scheme AND
+	p.name = c.name AND
+	p.version IS NOT DISTINCT FROM c.version
+WHERE c.dump_id = %s AND p.dump_id = %s
+ORDER BY c.id
+`
+
+const updateReferenceFilterQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/incremental.go:InheritPackages
+UPDATE lsif_references SET filter = %s WHERE id = %s
+`
+
+const inheritReferencesQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/incremental.go:InheritPackages
+INSERT INTO lsif_references (dump_id, scheme, name, version, filter)
+SELECT %s, p.scheme, p.name, p.version, p.filter
+FROM lsif_references p
+WHERE
+	p.dump_id = %s AND
+	NOT EXISTS (
+		SELECT 1
+		FROM lsif_references c
+		WHERE
+			c.dump_id = %s AND
+			c.scheme = p.scheme AND
+			c.name = p.name AND
+			c.version IS NOT DISTINCT FROM p.version
+	)
+`
+
+type sharedReference struct {
+	id           int
+	filter       []byte
+	parentFilter []byte
+}
+
+func scanSharedReferences(rows *sql.Rows, queryErr error) (_ []sharedReference, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var references []sharedReference
+	for rows.Next() {
+		var reference sharedReference
+		if err := rows.Scan(&reference.id, &reference.filter, &reference.parentFilter); err != nil {
+			return nil, err
+		}
+
+		references = append(references, reference)
+	}
+
+	return references, nil
+}
+
+// unionFilters returns the union of the given encoded bloom filters. Empty filters, which are
+// written for references without a filter, are ignored.
+func unionFilters(filters ...[]byte) ([]byte, error) {
+	nonEmpty := make([][]byte, 0, len(filters))
+	for _, filter := range filters {
+		if len(filter) != 0 {
+			nonEmpty = append(nonEmpty, filter)
+		}
+	}
+
+	switch len(nonEmpty) {
+	case 0:
+		return []byte{}, nil
+	case 1:
+		return nonEmpty[0], nil
+	}
+
+	return bloomfilter.Union(nonEmpty...)
+}
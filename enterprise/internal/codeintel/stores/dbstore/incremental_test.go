@@ -0,0 +1,114 @@
+package dbstore
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/bloomfilter"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestInheritPackages(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	insertUploads(t, db, Upload{ID: 1}, Upload{ID: 2})
+
+	parentFilter, err := bloomfilter.CreateFilter([]string{"foo"})
+	if err != nil {
+		t.Fatalf("unexpected error creating filter: %s", err)
+	}
+	childFilter, err := bloomfilter.CreateFilter([]string{"bar"})
+	if err != nil {
+		t.Fatalf("unexpected error creating filter: %s", err)
+	}
+
+	if err := store.UpdatePackages(ctx, 1, []precise.Package{
+		{Scheme: "gomod", Name: "a", Version: "v1"},
+		{Scheme: "gomod", Name: "b", Version: "v1"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+	if err := store.UpdatePackageReferences(ctx, 1, []precise.PackageReference{
+		{Package: precise.Package{Scheme: "gomod", Name: "c", Version: "v1"}, Filter: parentFilter},
+		{Package: precise.Package{Scheme: "gomod", Name: "d", Version: "v1"}, Filter: parentFilter},
+	}); err != nil {
+		t.Fatalf("unexpected error updating package references: %s", err)
+	}
+
+	if err := store.UpdatePackages(ctx, 2, []precise.Package{
+		{Scheme: "gomod", Name: "a", Version: "v1"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+	if err := store.UpdatePackageReferences(ctx, 2, []precise.PackageReference{
+		{Package: precise.Package{Scheme: "gomod", Name: "c", Version: "v1"}, Filter: childFilter},
+	}); err != nil {
+		t.Fatalf("unexpected error updating package references: %s", err)
+	}
+
+	if err := store.InheritPackages(ctx, 1, 2); err != nil {
+		t.Fatalf("unexpected error inheriting packages: %s", err)
+	}
+
+	packageNames, err := basestore.ScanStrings(store.Query(ctx, sqlf.Sprintf("SELECT name FROM lsif_packages WHERE dump_id = 2")))
+	if err != nil {
+		t.Fatalf("unexpected error querying packages: %s", err)
+	}
+	sort.Strings(packageNames)
+	if diff := cmp.Diff([]string{"a", "b"}, packageNames); diff != "" {
+		t.Errorf("unexpected packages (-want +got):\n%s", diff)
+	}
+
+	referenceNames, err := basestore.ScanStrings(store.Query(ctx, sqlf.Sprintf("SELECT name FROM lsif_references WHERE dump_id = 2")))
+	if err != nil {
+		t.Fatalf("unexpected error querying references: %s", err)
+	}
+	sort.Strings(referenceNames)
+	if diff := cmp.Diff([]string{"c", "d"}, referenceNames); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+
+	var rawFilter []byte
+	if err := db.QueryRowContext(ctx, "SELECT filter FROM lsif_references WHERE dump_id = 2 AND name = 'c'").Scan(&rawFilter); err != nil {
+		t.Fatalf("unexpected error querying filter: %s", err)
+	}
+	test, err := bloomfilter.Decode(rawFilter)
+	if err != nil {
+		t.Fatalf("unexpected error decoding filter: %s", err)
+	}
+	for _, identifier := range []string{"foo", "bar"} {
+		if !test(identifier) {
+			t.Errorf("expected %s to be in merged filter", identifier)
+		}
+	}
+}
+
+func TestUnionFilters(t *testing.T) {
+	filter, err := bloomfilter.CreateFilter([]string{"foo"})
+	if err != nil {
+		t.Fatalf("unexpected error creating filter: %s", err)
+	}
+
+	if union, err := unionFilters([]byte{}, filter); err != nil {
+		t.Fatalf("unexpected error unioning filters: %s", err)
+	} else if diff := cmp.Diff(filter, union); diff != "" {
+		t.Errorf("unexpected filter (-want +got):\n%s", diff)
+	}
+
+	if union, err := unionFilters([]byte{}, nil); err != nil {
+		t.Fatalf("unexpected error unioning filters: %s", err)
+	} else if len(union) != 0 {
+		t.Errorf("expected empty filter")
+	}
+}
@@ -42,6 +42,7 @@ type operations struct {
 	hasCommit                                   *observation.Operation
 	hasRepository                               *observation.Operation
 	indexQueueSize                              *observation.Operation
+	inheritPackages                             *observation.Operation
 	insertCloneableDependencyRepo               *observation.Operation
 	insertDependencyIndexingJob                 *observation.Operation
 	insertDependencySyncingJob                  *observation.Operation
@@ -55,17 +56,22 @@ type operations struct {
 	markIndexErrored                            *observation.Operation
 	markQueued                                  *observation.Operation
 	markRepositoryAsDirty                       *observation.Operation
+	markUploadsAsQueried                        *observation.Operation
+	organizationStorageUsage                    *observation.Operation
 	queueSize                                   *observation.Operation
 	referenceIDsAndFilters                      *observation.Operation
 	referencesForUpload                         *observation.Operation
 	refreshCommitResolvability                  *observation.Operation
 	repoIDsByGlobPatterns                       *observation.Operation
 	repoName                                    *observation.Operation
+	repositoryStorageUsage                      *observation.Operation
 	requeue                                     *observation.Operation
 	requeueIndex                                *observation.Operation
 	selectPoliciesForRepositoryMembershipUpdate *observation.Operation
 	selectRepositoriesForIndexScan              *observation.Operation
 	selectRepositoriesForRetentionScan          *observation.Operation
+	selectUploadsExceedingStorageQuota          *observation.Operation
+	selectUploadsWithoutStorageSize             *observation.Operation
 	softDeleteExpiredUploads                    *observation.Operation
 	staleSourcedCommits                         *observation.Operation
 	updateCommitedAt                            *observation.Operation
@@ -77,6 +83,7 @@ type operations struct {
 	updateReposMatchingPatterns                 *observation.Operation
 	updateSourcedCommits                        *observation.Operation
 	updateUploadRetention                       *observation.Operation
+	updateUploadStorageSizes                    *observation.Operation
 
 	persistNearestUploads      *observation.Operation
 	persistNearestUploadsLinks *observation.Operation
@@ -137,6 +144,7 @@ func newOperations(observationContext *observation.Context, metrics *metrics.RED
 		hasCommit:                           op("HasCommit"),
 		hasRepository:                       op("HasRepository"),
 		indexQueueSize:                      op("IndexQueueSize"),
+		inheritPackages:                     op("InheritPackages"),
 		insertCloneableDependencyRepo:       op("InsertCloneableDependencyRepo"),
 		insertDependencyIndexingJob:         op("InsertDependencyIndexingJob"),
 		insertDependencySyncingJob:          op("InsertDependencySyncingJob"),
@@ -150,17 +158,22 @@ func newOperations(observationContext *observation.Context, metrics *metrics.RED
 		markIndexErrored:                    op("MarkIndexErrored"),
 		markQueued:                          op("MarkQueued"),
 		markRepositoryAsDirty:               op("MarkRepositoryAsDirty"),
+		markUploadsAsQueried:                op("MarkUploadsAsQueried"),
+		organizationStorageUsage:            op("OrganizationStorageUsage"),
 		queueSize:                           op("QueueSize"),
 		referenceIDsAndFilters:              op("ReferenceIDsAndFilters"),
 		referencesForUpload:                 op("ReferencesForUpload"),
 		refreshCommitResolvability:          op("RefreshCommitResolvability"),
 		repoIDsByGlobPatterns:               op("repoIDsByGlobPatterns"),
 		repoName:                            op("RepoName"),
+		repositoryStorageUsage:              op("RepositoryStorageUsage"),
 		requeue:                             op("Requeue"),
 		requeueIndex:                        op("RequeueIndex"),
 		selectPoliciesForRepositoryMembershipUpdate: op("selectPoliciesForRepositoryMembershipUpdate"),
 		selectRepositoriesForIndexScan:              op("SelectRepositoriesForIndexScan"),
 		selectRepositoriesForRetentionScan:          op("SelectRepositoriesForRetentionScan"),
+		selectUploadsExceedingStorageQuota:          op("SelectUploadsExceedingStorageQuota"),
+		selectUploadsWithoutStorageSize:             op("SelectUploadsWithoutStorageSize"),
 		softDeleteExpiredUploads:                    op("SoftDeleteExpiredUploads"),
 		staleSourcedCommits:                         op("StaleSourcedCommits"),
 		updateCommitedAt:                            op("UpdateCommitedAt"),
@@ -173,6 +186,7 @@ func newOperations(observationContext *observation.Context, metrics *metrics.RED
 		updateReposMatchingPatterns:            op("UpdateReposMatchingPatterns"),
 		updateSourcedCommits:                   op("UpdateSourcedCommits"),
 		updateUploadRetention:                  op("UpdateUploadRetention"),
+		updateUploadStorageSizes:               op("UpdateUploadStorageSizes"),
 
 		persistNearestUploads:      subOp("persistNearestUploads"),
 		persistNearestUploadsLinks: subOp("persistNearestUploadsLinks"),
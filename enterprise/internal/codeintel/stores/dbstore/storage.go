@@ -0,0 +1,313 @@
+package dbstore
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+// StorageUsage describes the amount of processed code intelligence data held for a repository
+// or for the repositories of an organization.
+type StorageUsage struct {
+	// StorageBytes is the total size of the processed data of all measured completed uploads.
+	StorageBytes int64
+	// NumUploads is the number of completed uploads, including those not yet measured.
+	NumUploads int
+}
+
+func scanFirstStorageUsage(rows *sql.Rows, queryErr error) (_ StorageUsage, err error) {
+	if queryErr != nil {
+		return StorageUsage{}, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var usage StorageUsage
+	if rows.Next() {
+		if err := rows.Scan(&usage.StorageBytes, &usage.NumUploads); err != nil {
+			return StorageUsage{}, err
+		}
+	}
+
+	return usage, nil
+}
+
+// RepositoryStorageUsage returns the storage used by the completed uploads of the given repository.
+func (s *Store) RepositoryStorageUsage(ctx context.Context, repositoryID int) (_ StorageUsage, err error) {
+	ctx, endObservation := s.operations.repositoryStorageUsage.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanFirstStorageUsage(s.Store.Query(ctx, sqlf.Sprintf(repositoryStorageUsageQuery, repositoryID)))
+}
+
+const repositoryStorageUsageQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/storage.go:RepositoryStorageUsage
+SELECT COALESCE(SUM(u.storage_bytes), 0)::bigint, COUNT(*)
+FROM lsif_uploads u
+WHERE u.state = 'completed' AND u.repository_id = %s
+`
+
+// OrganizationStorageUsage returns the storage used by the completed uploads of all repositories
+// synced by an external service owned by the given organization.
+func (s *Store) OrganizationStorageUsage(ctx context.Context, orgID int) (_ StorageUsage, err error) {
+	ctx, endObservation := s.operations.organizationStorageUsage.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("orgID", orgID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanFirstStorageUsage(s.Store.Query(ctx, sqlf.Sprintf(organizationStorageUsageQuery, orgID)))
+}
+
+const organizationStorageUsageQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/storage.go:OrganizationStorageUsage
+SELECT COALESCE(SUM(u.storage_bytes), 0)::bigint, COUNT(*)
+FROM lsif_uploads u
+WHERE
+	u.state = 'completed' AND
+	u.repository_id IN (SELECT esr.repo_id FROM external_service_repos esr WHERE esr.org_id = %s)
+`
+
+// SelectUploadsWithoutStorageSize returns the identifiers of completed uploads whose processed data
+// has not yet been measured.
+func (s *Store) SelectUploadsWithoutStorageSize(ctx context.Context, limit int) (_ []int, err error) {
+	ctx, endObservation := s.operations.selectUploadsWithoutStorageSize.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return basestore.ScanInts(s.Store.Query(ctx, sqlf.Sprintf(selectUploadsWithoutStorageSizeQuery, limit)))
+}
+
+const selectUploadsWithoutStorageSizeQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/storage.go:SelectUploadsWithoutStorageSize
+SELECT u.id
+FROM lsif_uploads u
+WHERE u.state = 'completed' AND u.storage_bytes IS NULL
+ORDER BY u.finished_at, u.id
+LIMIT %s
+`
+
+// UpdateUploadStorageSizes sets the storage size of each of the given uploads.
+func (s *Store) UpdateUploadStorageSizes(ctx context.Context, sizes map[int]int64) (err error) {
+	ctx, endObservation := s.operations.updateUploadStorageSizes.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numUploads", len(sizes)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(sizes) == 0 {
+		return nil
+	}
+
+	// Ensure ids are sorted so that we take row locks during the UPDATE
+	// query in a determinstic order. This should prevent deadlocks with
+	// other queries that mass update lsif_uploads.
+	ids := make([]int, 0, len(sizes))
+	for id := range sizes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	values := make([]*sqlf.Query, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, sqlf.Sprintf("(%s::integer, %s::bigint)", id, sizes[id]))
+	}
+
+	return s.Store.Exec(ctx, sqlf.Sprintf(updateUploadStorageSizesQuery, sqlf.Join(values, ",")))
+}
+
+const updateUploadStorageSizesQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/storage.go:UpdateUploadStorageSizes
+UPDATE lsif_uploads u
+SET storage_bytes = v.storage_bytes
+FROM (VALUES %s) AS v(id, storage_bytes)
+WHERE u.id = v.id
+`
+
+// uploadQueriedThreshold is the minimum time between two updates of the last queried timestamp of
+// the same upload. This keeps the write load caused by code intelligence queries low.
+const uploadQueriedThreshold = time.Hour
+
+// MarkUploadsAsQueried updates the last queried timestamp of the given uploads. Uploads that have
+// been marked within the last hour are not updated.
+func (s *Store) MarkUploadsAsQueried(ctx context.Context, ids []int) error {
+	return s.markUploadsAsQueried(ctx, ids, timeutil.Now())
+}
+
+func (s *Store) markUploadsAsQueried(ctx context.Context, ids []int, now time.Time) (err error) {
+	ctx, endObservation := s.operations.markUploadsAsQueried.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numIDs", len(ids)),
+		log.String("ids", intsToString(ids)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	sort.Ints(ids)
+
+	queries := make([]*sqlf.Query, 0, len(ids))
+	for _, id := range ids {
+		queries = append(queries, sqlf.Sprintf("%s", id))
+	}
+
+	return s.Store.Exec(ctx, sqlf.Sprintf(
+		markUploadsAsQueriedQuery,
+		now,
+		sqlf.Join(queries, ","),
+		now,
+		int(uploadQueriedThreshold/time.Second),
+	))
+}
+
+const markUploadsAsQueriedQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/storage.go:markUploadsAsQueried
+UPDATE lsif_uploads
+SET last_queried_at = %s
+WHERE
+	id IN (%s) AND
+	(%s - last_queried_at > (%s * '1 second'::interval)) IS DISTINCT FROM FALSE
+`
+
+// SelectUploadsExceedingStorageQuota returns the identifiers of the unexpired uploads that need to
+// be evicted so that the storage used by each repository, and by the repositories of each organization,
+// fits within the given quotas. Uploads are evicted in order of least recent use: the last time the
+// upload answered a query or, if it has never been queried, the time it finished processing. Quotas
+// that are not positive are not enforced.
+func (s *Store) SelectUploadsExceedingStorageQuota(ctx context.Context, repositoryQuota, organizationQuota int64, limit int) (_ []int, err error) {
+	ctx, traceLog, endObservation := s.operations.selectUploadsExceedingStorageQuota.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int64("repositoryQuota", repositoryQuota),
+		log.Int64("organizationQuota", organizationQuota),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var uses []uploadUse
+	if repositoryQuota > 0 {
+		repositoryUses, err := scanUploadUses(s.Store.Query(ctx, sqlf.Sprintf(selectUploadsExceedingRepositoryStorageQuotaQuery, repositoryQuota, limit)))
+		if err != nil {
+			return nil, err
+		}
+		traceLog(log.Int("numRepositoryEvictions", len(repositoryUses)))
+
+		uses = append(uses, repositoryUses...)
+	}
+	if organizationQuota > 0 {
+		organizationUses, err := scanUploadUses(s.Store.Query(ctx, sqlf.Sprintf(selectUploadsExceedingOrganizationStorageQuotaQuery, organizationQuota, limit)))
+		if err != nil {
+			return nil, err
+		}
+		traceLog(log.Int("numOrganizationEvictions", len(organizationUses)))
+
+		uses = append(uses, organizationUses...)
+	}
+
+	return leastRecentlyUsedIDs(uses, limit), nil
+}
+
+// uploadUse is the identifier of an upload and the last time it was used.
+type uploadUse struct {
+	ID         int
+	LastUsedAt time.Time
+}
+
+func scanUploadUses(rows *sql.Rows, queryErr error) (_ []uploadUse, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var uses []uploadUse
+	for rows.Next() {
+		var use uploadUse
+		if err := rows.Scan(&use.ID, &use.LastUsedAt); err != nil {
+			return nil, err
+		}
+		uses = append(uses, use)
+	}
+
+	return uses, nil
+}
+
+const selectUploadsExceedingRepositoryStorageQuotaQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/storage.go:SelectUploadsExceedingStorageQuota
+WITH ranked_uploads AS (
+	SELECT
+		u.id,
+		SUM(u.storage_bytes) OVER (
+			PARTITION BY u.repository_id
+			ORDER BY COALESCE(u.last_queried_at, u.finished_at, u.uploaded_at) DESC, u.id DESC
+		) AS cumulative_storage_bytes,
+		COALESCE(u.last_queried_at, u.finished_at, u.uploaded_at) AS last_used_at
+	FROM lsif_uploads u
+	WHERE u.state = 'completed' AND NOT u.expired AND u.storage_bytes IS NOT NULL
+)
+SELECT ru.id, ru.last_used_at
+FROM ranked_uploads ru
+WHERE ru.cumulative_storage_bytes > %s
+ORDER BY ru.last_used_at, ru.id
+LIMIT %s
+`
+
+const selectUploadsExceedingOrganizationStorageQuotaQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/storage.go:SelectUploadsExceedingStorageQuota
+WITH
+organization_repositories AS (
+	SELECT DISTINCT esr.org_id, esr.repo_id
+	FROM external_service_repos esr
+	WHERE esr.org_id IS NOT NULL
+),
+ranked_uploads AS (
+	SELECT
+		u.id,
+		SUM(u.storage_bytes) OVER (
+			PARTITION BY orgr.org_id
+			ORDER BY COALESCE(u.last_queried_at, u.finished_at, u.uploaded_at) DESC, u.id DESC
+		) AS cumulative_storage_bytes,
+		COALESCE(u.last_queried_at, u.finished_at, u.uploaded_at) AS last_used_at
+	FROM lsif_uploads u
+	JOIN organization_repositories orgr ON orgr.repo_id = u.repository_id
+	WHERE u.state = 'completed' AND NOT u.expired AND u.storage_bytes IS NOT NULL
+)
+SELECT DISTINCT ru.id, ru.last_used_at
+FROM ranked_uploads ru
+WHERE ru.cumulative_storage_bytes > %s
+ORDER BY ru.last_used_at, ru.id
+LIMIT %s
+`
+
+// leastRecentlyUsedIDs returns the set of identifiers of the given uploads ordered from least to most
+// recently used, truncated to the given limit.
+func leastRecentlyUsedIDs(uses []uploadUse, limit int) []int {
+	sort.Slice(uses, func(i, j int) bool {
+		if !uses[i].LastUsedAt.Equal(uses[j].LastUsedAt) {
+			return uses[i].LastUsedAt.Before(uses[j].LastUsedAt)
+		}
+		return uses[i].ID < uses[j].ID
+	})
+
+	ids := make([]int, 0, len(uses))
+	seen := make(map[int]struct{}, len(uses))
+	for _, use := range uses {
+		if _, ok := seen[use.ID]; ok {
+			continue
+		}
+		seen[use.ID] = struct{}{}
+		ids = append(ids, use.ID)
+	}
+
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	return ids
+}
@@ -0,0 +1,158 @@
+package dbstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+func TestUploadStorageSizes(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	insertUploads(t, db,
+		Upload{ID: 1, RepositoryID: 50},
+		Upload{ID: 2, RepositoryID: 50},
+		Upload{ID: 3, RepositoryID: 50},
+		Upload{ID: 4, RepositoryID: 50, State: "queued"},
+		Upload{ID: 5, RepositoryID: 51},
+	)
+
+	if err := store.UpdateUploadStorageSizes(ctx, map[int]int64{1: 100, 2: 250, 5: 1000}); err != nil {
+		t.Fatalf("unexpected error updating storage sizes: %s", err)
+	}
+
+	ids, err := store.SelectUploadsWithoutStorageSize(ctx, 10)
+	if err != nil {
+		t.Fatalf("unexpected error selecting uploads without storage size: %s", err)
+	}
+	if diff := cmp.Diff([]int{3}, ids); diff != "" {
+		t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+	}
+
+	usage, err := store.RepositoryStorageUsage(ctx, 50)
+	if err != nil {
+		t.Fatalf("unexpected error getting repository storage usage: %s", err)
+	}
+	if diff := cmp.Diff(StorageUsage{StorageBytes: 350, NumUploads: 3}, usage); diff != "" {
+		t.Errorf("unexpected storage usage (-want +got):\n%s", diff)
+	}
+}
+
+func TestMarkUploadsAsQueried(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	insertUploads(t, db,
+		Upload{ID: 1},
+		Upload{ID: 2},
+	)
+
+	t1 := timeutil.Now()
+	t2 := t1.Add(time.Minute)
+	t3 := t1.Add(time.Hour * 2)
+
+	if err := store.markUploadsAsQueried(ctx, []int{1}, t1); err != nil {
+		t.Fatalf("unexpected error marking uploads as queried: %s", err)
+	}
+	// Too soon to update upload 1
+	if err := store.markUploadsAsQueried(ctx, []int{1, 2}, t2); err != nil {
+		t.Fatalf("unexpected error marking uploads as queried: %s", err)
+	}
+	assertLastQueriedAt(t, store, 1, t1)
+	assertLastQueriedAt(t, store, 2, t2)
+
+	if err := store.markUploadsAsQueried(ctx, []int{1}, t3); err != nil {
+		t.Fatalf("unexpected error marking uploads as queried: %s", err)
+	}
+	assertLastQueriedAt(t, store, 1, t3)
+}
+
+func assertLastQueriedAt(t *testing.T, store *Store, id int, expected time.Time) {
+	lastQueriedAt, _, err := basestore.ScanFirstTime(store.Query(context.Background(), sqlf.Sprintf(`SELECT last_queried_at FROM lsif_uploads WHERE id = %s`, id)))
+	if err != nil {
+		t.Fatalf("unexpected error querying last queried at: %s", err)
+	}
+	if !lastQueriedAt.Equal(expected) {
+		t.Errorf("unexpected last queried at for upload %d. want=%s have=%s", id, expected, lastQueriedAt)
+	}
+}
+
+func TestSelectUploadsExceedingStorageQuota(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Hour)
+	t3 := t1.Add(time.Hour * 2)
+	t4 := t1.Add(time.Hour * 3)
+
+	insertUploads(t, db,
+		Upload{ID: 1, RepositoryID: 50, FinishedAt: &t1}, // least recently used
+		Upload{ID: 2, RepositoryID: 50, FinishedAt: &t2},
+		Upload{ID: 3, RepositoryID: 50, FinishedAt: &t3},
+		Upload{ID: 4, RepositoryID: 50, FinishedAt: &t4}, // most recently finished
+		Upload{ID: 5, RepositoryID: 51, FinishedAt: &t1},
+		Upload{ID: 6, RepositoryID: 51, FinishedAt: &t2},
+	)
+
+	if err := store.UpdateUploadStorageSizes(ctx, map[int]int64{1: 100, 2: 100, 3: 100, 4: 100, 5: 100, 6: 100}); err != nil {
+		t.Fatalf("unexpected error updating storage sizes: %s", err)
+	}
+	// Querying upload 1 makes uploads 2 and 3 the least recently used uploads of repository 50
+	if err := store.markUploadsAsQueried(ctx, []int{1}, t4.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error marking uploads as queried: %s", err)
+	}
+
+	ids, err := store.SelectUploadsExceedingStorageQuota(ctx, 250, 0, 10)
+	if err != nil {
+		t.Fatalf("unexpected error selecting uploads exceeding storage quota: %s", err)
+	}
+	if diff := cmp.Diff([]int{2, 3}, ids); diff != "" {
+		t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+	}
+
+	ids, err = store.SelectUploadsExceedingStorageQuota(ctx, 0, 0, 10)
+	if err != nil {
+		t.Fatalf("unexpected error selecting uploads exceeding storage quota: %s", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("unexpected upload ids without quotas: %v", ids)
+	}
+}
+
+func TestLeastRecentlyUsedIDs(t *testing.T) {
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Hour)
+	t3 := t1.Add(time.Hour * 2)
+
+	uses := []uploadUse{
+		{ID: 1, LastUsedAt: t3},
+		{ID: 3, LastUsedAt: t1},
+		{ID: 2, LastUsedAt: t2},
+		{ID: 3, LastUsedAt: t1},
+		{ID: 5, LastUsedAt: t1},
+	}
+	if diff := cmp.Diff([]int{3, 5, 2}, leastRecentlyUsedIDs(uses, 3)); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+}
@@ -44,6 +44,7 @@ type Upload struct {
 	UploadSize        *int64     `json:"uploadSize"`
 	Rank              *int       `json:"placeInQueue"`
 	AssociatedIndexID *int       `json:"associatedIndex"`
+	ParentUploadID    *int       `json:"parentUpload"`
 }
 
 func (u Upload) RecordID() int {
@@ -81,6 +82,7 @@ func scanUploads(rows *sql.Rows, queryErr error) (_ []Upload, err error) {
 			pq.Array(&rawUploadedParts),
 			&upload.UploadSize,
 			&upload.AssociatedIndexID,
+			&upload.ParentUploadID,
 			&upload.Rank,
 		); err != nil {
 			return nil, err
@@ -178,6 +180,7 @@ SELECT
 	u.uploaded_parts,
 	u.upload_size,
 	u.associated_index_id,
+	u.parent_upload_id,
 	s.rank
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -235,6 +238,7 @@ SELECT
 	u.uploaded_parts,
 	u.upload_size,
 	u.associated_index_id,
+	u.parent_upload_id,
 	s.rank
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -472,6 +476,7 @@ SELECT
 	u.uploaded_parts,
 	u.upload_size,
 	u.associated_index_id,
+	u.parent_upload_id,
 	s.rank
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -565,6 +570,7 @@ func (s *Store) InsertUpload(ctx context.Context, upload Upload) (id int, err er
 			pq.Array(upload.UploadedParts),
 			upload.UploadSize,
 			upload.AssociatedIndexID,
+			upload.ParentUploadID,
 		),
 	))
 
@@ -582,8 +588,9 @@ INSERT INTO lsif_uploads (
 	num_parts,
 	uploaded_parts,
 	upload_size,
-	associated_index_id
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+	associated_index_id,
+	parent_upload_id
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -662,6 +669,7 @@ var uploadColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf("u.uploaded_parts"),
 	sqlf.Sprintf("u.upload_size"),
 	sqlf.Sprintf("u.associated_index_id"),
+	sqlf.Sprintf("u.parent_upload_id"),
 	sqlf.Sprintf("NULL"),
 }
 
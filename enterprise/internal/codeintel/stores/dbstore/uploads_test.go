@@ -626,6 +626,39 @@ func TestInsertUploadWithAssociatedIndexID(t *testing.T) {
 	}
 }
 
+func TestInsertUploadWithParentUploadID(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	insertUploads(t, db, Upload{ID: 1, Root: "sub/"})
+
+	parentUploadIDArg := 1
+	id, err := store.InsertUpload(context.Background(), Upload{
+		Commit:         makeCommit(2),
+		Root:           "sub/",
+		State:          "queued",
+		RepositoryID:   50,
+		Indexer:        "lsif-go",
+		NumParts:       1,
+		UploadedParts:  []int{0},
+		ParentUploadID: &parentUploadIDArg,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error enqueueing upload: %s", err)
+	}
+
+	if upload, exists, err := store.GetUploadByID(context.Background(), id); err != nil {
+		t.Fatalf("unexpected error getting upload: %s", err)
+	} else if !exists {
+		t.Fatal("expected record to exist")
+	} else if upload.ParentUploadID == nil || *upload.ParentUploadID != 1 {
+		t.Errorf("unexpected parent upload id. want=%d have=%v", 1, upload.ParentUploadID)
+	}
+}
+
 func TestMarkQueued(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
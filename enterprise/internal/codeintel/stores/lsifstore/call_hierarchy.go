@@ -0,0 +1,306 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// MaximumCallHierarchyLocations is the maximum limit when querying the definition or reference
+// locations for an IncomingCalls or OutgoingCalls request.
+const MaximumCallHierarchyLocations = 10000
+
+// IncomingCalls returns the callers of the function at the given position, each paired with the
+// locations at which the function is called. A caller is the innermost function definition whose
+// full range encloses a reference to the function at the given position.
+func (s *Store) IncomingCalls(ctx context.Context, bundleID int, path string, line, character int) (_ []CallHierarchyCall, err error) {
+	ctx, traceLog, endObservation := s.operations.incomingCalls.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+		log.Int("line", line),
+		log.Int("character", character),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	_, definition, _, ok, err := s.callableDefinition(ctx, bundleID, path, line, character)
+	if err != nil || !ok || definition.ReferenceResultID == "" {
+		return nil, err
+	}
+
+	locationsMap, _, err := s.locations(ctx, bundleID, []precise.ID{definition.ReferenceResultID}, MaximumCallHierarchyLocations, 0)
+	if err != nil {
+		return nil, err
+	}
+	references := locationsMap[definition.ReferenceResultID]
+	traceLog(log.Int("numReferences", len(references)))
+
+	documents, err := s.readDocuments(ctx, bundleID, pathsFromLocations(references))
+	if err != nil {
+		return nil, err
+	}
+
+	calls := newCallGrouper()
+	for _, reference := range references {
+		if caller, ok := enclosingCallable(documents[reference.Path], reference.Range); ok {
+			calls.add(Location{DumpID: bundleID, Path: reference.Path, Range: caller}, reference)
+		}
+	}
+	traceLog(log.Int("numCalls", len(calls.calls)))
+
+	return calls.calls, nil
+}
+
+// OutgoingCalls returns the functions called by the function at the given position, each paired
+// with the locations at which they are called. A callee is the definition of a reference that falls
+// within the full range of the function at the given position.
+func (s *Store) OutgoingCalls(ctx context.Context, bundleID int, path string, line, character int) (_ []CallHierarchyCall, err error) {
+	ctx, traceLog, endObservation := s.operations.outgoingCalls.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+		log.Int("line", line),
+		log.Int("character", character),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	definitionPath, definition, document, ok, err := s.callableDefinition(ctx, bundleID, path, line, character)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	callSites := callSitesWithin(document, *definition.Symbol)
+	traceLog(log.Int("numCallSites", len(callSites)))
+
+	definitionResultIDs := extractResultIDs(callSites, func(r precise.RangeData) precise.ID { return r.DefinitionResultID })
+	locationsMap, _, err := s.locations(ctx, bundleID, definitionResultIDs, MaximumCallHierarchyLocations, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var callees []Location
+	for _, locations := range locationsMap {
+		callees = append(callees, locations...)
+	}
+	documents, err := s.readDocuments(ctx, bundleID, pathsFromLocations(callees))
+	if err != nil {
+		return nil, err
+	}
+
+	calls := newCallGrouper()
+	for _, r := range callSites {
+		callSite := Location{DumpID: bundleID, Path: definitionPath, Range: newRange(r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter)}
+
+		for _, callee := range locationsMap[r.DefinitionResultID] {
+			if symbol, ok := symbolAt(documents[callee.Path], callee.Range); ok && symbol.IsCallable() {
+				calls.add(callee, callSite)
+			}
+		}
+	}
+	traceLog(log.Int("numCalls", len(calls.calls)))
+
+	return calls.calls, nil
+}
+
+// callableDefinition returns the path, range, and containing document of the function defined at
+// or referenced by the given position. If the position is not within the definition of a function,
+// the definition result of the ranges at that position is followed within the same bundle. A false
+// valued flag is returned if no such definition with symbol data exists.
+func (s *Store) callableDefinition(ctx context.Context, bundleID int, path string, line, character int) (string, precise.RangeData, precise.DocumentData, bool, error) {
+	documentData, exists, err := s.scanFirstDocumentData(s.Store.Query(ctx, sqlf.Sprintf(callHierarchyDocumentQuery, bundleID, path)))
+	if err != nil || !exists {
+		return "", precise.RangeData{}, precise.DocumentData{}, false, err
+	}
+
+	ranges := precise.FindRanges(documentData.Document.Ranges, line, character)
+	for i := len(ranges) - 1; i >= 0; i-- {
+		if ranges[i].Symbol != nil {
+			return path, ranges[i], documentData.Document, ranges[i].Symbol.IsCallable(), nil
+		}
+	}
+
+	definitionResultIDs := extractResultIDs(ranges, func(r precise.RangeData) precise.ID { return r.DefinitionResultID })
+	locationsMap, _, err := s.locations(ctx, bundleID, definitionResultIDs, MaximumCallHierarchyLocations, 0)
+	if err != nil {
+		return "", precise.RangeData{}, precise.DocumentData{}, false, err
+	}
+
+	var definitions []Location
+	for _, resultID := range definitionResultIDs {
+		definitions = append(definitions, locationsMap[resultID]...)
+	}
+	documents, err := s.readDocuments(ctx, bundleID, pathsFromLocations(definitions))
+	if err != nil {
+		return "", precise.RangeData{}, precise.DocumentData{}, false, err
+	}
+
+	for _, definition := range definitions {
+		document := documents[definition.Path]
+
+		if r, ok := rangeAt(document, definition.Range); ok && r.Symbol != nil && r.Symbol.IsCallable() {
+			return definition.Path, r, document, true, nil
+		}
+	}
+
+	return "", precise.RangeData{}, precise.DocumentData{}, false, nil
+}
+
+const callHierarchyDocumentQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/call_hierarchy.go:callableDefinition
+SELECT
+	dump_id,
+	path,
+	data,
+	ranges,
+	NULL AS hovers,
+	NULL AS monikers,
+	NULL AS packages,
+	NULL AS diagnostics
+FROM
+	lsif_data_documents
+WHERE
+	dump_id = %s AND
+	path = %s
+LIMIT 1
+`
+
+// readDocuments returns the documents with the given paths within the given bundle, keyed by path.
+func (s *Store) readDocuments(ctx context.Context, bundleID int, paths []string) (map[string]precise.DocumentData, error) {
+	documents := make(map[string]precise.DocumentData, len(paths))
+
+	for len(paths) > 0 {
+		var batch []string
+		if len(paths) <= documentBatchSize {
+			batch, paths = paths, nil
+		} else {
+			batch, paths = paths[:documentBatchSize], paths[documentBatchSize:]
+		}
+
+		visitDocuments := s.makeDocumentVisitor(func(path string, document precise.DocumentData) {
+			documents[path] = document
+		})
+
+		pathQueries := make([]*sqlf.Query, 0, len(batch))
+		for _, path := range batch {
+			pathQueries = append(pathQueries, sqlf.Sprintf("%s", path))
+		}
+		if err := visitDocuments(s.Store.Query(ctx, sqlf.Sprintf(readRangesFromDocumentsQuery, bundleID, sqlf.Join(pathQueries, ",")))); err != nil {
+			return nil, err
+		}
+	}
+
+	return documents, nil
+}
+
+// callSitesWithin returns the ranges of the given document that fall within the full range of the
+// given symbol and that have a definition result, in reading order. Ranges defining a symbol of their
+// own (e.g. the function name, parameters, or nested declarations) are not call sites.
+func callSitesWithin(document precise.DocumentData, symbol precise.SymbolData) []precise.RangeData {
+	var callSites []precise.RangeData
+	for _, r := range document.Ranges {
+		if r.Symbol == nil && r.DefinitionResultID != "" && precise.SymbolContainsRange(symbol, r) {
+			callSites = append(callSites, r)
+		}
+	}
+
+	sort.Slice(callSites, func(i, j int) bool {
+		return precise.CompareRanges(callSites[i], callSites[j]) < 0
+	})
+
+	return callSites
+}
+
+// enclosingCallable returns the range of the innermost function definition in the given document
+// whose full range encloses the given range. The definition range itself is not enclosed by its own
+// definition.
+func enclosingCallable(document precise.DocumentData, rn Range) (Range, bool) {
+	target := precise.RangeData{StartLine: rn.Start.Line, StartCharacter: rn.Start.Character, EndLine: rn.End.Line, EndCharacter: rn.End.Character}
+
+	var innermost *precise.RangeData
+	for _, r := range document.Ranges {
+		if r.Symbol == nil || !r.Symbol.IsCallable() || precise.CompareRanges(r, target) == 0 || !precise.SymbolContainsRange(*r.Symbol, target) {
+			continue
+		}
+
+		if innermost == nil || precise.SymbolContainsRange(*innermost.Symbol, symbolRange(*r.Symbol)) {
+			r := r
+			innermost = &r
+		}
+	}
+
+	if innermost == nil {
+		return Range{}, false
+	}
+
+	return newRange(innermost.StartLine, innermost.StartCharacter, innermost.EndLine, innermost.EndCharacter), true
+}
+
+// symbolRange returns the full range of the given symbol.
+func symbolRange(symbol precise.SymbolData) precise.RangeData {
+	return precise.RangeData{
+		StartLine:      symbol.StartLine,
+		StartCharacter: symbol.StartCharacter,
+		EndLine:        symbol.EndLine,
+		EndCharacter:   symbol.EndCharacter,
+	}
+}
+
+// symbolAt returns the symbol data of the range of the given document with the given bounds.
+func symbolAt(document precise.DocumentData, rn Range) (precise.SymbolData, bool) {
+	if r, ok := rangeAt(document, rn); ok && r.Symbol != nil {
+		return *r.Symbol, true
+	}
+
+	return precise.SymbolData{}, false
+}
+
+// rangeAt returns the range of the given document with the given bounds.
+func rangeAt(document precise.DocumentData, rn Range) (precise.RangeData, bool) {
+	for _, r := range document.Ranges {
+		if r.StartLine == rn.Start.Line && r.StartCharacter == rn.Start.Character && r.EndLine == rn.End.Line && r.EndCharacter == rn.End.Character {
+			return r, true
+		}
+	}
+
+	return precise.RangeData{}, false
+}
+
+// pathsFromLocations returns a deduplicated and sorted set of document paths of the given locations.
+func pathsFromLocations(locations []Location) []string {
+	pathMap := map[string]struct{}{}
+	for _, location := range locations {
+		pathMap[location.Path] = struct{}{}
+	}
+
+	paths := make([]string, 0, len(pathMap))
+	for path := range pathMap {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// callGrouper groups call sites by their caller or callee, in order of first occurrence.
+type callGrouper struct {
+	calls   []CallHierarchyCall
+	indexes map[Location]int
+}
+
+func newCallGrouper() *callGrouper {
+	return &callGrouper{indexes: map[Location]int{}}
+}
+
+func (g *callGrouper) add(location, callSite Location) {
+	index, ok := g.indexes[location]
+	if !ok {
+		index = len(g.calls)
+		g.indexes[location] = index
+		g.calls = append(g.calls, CallHierarchyCall{Location: location})
+	}
+
+	g.calls[index].CallSites = append(g.calls[index].CallSites, callSite)
+}
@@ -0,0 +1,75 @@
+package lsifstore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// 10: func outer() {
+// 11:     inner := func() {
+// 12:         helper()
+// 13:     }
+// 14:     helper()
+// 15:     inner()
+// 16: }
+var testCallHierarchyDocument = precise.DocumentData{
+	Ranges: map[precise.ID]precise.RangeData{
+		"outer": {
+			StartLine: 10, StartCharacter: 5, EndLine: 10, EndCharacter: 10,
+			DefinitionResultID: "d-outer",
+			Symbol:             &precise.SymbolData{Kind: protocol.Function, StartLine: 10, StartCharacter: 0, EndLine: 16, EndCharacter: 1},
+		},
+		"inner": {
+			StartLine: 11, StartCharacter: 1, EndLine: 11, EndCharacter: 6,
+			DefinitionResultID: "d-inner",
+			Symbol:             &precise.SymbolData{Kind: protocol.Function, StartLine: 11, StartCharacter: 10, EndLine: 13, EndCharacter: 2},
+		},
+		"helper-1": {StartLine: 12, StartCharacter: 2, EndLine: 12, EndCharacter: 8, DefinitionResultID: "d-helper"},
+		"helper-2": {StartLine: 14, StartCharacter: 1, EndLine: 14, EndCharacter: 7, DefinitionResultID: "d-helper"},
+		"inner-1":  {StartLine: 15, StartCharacter: 1, EndLine: 15, EndCharacter: 6, DefinitionResultID: "d-inner"},
+		"other":    {StartLine: 20, StartCharacter: 1, EndLine: 20, EndCharacter: 6, DefinitionResultID: "d-helper"},
+	},
+}
+
+func TestCallSitesWithin(t *testing.T) {
+	symbol := *testCallHierarchyDocument.Ranges["outer"].Symbol
+
+	var ids []precise.ID
+	for _, r := range callSitesWithin(testCallHierarchyDocument, symbol) {
+		ids = append(ids, r.DefinitionResultID)
+	}
+
+	if diff := cmp.Diff([]precise.ID{"d-helper", "d-helper", "d-inner"}, ids); diff != "" {
+		t.Errorf("unexpected call sites (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnclosingCallable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rn       Range
+		expected Range
+		ok       bool
+	}{
+		{name: "nested", rn: newRange(12, 2, 12, 8), expected: newRange(11, 1, 11, 6), ok: true},
+		{name: "outer", rn: newRange(14, 1, 14, 7), expected: newRange(10, 5, 10, 10), ok: true},
+		{name: "definition", rn: newRange(10, 5, 10, 10)},
+		{name: "outside", rn: newRange(20, 1, 20, 6)},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			caller, ok := enclosingCallable(testCallHierarchyDocument, testCase.rn)
+			if ok != testCase.ok {
+				t.Fatalf("unexpected flag. want=%v have=%v", testCase.ok, ok)
+			}
+			if diff := cmp.Diff(testCase.expected, caller); diff != "" {
+				t.Errorf("unexpected caller (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ var tableNames = []string{
 	"lsif_data_documents",
 	"lsif_data_documents_schema_versions",
 	"lsif_data_result_chunks",
+	"lsif_data_result_chunk_generations",
 	"lsif_data_definitions",
 	"lsif_data_definitions_schema_versions",
 	"lsif_data_references",
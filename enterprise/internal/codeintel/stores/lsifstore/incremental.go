@@ -0,0 +1,474 @@
+package lsifstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// ResultChunkGeneration describes the result chunks written while processing a single upload. The
+// range and result set identifiers written by a generation lie within [IDOffset, IDBound). After
+// subtracting IDOffset, result set identifiers are hashed into NumResultChunks result chunks, the
+// first of which is stored at index ResultChunkOffset.
+//
+// A dump processed from an incremental upload contains the generations of each of its (transitive)
+// parents, whose documents and result chunks have been copied into the dump, followed by its own.
+type ResultChunkGeneration struct {
+	IDOffset          int
+	IDBound           int
+	ResultChunkOffset int
+	NumResultChunks   int
+}
+
+// ResultChunkGenerations returns the result chunk generations of the given bundle ordered by their
+// identifier offsets. Bundles processed before result chunk generations were tracked have none.
+func (s *Store) ResultChunkGenerations(ctx context.Context, bundleID int) (_ []ResultChunkGeneration, err error) {
+	ctx, endObservation := s.operations.resultChunkGenerations.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanResultChunkGenerations(s.Store.Query(ctx, sqlf.Sprintf(resultChunkGenerationsQuery, bundleID)))
+}
+
+const resultChunkGenerationsQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/incremental.go:ResultChunkGenerations
+SELECT id_offset, id_bound, result_chunk_offset, num_result_chunks
+FROM lsif_data_result_chunk_generations
+WHERE dump_id = %s
+ORDER BY id_offset
+`
+
+func scanResultChunkGenerations(rows *sql.Rows, queryErr error) (_ []ResultChunkGeneration, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var generations []ResultChunkGeneration
+	for rows.Next() {
+		var generation ResultChunkGeneration
+		if err := rows.Scan(
+			&generation.IDOffset,
+			&generation.IDBound,
+			&generation.ResultChunkOffset,
+			&generation.NumResultChunks,
+		); err != nil {
+			return nil, err
+		}
+
+		generations = append(generations, generation)
+	}
+
+	return generations, nil
+}
+
+// WriteResultChunkGenerations is called (transactionally) from the precise-code-intel-worker.
+func (s *Store) WriteResultChunkGenerations(ctx context.Context, bundleID int, generations []ResultChunkGeneration) (err error) {
+	ctx, endObservation := s.operations.writeResultChunkGenerations.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.Int("numGenerations", len(generations)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(generations) == 0 {
+		return nil
+	}
+
+	values := make([]*sqlf.Query, 0, len(generations))
+	for _, generation := range generations {
+		values = append(values, sqlf.Sprintf(
+			"(%s, %s, %s, %s, %s)",
+			bundleID,
+			generation.IDOffset,
+			generation.IDBound,
+			generation.ResultChunkOffset,
+			generation.NumResultChunks,
+		))
+	}
+
+	return s.Store.Exec(ctx, sqlf.Sprintf(writeResultChunkGenerationsQuery, sqlf.Join(values, ",")))
+}
+
+const writeResultChunkGenerationsQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/incremental.go:WriteResultChunkGenerations
+INSERT INTO lsif_data_result_chunk_generations (dump_id, id_offset, id_bound, result_chunk_offset, num_result_chunks)
+VALUES %s
+`
+
+// resultChunkIndex returns the index of the result chunk that contains the given result set identifier
+// given the result chunk generations of its bundle. If the identifier was not written by any of the
+// generations, a false-valued flag is returned.
+func resultChunkIndex(generations []ResultChunkGeneration, id precise.ID) (int, bool) {
+	value, err := strconv.Atoi(string(id))
+	if err != nil {
+		return 0, false
+	}
+
+	for _, generation := range generations {
+		if generation.IDOffset <= value && value < generation.IDBound {
+			// Identifiers were hashed into result chunks before the offset was applied
+			unshiftedID := precise.ID(strconv.Itoa(value - generation.IDOffset))
+			return generation.ResultChunkOffset + precise.HashKey(unshiftedID, generation.NumResultChunks), true
+		}
+	}
+
+	return 0, false
+}
+
+// CopyDocuments is called (transactionally) from the precise-code-intel-worker. This method copies
+// the documents of the source bundle into the target bundle, skipping documents with one of the given
+// paths as well as documents already present in the target bundle.
+func (s *Store) CopyDocuments(ctx context.Context, sourceBundleID, targetBundleID int, excludedPaths []string) (count uint32, err error) {
+	ctx, endObservation := s.operations.copyDocuments.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("sourceBundleID", sourceBundleID),
+		log.Int("targetBundleID", targetBundleID),
+		log.Int("numExcludedPaths", len(excludedPaths)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if excludedPaths == nil {
+		excludedPaths = []string{}
+	}
+
+	n, _, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(
+		copyDocumentsQuery,
+		targetBundleID,
+		sourceBundleID,
+		pq.Array(excludedPaths),
+	)))
+	return uint32(n), err
+}
+
+const copyDocumentsQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/incremental.go:CopyDocuments
+WITH inserted AS (
+	INSERT INTO lsif_data_documents (dump_id, path, data, schema_version, num_diagnostics, ranges, hovers, monikers, packages, diagnostics)
+	SELECT %s, d.path, d.data, d.schema_version, d.num_diagnostics, d.ranges, d.hovers, d.monikers, d.packages, d.diagnostics
+	FROM lsif_data_documents d
+	WHERE d.dump_id = %s AND NOT (d.path = ANY(%s))
+	ON CONFLICT DO NOTHING
+	RETURNING 1
+)
+SELECT COUNT(*) FROM inserted
+`
+
+// CopyResultChunks is called (transactionally) from the precise-code-intel-worker. This method copies
+// every result chunk of the source bundle into the target bundle. The indexes of the copied result chunks
+// must not overlap the result chunks written for the target bundle.
+func (s *Store) CopyResultChunks(ctx context.Context, sourceBundleID, targetBundleID int) (count uint32, err error) {
+	ctx, endObservation := s.operations.copyResultChunks.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("sourceBundleID", sourceBundleID),
+		log.Int("targetBundleID", targetBundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	n, _, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(copyResultChunksQuery, targetBundleID, sourceBundleID)))
+	return uint32(n), err
+}
+
+const copyResultChunksQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/incremental.go:CopyResultChunks
+WITH inserted AS (
+	INSERT INTO lsif_data_result_chunks (dump_id, idx, data)
+	SELECT %s, rc.idx, rc.data
+	FROM lsif_data_result_chunks rc
+	WHERE rc.dump_id = %s
+	RETURNING 1
+)
+SELECT COUNT(*) FROM inserted
+`
+
+// monikerSchemaVersions maps the moniker tables that can be copied between bundles to the schema
+// version used for new rows of that table.
+var monikerSchemaVersions = map[string]int{
+	"definitions":     CurrentDefinitionsSchemaVersion,
+	"references":      CurrentReferencesSchemaVersion,
+	"implementations": CurrentImplementationsSchemaVersion,
+}
+
+// CopyMonikerLocations is called (transactionally) from the precise-code-intel-worker after the moniker
+// locations of the target bundle have been written to the given table. This method merges the moniker
+// locations of the source bundle into the target bundle, where the given replaced paths denote documents
+// whose data in the source bundle is stale.
+//
+// Only affected monikers, those attached to a replaced document of the source bundle or already written
+// for the target bundle, are decoded and recomputed. The rows of all other monikers are copied as-is.
+func (s *Store) CopyMonikerLocations(ctx context.Context, tableName string, sourceBundleID, targetBundleID int, replacedPaths []string) (count uint32, err error) {
+	ctx, traceLog, endObservation := s.operations.copyMonikerLocations.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("tableName", tableName),
+		log.Int("sourceBundleID", sourceBundleID),
+		log.Int("targetBundleID", targetBundleID),
+		log.Int("numReplacedPaths", len(replacedPaths)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	version, ok := monikerSchemaVersions[tableName]
+	if !ok {
+		return 0, errors.Errorf("unknown moniker table %q", tableName)
+	}
+	qualifiedTableName := fmt.Sprintf("lsif_data_%s", tableName)
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	targetMonikers, err := scanMonikerKeys(tx.Store.Query(ctx, sqlf.Sprintf(copyMonikerLocationsTargetMonikersQuery, sqlf.Sprintf(qualifiedTableName), targetBundleID)))
+	if err != nil {
+		return 0, err
+	}
+	replacedMonikers, err := tx.documentMonikers(ctx, sourceBundleID, replacedPaths)
+	if err != nil {
+		return 0, err
+	}
+	schemes, identifiers := monikerKeyArrays(targetMonikers, replacedMonikers)
+	traceLog(
+		log.Int("numTargetMonikers", len(targetMonikers)),
+		log.Int("numReplacedMonikers", len(replacedMonikers)),
+		log.Int("numAffectedMonikers", len(schemes)),
+	)
+
+	// Copy rows of unaffected monikers without decoding them
+	copied, _, err := basestore.ScanFirstInt(tx.Store.Query(ctx, sqlf.Sprintf(
+		copyMonikerLocationsQuery,
+		sqlf.Sprintf(qualifiedTableName),
+		targetBundleID,
+		sqlf.Sprintf(qualifiedTableName),
+		sourceBundleID,
+		pq.Array(schemes),
+		pq.Array(identifiers),
+	)))
+	if err != nil {
+		return 0, err
+	}
+	count = uint32(copied)
+	traceLog(log.Int("numCopiedRecords", copied))
+
+	if len(schemes) == 0 {
+		return count, nil
+	}
+
+	// Merge the locations of the affected monikers in the source bundle that fall outside of the replaced
+	// documents with the locations of the same moniker written for the target bundle
+	affectedLocations, err := tx.scanQualifiedMonikerLocations(tx.Store.Query(ctx, sqlf.Sprintf(
+		copyMonikerLocationsAffectedLocationsQuery,
+		sqlf.Sprintf(qualifiedTableName),
+		sourceBundleID,
+		targetBundleID,
+		pq.Array(schemes),
+		pq.Array(identifiers),
+	)))
+	if err != nil {
+		return 0, err
+	}
+	mergedLocations := mergeMonikerLocations(affectedLocations, sourceBundleID, replacedPaths)
+
+	if err := tx.Store.Exec(ctx, sqlf.Sprintf(
+		copyMonikerLocationsDeleteQuery,
+		sqlf.Sprintf(qualifiedTableName),
+		targetBundleID,
+		pq.Array(schemes),
+		pq.Array(identifiers),
+	)); err != nil {
+		return 0, err
+	}
+
+	if err := batch.WithInserter(
+		ctx,
+		tx.Handle().DB(),
+		qualifiedTableName,
+		[]string{"dump_id", "schema_version", "scheme", "identifier", "data", "num_locations"},
+		func(inserter *batch.Inserter) error {
+			for _, monikerLocations := range mergedLocations {
+				data, err := s.serializer.MarshalLocations(monikerLocations.Locations)
+				if err != nil {
+					return err
+				}
+
+				if err := inserter.Insert(ctx, targetBundleID, version, monikerLocations.Scheme, monikerLocations.Identifier, data, len(monikerLocations.Locations)); err != nil {
+					return err
+				}
+
+				atomic.AddUint32(&count, 1)
+			}
+
+			return nil
+		},
+	); err != nil {
+		return 0, err
+	}
+	traceLog(log.Int("numMergedRecords", len(mergedLocations)))
+
+	return count, nil
+}
+
+const copyMonikerLocationsTargetMonikersQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/incremental.go:CopyMonikerLocations
+SELECT scheme, identifier FROM %s WHERE dump_id = %s
+`
+
+const copyMonikerLocationsQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/incremental.go:CopyMonikerLocations
+WITH inserted AS (
+	INSERT INTO %s (dump_id, schema_version, scheme, identifier, data, num_locations)
+	SELECT %s, m.schema_version, m.scheme, m.identifier, m.data, m.num_locations
+	FROM %s m
+	WHERE
+		m.dump_id = %s AND
+		(m.scheme, m.identifier) NOT IN (SELECT * FROM unnest(%s::text[], %s::text[]))
+	RETURNING 1
+)
+SELECT COUNT(*) FROM inserted
+`
+
+const copyMonikerLocationsAffectedLocationsQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/incremental.go:CopyMonikerLocations
+SELECT dump_id, scheme, identifier, data
+FROM %s
+WHERE
+	dump_id IN (%s, %s) AND
+	(scheme, identifier) IN (SELECT * FROM unnest(%s::text[], %s::text[]))
+ORDER BY scheme, identifier, dump_id
+`
+
+const copyMonikerLocationsDeleteQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/incremental.go:CopyMonikerLocations
+DELETE FROM %s WHERE dump_id = %s AND (scheme, identifier) IN (SELECT * FROM unnest(%s::text[], %s::text[]))
+`
+
+// documentMonikers returns the monikers attached to the ranges of the documents with the given paths.
+func (s *Store) documentMonikers(ctx context.Context, bundleID int, paths []string) ([]precise.MonikerData, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	var monikers []precise.MonikerData
+	if err := s.makeDocumentVisitor(func(_ string, document precise.DocumentData) {
+		for _, moniker := range document.Monikers {
+			monikers = append(monikers, moniker)
+		}
+	})(s.Store.Query(ctx, sqlf.Sprintf(documentMonikersQuery, bundleID, pq.Array(paths)))); err != nil {
+		return nil, err
+	}
+
+	return monikers, nil
+}
+
+const documentMonikersQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/incremental.go:documentMonikers
+SELECT
+	dump_id,
+	path,
+	data,
+	NULL AS ranges,
+	NULL AS hovers,
+	monikers,
+	NULL AS packages,
+	NULL AS diagnostics
+FROM
+	lsif_data_documents
+WHERE
+	dump_id = %s AND
+	path = ANY(%s)
+`
+
+// scanMonikerKeys reads moniker (scheme, identifier) pairs from the given row object.
+func scanMonikerKeys(rows *sql.Rows, queryErr error) (_ []precise.MonikerData, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var monikers []precise.MonikerData
+	for rows.Next() {
+		var moniker precise.MonikerData
+		if err := rows.Scan(&moniker.Scheme, &moniker.Identifier); err != nil {
+			return nil, err
+		}
+
+		monikers = append(monikers, moniker)
+	}
+
+	return monikers, nil
+}
+
+// monikerKeyArrays returns the deduplicated schemes and identifiers of the given monikers as parallel
+// slices that can be unnested together within a query.
+func monikerKeyArrays(monikerSets ...[]precise.MonikerData) (schemes, identifiers []string) {
+	type monikerKey struct{ scheme, identifier string }
+	seen := map[monikerKey]struct{}{}
+
+	schemes, identifiers = []string{}, []string{}
+	for _, monikers := range monikerSets {
+		for _, moniker := range monikers {
+			key := monikerKey{moniker.Scheme, moniker.Identifier}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			schemes = append(schemes, moniker.Scheme)
+			identifiers = append(identifiers, moniker.Identifier)
+		}
+	}
+
+	return schemes, identifiers
+}
+
+// mergeMonikerLocations combines the locations of each moniker in the given rows into a single value
+// per moniker. Locations of rows belonging to the given source bundle that fall into one of the given
+// replaced paths are discarded. Monikers without any remaining locations are omitted.
+func mergeMonikerLocations(rows []QualifiedMonikerLocations, sourceBundleID int, replacedPaths []string) []precise.MonikerLocations {
+	replaced := make(map[string]struct{}, len(replacedPaths))
+	for _, path := range replacedPaths {
+		replaced[path] = struct{}{}
+	}
+
+	type monikerKey struct{ scheme, identifier string }
+	merged := map[monikerKey][]precise.LocationData{}
+	for _, row := range rows {
+		key := monikerKey{row.Scheme, row.Identifier}
+
+		for _, location := range row.Locations {
+			if row.DumpID == sourceBundleID {
+				if _, ok := replaced[location.URI]; ok {
+					continue
+				}
+			}
+
+			merged[key] = append(merged[key], location)
+		}
+	}
+
+	monikerLocations := make([]precise.MonikerLocations, 0, len(merged))
+	for key, locations := range merged {
+		monikerLocations = append(monikerLocations, precise.MonikerLocations{
+			Scheme:     key.scheme,
+			Identifier: key.identifier,
+			Locations:  locations,
+		})
+	}
+	sort.Slice(monikerLocations, func(i, j int) bool {
+		if monikerLocations[i].Scheme != monikerLocations[j].Scheme {
+			return monikerLocations[i].Scheme < monikerLocations[j].Scheme
+		}
+		return monikerLocations[i].Identifier < monikerLocations[j].Identifier
+	})
+
+	return monikerLocations
+}
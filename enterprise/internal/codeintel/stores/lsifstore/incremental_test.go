@@ -0,0 +1,132 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestResultChunkIndex(t *testing.T) {
+	generations := []ResultChunkGeneration{
+		{IDOffset: 0, IDBound: 1000, ResultChunkOffset: 0, NumResultChunks: 4},
+		{IDOffset: 1000, IDBound: 1500, ResultChunkOffset: 4, NumResultChunks: 2},
+	}
+
+	testCases := []struct {
+		id            precise.ID
+		expectedIndex int
+		expectedOK    bool
+	}{
+		{id: "42", expectedIndex: precise.HashKey("42", 4), expectedOK: true},
+		{id: "999", expectedIndex: precise.HashKey("999", 4), expectedOK: true},
+		{id: "1042", expectedIndex: 4 + precise.HashKey("42", 2), expectedOK: true},
+		{id: "1500", expectedOK: false},
+		{id: "foo", expectedOK: false},
+	}
+
+	for _, testCase := range testCases {
+		index, ok := resultChunkIndex(generations, testCase.id)
+		if ok != testCase.expectedOK {
+			t.Errorf("unexpected flag for %q. want=%v have=%v", testCase.id, testCase.expectedOK, ok)
+		}
+		if index != testCase.expectedIndex {
+			t.Errorf("unexpected index for %q. want=%d have=%d", testCase.id, testCase.expectedIndex, index)
+		}
+	}
+}
+
+func TestMergeMonikerLocations(t *testing.T) {
+	rows := []QualifiedMonikerLocations{
+		{DumpID: 1, MonikerLocations: precise.MonikerLocations{Scheme: "gomod", Identifier: "pkg:Foo", Locations: []precise.LocationData{
+			{URI: "a.go", StartLine: 1},
+			{URI: "b.go", StartLine: 2},
+		}}},
+		{DumpID: 2, MonikerLocations: precise.MonikerLocations{Scheme: "gomod", Identifier: "pkg:Foo", Locations: []precise.LocationData{
+			{URI: "b.go", StartLine: 3},
+		}}},
+		{DumpID: 1, MonikerLocations: precise.MonikerLocations{Scheme: "gomod", Identifier: "pkg:Bar", Locations: []precise.LocationData{
+			{URI: "b.go", StartLine: 4},
+		}}},
+	}
+
+	expected := []precise.MonikerLocations{
+		{Scheme: "gomod", Identifier: "pkg:Foo", Locations: []precise.LocationData{
+			{URI: "a.go", StartLine: 1},
+			{URI: "b.go", StartLine: 3},
+		}},
+	}
+	if diff := cmp.Diff(expected, mergeMonikerLocations(rows, 1, []string{"b.go"})); diff != "" {
+		t.Errorf("unexpected moniker locations (-want +got):\n%s", diff)
+	}
+}
+
+func TestCopyDocumentsAndResultChunks(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := NewStore(db, conf.DefaultClient(), &observation.TestContext)
+	ctx := context.Background()
+
+	for _, path := range []string{"a.go", "b.go", "c.go"} {
+		query := sqlf.Sprintf("INSERT INTO lsif_data_documents (dump_id, path, schema_version, num_diagnostics) VALUES (1, %s, %s, 0)", path, CurrentDocumentSchemaVersion)
+
+		if _, err := db.Exec(query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+			t.Fatalf("unexpected error inserting document: %s", err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		query := sqlf.Sprintf("INSERT INTO lsif_data_result_chunks (dump_id, idx, data) VALUES (1, %s, %s)", i, []byte("payload"))
+
+		if _, err := db.Exec(query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+			t.Fatalf("unexpected error inserting result chunk: %s", err)
+		}
+	}
+
+	// Document c.go was also written for the target bundle
+	query := sqlf.Sprintf("INSERT INTO lsif_data_documents (dump_id, path, schema_version, num_diagnostics) VALUES (2, 'c.go', %s, 0)", CurrentDocumentSchemaVersion)
+	if _, err := db.Exec(query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+		t.Fatalf("unexpected error inserting document: %s", err)
+	}
+
+	if count, err := store.CopyDocuments(ctx, 1, 2, []string{"b.go"}); err != nil {
+		t.Fatalf("unexpected error copying documents: %s", err)
+	} else if count != 1 {
+		t.Errorf("unexpected number of copied documents. want=%d have=%d", 1, count)
+	}
+
+	paths, err := basestore.ScanStrings(store.Query(ctx, sqlf.Sprintf("SELECT path FROM lsif_data_documents WHERE dump_id = 2 ORDER BY path")))
+	if err != nil {
+		t.Fatalf("unexpected error querying paths: %s", err)
+	}
+	if diff := cmp.Diff([]string{"a.go", "c.go"}, paths); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+
+	if count, err := store.CopyResultChunks(ctx, 1, 2); err != nil {
+		t.Fatalf("unexpected error copying result chunks: %s", err)
+	} else if count != 3 {
+		t.Errorf("unexpected number of copied result chunks. want=%d have=%d", 3, count)
+	}
+
+	generations := []ResultChunkGeneration{
+		{IDOffset: 0, IDBound: 100, ResultChunkOffset: 0, NumResultChunks: 3},
+		{IDOffset: 100, IDBound: 150, ResultChunkOffset: 3, NumResultChunks: 1},
+	}
+	if err := store.WriteResultChunkGenerations(ctx, 2, generations); err != nil {
+		t.Fatalf("unexpected error writing result chunk generations: %s", err)
+	}
+	if storedGenerations, err := store.ResultChunkGenerations(ctx, 2); err != nil {
+		t.Fatalf("unexpected error reading result chunk generations: %s", err)
+	} else if diff := cmp.Diff(generations, storedGenerations); diff != "" {
+		t.Errorf("unexpected result chunk generations (-want +got):\n%s", diff)
+	}
+}
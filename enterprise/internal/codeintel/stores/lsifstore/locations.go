@@ -35,6 +35,13 @@ func (s *Store) Implementations(ctx context.Context, bundleID int, path string,
 	return s.definitionsReferences(ctx, extractor, operation, bundleID, path, line, character, limit, offset)
 }
 
+// TypeDefinitions returns the set of locations defining the type of the symbol at the given position.
+func (s *Store) TypeDefinitions(ctx context.Context, bundleID int, path string, line, character, limit, offset int) (_ []Location, _ int, err error) {
+	extractor := func(r precise.RangeData) precise.ID { return r.TypeDefinitionResultID }
+	operation := s.operations.typeDefinitions
+	return s.definitionsReferences(ctx, extractor, operation, bundleID, path, line, character, limit, offset)
+}
+
 func (s *Store) definitionsReferences(ctx context.Context, extractor func(r precise.RangeData) precise.ID, operation *observation.Operation, bundleID int, path string, line, character, limit, offset int) (_ []Location, _ int, err error) {
 	ctx, traceLog, endObservation := operation.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.Int("bundleID", bundleID),
@@ -212,20 +219,35 @@ var ErrNoMetadata = errors.New("no rows in meta table")
 // translateIDsToResultChunkIndexes converts a set of result set identifiers within a given bundle into
 // a deduplicated and sorted set of result chunk indexes that compoletely cover those identifiers.
 func (s *Store) translateIDsToResultChunkIndexes(ctx context.Context, bundleID int, ids []precise.ID) ([]int, error) {
-	// Mapping ids to result chunk indexes relies on the number of total result chunks written during
-	// processing so that we can hash identifiers to their parent result chunk in the same deterministic
-	// way.
-	numResultChunks, exists, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(translateIDsToResultChunkIndexesQuery, bundleID)))
+	// Bundles that contain data copied from a parent upload hash the identifiers of each upload
+	// into a distinct set of result chunks.
+	generations, err := scanResultChunkGenerations(s.Store.Query(ctx, sqlf.Sprintf(resultChunkGenerationsQuery, bundleID)))
 	if err != nil {
 		return nil, err
 	}
-	if !exists {
-		return nil, ErrNoMetadata
-	}
 
 	resultChunkIndexMap := map[int]struct{}{}
-	for _, id := range ids {
-		resultChunkIndexMap[precise.HashKey(id, numResultChunks)] = struct{}{}
+	if len(generations) > 0 {
+		for _, id := range ids {
+			if index, ok := resultChunkIndex(generations, id); ok {
+				resultChunkIndexMap[index] = struct{}{}
+			}
+		}
+	} else {
+		// Mapping ids to result chunk indexes relies on the number of total result chunks written during
+		// processing so that we can hash identifiers to their parent result chunk in the same deterministic
+		// way.
+		numResultChunks, exists, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(translateIDsToResultChunkIndexesQuery, bundleID)))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrNoMetadata
+		}
+
+		for _, id := range ids {
+			resultChunkIndexMap[precise.HashKey(id, numResultChunks)] = struct{}{}
+		}
 	}
 
 	indexes := make([]int, 0, len(resultChunkIndexMap))
@@ -464,7 +486,7 @@ func extractResultIDs(ranges []precise.RangeData, fn func(r precise.RangeData) p
 }
 
 const locationsDocumentQuery = `
--- source: enterprise/internal/codeintel/stores/lsifstore/locations.go:{Definitions,References}
+-- source: enterprise/internal/codeintel/stores/lsifstore/locations.go:{Definitions,References,Implementations,TypeDefinitions}
 SELECT
 	dump_id,
 	path,
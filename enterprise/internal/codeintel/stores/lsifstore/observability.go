@@ -10,6 +10,9 @@ import (
 type operations struct {
 	bulkMonikerResults              *observation.Operation
 	clear                           *observation.Operation
+	copyDocuments                   *observation.Operation
+	copyMonikerLocations            *observation.Operation
+	copyResultChunks                *observation.Operation
 	definitions                     *observation.Operation
 	deleteOldSearchRecords          *observation.Operation
 	diagnostics                     *observation.Operation
@@ -26,12 +29,17 @@ type operations struct {
 	exists                          *observation.Operation
 	hover                           *observation.Operation
 	implementations                 *observation.Operation
+	incomingCalls                   *observation.Operation
 	monikerResults                  *observation.Operation
 	monikersByPosition              *observation.Operation
+	outgoingCalls                   *observation.Operation
 	packageInformation              *observation.Operation
 	ranges                          *observation.Operation
 	references                      *observation.Operation
+	resultChunkGenerations          *observation.Operation
 	stencil                         *observation.Operation
+	typeDefinitions                 *observation.Operation
+	uploadStorageSizes              *observation.Operation
 	writeDefinitions                *observation.Operation
 	writeDocumentationMappings      *observation.Operation
 	writeDocumentationPages         *observation.Operation
@@ -42,6 +50,7 @@ type operations struct {
 	writeImplementations            *observation.Operation
 	writeMeta                       *observation.Operation
 	writeReferences                 *observation.Operation
+	writeResultChunkGenerations     *observation.Operation
 	writeResultChunks               *observation.Operation
 
 	locations           *observation.Operation
@@ -76,6 +85,9 @@ func newOperations(observationContext *observation.Context) *operations {
 	return &operations{
 		bulkMonikerResults:              op("BulkMonikerResults"),
 		clear:                           op("Clear"),
+		copyDocuments:                   op("CopyDocuments"),
+		copyMonikerLocations:            op("CopyMonikerLocations"),
+		copyResultChunks:                op("CopyResultChunks"),
 		definitions:                     op("Definitions"),
 		deleteOldSearchRecords:          op("DeleteOldSearchRecords"),
 		diagnostics:                     op("Diagnostics"),
@@ -92,12 +104,17 @@ func newOperations(observationContext *observation.Context) *operations {
 		exists:                          op("Exists"),
 		hover:                           op("Hover"),
 		implementations:                 op("Implementations"),
+		incomingCalls:                   op("IncomingCalls"),
 		monikerResults:                  op("MonikerResults"),
 		monikersByPosition:              op("MonikersByPosition"),
+		outgoingCalls:                   op("OutgoingCalls"),
 		packageInformation:              op("PackageInformation"),
 		ranges:                          op("Ranges"),
 		references:                      op("References"),
+		resultChunkGenerations:          op("ResultChunkGenerations"),
 		stencil:                         op("Stencil"),
+		typeDefinitions:                 op("TypeDefinitions"),
+		uploadStorageSizes:              op("UploadStorageSizes"),
 		writeDefinitions:                op("WriteDefinitions"),
 		writeDocumentationMappings:      op("WriteDocumentationMappings"),
 		writeDocumentationPages:         op("WriteDocumentationPages"),
@@ -108,6 +125,7 @@ func newOperations(observationContext *observation.Context) *operations {
 		writeImplementations:            op("WriteImplementations"),
 		writeMeta:                       op("WriteMeta"),
 		writeReferences:                 op("WriteReferences"),
+		writeResultChunkGenerations:     op("WriteResultChunkGenerations"),
 		writeResultChunks:               op("WriteResultChunks"),
 
 		locations:           subOp("locations"),
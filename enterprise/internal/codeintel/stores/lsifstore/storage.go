@@ -0,0 +1,77 @@
+package lsifstore
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// UploadStorageSizes returns the number of bytes occupied by the data of each of the given bundles,
+// summed over the rows of every table that is cleared when the bundle is deleted. Bundles without any
+// data are mapped to zero.
+func (s *Store) UploadStorageSizes(ctx context.Context, bundleIDs []int) (_ map[int]int64, err error) {
+	ctx, traceLog, endObservation := s.operations.uploadStorageSizes.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numBundleIDs", len(bundleIDs)),
+		log.String("bundleIDs", intsToString(bundleIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	sizes := make(map[int]int64, len(bundleIDs))
+	if len(bundleIDs) == 0 {
+		return sizes, nil
+	}
+
+	sort.Ints(bundleIDs)
+
+	ids := make([]*sqlf.Query, 0, len(bundleIDs))
+	for _, bundleID := range bundleIDs {
+		ids = append(ids, sqlf.Sprintf("%d", bundleID))
+		sizes[bundleID] = 0
+	}
+
+	for _, tableName := range tableNames {
+		traceLog(log.String("tableName", tableName))
+
+		tableSizes, err := scanIntInt64Pairs(s.Store.Query(ctx, sqlf.Sprintf(uploadStorageSizesQuery, sqlf.Sprintf(tableName), sqlf.Join(ids, ","))))
+		if err != nil {
+			return nil, err
+		}
+
+		for bundleID, size := range tableSizes {
+			sizes[bundleID] += size
+		}
+	}
+
+	return sizes, nil
+}
+
+const uploadStorageSizesQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/storage.go:UploadStorageSizes
+SELECT t.dump_id, SUM(pg_column_size(t.*))::bigint FROM %s t WHERE t.dump_id IN (%s) GROUP BY t.dump_id
+`
+
+func scanIntInt64Pairs(rows *sql.Rows, queryErr error) (_ map[int]int64, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	values := map[int]int64{}
+	for rows.Next() {
+		var value1 int
+		var value2 int64
+		if err := rows.Scan(&value1, &value2); err != nil {
+			return nil, err
+		}
+
+		values[value1] = value2
+	}
+
+	return values, nil
+}
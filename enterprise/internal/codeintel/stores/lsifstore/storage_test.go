@@ -0,0 +1,53 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestUploadStorageSizes(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := NewStore(db, conf.DefaultClient(), &observation.TestContext)
+
+	for i := 0; i < 3; i++ {
+		query := sqlf.Sprintf("INSERT INTO lsif_data_metadata (dump_id, num_result_chunks) VALUES (%s, 0)", i+1)
+
+		if _, err := db.Exec(query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+			t.Fatalf("unexpected error inserting metadata: %s", err)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		query := sqlf.Sprintf("INSERT INTO lsif_data_result_chunks (dump_id, idx, data) VALUES (1, %s, %s)", i, []byte("payload"))
+
+		if _, err := db.Exec(query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+			t.Fatalf("unexpected error inserting result chunk: %s", err)
+		}
+	}
+
+	sizes, err := store.UploadStorageSizes(context.Background(), []int{1, 2, 4})
+	if err != nil {
+		t.Fatalf("unexpected error getting storage sizes: %s", err)
+	}
+
+	if len(sizes) != 3 {
+		t.Fatalf("unexpected number of sizes. want=%d have=%d", 3, len(sizes))
+	}
+	if sizes[2] == 0 {
+		t.Errorf("expected non-zero size for bundle 2")
+	}
+	if sizes[1] <= sizes[2] {
+		t.Errorf("expected bundle 1 to be larger than bundle 2. have=%d and %d", sizes[1], sizes[2])
+	}
+	if sizes[4] != 0 {
+		t.Errorf("unexpected size for bundle 4. want=%d have=%d", 0, sizes[4])
+	}
+}
@@ -38,3 +38,10 @@ type CodeIntelligenceRange struct {
 	HoverText           string
 	DocumentationPathID string
 }
+
+// CallHierarchyCall pairs the definition of a caller or a callee with the locations of the calls
+// between them. Call sites are always located within the caller.
+type CallHierarchyCall struct {
+	Location  Location
+	CallSites []Location
+}
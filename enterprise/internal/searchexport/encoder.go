@@ -0,0 +1,129 @@
+package searchexport
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+)
+
+// encoder writes search matches to an export file.
+type encoder interface {
+	Encode(streamhttp.EventMatch) error
+	// Flush writes any buffered data to the underlying writer.
+	Flush() error
+}
+
+func newEncoder(format Format, w io.Writer) encoder {
+	if format == FormatCSV {
+		return newCSVEncoder(w)
+	}
+	return newJSONLEncoder(w)
+}
+
+// jsonlEncoder writes each match as a JSON object on its own line. Matches
+// are encoded exactly like in the streaming search API.
+type jsonlEncoder struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func newJSONLEncoder(w io.Writer) *jsonlEncoder {
+	bw := bufio.NewWriter(w)
+	return &jsonlEncoder{w: bw, enc: json.NewEncoder(bw)}
+}
+
+func (e *jsonlEncoder) Encode(m streamhttp.EventMatch) error {
+	return e.enc.Encode(m)
+}
+
+func (e *jsonlEncoder) Flush() error {
+	return e.w.Flush()
+}
+
+// csvHeader is the first row of a CSV export.
+var csvHeader = []string{"type", "repository", "commit", "path", "line", "preview"}
+
+// csvEncoder writes matches as CSV rows. File matches are written as one
+// row per line or symbol match.
+type csvEncoder struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) Encode(m streamhttp.EventMatch) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	for _, row := range csvRows(m) {
+		if err := e.w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvEncoder) Flush() error {
+	// Exports without results still have a header.
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.wroteHeader {
+		return nil
+	}
+	e.wroteHeader = true
+	return e.w.Write(csvHeader)
+}
+
+func csvRows(m streamhttp.EventMatch) [][]string {
+	switch v := m.(type) {
+	case *streamhttp.EventContentMatch:
+		if len(v.LineMatches) == 0 {
+			return [][]string{{"content", v.Repository, v.Commit, v.Path, "", ""}}
+		}
+		rows := make([][]string, 0, len(v.LineMatches))
+		for _, lm := range v.LineMatches {
+			// Line numbers are 0-based in the API, but 1-based in exports
+			// as that is what people expect to see in a spreadsheet.
+			rows = append(rows, []string{"content", v.Repository, v.Commit, v.Path, strconv.Itoa(int(lm.LineNumber) + 1), lm.Line})
+		}
+		return rows
+	case *streamhttp.EventPathMatch:
+		return [][]string{{"path", v.Repository, v.Commit, v.Path, "", ""}}
+	case *streamhttp.EventSymbolMatch:
+		rows := make([][]string, 0, len(v.Symbols))
+		for _, s := range v.Symbols {
+			rows = append(rows, []string{"symbol", v.Repository, v.Commit, v.Path, "", s.Name})
+		}
+		return rows
+	case *streamhttp.EventRepoMatch:
+		return [][]string{{"repo", v.Repository, "", "", "", ""}}
+	case *streamhttp.EventCommitMatch:
+		return [][]string{{"commit", v.Repository, "", "", "", v.Label}}
+	case *streamhttp.EventOwnerMatch:
+		return [][]string{{"owner", v.Repository, v.Commit, "", "", v.Owner}}
+	case *streamhttp.EventMatchDiffMatch:
+		rows := make([][]string, 0, len(v.LineMatches))
+		for _, lm := range v.LineMatches {
+			commit := v.HeadCommit
+			if lm.Status == "removed" {
+				commit = v.BaseCommit
+			}
+			rows = append(rows, []string{"matchdiff-" + lm.Status, v.Repository, commit, v.Path, strconv.Itoa(int(lm.LineNumber) + 1), lm.Line})
+		}
+		return rows
+	}
+	return nil
+}
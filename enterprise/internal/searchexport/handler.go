@@ -0,0 +1,201 @@
+package searchexport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// progressInterval is how often the result count of a running export is
+// written to the database.
+const progressInterval = 5 * time.Second
+
+// searchTimeout is the timeout of the search of an export. Exports run in the
+// background, so they are not bound by the timeout of interactive searches.
+const searchTimeout = time.Hour
+
+// ObjectKey returns the key of the exported file of job in the blob store.
+func ObjectKey(job *Job) string {
+	return fmt.Sprintf("search-exports/%d.%s", job.ID, job.Format)
+}
+
+type handler struct {
+	store               *Store
+	uploadStore         uploadstore.Store
+	frontendInternalURL string
+}
+
+var _ workerutil.Handler = &handler{}
+
+// NewHandler returns a workerutil.Handler that runs the query of a search
+// export job and uploads its results to uploadStore.
+func NewHandler(store *Store, uploadStore uploadstore.Store) workerutil.Handler {
+	return &handler{
+		store:               store,
+		uploadStore:         uploadStore,
+		frontendInternalURL: internalapi.Client.URL + "/.internal",
+	}
+}
+
+func (h *handler) Handle(ctx context.Context, record workerutil.Record) (err error) {
+	job, ok := record.(*Job)
+	if !ok {
+		return errors.Errorf("unexpected record type %T", record)
+	}
+	key := ObjectKey(job)
+
+	// Results are streamed to the blob store as they arrive, so the size of
+	// an export is not bounded by memory.
+	pr, pw := io.Pipe()
+	uploadErr := make(chan error, 1)
+	go func() {
+		_, err := h.uploadStore.Upload(ctx, key, pr)
+		// Unblock the writer if the upload failed early.
+		pr.CloseWithError(err)
+		uploadErr <- err
+	}()
+
+	count, err := h.export(ctx, job, pw)
+	pw.CloseWithError(err)
+	if uerr := <-uploadErr; err == nil {
+		err = uerr
+	}
+	if err != nil {
+		return err
+	}
+
+	return h.store.MarkUploaded(ctx, job.ID, key, count)
+}
+
+// export runs the query of job and writes its matches to w. It returns the
+// number of matches written.
+func (h *handler) export(ctx context.Context, job *Job, w io.Writer) (count int, err error) {
+	enc := newEncoder(job.Format, w)
+	lastProgress := time.Now()
+
+	var encodeErr error
+	// 🚨 SECURITY: We impersonate the user who created the export, so that
+	// only results from repositories they have access to are exported.
+	searchCtx := actor.WithActor(ctx, actor.FromUser(job.UserID))
+	err = h.runSearch(searchCtx, exportQuery(job.Query), func(matches []streamhttp.EventMatch) {
+		if encodeErr != nil {
+			return
+		}
+		for _, m := range matches {
+			if encodeErr = enc.Encode(m); encodeErr != nil {
+				return
+			}
+			count++
+		}
+
+		if time.Since(lastProgress) >= progressInterval {
+			lastProgress = time.Now()
+			if err := h.store.UpdateResultCount(ctx, job.ID, count); err != nil {
+				log15.Warn("failed to update search export progress", "id", job.ID, "error", err)
+			}
+		}
+	})
+	if err != nil {
+		return count, err
+	}
+	if encodeErr != nil {
+		return count, encodeErr
+	}
+	return count, enc.Flush()
+}
+
+var queryCountRegex = regexp.MustCompile(`\bcount:(\d+|all)\b`)
+
+// exportQuery returns query with count:all appended, unless query already
+// specifies a count, so that exports are not capped by the result limit of
+// interactive searches.
+func exportQuery(query string) string {
+	if queryCountRegex.MatchString(query) {
+		return query
+	}
+	return query + " count:all"
+}
+
+const internalSearchClientUserAgent = "Search export worker"
+
+func (h *handler) runSearch(ctx context.Context, query string, onMatches func([]streamhttp.EventMatch)) (err error) {
+	req, err := streamhttp.NewRequest(h.frontendInternalURL, query)
+	if err != nil {
+		return err
+	}
+	params := req.URL.Query()
+	params.Set("timeout", searchTimeout.String())
+	req.URL.RawQuery = params.Encode()
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", internalSearchClientUserAgent)
+	req.Header.Set("X-Sourcegraph-User-ID", actor.FromContext(ctx).UIDString())
+
+	resp, err := httpcli.InternalClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("search failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var progress *streamapi.Progress
+	dec := streamhttp.FrontendStreamDecoder{
+		OnProgress: func(p *streamapi.Progress) {
+			progress = p
+		},
+		OnMatches: onMatches,
+		OnError: func(ee *streamhttp.EventError) {
+			err = errors.New(ee.Message)
+		},
+	}
+	if decErr := dec.ReadAll(resp.Body); decErr != nil {
+		return decErr
+	}
+	if err != nil {
+		return err
+	}
+	return incompleteError(progress)
+}
+
+// incompleteError returns an error if the final progress of a search reports
+// that results were skipped, for example because repositories timed out or
+// were still cloning. Forks and archived repositories excluded by the query
+// do not make an export incomplete, and neither do match limits, which are
+// hit when the query specifies its own count.
+func incompleteError(progress *streamapi.Progress) error {
+	if progress == nil {
+		return nil
+	}
+
+	var titles []string
+	for _, skipped := range progress.Skipped {
+		switch skipped.Reason {
+		case streamapi.ExcludedFork, streamapi.ExcludedArchive:
+		case streamapi.DocumentMatchLimit, streamapi.ShardMatchLimit, streamapi.DisplayLimit:
+		default:
+			titles = append(titles, skipped.Title)
+		}
+	}
+	if len(titles) == 0 {
+		return nil
+	}
+	return errors.Errorf("search export is incomplete: %s", strings.Join(titles, ", "))
+}
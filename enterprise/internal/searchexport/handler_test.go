@@ -0,0 +1,157 @@
+package searchexport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+)
+
+func TestExportQuery(t *testing.T) {
+	cases := map[string]string{
+		"foo":                "foo count:all",
+		"foo count:10":       "foo count:10",
+		"count:all repo:foo": "count:all repo:foo",
+		"foo discount:10":    "foo discount:10 count:all",
+		"type:diff TODO":     "type:diff TODO count:all",
+	}
+	for input, want := range cases {
+		if got := exportQuery(input); got != want {
+			t.Errorf("exportQuery(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestHandlerExport(t *testing.T) {
+	matches := []streamhttp.EventMatch{
+		&streamhttp.EventContentMatch{
+			Type:       streamhttp.ContentMatchType,
+			Repository: "github.com/sourcegraph/sourcegraph",
+			Commit:     "deadbeef",
+			Path:       "main.go",
+			LineMatches: []streamhttp.EventLineMatch{
+				{Line: "package main", LineNumber: 0},
+				{Line: "func main() {}", LineNumber: 2},
+			},
+		},
+		&streamhttp.EventRepoMatch{
+			Type:       streamhttp.RepoMatchType,
+			Repository: "github.com/sourcegraph/src-cli",
+		},
+	}
+
+	var gotQuery, gotTimeout string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("q")
+		gotTimeout = r.URL.Query().Get("timeout")
+		ew, err := streamhttp.NewWriter(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		ew.Event("matches", matches)
+		ew.Event("done", struct{}{})
+	}))
+	t.Cleanup(ts.Close)
+
+	h := &handler{frontendInternalURL: ts.URL}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		count, err := h.export(context.Background(), &Job{ID: 1, UserID: 1, Query: "main", Format: FormatCSV}, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 2 {
+			t.Errorf("unexpected count %d", count)
+		}
+		if gotQuery != "main count:all" {
+			t.Errorf("unexpected query %q", gotQuery)
+		}
+		if gotTimeout != searchTimeout.String() {
+			t.Errorf("unexpected timeout %q", gotTimeout)
+		}
+
+		want := `type,repository,commit,path,line,preview
+content,github.com/sourcegraph/sourcegraph,deadbeef,main.go,1,package main
+content,github.com/sourcegraph/sourcegraph,deadbeef,main.go,3,func main() {}
+repo,github.com/sourcegraph/src-cli,,,,
+`
+		if diff := cmp.Diff(want, buf.String()); diff != "" {
+			t.Fatalf("unexpected export (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := h.export(context.Background(), &Job{ID: 1, UserID: 1, Query: "main", Format: FormatJSONL}, &buf); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []streamhttp.EventMatch
+		err := streamhttp.FrontendStreamDecoder{
+			OnMatches: func(m []streamhttp.EventMatch) { got = append(got, m...) },
+		}.ReadAll(bytes.NewReader(jsonlToEvents(buf.Bytes())))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(matches, got); diff != "" {
+			t.Fatalf("unexpected export (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestHandlerExport_incomplete(t *testing.T) {
+	var skipped []streamapi.Skipped
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ew, err := streamhttp.NewWriter(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		ew.Event("progress", streamapi.Progress{Skipped: skipped})
+		ew.Event("done", struct{}{})
+	}))
+	t.Cleanup(ts.Close)
+
+	h := &handler{frontendInternalURL: ts.URL}
+	export := func() error {
+		_, err := h.export(context.Background(), &Job{ID: 1, UserID: 1, Query: "main", Format: FormatCSV}, io.Discard)
+		return err
+	}
+
+	skipped = []streamapi.Skipped{{Reason: streamapi.ExcludedFork, Title: "1 forked"}}
+	if err := export(); err != nil {
+		t.Fatalf("unexpected error for excluded forks: %s", err)
+	}
+
+	skipped = []streamapi.Skipped{{Reason: streamapi.ShardMatchLimit, Title: "result limit hit"}}
+	if err := export(); err != nil {
+		t.Fatalf("unexpected error for match limit: %s", err)
+	}
+
+	skipped = []streamapi.Skipped{{Reason: streamapi.ShardTimeout, Title: "2 timed out"}}
+	if err := export(); err == nil || !strings.Contains(err.Error(), "2 timed out") {
+		t.Fatalf("expected incomplete export error, got %v", err)
+	}
+}
+
+// jsonlToEvents wraps each line of a JSONL export in a matches event, so that
+// it can be decoded with a streamhttp.FrontendStreamDecoder.
+func jsonlToEvents(b []byte) []byte {
+	var out bytes.Buffer
+	for _, line := range bytes.Split(bytes.TrimSpace(b), []byte("\n")) {
+		out.WriteString("event: matches\ndata: [")
+		out.Write(line)
+		out.WriteString("]\n\n")
+	}
+	return out.Bytes()
+}
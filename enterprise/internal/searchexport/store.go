@@ -0,0 +1,178 @@
+package searchexport
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// ErrNotFound is returned when a search export job does not exist.
+var ErrNotFound = errors.New("search export not found")
+
+// Store reads and writes search export jobs.
+type Store struct {
+	*basestore.Store
+}
+
+// NewStore returns a new Store backed by the given database.
+func NewStore(db dbutil.DB) *Store {
+	return &Store{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// With creates a new Store with the underlying database handle from the given
+// store.
+func (s *Store) With(other basestore.ShareableStore) *Store {
+	return &Store{Store: s.Store.With(other)}
+}
+
+// jobColumns are the columns of search_export_jobs read by scanJob.
+var jobColumns = []*sqlf.Query{
+	sqlf.Sprintf("search_export_jobs.id"),
+	sqlf.Sprintf("search_export_jobs.user_id"),
+	sqlf.Sprintf("search_export_jobs.query"),
+	sqlf.Sprintf("search_export_jobs.format"),
+	sqlf.Sprintf("search_export_jobs.object_key"),
+	sqlf.Sprintf("search_export_jobs.result_count"),
+	sqlf.Sprintf("search_export_jobs.state"),
+	sqlf.Sprintf("search_export_jobs.failure_message"),
+	sqlf.Sprintf("search_export_jobs.started_at"),
+	sqlf.Sprintf("search_export_jobs.finished_at"),
+	sqlf.Sprintf("search_export_jobs.process_after"),
+	sqlf.Sprintf("search_export_jobs.num_resets"),
+	sqlf.Sprintf("search_export_jobs.num_failures"),
+	sqlf.Sprintf("search_export_jobs.created_at"),
+	sqlf.Sprintf("search_export_jobs.updated_at"),
+}
+
+const createJobFmtStr = `
+INSERT INTO search_export_jobs (user_id, query, format)
+VALUES (%s, %s, %s)
+RETURNING %s
+`
+
+// Create enqueues a new export of the results of query for the given user.
+func (s *Store) Create(ctx context.Context, userID int32, query string, format Format) (*Job, error) {
+	q := sqlf.Sprintf(createJobFmtStr, userID, query, string(format), sqlf.Join(jobColumns, ", "))
+	return scanJob(s.QueryRow(ctx, q))
+}
+
+const getJobFmtStr = `
+SELECT %s FROM search_export_jobs
+WHERE id = %s
+`
+
+// GetByID returns the job with the given ID. ErrNotFound is returned if it
+// does not exist.
+func (s *Store) GetByID(ctx context.Context, id int64) (*Job, error) {
+	j, err := scanJob(s.QueryRow(ctx, sqlf.Sprintf(getJobFmtStr, sqlf.Join(jobColumns, ", "), id)))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return j, err
+}
+
+// ListOpts are the options for listing search export jobs.
+type ListOpts struct {
+	UserID int32
+	First  int
+	After  int64
+}
+
+const listJobsFmtStr = `
+SELECT %s FROM search_export_jobs
+WHERE %s
+ORDER BY id DESC
+LIMIT %s
+`
+
+// List returns the jobs of a user, most recent first.
+func (s *Store) List(ctx context.Context, opts ListOpts) ([]*Job, error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("user_id = %s", opts.UserID)}
+	if opts.After != 0 {
+		conds = append(conds, sqlf.Sprintf("id < %s", opts.After))
+	}
+	limit := sqlf.Sprintf("ALL")
+	if opts.First > 0 {
+		limit = sqlf.Sprintf("%s", opts.First)
+	}
+
+	q := sqlf.Sprintf(listJobsFmtStr, sqlf.Join(jobColumns, ", "), sqlf.Join(conds, "AND"), limit)
+	return scanJobs(s.Query(ctx, q))
+}
+
+// Count returns the number of jobs of a user.
+func (s *Store) Count(ctx context.Context, userID int32) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf("SELECT COUNT(*) FROM search_export_jobs WHERE user_id = %s", userID)))
+	return count, err
+}
+
+// UpdateResultCount records the number of results written so far.
+func (s *Store) UpdateResultCount(ctx context.Context, id int64, resultCount int) error {
+	return s.Exec(ctx, sqlf.Sprintf("UPDATE search_export_jobs SET result_count = %s, updated_at = NOW() WHERE id = %s", resultCount, id))
+}
+
+// MarkUploaded records the key of the exported file in the blob store and
+// the final number of results.
+func (s *Store) MarkUploaded(ctx context.Context, id int64, objectKey string, resultCount int) error {
+	return s.Exec(ctx, sqlf.Sprintf("UPDATE search_export_jobs SET object_key = %s, result_count = %s, updated_at = NOW() WHERE id = %s", objectKey, resultCount, id))
+}
+
+// ScanFirstJobRecord scans the first job of rows as a workerutil.Record.
+func ScanFirstJobRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	jobs, err := scanJobs(rows, err)
+	if err != nil || len(jobs) == 0 {
+		return nil, false, err
+	}
+	return jobs[0], true, nil
+}
+
+func scanJobs(rows *sql.Rows, queryErr error) (_ []*Job, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var jobs []*Job
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+func scanJob(sc dbutil.Scanner) (*Job, error) {
+	var (
+		j      Job
+		format string
+	)
+	if err := sc.Scan(
+		&j.ID,
+		&j.UserID,
+		&j.Query,
+		&format,
+		&dbutil.NullString{S: &j.ObjectKey},
+		&j.ResultCount,
+		&j.State,
+		&j.FailureMessage,
+		&j.StartedAt,
+		&j.FinishedAt,
+		&j.ProcessAfter,
+		&j.NumResets,
+		&j.NumFailures,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	j.Format = Format(format)
+	return &j, nil
+}
@@ -0,0 +1,71 @@
+package searchexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := dbtest.NewDB(t)
+	s := NewStore(db)
+
+	var userID int32
+	q := sqlf.Sprintf("INSERT INTO users (username) VALUES (%s) RETURNING id", "exporter")
+	if err := db.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&userID); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := s.Create(ctx, userID, "repo:foo bar", FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.State != "queued" || job.Format != FormatCSV || job.Query != "repo:foo bar" || job.ObjectKey != "" {
+		t.Fatalf("unexpected job %+v", job)
+	}
+
+	if err := s.UpdateResultCount(ctx, job.ID, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.MarkUploaded(ctx, job.ID, ObjectKey(job), 12); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.GetByID(ctx, job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ResultCount != 12 || got.ObjectKey != "search-exports/1.csv" {
+		t.Fatalf("unexpected job %+v", got)
+	}
+
+	if _, err := s.GetByID(ctx, job.ID+1); err != ErrNotFound {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err := s.Create(ctx, userID, "baz", FormatJSONL); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err := s.List(ctx, ListOpts{UserID: userID, First: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Query != "baz" {
+		t.Fatalf("unexpected jobs %+v", jobs)
+	}
+	count, err := s.Count(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("unexpected count %d", count)
+	}
+}
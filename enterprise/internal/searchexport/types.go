@@ -0,0 +1,67 @@
+// Package searchexport runs search queries in the background and writes
+// their results to the blob store, so that result sets larger than the
+// interactive result limits can be downloaded.
+package searchexport
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Format is the file format an export is written in.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// ParseFormat returns the Format with the given (case-insensitive) name.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatCSV, FormatJSONL:
+		return f, nil
+	}
+	return "", errors.Errorf("unsupported export format %q", s)
+}
+
+// ContentType returns the MIME type of files written in format f.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+// Job is an export of the results of a search query.
+type Job struct {
+	ID     int64
+	UserID int32
+	Query  string
+	Format Format
+
+	// ObjectKey is the key of the exported file in the blob store. It is
+	// empty until the export has completed.
+	ObjectKey string
+
+	// ResultCount is the number of results written so far.
+	ResultCount int
+
+	// Fields demanded for any dbworker.
+	State          string
+	FailureMessage *string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int32
+	NumFailures    int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (j *Job) RecordID() int {
+	return int(j.ID)
+}
@@ -0,0 +1,53 @@
+package searchexport
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// NewWorkerStore returns a dbworker store over the search_export_jobs table.
+func NewWorkerStore(handle *basestore.TransactableHandle, observationContext *observation.Context) dbworkerstore.Store {
+	return dbworkerstore.NewWithMetrics(handle, dbworkerstore.Options{
+		Name:              "search_export_worker_store",
+		TableName:         "search_export_jobs",
+		ColumnExpressions: jobColumns,
+		Scan:              ScanFirstJobRecord,
+		OrderByExpression: sqlf.Sprintf("search_export_jobs.id"),
+		StalledMaxAge:     60 * time.Second,
+		MaxNumResets:      3,
+		RetryAfter:        30 * time.Second,
+		MaxNumRetries:     3,
+	}, observationContext)
+}
+
+// NewWorker returns a worker that processes queued search export jobs.
+func NewWorker(ctx context.Context, store *Store, workerStore dbworkerstore.Store, uploadStore uploadstore.Store, observationContext *observation.Context) *workerutil.Worker {
+	options := workerutil.WorkerOptions{
+		Name:              "search_export_worker",
+		NumHandlers:       2,
+		Interval:          5 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           workerutil.NewMetrics(observationContext, "search_export_worker"),
+	}
+	return dbworker.NewWorker(ctx, workerStore, NewHandler(store, uploadStore), options)
+}
+
+// NewResetter returns a resetter that moves stalled search export jobs back
+// to the queue.
+func NewResetter(workerStore dbworkerstore.Store, observationContext *observation.Context) *dbworker.Resetter {
+	options := dbworker.ResetterOptions{
+		Name:     "search_export_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics:  *dbworker.NewMetrics(observationContext, "search_export_worker"),
+	}
+	return dbworker.NewResetter(workerStore, options)
+}
@@ -186,6 +186,9 @@ type ConfigSavedQuery struct {
 	UserID          *int32  `json:"userID"`
 	OrgID           *int32  `json:"orgID"`
 	SlackWebhookURL *string `json:"slackWebhookURL"`
+	NotifyWebhook   bool    `json:"notifyWebhook,omitempty"`
+	WebhookURL      *string `json:"webhookURL,omitempty"`
+	WebhookSecret   *string `json:"webhookSecret,omitempty"`
 }
 
 func (sq ConfigSavedQuery) Equals(other ConfigSavedQuery) bool {
@@ -184,6 +184,20 @@ func CodeIntelAutoIndexingPolicyRepositoryMatchLimit() int {
 	return *val
 }
 
+func CodeIntelStorageQuotaRepositoryBytes() int64 {
+	if val := Get().CodeIntelStorageQuotaRepositoryBytes; val != nil && *val > 0 {
+		return int64(*val)
+	}
+	return 0
+}
+
+func CodeIntelStorageQuotaOrganizationBytes() int64 {
+	if val := Get().CodeIntelStorageQuotaOrganizationBytes; val != nil && *val > 0 {
+		return int64(*val)
+	}
+	return 0
+}
+
 func ProductResearchPageEnabled() bool {
 	if enabled := Get().ProductResearchPageEnabled; enabled != nil {
 		return *enabled
@@ -362,6 +376,19 @@ func GitMaxConcurrentClones() int {
 	return v
 }
 
+// GitReplicationFactor returns the number of gitservers which keep a copy of
+// each repository. It is never larger than the number of gitservers.
+func GitReplicationFactor() int {
+	v := Get().GitReplicationFactor
+	if v <= 0 {
+		return 1
+	}
+	if n := len(Get().ServiceConnections().GitServers); n > 0 && v > n {
+		return n
+	}
+	return v
+}
+
 func UserReposMaxPerUser() int {
 	v := Get().UserReposMaxPerUser
 	if v == 0 {
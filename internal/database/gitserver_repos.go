@@ -110,6 +110,11 @@ func (s *GitserverRepoStore) IterateRepoGitserverStatus(ctx context.Context, opt
 			&dbutil.NullTime{Time: &gr.LastFetched},
 			&dbutil.NullTime{Time: &gr.LastChanged},
 			&dbutil.NullTime{Time: &gr.UpdatedAt},
+			&dbutil.NullString{S: &gr.RebalanceFrom},
+			&dbutil.NullTime{Time: &gr.RebalanceStartedAt},
+			&dbutil.NullTime{Time: &gr.LastMaintenanceAt},
+			&dbutil.NullString{S: &gr.LastMaintenanceError},
+			&dbutil.NullInt{N: &gr.PackCount},
 		); err != nil {
 			return errors.Wrap(err, "scanning row")
 		}
@@ -146,7 +151,12 @@ SELECT
 	gr.last_error,
 	gr.last_fetched,
 	gr.last_changed,
-	gr.updated_at
+	gr.updated_at,
+	gr.rebalance_from,
+	gr.rebalance_started_at,
+	gr.last_maintenance_at,
+	gr.last_maintenance_error,
+	gr.pack_count
 FROM repo
 LEFT JOIN gitserver_repos gr ON gr.repo_id = repo.id
 WHERE repo.deleted_at IS NULL
@@ -163,7 +173,12 @@ const iterateRepoGitserverStatusWithoutShardQuery = `
 		NULL AS last_error,
 		NULL AS last_fetched,
 		NULL AS last_changed,
-		NULL AS updated_at
+		NULL AS updated_at,
+		NULL AS rebalance_from,
+		NULL AS rebalance_started_at,
+		NULL AS last_maintenance_at,
+		NULL AS last_maintenance_error,
+		NULL AS pack_count
 	FROM repo
 	WHERE repo.deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM gitserver_repos gr WHERE gr.repo_id = repo.id)
 ) UNION ALL (
@@ -175,7 +190,12 @@ const iterateRepoGitserverStatusWithoutShardQuery = `
 		gr.last_error,
 		gr.last_fetched,
 		gr.last_changed,
-		gr.updated_at
+		gr.updated_at,
+		gr.rebalance_from,
+		gr.rebalance_started_at,
+	gr.last_maintenance_at,
+	gr.last_maintenance_error,
+	gr.pack_count
 	FROM repo
 	JOIN gitserver_repos gr ON gr.repo_id = repo.id
 	WHERE repo.deleted_at IS NULL AND gr.shard_id = ''
@@ -192,12 +212,46 @@ SELECT
        last_error,
        last_fetched,
        last_changed,
-       updated_at
+       updated_at,
+       rebalance_from,
+       rebalance_started_at,
+       last_maintenance_at,
+       last_maintenance_error,
+       pack_count
 FROM gitserver_repos
 WHERE repo_id = %s
 `
 
-	row := s.QueryRow(ctx, sqlf.Sprintf(q, id))
+	return s.getOne(ctx, sqlf.Sprintf(q, id))
+}
+
+// GetByName returns the gitserver_repos row of the repo with the given name.
+func (s *GitserverRepoStore) GetByName(ctx context.Context, name api.RepoName) (*types.GitserverRepo, error) {
+	q := `
+-- source: internal/database/gitserver_repos.go:GitserverRepoStore.GetByName
+SELECT
+       gr.repo_id,
+       gr.clone_status,
+       gr.shard_id,
+       gr.last_error,
+       gr.last_fetched,
+       gr.last_changed,
+       gr.updated_at,
+       gr.rebalance_from,
+       gr.rebalance_started_at,
+       gr.last_maintenance_at,
+       gr.last_maintenance_error,
+       gr.pack_count
+FROM gitserver_repos gr
+JOIN repo ON repo.id = gr.repo_id
+WHERE repo.name = %s
+`
+
+	return s.getOne(ctx, sqlf.Sprintf(q, name))
+}
+
+func (s *GitserverRepoStore) getOne(ctx context.Context, q *sqlf.Query) (*types.GitserverRepo, error) {
+	row := s.QueryRow(ctx, q)
 	if row.Err() != nil {
 		return nil, errors.Wrap(row.Err(), "getting GitserverRepo")
 	}
@@ -211,6 +265,11 @@ WHERE repo_id = %s
 		&dbutil.NullTime{Time: &gr.LastFetched},
 		&dbutil.NullTime{Time: &gr.LastChanged},
 		&gr.UpdatedAt,
+		&dbutil.NullString{S: &gr.RebalanceFrom},
+		&dbutil.NullTime{Time: &gr.RebalanceStartedAt},
+		&dbutil.NullTime{Time: &gr.LastMaintenanceAt},
+		&dbutil.NullString{S: &gr.LastMaintenanceError},
+		&dbutil.NullInt{N: &gr.PackCount},
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "scanning GitserverRepo")
@@ -260,6 +319,49 @@ WHERE gitserver_repos.last_error IS DISTINCT FROM EXCLUDED.last_error
 	return errors.Wrap(err, "setting last error")
 }
 
+// SetRebalanceStarted records that the repo is being transferred from the
+// gitserver at fromAddr while rebalancing.
+func (s *GitserverRepoStore) SetRebalanceStarted(ctx context.Context, name api.RepoName, fromAddr string) error {
+	err := s.Exec(ctx, sqlf.Sprintf(`
+-- source: internal/database/gitserver_repos.go:GitserverRepoStore.SetRebalanceStarted
+UPDATE gitserver_repos
+SET rebalance_from = %s, rebalance_started_at = now(), updated_at = now()
+FROM repo
+WHERE repo.id = gitserver_repos.repo_id AND repo.name = %s
+`, fromAddr, name))
+
+	return errors.Wrap(err, "setting rebalance started")
+}
+
+// SetRebalanceFinished records that the transfer of the repo between
+// gitservers has completed.
+func (s *GitserverRepoStore) SetRebalanceFinished(ctx context.Context, name api.RepoName) error {
+	err := s.Exec(ctx, sqlf.Sprintf(`
+-- source: internal/database/gitserver_repos.go:GitserverRepoStore.SetRebalanceFinished
+UPDATE gitserver_repos
+SET rebalance_from = NULL, updated_at = now()
+FROM repo
+WHERE repo.id = gitserver_repos.repo_id AND repo.name = %s AND gitserver_repos.rebalance_from IS NOT NULL
+`, name))
+
+	return errors.Wrap(err, "setting rebalance finished")
+}
+
+// SetLastMaintenance records the result of a maintenance run on the repo and
+// the number of packfiles it has afterwards. maintenanceErr is empty if the
+// run succeeded.
+func (s *GitserverRepoStore) SetLastMaintenance(ctx context.Context, name api.RepoName, packCount int, maintenanceErr string) error {
+	err := s.Exec(ctx, sqlf.Sprintf(`
+-- source: internal/database/gitserver_repos.go:GitserverRepoStore.SetLastMaintenance
+UPDATE gitserver_repos
+SET last_maintenance_at = now(), last_maintenance_error = %s, pack_count = %s, updated_at = now()
+FROM repo
+WHERE repo.id = gitserver_repos.repo_id AND repo.name = %s
+`, dbutil.NewNullString(sanitizeToUTF8(maintenanceErr)), packCount, name))
+
+	return errors.Wrap(err, "setting last maintenance")
+}
+
 // GitserverFetchData is the metadata associated with a fetch operation on
 // gitserver.
 type GitserverFetchData struct {
@@ -479,3 +479,102 @@ func TestSanitizeToUTF8(t *testing.T) {
 		}
 	}
 }
+
+func TestGitserverReposRebalance(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	repo1 := &types.Repo{
+		Name: "github.com/sourcegraph/repo1",
+		URI:  "github.com/sourcegraph/repo1",
+	}
+	if err := Repos(db).Create(ctx, repo1); err != nil {
+		t.Fatal(err)
+	}
+	if err := GitserverRepos(db).Upsert(ctx, &types.GitserverRepo{
+		RepoID:      repo1.ID,
+		ShardID:     "gitserver-1",
+		CloneStatus: types.CloneStatusCloned,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := GitserverRepos(db).SetRebalanceStarted(ctx, repo1.Name, "gitserver-1:3178"); err != nil {
+		t.Fatal(err)
+	}
+	fromDB, err := GitserverRepos(db).GetByName(ctx, repo1.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromDB.RebalanceFrom != "gitserver-1:3178" || fromDB.RebalanceStartedAt.IsZero() {
+		t.Fatalf("expected rebalance to be started, got %+v", fromDB)
+	}
+
+	// Upserting the state on disk does not clear the rebalance.
+	fromDB.ShardID = "gitserver-2"
+	fromDB.CloneStatus = types.CloneStatusCloning
+	if err := GitserverRepos(db).Upsert(ctx, fromDB); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := GitserverRepos(db).SetRebalanceFinished(ctx, repo1.Name); err != nil {
+		t.Fatal(err)
+	}
+	fromDB, err = GitserverRepos(db).GetByID(ctx, repo1.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromDB.RebalanceFrom != "" || fromDB.ShardID != "gitserver-2" {
+		t.Fatalf("expected rebalance to be finished, got %+v", fromDB)
+	}
+}
+
+func TestGitserverReposSetLastMaintenance(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	db := dbtest.NewDB(t)
+	ctx := context.Background()
+
+	repo1 := &types.Repo{
+		Name: "github.com/sourcegraph/repo1",
+		URI:  "github.com/sourcegraph/repo1",
+	}
+	if err := Repos(db).Create(ctx, repo1); err != nil {
+		t.Fatal(err)
+	}
+	if err := GitserverRepos(db).Upsert(ctx, &types.GitserverRepo{
+		RepoID:      repo1.ID,
+		ShardID:     "gitserver-1",
+		CloneStatus: types.CloneStatusCloned,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := GitserverRepos(db).SetLastMaintenance(ctx, repo1.Name, 3, "oops"); err != nil {
+		t.Fatal(err)
+	}
+	fromDB, err := GitserverRepos(db).GetByName(ctx, repo1.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromDB.LastMaintenanceAt.IsZero() || fromDB.LastMaintenanceError != "oops" || fromDB.PackCount != 3 {
+		t.Fatalf("expected failed maintenance to be recorded, got %+v", fromDB)
+	}
+
+	if err := GitserverRepos(db).SetLastMaintenance(ctx, repo1.Name, 1, ""); err != nil {
+		t.Fatal(err)
+	}
+	fromDB, err = GitserverRepos(db).GetByID(ctx, repo1.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromDB.LastMaintenanceError != "" || fromDB.PackCount != 1 {
+		t.Fatalf("expected successful maintenance to be recorded, got %+v", fromDB)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
@@ -34,8 +35,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/jvmpackages"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/mercurial"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/phabricator"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/svn"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -474,10 +477,14 @@ func scanRepo(rows *sql.Rows, r *types.Repo) (err error) {
 		r.Metadata = new(awscodecommit.Repository)
 	case extsvc.TypeGitolite:
 		r.Metadata = new(gitolite.Repo)
+	case extsvc.TypeMercurial:
+		r.Metadata = new(mercurial.Repo)
 	case extsvc.TypePerforce:
 		r.Metadata = new(perforce.Depot)
 	case extsvc.TypePhabricator:
 		r.Metadata = new(phabricator.Repo)
+	case extsvc.TypeSVN:
+		r.Metadata = new(svn.Repo)
 	case extsvc.TypePagure:
 		r.Metadata = new(pagure.Project)
 	case extsvc.TypeOther:
@@ -572,6 +579,15 @@ type ReposListOptions struct {
 	// OnlyArchived excludes non-archived repositories from the list.
 	OnlyArchived bool
 
+	// Topics is a list of code host topics, all of which must be attached to
+	// all repositories returned in the list. Topics are read from the code host
+	// metadata of GitHub and GitLab repositories.
+	Topics []string
+
+	// DescriptionPatterns is a list of regular expressions, all of which must
+	// match the description of all repositories returned in the list.
+	DescriptionPatterns []string
+
 	// NoCloned excludes cloned repositories from the list.
 	NoCloned bool
 
@@ -894,6 +910,16 @@ func (s *repoStore) listSQL(ctx context.Context, opt ReposListOptions) (*sqlf.Qu
 	if opt.OnlyArchived {
 		where = append(where, sqlf.Sprintf("archived"))
 	}
+	for _, topic := range opt.Topics {
+		where = append(where, topicCond(topic))
+	}
+	for _, pattern := range opt.DescriptionPatterns {
+		pgPattern, err := postgresRegexp(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid description pattern %q", pattern)
+		}
+		where = append(where, sqlf.Sprintf("repo.description ~ %s", pgPattern))
+	}
 	if opt.NoCloned {
 		where = append(where, sqlf.Sprintf("(gr.clone_status = 'not_cloned' OR gr.clone_status IS NULL)"))
 	}
@@ -1031,6 +1057,19 @@ func (s *repoStore) listSQL(ctx context.Context, opt ReposListOptions) (*sqlf.Qu
 	return q, nil
 }
 
+// topicCond returns a condition matching repositories whose code host
+// metadata lists the given topic. GitHub stores topics as a GraphQL connection
+// (which only contains lowercase names), GitLab as a list of strings.
+func topicCond(topic string) *sqlf.Query {
+	githubTopics, _ := json.Marshal([]github.RepositoryTopic{{Topic: github.Topic{Name: strings.ToLower(topic)}}})
+	gitlabTopics, _ := json.Marshal([]string{topic})
+	return sqlf.Sprintf(
+		"((repo.external_service_type = %s AND repo.metadata->'RepositoryTopics'->'Nodes' @> %s::jsonb) OR (repo.external_service_type = %s AND repo.metadata->'topics' @> %s::jsonb))",
+		extsvc.TypeGitHub, string(githubTopics),
+		extsvc.TypeGitLab, string(gitlabTopics),
+	)
+}
+
 const userReposCTEFmtstr = `
 SELECT repo_id as id FROM external_service_repos WHERE user_id = %d
 `
@@ -1546,6 +1585,152 @@ func parsePattern(p string, caseSensitive bool) ([]*sqlf.Query, error) {
 	return []*sqlf.Query{sqlf.Sprintf("(%s)", sqlf.Join(conds, "OR"))}, nil
 }
 
+// postgresRegexp translates the given case-insensitive Go regular expression into an
+// equivalent case-sensitive Postgres regular expression. The syntax of the two engines
+// differs (e.g. in the meaning of "." and the flags they support), so the pattern is
+// rebuilt from its parse tree rather than passed through. An error is returned for
+// constructs that have no Postgres equivalent.
+func postgresRegexp(pattern string) (string, error) {
+	re, err := regexpsyntax.Parse(pattern, regexpsyntax.Perl|regexpsyntax.FoldCase)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := writePostgresRegexp(&b, re); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// maxPostgresRepeat is the largest bound Postgres accepts in a {m,n} repetition.
+const maxPostgresRepeat = 255
+
+func writePostgresRegexp(b *strings.Builder, re *regexpsyntax.Regexp) error {
+	switch re.Op {
+	case regexpsyntax.OpEmptyMatch:
+		b.WriteString("(?:)")
+	case regexpsyntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&regexpsyntax.FoldCase != 0 && unicode.SimpleFold(r) != r {
+				b.WriteByte('[')
+				for f := r; ; {
+					writePostgresRune(b, f, true)
+					if f = unicode.SimpleFold(f); f == r {
+						break
+					}
+				}
+				b.WriteByte(']')
+			} else {
+				writePostgresRune(b, r, false)
+			}
+		}
+	case regexpsyntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return errors.New("empty character classes are not supported")
+		}
+		b.WriteByte('[')
+		for i := 0; i < len(re.Rune); i += 2 {
+			writePostgresRune(b, re.Rune[i], true)
+			if re.Rune[i+1] != re.Rune[i] {
+				b.WriteByte('-')
+				writePostgresRune(b, re.Rune[i+1], true)
+			}
+		}
+		b.WriteByte(']')
+	case regexpsyntax.OpAnyCharNotNL:
+		b.WriteString(`[^\n]`)
+	case regexpsyntax.OpAnyChar:
+		// Postgres' "." matches newlines outside of newline-sensitive mode.
+		b.WriteString(".")
+	case regexpsyntax.OpBeginText:
+		b.WriteString("^")
+	case regexpsyntax.OpEndText:
+		b.WriteString("$")
+	case regexpsyntax.OpWordBoundary:
+		b.WriteString(`\y`)
+	case regexpsyntax.OpNoWordBoundary:
+		b.WriteString(`\Y`)
+	case regexpsyntax.OpCapture:
+		b.WriteString("(?:")
+		if err := writePostgresRegexp(b, re.Sub[0]); err != nil {
+			return err
+		}
+		b.WriteString(")")
+	case regexpsyntax.OpStar, regexpsyntax.OpPlus, regexpsyntax.OpQuest, regexpsyntax.OpRepeat:
+		b.WriteString("(?:")
+		if err := writePostgresRegexp(b, re.Sub[0]); err != nil {
+			return err
+		}
+		b.WriteString(")")
+		switch re.Op {
+		case regexpsyntax.OpStar:
+			b.WriteString("*")
+		case regexpsyntax.OpPlus:
+			b.WriteString("+")
+		case regexpsyntax.OpQuest:
+			b.WriteString("?")
+		default:
+			if re.Min > maxPostgresRepeat || re.Max > maxPostgresRepeat {
+				return errors.Errorf("repetition counts above %d are not supported", maxPostgresRepeat)
+			}
+			switch re.Max {
+			case -1:
+				fmt.Fprintf(b, "{%d,}", re.Min)
+			case re.Min:
+				fmt.Fprintf(b, "{%d}", re.Min)
+			default:
+				fmt.Fprintf(b, "{%d,%d}", re.Min, re.Max)
+			}
+		}
+		if re.Flags&regexpsyntax.NonGreedy != 0 {
+			b.WriteString("?")
+		}
+	case regexpsyntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := writePostgresRegexp(b, sub); err != nil {
+				return err
+			}
+		}
+	case regexpsyntax.OpAlternate:
+		b.WriteString("(?:")
+		for i, sub := range re.Sub {
+			if i > 0 {
+				b.WriteString("|")
+			}
+			if err := writePostgresRegexp(b, sub); err != nil {
+				return err
+			}
+		}
+		b.WriteString(")")
+	default:
+		// OpNoMatch, and OpBeginLine and OpEndLine from the (?m) flag.
+		return errors.Errorf("unsupported regular expression syntax: %s", re)
+	}
+	return nil
+}
+
+// writePostgresRune writes r to b, escaping it if it is special in a Postgres
+// regular expression or, if inClass is set, in a bracket expression.
+func writePostgresRune(b *strings.Builder, r rune, inClass bool) {
+	special := `\.+*?()|[]{}^$`
+	if inClass {
+		special = `\[]^-`
+	}
+	switch {
+	case strings.ContainsRune(special, r):
+		b.WriteByte('\\')
+		b.WriteRune(r)
+	case r < ' ' || r == 0x7f || (r > 0x7f && !unicode.IsPrint(r)):
+		if r > 0xffff {
+			fmt.Fprintf(b, `\U%08x`, r)
+		} else {
+			fmt.Fprintf(b, `\u%04x`, r)
+		}
+	default:
+		b.WriteRune(r)
+	}
+}
+
 // parseCursorConds returns the WHERE conditions for the given cursor
 func parseCursorConds(cs types.MultiCursor) (cond *sqlf.Query, err error) {
 	var (
@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
 )
@@ -1281,6 +1282,73 @@ func TestRepos_ListMinimalRepos_fork(t *testing.T) {
 	}
 }
 
+func TestRepos_ListMinimalRepos_topicsAndDescription(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := actor.WithInternalActor(context.Background())
+
+	githubRepo := &types.Repo{
+		Name:        "github.com/a/payments",
+		Description: "Payment processing service",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "a",
+			ServiceType: extsvc.TypeGitHub,
+			ServiceID:   "https://github.com/",
+		},
+		Metadata: &github.Repository{
+			RepositoryTopics: &github.RepositoryTopics{Nodes: []github.RepositoryTopic{
+				{Topic: github.Topic{Name: "payments"}},
+				{Topic: github.Topic{Name: "go"}},
+			}},
+		},
+	}
+	gitlabRepo := &types.Repo{
+		Name:        "gitlab.com/b/checkout",
+		Description: "Checkout frontend",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "b",
+			ServiceType: extsvc.TypeGitLab,
+			ServiceID:   "https://gitlab.com/",
+		},
+		Metadata: &gitlab.Project{Topics: []string{"payments", "typescript"}},
+	}
+	if err := Repos(db).Create(ctx, githubRepo, gitlabRepo); err != nil {
+		t.Fatal(err)
+	}
+
+	onGitHub := types.MinimalRepo{ID: githubRepo.ID, Name: githubRepo.Name}
+	onGitLab := types.MinimalRepo{ID: gitlabRepo.ID, Name: gitlabRepo.Name}
+
+	tests := []struct {
+		name string
+		opt  ReposListOptions
+		want []types.MinimalRepo
+	}{
+		{"topic on both", ReposListOptions{Topics: []string{"payments"}}, []types.MinimalRepo{onGitHub, onGitLab}},
+		{"topic on GitHub", ReposListOptions{Topics: []string{"Go"}}, []types.MinimalRepo{onGitHub}},
+		{"topic on GitLab", ReposListOptions{Topics: []string{"typescript"}}, []types.MinimalRepo{onGitLab}},
+		{"all topics must match", ReposListOptions{Topics: []string{"payments", "typescript"}}, []types.MinimalRepo{onGitLab}},
+		{"unknown topic", ReposListOptions{Topics: []string{"rust"}}, nil},
+		{"description", ReposListOptions{DescriptionPatterns: []string{"^payment"}}, []types.MinimalRepo{onGitHub}},
+		{"description and topic", ReposListOptions{DescriptionPatterns: []string{"checkout"}, Topics: []string{"payments"}}, []types.MinimalRepo{onGitLab}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.opt.OrderBy = RepoListOrderBy{{Field: RepoListID}}
+			repos, err := Repos(db).ListMinimalRepos(ctx, test.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertJSONEqual(t, test.want, repos)
+		})
+	}
+}
+
 func TestRepos_ListMinimalRepos_cloned(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -125,6 +125,35 @@ func TestParseIncludePattern(t *testing.T) {
 	}
 }
 
+func TestPostgresRegexp(t *testing.T) {
+	tests := map[string]string{
+		`ab1`:         `[Aa][Bb]1`,
+		`^go$`:        `^[Gg][Oo]$`,
+		`a.b`:         `[Aa][^\n][Bb]`,
+		`(?s)a.b`:     `[Aa].[Bb]`,
+		`x+?`:         `(?:[Xx])+?`,
+		`(ab|c){2,5}`: `(?:(?:(?:[Aa][Bb]|[Cc]))){2,5}`,
+		`[0-9]\.\d*`:  `[0-9]\.(?:[0-9])*`,
+		`\bfoo\B`:     `\y[Ff][Oo][Oo]\Y`,
+		`k`:           "[Kk\u212a]",
+		`a{3,}`:       `(?:[Aa]){3,}`,
+		`\$\{\}\[\]`:  `\$\{\}\[\]`,
+	}
+	for pattern, want := range tests {
+		if have, err := postgresRegexp(pattern); err != nil {
+			t.Errorf("unexpected error for %s: %s", pattern, err)
+		} else if have != want {
+			t.Errorf("got %q, want %q for %s", have, want, pattern)
+		}
+	}
+
+	for _, pattern := range []string{`(?m)^a$`, `a{256}`, `[^\x00-\x{10FFFF}]`, `(`} {
+		if _, err := postgresRegexp(pattern); err == nil {
+			t.Errorf("expected error for %s but got none", pattern)
+		}
+	}
+}
+
 func queriesToString(qs []*sqlf.Query) string {
 	q := sqlf.Join(qs, "AND")
 	return fmt.Sprintf("%s %s", q.Query(sqlf.PostgresBindVar), q.Args())
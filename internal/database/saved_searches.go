@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -88,7 +89,11 @@ func (s *savedSearchStore) ListAll(ctx context.Context) (savedSearches []api.Sav
 		notify_slack,
 		user_id,
 		org_id,
-		slack_webhook_url FROM saved_searches
+		slack_webhook_url,
+		notify_webhook,
+		webhook_url,
+		webhook_secret,
+		webhook_secret_encryption_key_id FROM saved_searches
 	`)
 	rows, err := s.Query(ctx, q)
 	if err != nil {
@@ -96,7 +101,10 @@ func (s *savedSearchStore) ListAll(ctx context.Context) (savedSearches []api.Sav
 	}
 
 	for rows.Next() {
-		var sq api.SavedQuerySpecAndConfig
+		var (
+			sq    api.SavedQuerySpecAndConfig
+			keyID string
+		)
 		if err := rows.Scan(
 			&sq.Config.Key,
 			&sq.Config.Description,
@@ -105,9 +113,16 @@ func (s *savedSearchStore) ListAll(ctx context.Context) (savedSearches []api.Sav
 			&sq.Config.NotifySlack,
 			&sq.Config.UserID,
 			&sq.Config.OrgID,
-			&sq.Config.SlackWebhookURL); err != nil {
+			&sq.Config.SlackWebhookURL,
+			&sq.Config.NotifyWebhook,
+			&sq.Config.WebhookURL,
+			&sq.Config.WebhookSecret,
+			&keyID); err != nil {
 			return nil, errors.Wrap(err, "Scan")
 		}
+		if sq.Config.WebhookSecret, err = decryptWebhookSecret(ctx, sq.Config.WebhookSecret, keyID); err != nil {
+			return nil, err
+		}
 		sq.Spec.Key = sq.Config.Key
 		if sq.Config.UserID != nil {
 			sq.Spec.Subject.User = sq.Config.UserID
@@ -126,7 +141,10 @@ func (s *savedSearchStore) ListAll(ctx context.Context) (savedSearches []api.Sav
 // user is an admin. It is the callers responsibility to ensure this response
 // only makes it to users with proper permissions to access the saved search.
 func (s *savedSearchStore) GetByID(ctx context.Context, id int32) (*api.SavedQuerySpecAndConfig, error) {
-	var sq api.SavedQuerySpecAndConfig
+	var (
+		sq    api.SavedQuerySpecAndConfig
+		keyID string
+	)
 	err := s.Handle().DB().QueryRowContext(ctx, `SELECT
 		id,
 		description,
@@ -135,7 +153,11 @@ func (s *savedSearchStore) GetByID(ctx context.Context, id int32) (*api.SavedQue
 		notify_slack,
 		user_id,
 		org_id,
-		slack_webhook_url
+		slack_webhook_url,
+		notify_webhook,
+		webhook_url,
+		webhook_secret,
+		webhook_secret_encryption_key_id
 		FROM saved_searches WHERE id=$1`, id).Scan(
 		&sq.Config.Key,
 		&sq.Config.Description,
@@ -144,10 +166,17 @@ func (s *savedSearchStore) GetByID(ctx context.Context, id int32) (*api.SavedQue
 		&sq.Config.NotifySlack,
 		&sq.Config.UserID,
 		&sq.Config.OrgID,
-		&sq.Config.SlackWebhookURL)
+		&sq.Config.SlackWebhookURL,
+		&sq.Config.NotifyWebhook,
+		&sq.Config.WebhookURL,
+		&sq.Config.WebhookSecret,
+		&keyID)
 	if err != nil {
 		return nil, err
 	}
+	if sq.Config.WebhookSecret, err = decryptWebhookSecret(ctx, sq.Config.WebhookSecret, keyID); err != nil {
+		return nil, err
+	}
 	sq.Spec.Key = sq.Config.Key
 	if sq.Config.UserID != nil {
 		sq.Spec.Subject.User = sq.Config.UserID
@@ -192,7 +221,11 @@ func (s *savedSearchStore) ListSavedSearchesByUserID(ctx context.Context, userID
 		notify_slack,
 		user_id,
 		org_id,
-		slack_webhook_url
+		slack_webhook_url,
+		notify_webhook,
+		webhook_url,
+		webhook_secret,
+		webhook_secret_encryption_key_id
 		FROM saved_searches %v`, conds)
 
 	rows, err := s.Query(ctx, query)
@@ -200,10 +233,16 @@ func (s *savedSearchStore) ListSavedSearchesByUserID(ctx context.Context, userID
 		return nil, errors.Wrap(err, "QueryContext(2)")
 	}
 	for rows.Next() {
-		var ss types.SavedSearch
-		if err := rows.Scan(&ss.ID, &ss.Description, &ss.Query, &ss.Notify, &ss.NotifySlack, &ss.UserID, &ss.OrgID, &ss.SlackWebhookURL); err != nil {
+		var (
+			ss    types.SavedSearch
+			keyID string
+		)
+		if err := rows.Scan(&ss.ID, &ss.Description, &ss.Query, &ss.Notify, &ss.NotifySlack, &ss.UserID, &ss.OrgID, &ss.SlackWebhookURL, &ss.NotifyWebhook, &ss.WebhookURL, &ss.WebhookSecret, &keyID); err != nil {
 			return nil, errors.Wrap(err, "Scan(2)")
 		}
+		if ss.WebhookSecret, err = decryptWebhookSecret(ctx, ss.WebhookSecret, keyID); err != nil {
+			return nil, err
+		}
 		savedSearches = append(savedSearches, &ss)
 	}
 	return savedSearches, nil
@@ -227,7 +266,11 @@ func (s *savedSearchStore) ListSavedSearchesByOrgID(ctx context.Context, orgID i
 		notify_slack,
 		user_id,
 		org_id,
-		slack_webhook_url
+		slack_webhook_url,
+		notify_webhook,
+		webhook_url,
+		webhook_secret,
+		webhook_secret_encryption_key_id
 		FROM saved_searches %v`, conds)
 
 	rows, err := s.Query(ctx, query)
@@ -235,10 +278,16 @@ func (s *savedSearchStore) ListSavedSearchesByOrgID(ctx context.Context, orgID i
 		return nil, errors.Wrap(err, "QueryContext")
 	}
 	for rows.Next() {
-		var ss types.SavedSearch
-		if err := rows.Scan(&ss.ID, &ss.Description, &ss.Query, &ss.Notify, &ss.NotifySlack, &ss.UserID, &ss.OrgID, &ss.SlackWebhookURL); err != nil {
+		var (
+			ss    types.SavedSearch
+			keyID string
+		)
+		if err := rows.Scan(&ss.ID, &ss.Description, &ss.Query, &ss.Notify, &ss.NotifySlack, &ss.UserID, &ss.OrgID, &ss.SlackWebhookURL, &ss.NotifyWebhook, &ss.WebhookURL, &ss.WebhookSecret, &keyID); err != nil {
 			return nil, errors.Wrap(err, "Scan")
 		}
+		if ss.WebhookSecret, err = decryptWebhookSecret(ctx, ss.WebhookSecret, keyID); err != nil {
+			return nil, err
+		}
 
 		savedSearches = append(savedSearches, &ss)
 	}
@@ -263,12 +312,20 @@ func (s *savedSearchStore) Create(ctx context.Context, newSavedSearch *types.Sav
 	}()
 
 	savedQuery = &types.SavedSearch{
-		Description: newSavedSearch.Description,
-		Query:       newSavedSearch.Query,
-		Notify:      newSavedSearch.Notify,
-		NotifySlack: newSavedSearch.NotifySlack,
-		UserID:      newSavedSearch.UserID,
-		OrgID:       newSavedSearch.OrgID,
+		Description:   newSavedSearch.Description,
+		Query:         newSavedSearch.Query,
+		Notify:        newSavedSearch.Notify,
+		NotifySlack:   newSavedSearch.NotifySlack,
+		UserID:        newSavedSearch.UserID,
+		OrgID:         newSavedSearch.OrgID,
+		NotifyWebhook: newSavedSearch.NotifyWebhook,
+		WebhookURL:    newSavedSearch.WebhookURL,
+		WebhookSecret: newSavedSearch.WebhookSecret,
+	}
+
+	webhookSecret, keyID, err := encryptWebhookSecret(ctx, newSavedSearch.WebhookSecret)
+	if err != nil {
+		return nil, err
 	}
 
 	err = s.Handle().DB().QueryRowContext(ctx, `INSERT INTO saved_searches(
@@ -277,14 +334,22 @@ func (s *savedSearchStore) Create(ctx context.Context, newSavedSearch *types.Sav
 			notify_owner,
 			notify_slack,
 			user_id,
-			org_id
-		) VALUES($1, $2, $3, $4, $5, $6) RETURNING id`,
+			org_id,
+			notify_webhook,
+			webhook_url,
+			webhook_secret,
+			webhook_secret_encryption_key_id
+		) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
 		newSavedSearch.Description,
 		savedQuery.Query,
 		newSavedSearch.Notify,
 		newSavedSearch.NotifySlack,
 		newSavedSearch.UserID,
 		newSavedSearch.OrgID,
+		newSavedSearch.NotifyWebhook,
+		newSavedSearch.WebhookURL,
+		webhookSecret,
+		keyID,
 	).Scan(&savedQuery.ID)
 	if err != nil {
 		return nil, err
@@ -312,6 +377,9 @@ func (s *savedSearchStore) Update(ctx context.Context, savedSearch *types.SavedS
 		UserID:          savedSearch.UserID,
 		OrgID:           savedSearch.OrgID,
 		SlackWebhookURL: savedSearch.SlackWebhookURL,
+		NotifyWebhook:   savedSearch.NotifyWebhook,
+		WebhookURL:      savedSearch.WebhookURL,
+		WebhookSecret:   savedSearch.WebhookSecret,
 	}
 
 	fieldUpdates := []*sqlf.Query{
@@ -323,6 +391,21 @@ func (s *savedSearchStore) Update(ctx context.Context, savedSearch *types.SavedS
 		sqlf.Sprintf("user_id=%v", savedSearch.UserID),
 		sqlf.Sprintf("org_id=%v", savedSearch.OrgID),
 		sqlf.Sprintf("slack_webhook_url=%v", savedSearch.SlackWebhookURL),
+		sqlf.Sprintf("notify_webhook=%t", savedSearch.NotifyWebhook),
+		sqlf.Sprintf("webhook_url=%v", savedSearch.WebhookURL),
+	}
+
+	// The webhook secret is write-only, so a nil secret keeps the existing
+	// one.
+	if savedSearch.WebhookSecret != nil {
+		webhookSecret, keyID, err := encryptWebhookSecret(ctx, savedSearch.WebhookSecret)
+		if err != nil {
+			return nil, err
+		}
+		fieldUpdates = append(fieldUpdates,
+			sqlf.Sprintf("webhook_secret=%v", webhookSecret),
+			sqlf.Sprintf("webhook_secret_encryption_key_id=%s", keyID),
+		)
 	}
 
 	updateQuery := sqlf.Sprintf(`UPDATE saved_searches SET %s WHERE ID=%v RETURNING id`, sqlf.Join(fieldUpdates, ", "), savedSearch.ID)
@@ -346,3 +429,32 @@ func (s *savedSearchStore) Delete(ctx context.Context, id int32) (err error) {
 	_, err = s.Handle().DB().ExecContext(ctx, `DELETE FROM saved_searches WHERE ID=$1`, id)
 	return err
 }
+
+// encryptWebhookSecret encrypts the given webhook secret with the saved search
+// webhook key, if one is configured. It returns the value to store along with
+// the identifier of the key used, which is empty if the secret is stored in
+// plaintext.
+func encryptWebhookSecret(ctx context.Context, secret *string) (*string, string, error) {
+	if secret == nil {
+		return nil, "", nil
+	}
+
+	data, keyID, err := MaybeEncrypt(ctx, keyring.Default().SavedSearchWebhookKey, *secret)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "encrypting webhook secret")
+	}
+	return &data, keyID, nil
+}
+
+// decryptWebhookSecret decrypts a webhook secret read from the database.
+func decryptWebhookSecret(ctx context.Context, secret *string, keyID string) (*string, error) {
+	if secret == nil {
+		return nil, nil
+	}
+
+	data, err := MaybeDecrypt(ctx, keyring.Default().SavedSearchWebhookKey, *secret, keyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting webhook secret")
+	}
+	return &data, nil
+}
@@ -100,6 +100,13 @@ func NewRing(ctx context.Context, keyConfig *schema.EncryptionKeys) (*Ring, erro
 		}
 	}
 
+	if keyConfig.SavedSearchWebhookKey != nil {
+		r.SavedSearchWebhookKey, err = NewKey(ctx, keyConfig.SavedSearchWebhookKey, keyConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if keyConfig.UserExternalAccountKey != nil {
 		r.UserExternalAccountKey, err = NewKey(ctx, keyConfig.UserExternalAccountKey, keyConfig)
 		if err != nil {
@@ -120,6 +127,7 @@ func NewRing(ctx context.Context, keyConfig *schema.EncryptionKeys) (*Ring, erro
 type Ring struct {
 	BatchChangesCredentialKey encryption.Key
 	ExternalServiceKey        encryption.Key
+	SavedSearchWebhookKey     encryption.Key
 	UserExternalAccountKey    encryption.Key
 	WebhookLogKey             encryption.Key
 }
@@ -1667,6 +1667,25 @@ type Repository struct {
 	// to identify if a repository is public or private or internal.
 	// https://developer.github.com/changes/2019-12-03-internal-visibility-changes/#repository-visibility-fields
 	Visibility Visibility `json:",omitempty"`
+
+	// RepositoryTopics are the topics the repository is tagged with. The
+	// shape mirrors the GraphQL API response.
+	RepositoryTopics *RepositoryTopics `json:",omitempty"`
+}
+
+// RepositoryTopics is the connection of topics a repository is tagged with.
+type RepositoryTopics struct {
+	Nodes []RepositoryTopic `json:",omitempty"`
+}
+
+// RepositoryTopic is a topic a repository is tagged with.
+type RepositoryTopic struct {
+	Topic Topic
+}
+
+// Topic is a GitHub topic.
+type Topic struct {
+	Name string
 }
 
 func ownerNameCacheKey(owner, name string) string       { return "0:" + owner + "/" + name }
@@ -1739,6 +1758,7 @@ type restRepository struct {
 	Stars       int                       `json:"stargazers_count"`
 	Forks       int                       `json:"forks_count"`
 	Visibility  string                    `json:"visibility"`
+	Topics      []string                  `json:"topics"`
 }
 
 // getRepositoryFromAPI attempts to fetch a repository from the GitHub API without use of the redis cache.
@@ -1776,6 +1796,13 @@ func convertRestRepo(restRepo restRepository) *Repository {
 		ForkCount:        restRepo.Forks,
 	}
 
+	if len(restRepo.Topics) > 0 {
+		repo.RepositoryTopics = &RepositoryTopics{Nodes: make([]RepositoryTopic, 0, len(restRepo.Topics))}
+		for _, topic := range restRepo.Topics {
+			repo.RepositoryTopics.Nodes = append(repo.RepositoryTopics.Nodes, RepositoryTopic{Topic: Topic{Name: topic}})
+		}
+	}
+
 	if conf.ExperimentalFeatures().EnableGithubInternalRepoVisibility {
 		repo.Visibility = Visibility(restRepo.Visibility)
 	}
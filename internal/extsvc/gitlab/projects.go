@@ -30,6 +30,7 @@ type Project struct {
 	Archived          bool           `json:"archived"`
 	StarCount         int            `json:"star_count"`
 	ForksCount        int            `json:"forks_count"`
+	Topics            []string       `json:"topics,omitempty"`
 }
 
 type ProjectCommon struct {
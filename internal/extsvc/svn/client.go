@@ -0,0 +1,113 @@
+package svn
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// Client lists the contents of a Subversion server which serves repositories
+// over HTTP(S) with WebDAV, such as Apache with mod_dav_svn.
+type Client struct {
+	httpClient httpcli.Doer
+
+	// URL is the root URL of the repositories.
+	URL *url.URL
+
+	username, password string
+}
+
+// NewClient returns a client for the Subversion server at rootURL. If a nil
+// httpClient is provided, httpcli.ExternalDoer will be used.
+func NewClient(rootURL *url.URL, username, password string, httpClient httpcli.Doer) *Client {
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+	return &Client{
+		httpClient: httpClient,
+		URL:        rootURL,
+		username:   username,
+		password:   password,
+	}
+}
+
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?><propfind xmlns="DAV:"><prop><resourcetype/></prop></propfind>`
+
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Prop struct {
+				ResourceType struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+			} `xml:"prop"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+// ListDirectories returns the names of the directories directly below the
+// root URL.
+func (c *Client) ListDirectories(ctx context.Context) ([]string, error) {
+	u := *c.URL
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	u.User = nil
+
+	req, err := http.NewRequest("PROPFIND", u.String(), strings.NewReader(propfindBody))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Depth", "1")
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("unexpected response from Subversion server: %s: %s", resp.Status, body)
+	}
+
+	var ms multistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response from Subversion server")
+	}
+
+	root := path.Clean(u.Path)
+	var dirs []string
+	for _, r := range ms.Responses {
+		href, err := url.Parse(r.Href)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid href %q in response from Subversion server", r.Href)
+		}
+		p := path.Clean(href.Path)
+		// The response includes the root itself.
+		if p == root || path.Dir(p) != root {
+			continue
+		}
+		for _, ps := range r.Propstat {
+			if ps.Prop.ResourceType.Collection != nil {
+				dirs = append(dirs, path.Base(p))
+				break
+			}
+		}
+	}
+	return dirs, nil
+}
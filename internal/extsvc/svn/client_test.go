@@ -0,0 +1,62 @@
+package svn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const listing = `<?xml version="1.0" encoding="utf-8"?>
+<D:multistatus xmlns:D="DAV:">
+<D:response>
+<D:href>/repos/</D:href>
+<D:propstat><D:prop><D:resourcetype><D:collection/></D:resourcetype></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat>
+</D:response>
+<D:response>
+<D:href>/repos/monorepo/</D:href>
+<D:propstat><D:prop><D:resourcetype><D:collection/></D:resourcetype></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat>
+</D:response>
+<D:response>
+<D:href>/repos/README.txt</D:href>
+<D:propstat><D:prop><D:resourcetype/></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat>
+</D:response>
+<D:response>
+<D:href>/repos/legacy%20billing/</D:href>
+<D:propstat><D:prop><D:resourcetype><D:collection/></D:resourcetype></D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat>
+</D:response>
+</D:multistatus>`
+
+func TestClient_ListDirectories(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PROPFIND" || r.URL.Path != "/repos/" || r.Header.Get("Depth") != "1" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = w.Write([]byte(listing))
+	}))
+	defer s.Close()
+
+	u, _ := url.Parse(s.URL + "/repos")
+
+	dirs, err := NewClient(u, "admin", "secret", http.DefaultClient).ListDirectories(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"monorepo", "legacy billing"}, dirs); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = NewClient(u, "admin", "wrong", http.DefaultClient).ListDirectories(context.Background())
+	if err == nil {
+		t.Fatal("expected error for wrong credentials")
+	}
+}
@@ -0,0 +1,8 @@
+package svn
+
+// Repo contains information of a Subversion repository.
+type Repo struct {
+	// Path is the path of the repository relative to the root URL of the
+	// connection.
+	Path string `json:"path"`
+}
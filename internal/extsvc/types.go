@@ -80,8 +80,10 @@ const (
 	KindGitHub          = "GITHUB"
 	KindGitLab          = "GITLAB"
 	KindGitolite        = "GITOLITE"
+	KindMercurial       = "MERCURIAL"
 	KindPerforce        = "PERFORCE"
 	KindPhabricator     = "PHABRICATOR"
+	KindSVN             = "SVN"
 	KindJVMPackages     = "JVMPACKAGES"
 	KindPagure          = "PAGURE"
 	KindOther           = "OTHER"
@@ -114,12 +116,20 @@ const (
 	// TypeGitolite is the (api.ExternalRepoSpec).ServiceType value for Gitolite projects.
 	TypeGitolite = "gitolite"
 
+	// TypeMercurial is the (api.ExternalRepoSpec).ServiceType value for Mercurial repositories. The
+	// ServiceID value is the base URL of the Mercurial server.
+	TypeMercurial = "mercurial"
+
 	// TypePerforce is the (api.ExternalRepoSpec).ServiceType value for Perforce projects.
 	TypePerforce = "perforce"
 
 	// TypePhabricator is the (api.ExternalRepoSpec).ServiceType value for Phabricator projects.
 	TypePhabricator = "phabricator"
 
+	// TypeSVN is the (api.ExternalRepoSpec).ServiceType value for Subversion repositories. The
+	// ServiceID value is the root URL of the repositories.
+	TypeSVN = "svn"
+
 	// TypeJVMPackages is the (api.ExternalRepoSpec).ServiceType value for Maven packages (Java/JVM ecosystem libraries).
 	TypeJVMPackages = "jvmPackages"
 
@@ -148,10 +158,14 @@ func KindToType(kind string) string {
 		return TypeGitLab
 	case KindGitolite:
 		return TypeGitolite
+	case KindMercurial:
+		return TypeMercurial
 	case KindPhabricator:
 		return TypePhabricator
 	case KindPerforce:
 		return TypePerforce
+	case KindSVN:
+		return TypeSVN
 	case KindJVMPackages:
 		return TypeJVMPackages
 	case KindPagure:
@@ -179,10 +193,14 @@ func TypeToKind(t string) string {
 		return KindGitLab
 	case TypeGitolite:
 		return KindGitolite
+	case TypeMercurial:
+		return KindMercurial
 	case TypePerforce:
 		return KindPerforce
 	case TypePhabricator:
 		return KindPhabricator
+	case TypeSVN:
+		return KindSVN
 	case TypeJVMPackages:
 		return KindJVMPackages
 	case TypePagure:
@@ -217,10 +235,14 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeGitLab, true
 	case TypeGitolite:
 		return TypeGitolite, true
+	case TypeMercurial:
+		return TypeMercurial, true
 	case TypePerforce:
 		return TypePerforce, true
 	case TypePhabricator:
 		return TypePhabricator, true
+	case TypeSVN:
+		return TypeSVN, true
 	case jvmLower:
 		return TypeJVMPackages, true
 	case TypePagure:
@@ -248,10 +270,14 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindGitLab, true
 	case KindGitolite:
 		return KindGitolite, true
+	case KindMercurial:
+		return KindMercurial, true
 	case KindPerforce:
 		return KindPerforce, true
 	case KindPhabricator:
 		return KindPhabricator, true
+	case KindSVN:
+		return KindSVN, true
 	case KindJVMPackages:
 		return KindJVMPackages, true
 	case KindPagure:
@@ -291,10 +317,14 @@ func ParseConfig(kind, config string) (cfg interface{}, _ error) {
 		cfg = &schema.GitLabConnection{}
 	case KindGitolite:
 		cfg = &schema.GitoliteConnection{}
+	case KindMercurial:
+		cfg = &schema.MercurialConnection{}
 	case KindPerforce:
 		cfg = &schema.PerforceConnection{}
 	case KindPhabricator:
 		cfg = &schema.PhabricatorConnection{}
+	case KindSVN:
+		cfg = &schema.SVNConnection{}
 	case KindJVMPackages:
 		cfg = &schema.JVMPackagesConnection{}
 	case KindPagure:
@@ -522,6 +552,10 @@ func UniqueCodeHostIdentifier(kind, config string) (string, error) {
 		rawURL = c.Url
 	case *schema.GitoliteConnection:
 		rawURL = c.Host
+	case *schema.MercurialConnection:
+		rawURL = c.Url
+	case *schema.SVNConnection:
+		rawURL = c.Url
 	case *schema.AWSCodeCommitConnection:
 		// AWS Code Commit does not have a URL in the config, so we return a
 		// unique string here and return early:
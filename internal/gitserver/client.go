@@ -66,8 +66,9 @@ func NewClient(cli httpcli.Doer) *Client {
 		Addrs: func() []string {
 			return conf.Get().ServiceConnections().GitServers
 		},
-		HTTPClient:  cli,
-		HTTPLimiter: parallel.NewRun(500),
+		ReplicationFactor: conf.GitReplicationFactor,
+		HTTPClient:        cli,
+		HTTPLimiter:       parallel.NewRun(500),
 		// Use the binary name for UserAgent. This should effectively identify
 		// which service is making the request (excluding requests proxied via the
 		// frontend internal API)
@@ -88,6 +89,11 @@ type Client struct {
 	// concurrent use. It may return different results at different times.
 	Addrs func() []string
 
+	// ReplicationFactor is a function which should return the number of
+	// gitservers which keep a copy of each repository. If nil, each repository
+	// is kept by a single gitserver.
+	ReplicationFactor func() int
+
 	// UserAgent is a string identifying who the client is. It will be logged in
 	// the telemetry in gitserver.
 	UserAgent string
@@ -102,6 +108,24 @@ func (c *Client) AddrForRepo(repo api.RepoName) string {
 	return AddrForRepo(repo, addrs)
 }
 
+// ReplicaAddrsForRepo returns the addresses of the gitservers which keep a
+// copy of the given repo name. The first address is the primary, which is the
+// address returned by AddrForRepo.
+func (c *Client) ReplicaAddrsForRepo(repo api.RepoName) []string {
+	addrs := c.Addrs()
+	if len(addrs) == 0 {
+		panic("unexpected state: no gitserver addresses")
+	}
+	return ReplicaAddrsForRepo(repo, addrs, c.replicationFactor())
+}
+
+func (c *Client) replicationFactor() int {
+	if c.ReplicationFactor == nil {
+		return 1
+	}
+	return c.ReplicationFactor()
+}
+
 // RendezvousAddrForRepo returns the gitserver address to use for the given repo name using the
 // Rendezvous hashing scheme.
 func (c *Client) RendezvousAddrForRepo(repo api.RepoName) string {
@@ -137,6 +161,28 @@ func AddrForRepo(repo api.RepoName, addrs []string) string {
 	return addrForKey(string(repo), addrs)
 }
 
+// ReplicaAddrsForRepo returns the addresses of the n gitservers which keep a
+// copy of the given repo name. The first address is the primary returned by
+// AddrForRepo, the replicas are the addresses following it in addrs.
+//
+// It should never be called with an empty slice.
+func ReplicaAddrsForRepo(repo api.RepoName, addrs []string, n int) []string {
+	if n > len(addrs) {
+		n = len(addrs)
+	}
+	if n < 1 {
+		n = 1
+	}
+
+	repo = protocol.NormalizeRepo(repo)
+	primary := addrIndexForKey(string(repo), len(addrs))
+	replicas := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		replicas = append(replicas, addrs[(primary+i)%len(addrs)])
+	}
+	return replicas
+}
+
 // RendezvousAddrForRepo returns the gitserver address to use for the given repo name using the
 // Rendezvous hashing scheme.
 //
@@ -149,9 +195,14 @@ func RendezvousAddrForRepo(repo api.RepoName, addrs []string) string {
 // addrForKey returns the gitserver address to use for the given string key,
 // which is hashed for sharding purposes.
 func addrForKey(key string, addrs []string) string {
+	return addrs[addrIndexForKey(key, len(addrs))]
+}
+
+// addrIndexForKey returns the index of the gitserver address to use for the
+// given string key among n addresses.
+func addrIndexForKey(key string, n int) int {
 	sum := md5.Sum([]byte(key))
-	serverIndex := binary.BigEndian.Uint64(sum[:]) % uint64(len(addrs))
-	return addrs[serverIndex]
+	return int(binary.BigEndian.Uint64(sum[:]) % uint64(n))
 }
 
 // ArchiveOptions contains options for the Archive func.
@@ -189,6 +240,10 @@ func (a *archiveReader) Close() error {
 // ArchiveURL returns a URL from which an archive of the given Git repository can
 // be downloaded from.
 func (c *Client) ArchiveURL(repo api.RepoName, opt ArchiveOptions) *url.URL {
+	return archiveURL(c.AddrForRepo(repo), repo, opt)
+}
+
+func archiveURL(addr string, repo api.RepoName, opt ArchiveOptions) *url.URL {
 	q := url.Values{
 		"repo":    {string(repo)},
 		"treeish": {opt.Treeish},
@@ -201,7 +256,7 @@ func (c *Client) ArchiveURL(repo api.RepoName, opt ArchiveOptions) *url.URL {
 
 	return &url.URL{
 		Scheme:   "http",
-		Host:     c.AddrForRepo(repo),
+		Host:     addr,
 		Path:     "/archive",
 		RawQuery: q.Encode(),
 	}
@@ -226,8 +281,9 @@ func (c *Client) Archive(ctx context.Context, repo api.RepoName, opt ArchiveOpti
 		return nil, err
 	}
 
-	u := c.ArchiveURL(repo, opt)
-	resp, err := c.do(ctx, repo, "GET", u.String(), nil)
+	resp, err := c.doWithFailover(ctx, repo, "GET", func(addr string) string {
+		return archiveURL(addr, repo, opt).String()
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -292,7 +348,13 @@ func (c *Cmd) sendExec(ctx context.Context) (_ io.ReadCloser, _ http.Header, err
 		EnsureRevision: c.EnsureRevision,
 		Args:           c.Args[1:],
 	}
-	resp, err := c.client.httpPost(ctx, repoName, "exec", req)
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.client.doWithFailover(ctx, repoName, "POST", func(addr string) string {
+		return "http://" + addr + "/exec"
+	}, b)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -341,13 +403,22 @@ func (c *Client) Search(ctx context.Context, args *protocol.SearchRequest, onMat
 		return false, err
 	}
 
-	uri := "http://" + c.AddrForRepo(repoName) + "/search"
-	resp, err := c.do(ctx, repoName, "POST", uri, buf.Bytes())
+	resp, err := c.doWithFailover(ctx, repoName, "POST", func(addr string) string {
+		return "http://" + addr + "/search"
+	}, buf.Bytes())
 	if err != nil {
 		return false, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		var payload protocol.NotFoundPayload
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			return false, err
+		}
+		return false, &gitdomain.RepoNotExistError{Repo: repoName, CloneInProgress: payload.CloneInProgress, CloneProgress: payload.CloneProgress}
+	}
+
 	var (
 		decodeErr error
 		eventDone protocol.SearchEventDone
@@ -640,7 +711,7 @@ func (c *Client) RequestRepoUpdate(ctx context.Context, repo api.RepoName, since
 		Repo:  repo,
 		Since: since,
 	}
-	resp, err := c.httpPost(ctx, repo, "repo-update", req)
+	resp, err := c.httpPostReplicas(ctx, repo, "repo-update", req)
 	if err != nil {
 		return nil, err
 	}
@@ -963,7 +1034,7 @@ func (c *Client) Remove(ctx context.Context, repo api.RepoName) error {
 	req := &protocol.RepoDeleteRequest{
 		Repo: repo,
 	}
-	resp, err := c.httpPost(ctx, repo, "delete", req)
+	resp, err := c.httpPostReplicas(ctx, repo, "delete", req)
 	if err != nil {
 		return err
 	}
@@ -989,6 +1060,117 @@ func (c *Client) httpPost(ctx context.Context, repo api.RepoName, op string, pay
 	return c.do(ctx, repo, "POST", uri, b)
 }
 
+// httpPostReplicas sends the HTTP POST request to every gitserver instance
+// which keeps a copy of repo and returns the response of the primary. Failed
+// requests to the other replicas are only logged, gitserver repairs missing
+// replicas in the background.
+func (c *Client) httpPostReplicas(ctx context.Context, repo api.RepoName, op string, payload interface{}) (resp *http.Response, err error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := c.ReplicaAddrsForRepo(repo)
+
+	var wg sync.WaitGroup
+	for _, addr := range addrs[1:] {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			resp, err := c.do(ctx, repo, "POST", "http://"+addr+"/"+op, b)
+			if err == nil {
+				// Drain the body so that replicas complete the request
+				// before we return.
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusOK {
+					err = errors.Errorf("http status %d", resp.StatusCode)
+				}
+			}
+			if err != nil {
+				log15.Warn("gitserver replica request failed", "repo", repo, "addr", addr, "op", op, "error", err)
+			}
+		}(addr)
+	}
+
+	resp, err = c.do(ctx, repo, "POST", "http://"+addrs[0]+"/"+op, b)
+	wg.Wait()
+	return resp, err
+}
+
+var replicaFailoverCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_gitserver_client_replica_failover_total",
+	Help: "Number of gitserver requests which were retried against another replica of the repository.",
+})
+
+// doWithFailover sends the request to each gitserver instance which keeps a
+// copy of repo in turn, starting with the primary. uri returns the request URI
+// for a gitserver address. We move on to the next replica if a gitserver
+// can't be reached, fails with a server error or hasn't cloned the
+// repository. The response of the last replica is returned if all of them
+// fail.
+//
+// If a gitserver reports that the repository is being transferred to it from
+// another gitserver, we try that gitserver next.
+func (c *Client) doWithFailover(ctx context.Context, repo api.RepoName, method string, uri func(addr string) string, payload []byte) (resp *http.Response, err error) {
+	addrs := c.ReplicaAddrsForRepo(repo)
+	for i := 0; i < len(addrs); i++ {
+		addr := addrs[i]
+		resp, err = c.do(ctx, repo, method, uri(addr), payload)
+		if err == nil && resp.StatusCode == http.StatusNotFound {
+			if from := movingFrom(resp); from != "" && !contains(addrs, from) {
+				addrs = append(addrs[:i+1], append([]string{from}, addrs[i+1:]...)...)
+			}
+		}
+		if i == len(addrs)-1 || ctx.Err() != nil || !shouldFailover(resp, err) {
+			break
+		}
+		reason := fmt.Sprint(err)
+		if err == nil {
+			reason = resp.Status
+			resp.Body.Close()
+		}
+		replicaFailoverCounter.Inc()
+		log15.Debug("failing over to gitserver replica", "repo", repo, "addr", addr, "next", addrs[i+1], "reason", reason)
+	}
+	return resp, err
+}
+
+// movingFrom returns the address of the gitserver the repository is being
+// transferred from, as reported in a not found response. The body of resp can
+// still be read afterwards.
+func movingFrom(resp *http.Response) string {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	var payload protocol.NotFoundPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return payload.MovingFrom
+}
+
+func contains(addrs []string, addr string) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldFailover returns true if a request to a gitserver instance should be
+// retried against another replica.
+func shouldFailover(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusNotFound || resp.StatusCode >= http.StatusInternalServerError
+}
+
 // httpPostWithURI does not apply any transformations to the given URI. This allows the consumer to
 // use the predetermined hashing scheme (md5 or rendezvous) of their choice to derive the gitserver
 // instance to which the HTTP POST request is sent.
@@ -1056,6 +1238,13 @@ func userFromContext(ctx context.Context) string {
 // CreateCommitFromPatch will attempt to create a commit from a patch
 // If possible, the error returned will be of type protocol.CreateCommitFromPatchError
 func (c *Client) CreateCommitFromPatch(ctx context.Context, req protocol.CreateCommitFromPatchRequest) (string, error) {
+	replicated := c.replicationFactor() > 1
+	if replicated && req.CommitInfo.Date.IsZero() {
+		// Every replica has to create the same commit, so the date must not
+		// be left to each gitserver.
+		req.CommitInfo.Date = time.Now()
+	}
+
 	resp, err := c.httpPost(ctx, req.Repo, "create-commit-from-patch", req)
 	if err != nil {
 		return "", err
@@ -1078,9 +1267,116 @@ func (c *Client) CreateCommitFromPatch(ctx context.Context, req protocol.CreateC
 	if res.Error != nil {
 		return res.Rev, res.Error
 	}
+
+	if replicated {
+		c.createCommitFromPatchOnReplicas(ctx, req, res.Rev)
+	}
 	return res.Rev, nil
 }
 
+// createCommitFromPatchOnReplicas creates the commit which the primary
+// created for req on the other replicas of the repository. rev is the ref
+// created by the primary. The commit was already pushed by the primary, so the
+// replicas only create the ref.
+func (c *Client) createCommitFromPatchOnReplicas(ctx context.Context, req protocol.CreateCommitFromPatchRequest, rev string) {
+	req.TargetRef = rev
+	req.UniqueRef = false
+	req.Push = nil
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		log15.Warn("encoding create-commit-from-patch request for replicas", "repo", req.Repo, "error", err)
+		return
+	}
+
+	for _, addr := range c.ReplicaAddrsForRepo(req.Repo)[1:] {
+		resp, err := c.do(ctx, req.Repo, "POST", "http://"+addr+"/create-commit-from-patch", b)
+		if err != nil {
+			log15.Warn("creating commit from patch on gitserver replica", "repo", req.Repo, "addr", addr, "error", err)
+			continue
+		}
+		var res protocol.CreateCommitFromPatchResponse
+		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+			log15.Warn("decoding gitserver create-commit-from-patch response", "repo", req.Repo, "addr", addr, "error", err)
+		} else if res.Error != nil {
+			log15.Warn("creating commit from patch on gitserver replica", "repo", req.Repo, "addr", addr, "error", res.Error)
+		}
+		resp.Body.Close()
+	}
+}
+
+// UpdateBranch rebases or merges a branch onto a new base and sets
+// req.TargetRef to the result. If the branch cannot be updated because of
+// conflicts, a *protocol.UpdateBranchConflictError is returned.
+func (c *Client) UpdateBranch(ctx context.Context, req protocol.UpdateBranchRequest) (*protocol.UpdateBranchResponse, error) {
+	replicated := c.replicationFactor() > 1
+	if replicated && req.CommitInfo.Date.IsZero() {
+		// Every replica has to create the same commits, so the date must not
+		// be left to each gitserver.
+		req.CommitInfo.Date = time.Now()
+	}
+
+	resp, err := c.httpPost(ctx, req.Repo, "update-branch", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log15.Warn("reading gitserver update-branch response", "err", err.Error())
+		return nil, &url.Error{URL: resp.Request.URL.String(), Op: "UpdateBranch", Err: errors.Errorf("UpdateBranch: http status %d %s", resp.StatusCode, err.Error())}
+	}
+
+	var res protocol.UpdateBranchResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		log15.Warn("decoding gitserver update-branch response", "err", err.Error())
+		return nil, &url.Error{URL: resp.Request.URL.String(), Op: "UpdateBranch", Err: errors.Errorf("UpdateBranch: http status %d %s", resp.StatusCode, string(data))}
+	}
+
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if len(res.Conflicts) > 0 {
+		return nil, &protocol.UpdateBranchConflictError{Repo: req.Repo, Conflicts: res.Conflicts}
+	}
+
+	if replicated {
+		c.updateBranchOnReplicas(ctx, req, res.Rev)
+	}
+	return &res, nil
+}
+
+// updateBranchOnReplicas updates the branch which the primary updated for req
+// on the other replicas of the repository. rev is the ref updated by the
+// primary. The branch was already pushed by the primary, so the replicas only
+// update the ref.
+func (c *Client) updateBranchOnReplicas(ctx context.Context, req protocol.UpdateBranchRequest, rev string) {
+	req.TargetRef = rev
+	req.Push = nil
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		log15.Warn("encoding update-branch request for replicas", "repo", req.Repo, "error", err)
+		return
+	}
+
+	for _, addr := range c.ReplicaAddrsForRepo(req.Repo)[1:] {
+		resp, err := c.do(ctx, req.Repo, "POST", "http://"+addr+"/update-branch", b)
+		if err != nil {
+			log15.Warn("updating branch on gitserver replica", "repo", req.Repo, "addr", addr, "error", err)
+			continue
+		}
+		var res protocol.UpdateBranchResponse
+		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+			log15.Warn("decoding gitserver update-branch response", "repo", req.Repo, "addr", addr, "error", err)
+		} else if res.Error != nil {
+			log15.Warn("updating branch on gitserver replica", "repo", req.Repo, "addr", addr, "error", res.Error)
+		}
+		resp.Body.Close()
+	}
+}
+
 // GetObject fetches git object data in the supplied repo
 func (c *Client) GetObject(ctx context.Context, repo api.RepoName, objectName string) (*gitdomain.GitObject, error) {
 	if ClientMocks.GetObject != nil {
@@ -1112,3 +1408,30 @@ func (c *Client) GetObject(ctx context.Context, repo api.RepoName, objectName st
 
 	return &res.Object, nil
 }
+
+// BlameStats returns the number of lines each author changed last and when
+// they last changed them below the directory path at commit. gitserver caches
+// the statistics by the hash of the directory tree.
+func (c *Client) BlameStats(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (*protocol.BlameStatsResponse, error) {
+	req := protocol.BlameStatsRequest{
+		Repo:   repo,
+		Commit: commit,
+		Path:   path,
+	}
+	resp, err := c.httpPost(ctx, req.Repo, "blame-stats", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &url.Error{URL: resp.Request.URL.String(), Op: "BlameStats", Err: errors.Errorf("BlameStats: http status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))}
+	}
+
+	var res protocol.BlameStatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, &url.Error{URL: resp.Request.URL.String(), Op: "BlameStats", Err: errors.Wrap(err, "decoding response")}
+	}
+	return &res, nil
+}
@@ -14,6 +14,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"sync"
 	"testing"
 
 	"github.com/cockroachdb/errors"
@@ -360,6 +361,181 @@ func TestRendezvousAddrForRepo(t *testing.T) {
 	}
 }
 
+func TestReplicaAddrsForRepo(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	testCases := []struct {
+		name   string
+		repo   api.RepoName
+		factor int
+		want   []string
+	}{
+		{
+			name:   "no replication",
+			repo:   api.RepoName("repo1"),
+			factor: 1,
+			want:   []string{"gitserver-3"},
+		},
+		{
+			name:   "replicas wrap around",
+			repo:   api.RepoName("repo1"),
+			factor: 2,
+			want:   []string{"gitserver-3", "gitserver-1"},
+		},
+		{
+			name:   "factor larger than number of gitservers",
+			repo:   api.RepoName("github.com/sourcegraph/sourcegraph.git"),
+			factor: 5,
+			want:   []string{"gitserver-2", "gitserver-3", "gitserver-1"},
+		},
+		{
+			name:   "invalid factor",
+			repo:   api.RepoName("repo1"),
+			factor: 0,
+			want:   []string{"gitserver-3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := gitserver.ReplicaAddrsForRepo(tc.repo, addrs, tc.factor)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("Mismatch (-want +got):\n%s", diff)
+			}
+			if got[0] != gitserver.AddrForRepo(tc.repo, addrs) {
+				t.Fatalf("primary %q is not AddrForRepo", got[0])
+			}
+		})
+	}
+}
+
+func TestClient_ExecFailover(t *testing.T) {
+	repo := api.RepoName("repo1")
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	var requested []string
+	cli := &gitserver.Client{
+		Addrs:             func() []string { return addrs },
+		ReplicationFactor: func() int { return 3 },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			requested = append(requested, r.URL.Host)
+			switch r.URL.Host {
+			case "gitserver-3":
+				return nil, errors.New("connection refused")
+			case "gitserver-1":
+				// The replica is still cloning.
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"cloneInProgress":true}`)),
+				}, nil
+			default:
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString("HEAD")),
+					Trailer:    http.Header{"X-Exec-Exit-Status": {"0"}},
+				}, nil
+			}
+		}),
+	}
+
+	cmd := cli.Command("git", "rev-parse", "HEAD")
+	cmd.Repo = repo
+	out, err := cmd.Output(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "HEAD" {
+		t.Fatalf("got output %q, want %q", out, "HEAD")
+	}
+
+	want := []string{"gitserver-3", "gitserver-1", "gitserver-2"}
+	if diff := cmp.Diff(want, requested); diff != "" {
+		t.Fatalf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_ExecMovingFrom(t *testing.T) {
+	repo := api.RepoName("repo1")
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	var requested []string
+	cli := &gitserver.Client{
+		Addrs: func() []string { return addrs },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			requested = append(requested, r.URL.Host)
+			switch r.URL.Host {
+			case "gitserver-3":
+				// The repo is being transferred from gitserver-1.
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"cloneInProgress":true,"movingFrom":"gitserver-1"}`)),
+				}, nil
+			case "gitserver-1":
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString("HEAD")),
+					Trailer:    http.Header{"X-Exec-Exit-Status": {"0"}},
+				}, nil
+			default:
+				return nil, errors.Errorf("unexpected request to %s", r.URL.Host)
+			}
+		}),
+	}
+
+	cmd := cli.Command("git", "rev-parse", "HEAD")
+	cmd.Repo = repo
+	if _, err := cmd.Output(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"gitserver-3", "gitserver-1"}
+	if diff := cmp.Diff(want, requested); diff != "" {
+		t.Fatalf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_RequestRepoUpdateReplicas(t *testing.T) {
+	repo := api.RepoName("repo1")
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	var (
+		mu        sync.Mutex
+		requested []string
+	)
+	cli := &gitserver.Client{
+		Addrs:             func() []string { return addrs },
+		ReplicationFactor: func() int { return 2 },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requested = append(requested, r.URL.String())
+			mu.Unlock()
+
+			// A failing replica does not fail the update.
+			if r.URL.Host == "gitserver-1" {
+				return nil, errors.New("connection refused")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"cloned":true}`)),
+			}, nil
+		}),
+	}
+
+	resp, err := cli.RequestRepoUpdate(context.Background(), repo, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Cloned {
+		t.Fatal("expected response of primary")
+	}
+
+	want := []string{"http://gitserver-1/repo-update", "http://gitserver-3/repo-update"}
+	sort.Strings(requested)
+	if diff := cmp.Diff(want, requested); diff != "" {
+		t.Fatalf("Mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestClient_P4Exec(t *testing.T) {
 	root, err := os.MkdirTemp("", t.Name())
 	if err != nil {
@@ -2,6 +2,8 @@ package protocol
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -75,6 +77,18 @@ type CommitMatch struct {
 
 	Message result.MatchedString `json:",omitempty"`
 	Diff    result.MatchedString `json:",omitempty"`
+
+	// Renames are the matched files which the commit modifies under another
+	// name than the one they matched by. They are only set for queries which
+	// follow renames, see DiffModifiesFile.
+	Renames []FileRename `json:",omitempty"`
+}
+
+// FileRename is a file which had the name OldPath before it was renamed to
+// NewPath.
+type FileRename struct {
+	OldPath string
+	NewPath string
 }
 
 type Signature struct {
@@ -165,6 +179,11 @@ type NotFoundPayload struct {
 
 	// CloneProgress is a progress message from the running clone command.
 	CloneProgress string `json:"cloneProgress,omitempty"`
+
+	// MovingFrom is the address of the gitserver the repository is being
+	// transferred from while gitservers are rebalanced. Clients can read the
+	// repository from it until the transfer has completed.
+	MovingFrom string `json:"movingFrom,omitempty"`
 }
 
 // IsRepoCloneableRequest is a request to determine if a repo is cloneable.
@@ -229,6 +248,32 @@ type ReposStats struct {
 
 	// GitDirBytes is the amount of bytes stored in .git directories.
 	GitDirBytes int64
+
+	// Maintenance is the state of repository maintenance on the gitserver.
+	Maintenance RepoMaintenanceStats
+}
+
+// RepoMaintenanceStats describes the last run of the repository maintenance
+// of a gitserver, which writes commit-graphs, multi-pack-indexes and bitmaps.
+type RepoMaintenanceStats struct {
+	// LastRunAt is the time the last maintenance run finished. It is zero if
+	// maintenance has not run yet.
+	LastRunAt time.Time
+
+	// Due is the number of repositories which were due for maintenance at the
+	// start of the last run.
+	Due int
+
+	// Maintained is the number of repositories maintained by the last run.
+	Maintained int
+
+	// Failed is the number of repositories whose maintenance failed in the
+	// last run.
+	Failed int
+
+	// Packs is the number of packfiles of all repositories at the start of
+	// the last run.
+	Packs int
 }
 
 // RepoCloneProgressRequest is a request for information about the clone progress of multiple
@@ -342,6 +387,105 @@ func (e *CreateCommitFromPatchError) Error() string {
 	return e.InternalError
 }
 
+// UpdateBranchStrategy is the way UpdateBranch brings a branch up to date with
+// a new base.
+type UpdateBranchStrategy string
+
+const (
+	// UpdateBranchRebase replays the commits of the branch which are not in
+	// the base on top of the base.
+	UpdateBranchRebase UpdateBranchStrategy = "rebase"
+	// UpdateBranchMerge merges the base into the branch.
+	UpdateBranchMerge UpdateBranchStrategy = "merge"
+)
+
+// UpdateBranchRequest is a request to rebase or merge a branch onto a new
+// base.
+type UpdateBranchRequest struct {
+	Repo api.RepoName
+	// HeadCommit is the current head of the branch.
+	HeadCommit api.CommitID
+	// BaseCommit is the commit the branch is brought up to date with.
+	BaseCommit api.CommitID
+	// TargetRef is the ref which is set to the updated branch, e.g.
+	// "refs/heads/my-branch".
+	TargetRef string
+	Strategy  UpdateBranchStrategy
+	// CommitInfo is used as the committer of rebased commits and as the
+	// author, committer and message of merge commits. The authors and
+	// messages of rebased commits are kept.
+	CommitInfo PatchCommitInfo
+	// Push, if set, force pushes the updated branch to the code host.
+	Push *PushConfig
+}
+
+// UpdateBranchResponse is the response to an UpdateBranchRequest.
+type UpdateBranchResponse struct {
+	// Rev is the ref the updated branch can be found at.
+	Rev string
+	// Commit is the new head of the branch.
+	Commit api.CommitID
+
+	// Conflicts is populated if the branch could not be updated because of
+	// conflicts. Neither the ref is updated nor anything pushed then.
+	Conflicts []UpdateBranchConflict
+
+	// Error is populated only on error
+	Error *CreateCommitFromPatchError
+}
+
+// SetError adds the supplied error related details to e.
+func (e *UpdateBranchResponse) SetError(repo, command, out string, err error) {
+	if e.Error == nil {
+		e.Error = &CreateCommitFromPatchError{}
+	}
+	e.Error.RepositoryName = repo
+	e.Error.Command = command
+	e.Error.CombinedOutput = out
+	e.Error.InternalError = err.Error()
+}
+
+// ConflictKind is the kind of an UpdateBranchConflict.
+type ConflictKind string
+
+const (
+	// ConflictContent is a file modified in both the branch and the base.
+	ConflictContent ConflictKind = "content"
+	// ConflictAddAdd is a file added differently in both the branch and the
+	// base.
+	ConflictAddAdd ConflictKind = "add/add"
+	// ConflictDeletedInBase is a file modified in the branch but deleted in
+	// the base.
+	ConflictDeletedInBase ConflictKind = "deleted-in-base"
+	// ConflictDeletedInBranch is a file modified in the base but deleted in
+	// the branch.
+	ConflictDeletedInBranch ConflictKind = "deleted-in-branch"
+)
+
+// UpdateBranchConflict is a file which conflicted when updating a branch.
+type UpdateBranchConflict struct {
+	Path string
+	Kind ConflictKind
+	// Commit is the commit of the branch which failed to be rebased. It is
+	// empty for merges.
+	Commit api.CommitID `json:",omitempty"`
+}
+
+// UpdateBranchConflictError is returned if a branch could not be updated
+// because of conflicts.
+type UpdateBranchConflictError struct {
+	Repo      api.RepoName
+	Conflicts []UpdateBranchConflict
+}
+
+func (e *UpdateBranchConflictError) Error() string {
+	paths := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		paths = append(paths, c.Path)
+	}
+	return fmt.Sprintf("updating branch of %s: %d conflicting files: %s", e.Repo, len(e.Conflicts), strings.Join(paths, ", "))
+}
+
 type GetObjectRequest struct {
 	Repo       api.RepoName
 	ObjectName string
@@ -350,3 +494,34 @@ type GetObjectRequest struct {
 type GetObjectResponse struct {
 	Object gitdomain.GitObject
 }
+
+// BlameStatsRequest is a request for the blame statistics of a directory tree
+// at a commit.
+type BlameStatsRequest struct {
+	Repo   api.RepoName
+	Commit api.CommitID
+	// Path is the directory, relative to the repository root. The empty
+	// string is the root directory.
+	Path string
+}
+
+// BlameStatsResponse is the response to a BlameStatsRequest.
+type BlameStatsResponse struct {
+	// TreeHash is the hash of the git tree of the directory.
+	TreeHash string
+	// Files is the number of files below the directory.
+	Files int
+	// Authors are the authors of the lines of the files below the directory,
+	// ordered by the number of lines they authored, most first.
+	Authors []AuthorBlameStats
+}
+
+// AuthorBlameStats are the blame statistics of an author in a directory tree.
+type AuthorBlameStats struct {
+	Name  string
+	Email string
+	// Lines is the number of lines the author changed last.
+	Lines int
+	// LastTouched is the most recent author date of the lines.
+	LastTouched time.Time
+}
@@ -79,6 +79,11 @@ func (d *DiffMatches) String() string {
 type DiffModifiesFile struct {
 	Expr       string
 	IgnoreCase bool
+
+	// FollowRenames makes the predicate also match commits which modify a
+	// file under a name it had before it was renamed to a matching name, so
+	// the history of a file doesn't end where it was moved.
+	FollowRenames bool
 }
 
 func (d *DiffModifiesFile) String() string {
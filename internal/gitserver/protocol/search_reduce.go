@@ -207,11 +207,12 @@ func mergeOrRegexp(n Node) Node {
 				mergeable[key] = v
 			}
 		case *DiffModifiesFile:
-			key := DiffModifiesFile{IgnoreCase: v.IgnoreCase}
+			key := DiffModifiesFile{IgnoreCase: v.IgnoreCase, FollowRenames: v.FollowRenames}
 			if prev, ok := mergeable[key]; ok {
 				mergeable[key] = &DiffModifiesFile{
-					Expr:       "(" + prev.(*DiffModifiesFile).Expr + ")|(" + v.Expr + ")",
-					IgnoreCase: v.IgnoreCase,
+					Expr:          "(" + prev.(*DiffModifiesFile).Expr + ")|(" + v.Expr + ")",
+					IgnoreCase:    v.IgnoreCase,
+					FollowRenames: v.FollowRenames,
 				}
 			} else {
 				mergeable[key] = v
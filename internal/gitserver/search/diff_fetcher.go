@@ -15,6 +15,11 @@ import (
 // started with StartDiffFetcher
 type DiffFetcher struct {
 	dir string
+	env []string
+
+	// DetectRenames makes diffs report renamed files as renames instead of a
+	// deletion and an addition. It must be set before the first Fetch.
+	DetectRenames bool
 
 	startOnce sync.Once
 	stdin     io.Writer
@@ -25,10 +30,11 @@ type DiffFetcher struct {
 }
 
 // NewDiffFetcher starts a git diff-tree subprocess that waits, listening on stdin
-// for comimt hashes to generate patches for.
-func NewDiffFetcher(dir string) (*DiffFetcher, error) {
+// for comimt hashes to generate patches for. If env is nil, the subprocess
+// inherits the environment of the current process.
+func NewDiffFetcher(dir string, env []string) (*DiffFetcher, error) {
 
-	return &DiffFetcher{dir: dir}, nil
+	return &DiffFetcher{dir: dir, env: env}, nil
 }
 
 func (d *DiffFetcher) Stop() {
@@ -42,15 +48,20 @@ func (d *DiffFetcher) start() (err error) {
 	d.startOnce.Do(func() {
 		ctx := context.Background()
 		ctx, d.cancel = context.WithCancel(ctx)
-		d.cmd = exec.CommandContext(ctx, "git",
+		args := []string{
 			"diff-tree",
 			"--stdin",          // Read commit hashes from stdin
 			"--no-prefix",      // Do not prefix file names with a/ and b/
 			"-p",               // Output in patch format
 			"--format=format:", // Output only the patch, not any other commit metadata
 			"--root",           // Treat the root commit as a big creation event (otherwise the diff would be empty)
-		)
+		}
+		if d.DetectRenames {
+			args = append(args, "-M")
+		}
+		d.cmd = exec.CommandContext(ctx, "git", args...)
 		d.cmd.Dir = d.dir
+		d.cmd.Env = d.env
 
 		var stdoutReader io.ReadCloser
 		stdoutReader, err = d.cmd.StdoutPipe()
@@ -3,6 +3,7 @@ package search
 import (
 	"sort"
 
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -45,6 +46,10 @@ type MatchedFileDiff struct {
 	OldFile      result.Ranges
 	NewFile      result.Ranges
 	MatchedHunks map[int]MatchedHunk
+
+	// Rename is set if the file matched under another name than the one it
+	// has in the diff, see DiffModifiesFile.FollowRenames.
+	Rename *protocol.FileRename
 }
 
 func (f MatchedFileDiff) Merge(other MatchedFileDiff) MatchedFileDiff {
@@ -54,6 +59,10 @@ func (f MatchedFileDiff) Merge(other MatchedFileDiff) MatchedFileDiff {
 	f.NewFile = append(f.NewFile, other.NewFile...)
 	sort.Sort(f.NewFile)
 
+	if f.Rename == nil {
+		f.Rename = other.Rename
+	}
+
 	if f.MatchedHunks == nil {
 		f.MatchedHunks = other.MatchedHunks
 	} else {
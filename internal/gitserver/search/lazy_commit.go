@@ -58,6 +58,11 @@ func (l *LazyCommit) Diff() ([]*diff.FileDiff, error) {
 		return nil, err
 	}
 
+	// git diff-tree separates the patches of consecutive commits with a
+	// newline, which go-diff fails to skip when the first file diff has no
+	// hunks, like a file which was only renamed.
+	rawDiff = bytes.TrimLeft(rawDiff, "\n")
+
 	r := diff.NewMultiFileDiffReader(bytes.NewReader(rawDiff))
 	diff, err := r.ReadAllFiles()
 	if err != nil {
@@ -2,6 +2,7 @@ package search
 
 import (
 	"bytes"
+	"time"
 	"unicode/utf8"
 
 	"github.com/cockroachdb/errors"
@@ -34,7 +35,7 @@ func ToMatchTree(q protocol.Node) (MatchTree, error) {
 		return &DiffMatches{re}, err
 	case *protocol.DiffModifiesFile:
 		re, err := casetransform.CompileRegexp(v.Expr, v.IgnoreCase)
-		return &DiffModifiesFile{re}, err
+		return &DiffModifiesFile{Regexp: re, FollowRenames: v.FollowRenames}, err
 	case *protocol.Boolean:
 		return &Constant{v.Value}, nil
 	case *protocol.Operator:
@@ -190,6 +191,14 @@ func (dm *DiffMatches) Match(lc *LazyCommit) (CommitFilterResult, MatchedCommit,
 // that match the given regex pattern.
 type DiffModifiesFile struct {
 	*casetransform.Regexp
+
+	// FollowRenames is true if the predicate also matches files under the
+	// names they had before they were renamed to a matching name.
+	FollowRenames bool
+
+	// oldNames maps the old names of renamed files to the matching names they
+	// were renamed to. It is set by followRenames before searching.
+	oldNames map[string]string
 }
 
 func (dmf *DiffModifiesFile) Match(lc *LazyCommit) (CommitFilterResult, MatchedCommit, error) {
@@ -203,13 +212,15 @@ func (dmf *DiffModifiesFile) Match(lc *LazyCommit) (CommitFilterResult, MatchedC
 	for fileIdx, fileDiff := range diff {
 		oldFileMatches := dmf.FindAllIndex([]byte(fileDiff.OrigName), -1, &lc.LowerBuf)
 		newFileMatches := dmf.FindAllIndex([]byte(fileDiff.NewName), -1, &lc.LowerBuf)
-		if oldFileMatches != nil || newFileMatches != nil {
+		rename := dmf.rename(fileDiff.OrigName, fileDiff.NewName, newFileMatches != nil)
+		if oldFileMatches != nil || newFileMatches != nil || rename != nil {
 			if fileDiffHighlights == nil {
 				fileDiffHighlights = make(map[int]MatchedFileDiff)
 			}
 			fileDiffHighlights[fileIdx] = MatchedFileDiff{
 				OldFile: matchesToRanges([]byte(fileDiff.OrigName), oldFileMatches),
 				NewFile: matchesToRanges([]byte(fileDiff.NewName), newFileMatches),
+				Rename:  rename,
 			}
 			matchedFileDiffs[fileIdx] = struct{}{}
 		}
@@ -218,6 +229,91 @@ func (dmf *DiffModifiesFile) Match(lc *LazyCommit) (CommitFilterResult, MatchedC
 	return CommitFilterResult{MatchedFileDiffs: matchedFileDiffs}, MatchedCommit{Diff: fileDiffHighlights}, nil
 }
 
+// rename returns the rename of the file with the name origName before and
+// newName after the commit if the file matches because of it, or nil.
+// newNameMatches is true if newName matches the regex pattern.
+func (dmf *DiffModifiesFile) rename(origName, newName string, newNameMatches bool) *protocol.FileRename {
+	if !dmf.FollowRenames {
+		return nil
+	}
+	// The commit renames a file to a matching name.
+	if newNameMatches {
+		if origName != newName && origName != "/dev/null" {
+			return &protocol.FileRename{OldPath: origName, NewPath: newName}
+		}
+		return nil
+	}
+	// The commit modifies a file which is renamed to a matching name later.
+	// Added files only have a new name.
+	for _, name := range []string{origName, newName} {
+		if matchingName, ok := dmf.oldNames[name]; ok {
+			return &protocol.FileRename{OldPath: name, NewPath: matchingName}
+		}
+	}
+	return nil
+}
+
+// followRenames records the old names of the files renamed to a matching name
+// by renames, which must be ordered from the newest to the oldest commit. A
+// file renamed several times is followed across all of its names.
+//
+// The time of a rename is not taken into account: a commit which modifies a
+// file under an old name matches even if it comes after the rename, for
+// example if the old name was reused for another file.
+func (dmf *DiffModifiesFile) followRenames(renames []protocol.FileRename) {
+	var lowerBuf []byte
+	dmf.oldNames = make(map[string]string)
+	for _, r := range renames {
+		if matchingName, ok := dmf.oldNames[r.NewPath]; ok {
+			dmf.oldNames[r.OldPath] = matchingName
+		} else if dmf.Regexp.Match([]byte(r.NewPath), &lowerBuf) {
+			dmf.oldNames[r.OldPath] = r.NewPath
+		}
+	}
+}
+
+// followedFiles returns the DiffModifiesFile predicates of the match tree
+// which follow renames.
+func followedFiles(mt MatchTree) []*DiffModifiesFile {
+	switch v := mt.(type) {
+	case *DiffModifiesFile:
+		if v.FollowRenames {
+			return []*DiffModifiesFile{v}
+		}
+	case *Operator:
+		var res []*DiffModifiesFile
+		for _, operand := range v.Operands {
+			res = append(res, followedFiles(operand)...)
+		}
+		return res
+	}
+	return nil
+}
+
+// committedAfter returns the latest date which every commit matched by mt
+// must be committed after, if any.
+func committedAfter(mt MatchTree) (time.Time, bool) {
+	switch v := mt.(type) {
+	case *CommitAfter:
+		return v.Time, true
+	case *Operator:
+		if v.Kind != protocol.And {
+			return time.Time{}, false
+		}
+		var (
+			after time.Time
+			found bool
+		)
+		for _, operand := range v.Operands {
+			if t, ok := committedAfter(operand); ok && (!found || t.After(after)) {
+				after, found = t, true
+			}
+		}
+		return after, found
+	}
+	return time.Time{}, false
+}
+
 type Constant struct {
 	Value bool
 }
@@ -6,7 +6,9 @@ import (
 	"context"
 	"io"
 	"os/exec"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/hashicorp/go-multierror"
@@ -77,6 +79,14 @@ type CommitSearcher struct {
 	Query       MatchTree
 	Revisions   []protocol.RevisionSpecifier
 	IncludeDiff bool
+
+	// Env is the environment of the git commands. If nil, they inherit the
+	// environment of the current process.
+	Env []string
+
+	// detectRenames is true if diffs report renamed files as renames instead
+	// of a deletion and an addition. It is set when the query follows renames.
+	detectRenames bool
 }
 
 // Search runs a search for commits matching the given predicate across the revisions passed in as revisionArgs.
@@ -88,6 +98,17 @@ type CommitSearcher struct {
 // This allows our worker pool to run the jobs in parallel, but we still emit matches in the same order that
 // git log outputs them.
 func (cs *CommitSearcher) Search(ctx context.Context, onMatch func(*protocol.CommitMatch)) error {
+	if followed := followedFiles(cs.Query); len(followed) > 0 {
+		renames, err := cs.renames(ctx)
+		if err != nil {
+			return err
+		}
+		for _, dmf := range followed {
+			dmf.followRenames(renames)
+		}
+		cs.detectRenames = true
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 
 	jobs := make(chan job, 128)
@@ -126,6 +147,7 @@ func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, result
 	revArgs := revsToGitArgs(cs.Revisions)
 	cmd := exec.CommandContext(ctx, "git", append(logArgs, revArgs...)...)
 	cmd.Dir = cs.RepoDir
+	cmd.Env = cs.Env
 	stdoutReader, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -174,6 +196,58 @@ func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, result
 	return scanner.Err()
 }
 
+// renames returns the files renamed by the commits in the searched revisions,
+// ordered like git log orders the commits. If the query only matches commits
+// after a date, older commits are not listed, since renames before the first
+// matching commit do not affect which files it modifies.
+func (cs *CommitSearcher) renames(ctx context.Context) ([]protocol.FileRename, error) {
+	args := []string{
+		"log",
+		"--no-merges",
+		"-M",               // Detect renames
+		"--diff-filter=R",  // Only list renamed files
+		"--name-status",    // Output the status, old name and new name of each renamed file
+		"--format=format:", // Output no commit metadata
+		"-z",
+	}
+	if after, ok := committedAfter(cs.Query); ok {
+		args = append(args, "--since="+after.Format(time.RFC3339))
+	}
+	cmd := exec.CommandContext(ctx, "git", append(args, revsToGitArgs(cs.Revisions)...)...)
+	cmd.Dir = cs.RepoDir
+	cmd.Env = cs.Env
+	var stderrBuf bytes.Buffer
+	cmd.Stderr = &stderrBuf
+	out, err := cmd.Output()
+	if err != nil {
+		if err := tryInterpretErrorWithStderr(ctx, err, stderrBuf.String()); err != nil {
+			return nil, errors.Wrap(err, "listing renames")
+		}
+		return nil, nil
+	}
+	return parseRenames(out), nil
+}
+
+// parseRenames parses the output of git log --name-status -z for renamed
+// files. Each rename is a status like R100 followed by the old and the new
+// name, all terminated by null bytes.
+func parseRenames(out []byte) []protocol.FileRename {
+	var renames []protocol.FileRename
+	fields := bytes.Split(out, sep)
+	for i := 0; i < len(fields); i++ {
+		status := bytes.TrimLeft(fields[i], "\n")
+		if len(status) == 0 || status[0] != 'R' || i+2 >= len(fields) {
+			continue
+		}
+		renames = append(renames, protocol.FileRename{
+			OldPath: string(fields[i+1]),
+			NewPath: string(fields[i+2]),
+		})
+		i += 2
+	}
+	return renames
+}
+
 func tryInterpretErrorWithStderr(ctx context.Context, err error, stderr string) error {
 	if ctx.Err() != nil {
 		// Ignore errors when context is cancelled
@@ -189,10 +263,11 @@ func tryInterpretErrorWithStderr(ctx context.Context, err error, stderr string)
 
 func (cs *CommitSearcher) runJobs(ctx context.Context, jobs chan job) error {
 	// Create a new diff fetcher subprocess for each worker
-	diffFetcher, err := NewDiffFetcher(cs.RepoDir)
+	diffFetcher, err := NewDiffFetcher(cs.RepoDir, cs.Env)
 	if err != nil {
 		return err
 	}
+	diffFetcher.DetectRenames = cs.detectRenames
 	defer diffFetcher.Stop()
 
 	startBuf := make([]byte, 1024)
@@ -363,6 +438,13 @@ func CreateCommitMatch(lc *LazyCommit, hc MatchedCommit, includeDiff bool) (*pro
 		return nil, err
 	}
 
+	var renames []protocol.FileRename
+	for _, fileIdx := range sortedKeys(hc.Diff) {
+		if r := hc.Diff[fileIdx].Rename; r != nil {
+			renames = append(renames, *r)
+		}
+	}
+
 	diff := result.MatchedString{}
 	if includeDiff {
 		rawDiff, err := lc.Diff()
@@ -391,6 +473,16 @@ func CreateCommitMatch(lc *LazyCommit, hc MatchedCommit, includeDiff bool) (*pro
 			Content:       string(lc.Message),
 			MatchedRanges: hc.Message,
 		},
-		Diff: diff,
+		Diff:    diff,
+		Renames: renames,
 	}, nil
 }
+
+func sortedKeys(m map[int]MatchedFileDiff) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/sourcegraph/go-diff/diff"
@@ -190,6 +191,97 @@ func TestSearch(t *testing.T) {
 	})
 }
 
+func TestSearchFollowRenames(t *testing.T) {
+	commit := func(msg string) string {
+		return "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_AUTHOR_NAME=a GIT_AUTHOR_EMAIL=a@a.com git commit -m " + msg
+	}
+	cmds := []string{
+		"printf 'package a\\n\\nfunc A() {}\\n' > a.go",
+		"echo other > other.txt",
+		"git add -A",
+		commit("create"),
+		"echo '// A does nothing.' >> a.go",
+		"git add -A",
+		commit("modify"),
+		"git mv a.go b.go",
+		commit("rename1"),
+		"git mv b.go c.go",
+		"echo '// C is renamed.' >> c.go",
+		"git add -A",
+		commit("rename2"),
+		"echo '// C is moved.' >> c.go",
+		"echo changed > other.txt",
+		"git add -A",
+		commit("modify2"),
+	}
+	dir := initGitRepository(t, cmds...)
+
+	search := func(t *testing.T, query protocol.Node) []*protocol.CommitMatch {
+		t.Helper()
+		tree, err := ToMatchTree(query)
+		require.NoError(t, err)
+		searcher := &CommitSearcher{
+			RepoDir:     dir,
+			Query:       tree,
+			IncludeDiff: true,
+		}
+		var matches []*protocol.CommitMatch
+		err = searcher.Search(context.Background(), func(match *protocol.CommitMatch) {
+			matches = append(matches, match)
+		})
+		require.NoError(t, err)
+		return matches
+	}
+
+	messages := func(matches []*protocol.CommitMatch) []string {
+		var res []string
+		for _, m := range matches {
+			res = append(res, m.Message.Content)
+		}
+		return res
+	}
+
+	t.Run("without following renames", func(t *testing.T) {
+		matches := search(t, &protocol.DiffModifiesFile{Expr: `^c\.go$`})
+		require.Equal(t, []string{"modify2", "rename2"}, messages(matches))
+		for _, m := range matches {
+			require.Empty(t, m.Renames)
+		}
+	})
+
+	t.Run("following renames", func(t *testing.T) {
+		matches := search(t, &protocol.DiffModifiesFile{Expr: `^c\.go$`, FollowRenames: true})
+		require.Equal(t, []string{"modify2", "rename2", "rename1", "modify", "create"}, messages(matches))
+
+		require.Empty(t, matches[0].Renames)
+		require.Equal(t, []protocol.FileRename{{OldPath: "b.go", NewPath: "c.go"}}, matches[1].Renames)
+		require.Equal(t, []protocol.FileRename{{OldPath: "a.go", NewPath: "c.go"}}, matches[2].Renames)
+		require.Equal(t, []protocol.FileRename{{OldPath: "a.go", NewPath: "c.go"}}, matches[3].Renames)
+		require.Equal(t, []protocol.FileRename{{OldPath: "a.go", NewPath: "c.go"}}, matches[4].Renames)
+
+		// Only the followed file is part of the diff.
+		require.True(t, strings.HasPrefix(matches[1].Diff.Content, "b.go c.go\n"), matches[1].Diff.Content)
+		require.NotContains(t, matches[4].Diff.Content, "other.txt")
+	})
+
+	t.Run("following renames with diff matches", func(t *testing.T) {
+		matches := search(t, protocol.NewAnd(
+			&protocol.DiffModifiesFile{Expr: `^c\.go$`, FollowRenames: true},
+			&protocol.DiffMatches{Expr: "does nothing"},
+		))
+		require.Equal(t, []string{"modify"}, messages(matches))
+	})
+}
+
+func TestParseRenames(t *testing.T) {
+	out := []byte("R100\x00b.go\x00c.go\x00\x00\nR093\x00a.go\x00b.go\x00")
+	want := []protocol.FileRename{
+		{OldPath: "b.go", NewPath: "c.go"},
+		{OldPath: "a.go", NewPath: "b.go"},
+	}
+	require.Equal(t, want, parseRenames(out))
+}
+
 func TestCommitScanner(t *testing.T) {
 	cases := []struct {
 		input    []byte
@@ -513,3 +605,16 @@ func (authorNameGenerator) Generate(rand *rand.Rand, size int) reflect.Value {
 	}
 	return reflect.ValueOf(buf)
 }
+
+func TestCommittedAfter(t *testing.T) {
+	t1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	after := func(t time.Time) MatchTree { return &CommitAfter{protocol.CommitAfter{Time: t}} }
+
+	got, ok := committedAfter(&Operator{Kind: protocol.And, Operands: []MatchTree{after(t1), &Constant{true}, after(t2)}})
+	require.True(t, ok)
+	require.Equal(t, t2, got)
+
+	_, ok = committedAfter(&Operator{Kind: protocol.Or, Operands: []MatchTree{after(t1), &Constant{true}}})
+	require.False(t, ok)
+}
@@ -8,6 +8,8 @@ import (
 	"io"
 	"io/fs"
 	"log"
+	"path"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/go-enry/go-enry/v2"
@@ -73,7 +75,7 @@ func getLang(ctx context.Context, file fs.FileInfo, buf []byte, getFileReader fu
 		if err != nil && err != io.ErrUnexpectedEOF {
 			return lang, errors.Wrap(err, "reading initial file data")
 		}
-		matchedLang = enry.GetLanguage(file.Name(), buf[:n])
+		matchedLang = GetLanguage(file.Name(), buf[:n])
 		lang.TotalBytes += uint64(n)
 		lang.TotalLines += uint64(bytes.Count(buf[:n], newLine))
 		lang.Name = matchedLang
@@ -131,6 +133,42 @@ func GetLanguageByFilename(name string) (language string, safe bool) {
 	return enry.GetLanguageByExtension(name)
 }
 
+// GetLanguageByName is like GetLanguageByFilename, but also recognizes
+// variants of well-known filenames such as Dockerfile.prod. If safe is false,
+// GetLanguage should be used to classify the file by its content.
+func GetLanguageByName(name string) (language string, safe bool) {
+	if lang, safe := GetLanguageByFilename(name); safe {
+		return lang, true
+	}
+	base := path.Base(name)
+	for _, v := range filenameVariants {
+		if strings.HasPrefix(base, v.prefix) {
+			return v.lang, true
+		}
+	}
+	return GetLanguageByFilename(name)
+}
+
+// GetLanguage returns the language of the named file, using content to
+// classify files whose name is not conclusive: scripts without an extension
+// are classified by their shebang, Dockerfile variants by their name, and
+// extensions shared by several languages (such as .h for C, C++ and
+// Objective-C) by content heuristics. content may be a prefix of the file.
+func GetLanguage(name string, content []byte) string {
+	if lang, safe := GetLanguageByName(name); safe {
+		return lang
+	}
+	return enry.GetLanguage(name, content)
+}
+
+// filenameVariants are prefixes of well-known filenames whose suffix names a
+// variant rather than a language, such as Dockerfile.prod.
+var filenameVariants = []struct{ prefix, lang string }{
+	{"Dockerfile.", "Dockerfile"},
+	{"Containerfile", "Dockerfile"},
+	{"Makefile.", "Makefile"},
+}
+
 func init() {
 	// Treat .tsx and .jsx as TypeScript and JavaScript, respectively, instead of distinct languages
 	// called "TSX" and "JSX". This is more consistent with user expectations.
@@ -76,6 +76,30 @@ func TestGetLang_language(t *testing.T) {
 	}
 }
 
+func TestGetLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "a.go", want: "Go"},
+		{name: "dir/a.tsx", want: "TypeScript"},
+		{name: "bin/deploy", content: "#!/usr/bin/env python3\nimport sys\n", want: "Python"},
+		{name: "scripts/build", content: "#!/bin/bash\nset -e\n", want: "Shell"},
+		{name: "Dockerfile", content: "FROM alpine\n", want: "Dockerfile"},
+		{name: "docker/Dockerfile.prod", content: "FROM alpine\n", want: "Dockerfile"},
+		{name: "Containerfile", content: "FROM alpine\n", want: "Dockerfile"},
+		{name: "a.h", content: "#include <stdio.h>\nint f(void);\n", want: "C"},
+		{name: "a.h", content: "namespace foo {\nclass Bar;\n}\n", want: "C++"},
+		{name: "a.h", content: "@interface Foo : NSObject\n@end\n", want: "Objective-C"},
+	}
+	for _, test := range tests {
+		if got := GetLanguage(test.name, []byte(test.content)); got != test.want {
+			t.Errorf("GetLanguage(%q, %q) got %q, want %q", test.name, test.content, got, test.want)
+		}
+	}
+}
+
 func makeFileReader(ctx context.Context, path, contents string) func(context.Context, string) (io.ReadCloser, error) {
 	return func(ctx context.Context, path string) (io.ReadCloser, error) {
 		return io.NopCloser(strings.NewReader(contents)), nil
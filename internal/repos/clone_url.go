@@ -16,8 +16,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/jvmpackages"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/mercurial"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/phabricator"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/svn"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -56,6 +58,10 @@ func CloneURL(kind, config string, repo *types.Repo) (string, error) {
 		if r, ok := repo.Metadata.(*gitolite.Repo); ok {
 			return r.URL, nil
 		}
+	case *schema.MercurialConnection:
+		if r, ok := repo.Metadata.(*mercurial.Repo); ok {
+			return mercurialCloneURL(r, t), nil
+		}
 	case *schema.PerforceConnection:
 		if r, ok := repo.Metadata.(*perforce.Depot); ok {
 			return perforceCloneURL(r, t), nil
@@ -64,6 +70,10 @@ func CloneURL(kind, config string, repo *types.Repo) (string, error) {
 		if r, ok := repo.Metadata.(*phabricator.Repo); ok {
 			return phabricatorCloneURL(r, t), nil
 		}
+	case *schema.SVNConnection:
+		if r, ok := repo.Metadata.(*svn.Repo); ok {
+			return svnCloneURL(r, t), nil
+		}
 	case *schema.PagureConnection:
 		if r, ok := repo.Metadata.(*pagure.Project); ok {
 			return r.FullURL, nil
@@ -209,6 +219,22 @@ func perforceCloneURL(depot *perforce.Depot, cfg *schema.PerforceConnection) str
 	return cloneURL.String()
 }
 
+// mercurialCloneURL returns the URL of the Mercurial repository, including
+// the credentials of the connection if there are any.
+func mercurialCloneURL(repo *mercurial.Repo, cfg *schema.MercurialConnection) string {
+	base, err := url.Parse(cfg.Url)
+	if err != nil {
+		log15.Warn("Error parsing Mercurial server URL.", "url", cfg.Url, "error", err)
+		return ""
+	}
+	u, err := mercurialRepoURL(base, repo.Path)
+	if err != nil {
+		log15.Warn("Error composing Mercurial repository URL.", "url", cfg.Url, "path", repo.Path, "error", err)
+		return ""
+	}
+	return setUserinfoBestEffort(u.String(), cfg.Username, cfg.Password)
+}
+
 func phabricatorCloneURL(repo *phabricator.Repo, _ *schema.PhabricatorConnection) string {
 	var external []*phabricator.URI
 	builtin := make(map[string]*phabricator.URI)
@@ -259,6 +285,22 @@ func phabricatorCloneURL(repo *phabricator.Repo, _ *schema.PhabricatorConnection
 	return cloneURL
 }
 
+// svnCloneURL returns the URL of the Subversion repository, including the
+// credentials of the connection if there are any.
+func svnCloneURL(repo *svn.Repo, cfg *schema.SVNConnection) string {
+	root, err := url.Parse(cfg.Url)
+	if err != nil {
+		log15.Warn("Error parsing Subversion root URL.", "url", cfg.Url, "error", err)
+		return ""
+	}
+	u, err := svnRepoURL(root, repo.Path)
+	if err != nil {
+		log15.Warn("Error composing Subversion repository URL.", "url", cfg.Url, "path", repo.Path, "error", err)
+		return ""
+	}
+	return setUserinfoBestEffort(u.String(), cfg.Username, cfg.Password)
+}
+
 func otherCloneURL(repo *types.Repo, m *extsvc.OtherRepoMetadata) string {
 	return repo.ExternalRepo.ServiceID + m.RelativePath
 }
@@ -0,0 +1,112 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/mercurial"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A MercurialSource yields repositories from a single Mercurial connection
+// configured in Sourcegraph via the external services configuration.
+type MercurialSource struct {
+	svc    *types.ExternalService
+	config *schema.MercurialConnection
+}
+
+// NewMercurialSource returns a new MercurialSource from the given external
+// service.
+func NewMercurialSource(svc *types.ExternalService) (*MercurialSource, error) {
+	var c schema.MercurialConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return &MercurialSource{svc: svc, config: &c}, nil
+}
+
+// ListRepos returns all Mercurial repositories configured in the connection.
+func (s MercurialSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	base, err := url.Parse(s.config.Url)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: errors.Wrap(err, "parsing Mercurial server URL")}
+		return
+	}
+
+	// The service ID must not contain the credentials of the connection.
+	serviceID := *base
+	serviceID.User = nil
+	extsvc.NormalizeBaseURL(&serviceID)
+
+	urn := s.svc.URN()
+	for _, path := range s.config.Repos {
+		r, err := s.makeRepo(urn, base, serviceID.String(), path)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			continue
+		}
+		results <- SourceResult{Source: s, Repo: r}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s MercurialSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s MercurialSource) makeRepo(urn string, base *url.URL, serviceID, path string) (*types.Repo, error) {
+	path = strings.Trim(path, "/")
+	if _, err := mercurialRepoURL(base, path); err != nil {
+		return nil, errors.Wrapf(err, "invalid Mercurial repository path %q", path)
+	}
+
+	baseName := base.Host + strings.TrimSuffix(base.Path, "/")
+	repo := &mercurial.Repo{Path: path}
+
+	return &types.Repo{
+		Name: api.RepoName(reposource.OtherRepoName(s.config.RepositoryPathPattern, baseName, path)),
+		URI:  reposource.OtherRepoName("", baseName, path),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          path,
+			ServiceType: extsvc.TypeMercurial,
+			ServiceID:   serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: mercurialCloneURL(repo, s.config),
+			},
+		},
+		Metadata: repo,
+		// Mercurial servers don't tell us whether a repository is public,
+		// so we treat all of them as private.
+		Private: true,
+	}, nil
+}
+
+// mercurialRepoURL returns the URL of the repository at path on the Mercurial
+// server at base.
+func mercurialRepoURL(base *url.URL, path string) (*url.URL, error) {
+	b := *base
+	if !strings.HasSuffix(b.Path, "/") {
+		b.Path += "/"
+	}
+	u, err := b.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https", "ssh":
+		return u, nil
+	default:
+		return nil, errors.Errorf("unsupported scheme %q", u.Scheme)
+	}
+}
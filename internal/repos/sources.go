@@ -54,6 +54,10 @@ func NewSource(svc *types.ExternalService, cf *httpcli.Factory) (Source, error)
 		return NewGitoliteSource(svc, cf)
 	case extsvc.KindPhabricator:
 		return NewPhabricatorSource(svc, cf)
+	case extsvc.KindMercurial:
+		return NewMercurialSource(svc)
+	case extsvc.KindSVN:
+		return NewSVNSource(svc, cf)
 	case extsvc.KindAWSCodeCommit:
 		return NewAWSCodeCommitSource(svc, cf)
 	case extsvc.KindPerforce:
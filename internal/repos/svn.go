@@ -0,0 +1,138 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/svn"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A SVNSource yields repositories from a single Subversion connection
+// configured in Sourcegraph via the external services configuration.
+type SVNSource struct {
+	svc    *types.ExternalService
+	config *schema.SVNConnection
+	client httpcli.Doer
+}
+
+// NewSVNSource returns a new SVNSource from the given external service.
+func NewSVNSource(svc *types.ExternalService, cf *httpcli.Factory) (*SVNSource, error) {
+	var c schema.SVNConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SVNSource{svc: svc, config: &c, client: cli}, nil
+}
+
+// ListRepos returns all Subversion repositories of the connection. Unless
+// they are listed explicitly, these are the directories directly below the
+// root URL.
+func (s SVNSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	root, err := url.Parse(s.config.Url)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: errors.Wrap(err, "parsing Subversion root URL")}
+		return
+	}
+
+	paths := s.config.Repos
+	if len(paths) == 0 {
+		if root.Scheme != "http" && root.Scheme != "https" {
+			results <- SourceResult{Source: s, Err: errors.Errorf("cannot list repositories under %s: set repos to list them explicitly", root.Redacted())}
+			return
+		}
+		paths, err = svn.NewClient(root, s.config.Username, s.config.Password, s.client).ListDirectories(ctx)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrap(err, "listing Subversion repositories")}
+			return
+		}
+	}
+
+	// The service ID must not contain the credentials of the connection.
+	serviceID := *root
+	serviceID.User = nil
+	extsvc.NormalizeBaseURL(&serviceID)
+
+	urn := s.svc.URN()
+	for _, path := range paths {
+		r, err := s.makeRepo(urn, root, serviceID.String(), path)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			continue
+		}
+		results <- SourceResult{Source: s, Repo: r}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s SVNSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s SVNSource) makeRepo(urn string, root *url.URL, serviceID, path string) (*types.Repo, error) {
+	path = strings.Trim(path, "/")
+	if _, err := svnRepoURL(root, path); err != nil {
+		return nil, errors.Wrapf(err, "invalid Subversion repository path %q", path)
+	}
+
+	baseName := root.Host + strings.TrimSuffix(root.Path, "/")
+	repo := &svn.Repo{Path: path}
+
+	return &types.Repo{
+		Name: api.RepoName(reposource.OtherRepoName(s.config.RepositoryPathPattern, baseName, path)),
+		URI:  reposource.OtherRepoName("", baseName, path),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          path,
+			ServiceType: extsvc.TypeSVN,
+			ServiceID:   serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: svnCloneURL(repo, s.config),
+			},
+		},
+		Metadata: repo,
+		// Subversion path-based authorization is not mirrored, so we treat
+		// all repositories as private.
+		Private: true,
+	}, nil
+}
+
+// svnRepoURL returns the URL of the repository at path below the Subversion
+// root URL.
+func svnRepoURL(root *url.URL, path string) (*url.URL, error) {
+	r := *root
+	if !strings.HasSuffix(r.Path, "/") {
+		r.Path += "/"
+	}
+	u, err := r.Parse((&url.URL{Path: path}).EscapedPath())
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https", "svn", "svn+ssh":
+		return u, nil
+	default:
+		return nil, errors.Errorf("unsupported scheme %q", u.Scheme)
+	}
+}
@@ -0,0 +1,121 @@
+// Package codeowners parses CODEOWNERS files and resolves the owners of the
+// files matched by a search.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	rules []rule
+}
+
+type rule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// Parse parses the CODEOWNERS file read from r. The syntax is the one
+// understood by GitHub and GitLab: each non-empty line that is not a comment
+// is a gitignore-style path pattern followed by zero or more owners. GitLab
+// section headers ("[Section]") are accepted but ignored.
+func Parse(r io.Reader) (*Ruleset, error) {
+	var rs Ruleset
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		pattern, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNumber)
+		}
+		rs.rules = append(rs.rules, rule{pattern: pattern, owners: fields[1:]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &rs, nil
+}
+
+// Match returns the owners of path, which is relative to the root of the
+// repository. As in git, the last matching rule takes precedence. A matching
+// rule without owners means path has no owners.
+func (rs *Ruleset) Match(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(rs.rules) - 1; i >= 0; i-- {
+		if rs.rules[i].pattern.MatchString(path) {
+			return rs.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// compilePattern converts a gitignore-style pattern into a regular expression
+// matching repository-relative paths.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	// A pattern is anchored to the root of the repository if it starts with
+	// or contains a slash. A trailing slash only matches directories.
+	anchored := strings.HasPrefix(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if strings.Contains(pattern, "/") {
+		anchored = true
+	}
+	if pattern == "" {
+		return nil, errors.New("empty pattern")
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	// A pattern matching a directory applies to everything below it.
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
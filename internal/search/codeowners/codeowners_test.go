@@ -0,0 +1,110 @@
+package codeowners
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+const testFile = `
+# Default owners
+*                       @global-owner
+
+*.go                    @gopher
+/docs/                  docs@example.com
+build/logs/             @ops
+apps/**/config.yaml     @org/config-team
+/scripts/*.sh           @ops @shell
+
+[Section]
+vendor/                 # no owners for vendored code
+`
+
+func TestParse(t *testing.T) {
+	rs, err := Parse(strings.NewReader(testFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@global-owner"}},
+		{"main.go", []string{"@gopher"}},
+		{"cmd/server/main.go", []string{"@gopher"}},
+		{"docs/index.md", []string{"docs@example.com"}},
+		{"docs/nested/main.go", []string{"docs@example.com"}},
+		{"src/docs/index.md", []string{"@global-owner"}},
+		{"build/logs/out.log", []string{"@ops"}},
+		{"src/build/logs/out.log", []string{"@global-owner"}},
+		{"apps/config.yaml", []string{"@org/config-team"}},
+		{"apps/a/b/config.yaml", []string{"@org/config-team"}},
+		{"scripts/deploy.sh", []string{"@ops", "@shell"}},
+		{"scripts/nested/deploy.sh", []string{"@global-owner"}},
+		{"vendor/lib/lib.go", []string{}},
+		{"/main.go", []string{"@gopher"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			got := rs.Match(tc.path)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse_noMatch(t *testing.T) {
+	rs, err := Parse(strings.NewReader("/src/ @alice\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rs.Match("README.md"); got != nil {
+		t.Fatalf("expected no owners, got %v", got)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	var reads []string
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		reads = append(reads, name)
+		if name == ".github/CODEOWNERS" {
+			return []byte("*.go @gopher\n"), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	t.Cleanup(func() { git.Mocks.ReadFile = nil })
+
+	r := NewResolver()
+	for _, path := range []string{"main.go", "README.md"} {
+		fm := &result.FileMatch{File: result.File{
+			Repo:     types.MinimalRepo{Name: "github.com/sourcegraph/sourcegraph"},
+			CommitID: "deadbeef",
+			Path:     path,
+		}}
+		owners, err := r.Owners(context.Background(), fm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path == "main.go" && !cmp.Equal(owners, []string{"@gopher"}) {
+			t.Fatalf("unexpected owners for %s: %v", path, owners)
+		}
+		if path == "README.md" && len(owners) != 0 {
+			t.Fatalf("unexpected owners for %s: %v", path, owners)
+		}
+	}
+
+	// The CODEOWNERS file is only read once per repository commit.
+	if want := []string{"CODEOWNERS", ".github/CODEOWNERS"}; !cmp.Equal(reads, want) {
+		t.Fatalf("unexpected reads: %v", reads)
+	}
+}
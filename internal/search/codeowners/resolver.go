@@ -0,0 +1,90 @@
+package codeowners
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// Paths are the locations a CODEOWNERS file is read from, in order of
+// precedence. Only the first file found is used.
+var Paths = []string{
+	"CODEOWNERS",
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// maxFileSize is the largest CODEOWNERS file we will read.
+const maxFileSize = 1 << 20 // 1mb
+
+// Resolver resolves the owners of file matches. It fetches and parses the
+// CODEOWNERS file of each repository commit at most once, so a Resolver
+// should be scoped to a single search.
+type Resolver struct {
+	mu       sync.Mutex
+	rulesets map[repoCommit]*rulesetEntry
+}
+
+type repoCommit struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+type rulesetEntry struct {
+	once    sync.Once
+	ruleset *Ruleset
+	err     error
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{rulesets: map[repoCommit]*rulesetEntry{}}
+}
+
+// Owners returns the owners of the file matched by fm. It returns no owners
+// if the repository has no CODEOWNERS file or no rule matches the file.
+func (r *Resolver) Owners(ctx context.Context, fm *result.FileMatch) ([]string, error) {
+	rs, err := r.ruleset(ctx, fm.Repo.Name, fm.CommitID)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	return rs.Match(fm.Path), nil
+}
+
+func (r *Resolver) ruleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	key := repoCommit{repo: repo, commit: commit}
+
+	r.mu.Lock()
+	entry, ok := r.rulesets[key]
+	if !ok {
+		entry = &rulesetEntry{}
+		r.rulesets[key] = entry
+	}
+	r.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.ruleset, entry.err = fetchRuleset(ctx, repo, commit)
+	})
+	return entry.ruleset, entry.err
+}
+
+// fetchRuleset reads and parses the CODEOWNERS file of repo at commit. It
+// returns a nil Ruleset if there is no CODEOWNERS file.
+func fetchRuleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	for _, path := range Paths {
+		content, err := git.ReadFile(ctx, repo, commit, path, maxFileSize)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return Parse(bytes.NewReader(content))
+	}
+	return nil, nil
+}
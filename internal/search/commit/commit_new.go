@@ -197,7 +197,7 @@ func HasTimeFilter(q query.Q) bool {
 }
 
 func QueryToGitQuery(q query.Q, diff bool) gitprotocol.Node {
-	return gitprotocol.Reduce(gitprotocol.NewAnd(queryNodesToPredicates(q, q.IsCaseSensitive(), diff)...))
+	return gitprotocol.Reduce(gitprotocol.NewAnd(queryNodesToPredicates(q, q.IsCaseSensitive(), diff, diff && q.FollowsRenames())...))
 }
 
 func searchRevsToGitserverRevs(in []search.RevisionSpecifier) []gitprotocol.RevisionSpecifier {
@@ -212,17 +212,17 @@ func searchRevsToGitserverRevs(in []search.RevisionSpecifier) []gitprotocol.Revi
 	return out
 }
 
-func queryNodesToPredicates(nodes []query.Node, caseSensitive, diff bool) []gitprotocol.Node {
+func queryNodesToPredicates(nodes []query.Node, caseSensitive, diff, followRenames bool) []gitprotocol.Node {
 	res := make([]gitprotocol.Node, 0, len(nodes))
 	for _, node := range nodes {
 		var newPred gitprotocol.Node
 		switch v := node.(type) {
 		case query.Operator:
-			newPred = queryOperatorToPredicate(v, caseSensitive, diff)
+			newPred = queryOperatorToPredicate(v, caseSensitive, diff, followRenames)
 		case query.Pattern:
 			newPred = queryPatternToPredicate(v, caseSensitive, diff)
 		case query.Parameter:
-			newPred = queryParameterToPredicate(v, caseSensitive, diff)
+			newPred = queryParameterToPredicate(v, caseSensitive, diff, followRenames)
 		}
 		if newPred != nil {
 			res = append(res, newPred)
@@ -231,12 +231,12 @@ func queryNodesToPredicates(nodes []query.Node, caseSensitive, diff bool) []gitp
 	return res
 }
 
-func queryOperatorToPredicate(op query.Operator, caseSensitive, diff bool) gitprotocol.Node {
+func queryOperatorToPredicate(op query.Operator, caseSensitive, diff, followRenames bool) gitprotocol.Node {
 	switch op.Kind {
 	case query.And:
-		return gitprotocol.NewAnd(queryNodesToPredicates(op.Operands, caseSensitive, diff)...)
+		return gitprotocol.NewAnd(queryNodesToPredicates(op.Operands, caseSensitive, diff, followRenames)...)
 	case query.Or:
-		return gitprotocol.NewOr(queryNodesToPredicates(op.Operands, caseSensitive, diff)...)
+		return gitprotocol.NewOr(queryNodesToPredicates(op.Operands, caseSensitive, diff, followRenames)...)
 	default:
 		// I don't think we should have concats at this point, but ignore it if we do
 		return nil
@@ -262,7 +262,7 @@ func queryPatternToPredicate(pattern query.Pattern, caseSensitive, diff bool) gi
 	return newPred
 }
 
-func queryParameterToPredicate(parameter query.Parameter, caseSensitive, diff bool) gitprotocol.Node {
+func queryParameterToPredicate(parameter query.Parameter, caseSensitive, diff, followRenames bool) gitprotocol.Node {
 	var newPred gitprotocol.Node
 	switch parameter.Field {
 	case query.FieldAuthor:
@@ -285,7 +285,9 @@ func queryParameterToPredicate(parameter query.Parameter, caseSensitive, diff bo
 			newPred = &gitprotocol.MessageMatches{Expr: parameter.Value, IgnoreCase: !caseSensitive}
 		}
 	case query.FieldFile:
-		newPred = &gitprotocol.DiffModifiesFile{Expr: parameter.Value, IgnoreCase: !caseSensitive}
+		// With follow:yes, diff searches follow renames so they return the
+		// whole history of the matching files.
+		newPred = &gitprotocol.DiffModifiesFile{Expr: parameter.Value, IgnoreCase: !caseSensitive, FollowRenames: followRenames}
 	case query.FieldLang:
 		newPred = &gitprotocol.DiffModifiesFile{Expr: search.LangToFileRegexp(parameter.Value), IgnoreCase: true}
 	}
@@ -320,6 +322,11 @@ func protocolMatchToCommitMatch(repo types.MinimalRepo, diff bool, in protocol.C
 		}
 	}
 
+	var renames []result.FileRename
+	for _, r := range in.Renames {
+		renames = append(renames, result.FileRename{OldPath: r.OldPath, NewPath: r.NewPath})
+	}
+
 	return &result.CommitMatch{
 		Commit: gitdomain.Commit{
 			ID: in.Oid,
@@ -343,6 +350,7 @@ func protocolMatchToCommitMatch(repo types.MinimalRepo, diff bool, in protocol.C
 			Value:      matchBody,
 			Highlights: matchHighlights,
 		},
+		Renames: renames,
 	}
 }
 
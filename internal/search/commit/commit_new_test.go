@@ -62,6 +62,25 @@ func TestQueryToGitQuery(t *testing.T) {
 			&protocol.MessageMatches{Expr: "message2", IgnoreCase: true},
 			&protocol.DiffModifiesFile{Expr: "file", IgnoreCase: true},
 		),
+	}, {
+		name: "diff searches do not follow renames by default",
+		input: []query.Node{
+			query.Parameter{Field: query.FieldFile, Value: "file"},
+		},
+		diff:   true,
+		output: &protocol.DiffModifiesFile{Expr: "file", IgnoreCase: true},
+	}, {
+		name: "diff searches follow renames with follow:yes",
+		input: []query.Node{
+			query.Parameter{Field: query.FieldFile, Value: "file"},
+			query.Parameter{Field: query.FieldLang, Value: "go"},
+			query.Parameter{Field: query.FieldFollow, Value: "yes"},
+		},
+		diff: true,
+		output: protocol.NewAnd(
+			&protocol.DiffModifiesFile{Expr: "file", IgnoreCase: true, FollowRenames: true},
+			&protocol.DiffModifiesFile{Expr: `\.go$`, IgnoreCase: true},
+		),
 	}}
 
 	for _, tc := range cases {
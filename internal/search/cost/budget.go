@@ -0,0 +1,230 @@
+package cost
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Action is what happens to a query depending on the search cost budget of
+// the user issuing it.
+type Action string
+
+const (
+	// ActionAdmit runs the query as is.
+	ActionAdmit Action = "admit"
+	// ActionQueue runs the query once enough budget is available.
+	ActionQueue Action = "queue"
+	// ActionDowngrade runs a cheaper version of the query, see Downgrade.
+	ActionDowngrade Action = "downgrade"
+	// ActionReject does not run the query.
+	ActionReject Action = "reject"
+)
+
+// Decision describes how a query was admitted.
+type Decision struct {
+	Action Action
+
+	// Cost is the estimated cost of the query.
+	Cost Cost
+
+	// Remaining is the budget the user had left before the query.
+	Remaining Cost
+
+	// PerMinute is the budget of the user per minute.
+	PerMinute Cost
+
+	// Delay is how long the query was queued for.
+	Delay time.Duration
+}
+
+// Budget admits queries as long as every user stays within a budget of cost
+// units per minute. The budget of a user is replenished continuously. All
+// anonymous users share a budget.
+//
+// Budgets are kept in memory, so every frontend replica keeps its own budget
+// for each user.
+type Budget struct {
+	perMinute Cost
+	action    Action
+	maxQueue  time.Duration
+
+	// now is replaced in tests.
+	now func() time.Time
+
+	mu    sync.Mutex
+	users map[int32]*bucket
+}
+
+type bucket struct {
+	available float64
+	last      time.Time
+}
+
+// NewBudget returns a Budget for the given configuration.
+func NewBudget(c *schema.SearchCostBudget) *Budget {
+	action := Action(c.Action)
+	switch action {
+	case ActionQueue, ActionDowngrade:
+	default:
+		action = ActionReject
+	}
+	maxQueue := 10 * time.Second
+	if c.MaxQueueSeconds > 0 {
+		maxQueue = time.Duration(c.MaxQueueSeconds) * time.Second
+	}
+	return &Budget{
+		perMinute: Cost(c.PerUserPerMinute),
+		action:    action,
+		maxQueue:  maxQueue,
+		now:       time.Now,
+		users:     map[int32]*bucket{},
+	}
+}
+
+// bucketLocked returns the replenished bucket of userID. b.mu must be held.
+func (b *Budget) bucketLocked(userID int32) *bucket {
+	now := b.now()
+	u, ok := b.users[userID]
+	if !ok {
+		u = &bucket{available: float64(b.perMinute), last: now}
+		b.users[userID] = u
+	}
+	if elapsed := now.Sub(u.last); elapsed > 0 {
+		u.available = math.Min(float64(b.perMinute), u.available+elapsed.Minutes()*float64(b.perMinute))
+		u.last = now
+	}
+	return u
+}
+
+// Admit decides whether a query with the given cost issued by userID runs,
+// and charges the cost to the budget of the user if it does. If the query is
+// queued, Admit blocks until enough budget is available or ctx is done.
+//
+// If the query is downgraded, nothing is charged and the caller is expected
+// to Charge the cost of the downgraded query.
+func (b *Budget) Admit(ctx context.Context, userID int32, cost Cost) (Decision, error) {
+	b.mu.Lock()
+	u := b.bucketLocked(userID)
+	d := Decision{
+		Action:    ActionAdmit,
+		Cost:      cost,
+		Remaining: Cost(math.Max(0, math.Floor(u.available))),
+		PerMinute: b.perMinute,
+	}
+
+	if float64(cost) <= u.available {
+		u.available -= float64(cost)
+		b.mu.Unlock()
+		return d, nil
+	}
+
+	if b.action != ActionQueue {
+		b.mu.Unlock()
+		d.Action = b.action
+		return d, nil
+	}
+
+	missing := float64(cost) - u.available
+	delay := time.Duration(missing / float64(b.perMinute) * float64(time.Minute))
+	if cost > b.perMinute || delay > b.maxQueue {
+		// The query can never run, or not within the time we are
+		// willing to hold it.
+		b.mu.Unlock()
+		d.Action = ActionReject
+		return d, nil
+	}
+
+	// Reserve the budget now, so that later queries of the user queue
+	// behind this one.
+	u.available -= float64(cost)
+	b.mu.Unlock()
+
+	d.Action = ActionQueue
+	d.Delay = delay
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return d, nil
+	case <-ctx.Done():
+		b.Charge(userID, -cost)
+		return d, ctx.Err()
+	}
+}
+
+// Charge charges cost to the budget of userID without checking whether the
+// budget is sufficient. The budget may become negative, which delays later
+// queries of the user.
+func (b *Budget) Charge(userID int32, cost Cost) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	u := b.bucketLocked(userID)
+	u.available = math.Min(float64(b.perMinute), u.available-float64(cost))
+}
+
+// Downgrade returns a cheaper version of plan, which only searches indexed
+// repositories with the default result limit.
+func Downgrade(plan query.Plan) query.Plan {
+	downgraded := make(query.Plan, 0, len(plan))
+	for _, b := range plan {
+		parameters := make([]query.Parameter, 0, len(b.Parameters)+1)
+		for _, p := range b.Parameters {
+			if p.Field == query.FieldIndex || p.Field == query.FieldCount {
+				continue
+			}
+			parameters = append(parameters, p)
+		}
+		parameters = append(parameters, query.Parameter{Field: query.FieldIndex, Value: string(query.Only)})
+		downgraded = append(downgraded, b.MapParameters(parameters))
+	}
+	return downgraded
+}
+
+var current struct {
+	sync.Mutex
+	config *schema.SearchCostBudget
+	budget *Budget
+}
+
+// CurrentBudget returns the Budget configured in the site configuration, or
+// nil if the cost of queries is not limited. Budgets are kept as long as the
+// configuration doesn't change.
+func CurrentBudget() *Budget {
+	c := conf.Get().SearchCostBudget
+
+	current.Lock()
+	defer current.Unlock()
+
+	if c == nil || c.PerUserPerMinute <= 0 {
+		current.config, current.budget = nil, nil
+		return nil
+	}
+	if current.budget == nil || !reflect.DeepEqual(current.config, c) {
+		current.config, current.budget = c, NewBudget(c)
+	}
+	return current.budget
+}
+
+type exemptKey struct{}
+
+// WithExempt returns a context whose searches are not charged to any budget.
+// It is used for searches which background jobs run on behalf of a user, such
+// as search exports, which would otherwise use up the budget of the user
+// without the user seeing why their own searches are limited.
+func WithExempt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, exemptKey{}, true)
+}
+
+// IsExempt reports whether the searches of ctx are not charged to any budget.
+func IsExempt(ctx context.Context) bool {
+	exempt, _ := ctx.Value(exemptKey{}).(bool)
+	return exempt
+}
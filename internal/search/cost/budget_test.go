@@ -0,0 +1,125 @@
+package cost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newTestBudget(c *schema.SearchCostBudget) (*Budget, *time.Time) {
+	now := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBudget(c)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func admit(t *testing.T, b *Budget, userID int32, c Cost) Decision {
+	t.Helper()
+	d, err := b.Admit(context.Background(), userID, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestBudget_Reject(t *testing.T) {
+	b, now := newTestBudget(&schema.SearchCostBudget{PerUserPerMinute: 100})
+
+	if d := admit(t, b, 1, 60); d.Action != ActionAdmit || d.Remaining != 100 {
+		t.Fatalf("got %+v, want admitted with 100 remaining", d)
+	}
+	if d := admit(t, b, 1, 60); d.Action != ActionReject || d.Remaining != 40 {
+		t.Fatalf("got %+v, want rejected with 40 remaining", d)
+	}
+
+	// Other users have their own budget.
+	if d := admit(t, b, 2, 60); d.Action != ActionAdmit {
+		t.Fatalf("got %+v, want admitted", d)
+	}
+
+	// After 12 seconds 20 units are replenished.
+	*now = now.Add(12 * time.Second)
+	if d := admit(t, b, 1, 60); d.Action != ActionAdmit || d.Remaining != 60 {
+		t.Fatalf("got %+v, want admitted with 60 remaining", d)
+	}
+
+	// The budget doesn't grow beyond the budget per minute.
+	*now = now.Add(time.Hour)
+	if d := admit(t, b, 1, 60); d.Remaining != 100 {
+		t.Fatalf("got %+v, want 100 remaining", d)
+	}
+}
+
+func TestBudget_Downgrade(t *testing.T) {
+	b, _ := newTestBudget(&schema.SearchCostBudget{PerUserPerMinute: 100, Action: "downgrade"})
+
+	if d := admit(t, b, 1, 200); d.Action != ActionDowngrade {
+		t.Fatalf("got %+v, want downgraded", d)
+	}
+
+	// Downgraded queries are charged by the caller, and may exceed the
+	// budget.
+	b.Charge(1, 150)
+	if d := admit(t, b, 1, 1); d.Action != ActionDowngrade || d.Remaining != 0 {
+		t.Fatalf("got %+v, want downgraded with 0 remaining", d)
+	}
+}
+
+func TestBudget_Queue(t *testing.T) {
+	b, _ := newTestBudget(&schema.SearchCostBudget{PerUserPerMinute: 6000, Action: "queue", MaxQueueSeconds: 1})
+
+	if d := admit(t, b, 1, 5990); d.Action != ActionAdmit {
+		t.Fatalf("got %+v, want admitted", d)
+	}
+
+	// 10 units are missing, which takes 100ms to replenish.
+	d := admit(t, b, 1, 20)
+	if d.Action != ActionQueue || d.Delay != 100*time.Millisecond {
+		t.Fatalf("got %+v, want queued for 100ms", d)
+	}
+
+	// Queries which would wait longer than maxQueueSeconds are rejected.
+	if d := admit(t, b, 1, 200); d.Action != ActionReject {
+		t.Fatalf("got %+v, want rejected", d)
+	}
+
+	// As are queries which exceed the budget.
+	if d := admit(t, b, 2, 6001); d.Action != ActionReject {
+		t.Fatalf("got %+v, want rejected", d)
+	}
+
+	// Cancelled queries are refunded.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := b.Admit(ctx, 1, 50); err != context.Canceled {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+	if d := admit(t, b, 1, 1); d.Remaining != 0 || d.Action != ActionQueue {
+		t.Fatalf("got %+v, want queued with 0 remaining", d)
+	}
+}
+
+func TestDowngrade(t *testing.T) {
+	plan, err := query.Pipeline(query.InitLiteral("foo index:no count:10000 repo:bar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Downgrade(plan).ToParseTree().String()
+	want := `(and "repo:bar" "index:only" "foo")`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestWithExempt(t *testing.T) {
+	ctx := context.Background()
+	if IsExempt(ctx) {
+		t.Fatal("expected background context not to be exempt")
+	}
+	if !IsExempt(WithExempt(ctx)) {
+		t.Fatal("expected context to be exempt")
+	}
+}
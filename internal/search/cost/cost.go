@@ -0,0 +1,238 @@
+// Package cost estimates the resource demand of search queries and admits
+// queries against a per-user budget.
+package cost
+
+import (
+	"math"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// Cost is an approximation of the resource demand of a search query, in
+// arbitrary units. A literal search over a single indexed repository costs
+// about one unit.
+type Cost int
+
+const (
+	// IndexedRepo is the cost of searching the default branch of a repository
+	// with Zoekt.
+	IndexedRepo = 1.0
+	// UnindexedRepo is the cost of searching a revision of a repository with
+	// searcher, which has to fetch and scan an archive of the revision.
+	UnindexedRepo = 10.0
+	// CommitRepo is the cost of searching the history of a repository for
+	// type:commit and type:diff searches.
+	CommitRepo = 20.0
+	// RefGlobRevisions is the number of revisions we assume a ref glob such
+	// as repo:foo@*refs/heads/* expands to.
+	RefGlobRevisions = 10
+)
+
+// RepoCounter returns the number of repositories the basic query b is run
+// over.
+type RepoCounter func(b query.Basic) (int, error)
+
+// Estimate returns the estimated cost of running plan. countRepos is called
+// for each basic query of plan.
+func Estimate(plan query.Plan, countRepos RepoCounter) (Cost, error) {
+	total := 0.0
+	for _, b := range plan {
+		repos, err := countRepos(b)
+		if err != nil {
+			return 0, err
+		}
+		total += estimateBasic(b, repos)
+	}
+	if total > math.MaxInt32 {
+		return math.MaxInt32, nil
+	}
+	return Cost(math.Ceil(total)), nil
+}
+
+func estimateBasic(b query.Basic, repos int) float64 {
+	if repos == 0 {
+		return 0
+	}
+
+	var types []string
+	b.VisitParameter(query.FieldType, func(value string, _ bool, _ query.Annotation) {
+		types = append(types, value)
+	})
+	if len(types) == 0 {
+		// Without type: we search file contents and paths.
+		types = []string{"file"}
+	}
+
+	revs := revisionCount(b)
+	perRepo := IndexedRepo
+	if revs > 1 || b.Index() == query.No || hasRevision(b) {
+		perRepo = UnindexedRepo
+	}
+	perRepo *= float64(revs)
+
+	total := 0.0
+	for _, typ := range types {
+		switch typ {
+		case "repo":
+			// Resolving repositories is a database query, which is
+			// cheap compared to any search backend.
+			total += float64(repos) * 0.01
+		case "commit", "diff":
+			total += float64(repos) * CommitRepo * patternFactor(b)
+		case "symbol":
+			total += float64(repos) * perRepo * 2
+		default:
+			total += float64(repos) * perRepo * patternFactor(b)
+		}
+	}
+	return total * countFactor(b)
+}
+
+// revisionCount returns the number of revisions searched in each repository
+// by b.
+func revisionCount(b query.Basic) int {
+	revs := 1
+	visit := func(revSpec string) {
+		n := 0
+		for _, rev := range strings.Split(revSpec, ":") {
+			if rev == "" {
+				continue
+			}
+			if strings.HasPrefix(rev, "*") {
+				n += RefGlobRevisions
+			} else {
+				n++
+			}
+		}
+		if n > revs {
+			revs = n
+		}
+	}
+	b.VisitParameter(query.FieldRepo, func(value string, negated bool, _ query.Annotation) {
+		if i := strings.Index(value, "@"); i >= 0 && !negated {
+			visit(value[i+1:])
+		}
+	})
+	b.VisitParameter(query.FieldRev, func(value string, _ bool, _ query.Annotation) {
+		visit(value)
+	})
+	return revs
+}
+
+// hasRevision returns true if b searches a revision other than the default
+// branch, which is typically not indexed.
+func hasRevision(b query.Basic) bool {
+	found := false
+	b.VisitParameter(query.FieldRepo, func(value string, negated bool, _ query.Annotation) {
+		if !negated && strings.Contains(value, "@") {
+			found = true
+		}
+	})
+	b.VisitParameter(query.FieldRev, func(string, bool, query.Annotation) {
+		found = true
+	})
+	return found
+}
+
+// patternFactor returns how much more expensive it is to search for the
+// pattern of b than for a literal.
+func patternFactor(b query.Basic) float64 {
+	if b.Pattern == nil {
+		// Only file paths are matched.
+		return 0.1
+	}
+	switch {
+	case b.IsStructural():
+		return 10
+	case b.IsFuzzy():
+		return 4
+	}
+
+	factor := 0.0
+	query.VisitPattern([]query.Node{b.Pattern}, func(value string, _ bool, annotation query.Annotation) {
+		f := 1.0
+		if annotation.Labels.IsSet(query.Regexp) {
+			f = RegexpComplexity(value)
+		}
+		factor += f
+	})
+	if factor == 0 {
+		return 1
+	}
+	return factor
+}
+
+// RegexpComplexity returns how much more expensive it is to search for the
+// regular expression pattern than for a literal. Repetitions, alternations
+// and wildcards all need more work from the regex engine, and patterns
+// without a literal substring of at least three characters can't be
+// narrowed down with the trigram index of Zoekt.
+func RegexpComplexity(pattern string) float64 {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		// Invalid patterns are rejected before any search runs.
+		return 1
+	}
+	re = re.Simplify()
+
+	complexity := 1.0
+	var walk func(re *syntax.Regexp)
+	walk = func(re *syntax.Regexp) {
+		switch re.Op {
+		case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+			complexity += 0.5
+		case syntax.OpAlternate:
+			complexity += 0.25 * float64(len(re.Sub)-1)
+		case syntax.OpAnyChar, syntax.OpAnyCharNotNL, syntax.OpCharClass:
+			complexity += 0.25
+		}
+		for _, sub := range re.Sub {
+			walk(sub)
+		}
+	}
+	walk(re)
+
+	if len(longestLiteral(re)) < 3 {
+		complexity *= 4
+	}
+	return complexity
+}
+
+// longestLiteral returns the longest literal that appears in every match of
+// re.
+func longestLiteral(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return string(re.Rune)
+	case syntax.OpCapture, syntax.OpPlus:
+		return longestLiteral(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min >= 1 {
+			return longestLiteral(re.Sub[0])
+		}
+	case syntax.OpConcat:
+		longest := ""
+		for _, sub := range re.Sub {
+			if l := longestLiteral(sub); len(l) > len(longest) {
+				longest = l
+			}
+		}
+		return longest
+	}
+	return ""
+}
+
+// countFactor returns how much more expensive b is than with the default
+// result limit. Higher limits let backends search longer, up to the point
+// where all repositories have been searched.
+func countFactor(b query.Basic) float64 {
+	count, err := strconv.Atoi(b.GetCount())
+	if err != nil || count <= search.DefaultMaxSearchResultsStreaming {
+		return 1
+	}
+	return math.Min(float64(count)/search.DefaultMaxSearchResultsStreaming, 10)
+}
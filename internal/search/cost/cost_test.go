@@ -0,0 +1,71 @@
+package cost
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func estimate(t *testing.T, searchType query.SearchType, q string, repos int) Cost {
+	t.Helper()
+	plan, err := query.Pipeline(query.Init(q, searchType))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := Estimate(plan, func(query.Basic) (int, error) { return repos, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestEstimate(t *testing.T) {
+	cases := []struct {
+		query string
+		typ   query.SearchType
+		repos int
+		want  Cost
+	}{
+		{query: "foo", typ: query.SearchTypeLiteral, repos: 0, want: 0},
+		{query: "foo", typ: query.SearchTypeLiteral, repos: 100, want: 100},
+		{query: "foo type:repo", typ: query.SearchTypeLiteral, repos: 100, want: 1},
+		{query: "file:foo", typ: query.SearchTypeLiteral, repos: 100, want: 10},
+		{query: "foo type:symbol", typ: query.SearchTypeLiteral, repos: 100, want: 200},
+		{query: "foo index:no", typ: query.SearchTypeLiteral, repos: 100, want: 1000},
+		{query: "repo:foo@a:b bar", typ: query.SearchTypeLiteral, repos: 1, want: 20},
+		{query: "repo:foo@*refs/heads/* bar", typ: query.SearchTypeLiteral, repos: 1, want: 100},
+		{query: "foo type:commit", typ: query.SearchTypeLiteral, repos: 10, want: 200},
+		{query: "foo count:5000", typ: query.SearchTypeLiteral, repos: 100, want: 1000},
+		{query: "foo count:100000", typ: query.SearchTypeLiteral, repos: 100, want: 1000},
+		{query: "foo", typ: query.SearchTypeStructural, repos: 10, want: 100},
+		{query: "foobar", typ: query.SearchTypeFuzzy, repos: 10, want: 40},
+		{query: "foo or bar", typ: query.SearchTypeLiteral, repos: 10, want: 20},
+		{query: "foo.*bar", typ: query.SearchTypeRegex, repos: 100, want: 175},
+	}
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			if got := estimate(t, c.typ, c.query, c.repos); got != c.want {
+				t.Errorf("got %d, want %d", got, c.want)
+			}
+		})
+	}
+}
+
+func TestRegexpComplexity(t *testing.T) {
+	cases := []struct {
+		pattern string
+		want    float64
+	}{
+		{pattern: "foobar", want: 1},
+		{pattern: "foo.*bar", want: 1.75},
+		{pattern: "(foo|bar|baz)", want: 4 * 1.5},
+		{pattern: "[a-z]+", want: 4 * 1.75},
+		{pattern: "f.o", want: 4 * 1.25},
+		{pattern: "(foo", want: 1},
+	}
+	for _, c := range cases {
+		if got := RegexpComplexity(c.pattern); got != c.want {
+			t.Errorf("RegexpComplexity(%q) got %v, want %v", c.pattern, got, c.want)
+		}
+	}
+}
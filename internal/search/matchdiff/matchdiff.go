@@ -0,0 +1,309 @@
+// Package matchdiff compares the content matches of a search pattern in two
+// revisions of a repository.
+package matchdiff
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/endpoint"
+	"github.com/sourcegraph/sourcegraph/internal/mutablelimiter"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// revisionMatchLimit is the maximum number of matches we search for in each
+// revision. If a revision has more matches, the difference is incomplete.
+const revisionMatchLimit = 5000
+
+// repoLimiter limits the number of repositories searched concurrently. Each
+// repository is searched with two concurrent searcher requests.
+var repoLimiter = mutablelimiter.New(16)
+
+// MatchDiffSearch searches two revisions of each repository with searcher,
+// and returns the line matches which appear, disappear or move between them.
+// The revisions are specified as a range like rev:v1.2..v1.3.
+type MatchDiffSearch struct {
+	PatternInfo     *search.TextPatternInfo
+	RepoOpts        search.RepoOptions
+	SearcherURLs    *endpoint.Map
+	UseFullDeadline bool
+}
+
+func (j *MatchDiffSearch) Run(ctx context.Context, stream streaming.Sender, repos searchrepos.Pager) error {
+	ctx, stream, cleanup := streaming.WithLimit(ctx, stream, int(j.PatternInfo.FileMatchLimit))
+	defer cleanup()
+
+	// Resolve both ends of each revision range, so that we only search
+	// repositories in which both revisions exist.
+	opts := j.RepoOpts
+	opts.RepoFilters = splitRevRanges(opts.RepoFilters)
+
+	return repos.Paginate(ctx, &opts, func(page *searchrepos.Resolved) error {
+		g, ctx := errgroup.WithContext(ctx)
+		for _, repoRev := range page.RepoRevs {
+			if len(repoRev.Revs) != 2 {
+				// One of the revisions is missing, which Paginate
+				// reports.
+				continue
+			}
+			repo, base, head := repoRev.Repo, repoRev.Revs[0].RevSpec, repoRev.Revs[1].RevSpec
+			limitCtx, limitDone, err := repoLimiter.Acquire(ctx)
+			if err != nil {
+				// ctx is done, return the error of the search that
+				// canceled it, if any.
+				if gErr := g.Wait(); gErr != nil {
+					return gErr
+				}
+				return err
+			}
+			g.Go(func() error {
+				defer limitDone()
+				return j.searchRepo(limitCtx, repo, base, head, stream)
+			})
+		}
+		return g.Wait()
+	})
+}
+
+func (*MatchDiffSearch) Name() string {
+	return "MatchDiff"
+}
+
+func (j *MatchDiffSearch) searchRepo(ctx context.Context, repo types.MinimalRepo, base, head string, stream streaming.Sender) error {
+	var (
+		baseMatches, headMatches []*result.FileMatch
+		baseCommit, headCommit   api.CommitID
+		baseLimit, headLimit     bool
+	)
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		baseCommit, baseMatches, baseLimit, err = j.searchRevision(ctx, repo, base)
+		return err
+	})
+	g.Go(func() (err error) {
+		headCommit, headMatches, headLimit, err = j.searchRevision(ctx, repo, head)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	diffs := diff(baseMatches, headMatches)
+	matches := make([]result.Match, 0, len(diffs))
+	for _, m := range diffs {
+		m.Repo = repo
+		m.Base, m.Head = base, head
+		m.BaseCommit, m.HeadCommit = baseCommit, headCommit
+		matches = append(matches, m)
+	}
+	stream.Send(streaming.SearchEvent{
+		Results: matches,
+		Stats: streaming.Stats{
+			IsLimitHit: baseLimit || headLimit,
+		},
+	})
+	return nil
+}
+
+// searchRevision returns all file matches of rev.
+func (j *MatchDiffSearch) searchRevision(ctx context.Context, repo types.MinimalRepo, rev string) (api.CommitID, []*result.FileMatch, bool, error) {
+	// Do not trigger a repo-updater lookup, the revision was resolved
+	// when resolving repositories.
+	commit, err := git.ResolveRevision(ctx, repo.Name, rev, git.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	info := *j.PatternInfo
+	info.FileMatchLimit = revisionMatchLimit
+	info.PatternMatchesContent = true
+	info.PatternMatchesPath = false
+
+	var (
+		mu      sync.Mutex
+		matches []*result.FileMatch
+	)
+	onMatches := func(fms []*protocol.FileMatch) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, fm := range fms {
+			matches = append(matches, toFileMatch(repo, commit, fm))
+		}
+	}
+
+	limitHit, err := searcher.Search(ctx, j.SearcherURLs, repo.Name, repo.ID, rev, commit, false, &info, j.fetchTimeout(ctx), nil, onMatches)
+	return commit, matches, limitHit, err
+}
+
+func (j *MatchDiffSearch) fetchTimeout(ctx context.Context) time.Duration {
+	if !j.UseFullDeadline {
+		return 500 * time.Millisecond
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return time.Minute
+}
+
+func toFileMatch(repo types.MinimalRepo, commit api.CommitID, fm *protocol.FileMatch) *result.FileMatch {
+	lineMatches := make([]*result.LineMatch, 0, len(fm.LineMatches))
+	for _, lm := range fm.LineMatches {
+		ranges := make([][2]int32, 0, len(lm.OffsetAndLengths))
+		for _, ol := range lm.OffsetAndLengths {
+			ranges = append(ranges, [2]int32{int32(ol[0]), int32(ol[1])})
+		}
+		lineMatches = append(lineMatches, &result.LineMatch{
+			Preview:          lm.Preview,
+			OffsetAndLengths: ranges,
+			LineNumber:       int32(lm.LineNumber),
+		})
+	}
+	return &result.FileMatch{
+		File: result.File{
+			Path:     fm.Path,
+			Repo:     repo,
+			CommitID: commit,
+		},
+		LineMatches: lineMatches,
+		LimitHit:    fm.LimitHit,
+	}
+}
+
+// splitRevRanges replaces revision ranges in repo filters such as
+// foo@v1.2..v1.3 with the list of both revisions, foo@v1.2:v1.3.
+func splitRevRanges(repoFilters []string) []string {
+	split := make([]string, 0, len(repoFilters))
+	for _, filter := range repoFilters {
+		if i := strings.Index(filter, "@"); i >= 0 {
+			if base, head, ok := query.ParseRevRange(filter[i+1:]); ok {
+				filter = filter[:i+1] + base + ":" + head
+			}
+		}
+		split = append(split, filter)
+	}
+	return split
+}
+
+type occurrence struct {
+	path string
+	line *result.LineMatch
+}
+
+// diff returns the line matches which were added, removed or moved between
+// the file matches of base and head, grouped by file. Lines are compared by
+// their content. A line which appears in the same file in both revisions is
+// unchanged, even if its line number changed. A line which disappears from one
+// file and appears in another is moved.
+func diff(base, head []*result.FileMatch) []*result.MatchDiffMatch {
+	baseOccurrences, headOccurrences := byContent(base), byContent(head)
+
+	files := map[string]*result.MatchDiffMatch{}
+	add := func(path string, line *result.MatchDiffLine) {
+		m, ok := files[path]
+		if !ok {
+			m = &result.MatchDiffMatch{Path: path}
+			files[path] = m
+		}
+		m.Lines = append(m.Lines, line)
+	}
+
+	for content, hs := range headOccurrences {
+		bs, hs := unpaired(baseOccurrences[content], hs)
+		for i, h := range hs {
+			if i < len(bs) {
+				add(h.path, &result.MatchDiffLine{
+					LineMatch:     *h.line,
+					Status:        result.MatchMoved,
+					OldPath:       bs[i].path,
+					OldLineNumber: bs[i].line.LineNumber,
+				})
+				continue
+			}
+			add(h.path, &result.MatchDiffLine{LineMatch: *h.line, Status: result.MatchAdded})
+		}
+		for i := len(hs); i < len(bs); i++ {
+			add(bs[i].path, &result.MatchDiffLine{LineMatch: *bs[i].line, Status: result.MatchRemoved})
+		}
+	}
+	for content, bs := range baseOccurrences {
+		if _, ok := headOccurrences[content]; ok {
+			continue
+		}
+		for _, b := range bs {
+			add(b.path, &result.MatchDiffLine{LineMatch: *b.line, Status: result.MatchRemoved})
+		}
+	}
+
+	matches := make([]*result.MatchDiffMatch, 0, len(files))
+	for _, m := range files {
+		sort.Slice(m.Lines, func(i, j int) bool {
+			if m.Lines[i].LineNumber != m.Lines[j].LineNumber {
+				return m.Lines[i].LineNumber < m.Lines[j].LineNumber
+			}
+			return m.Lines[i].Status < m.Lines[j].Status
+		})
+		matches = append(matches, m)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Path < matches[j].Path
+	})
+	return matches
+}
+
+// byContent groups the line matches of fms by the content of the line. The
+// occurrences of each line are sorted by path and line number.
+func byContent(fms []*result.FileMatch) map[string][]occurrence {
+	occurrences := map[string][]occurrence{}
+	for _, fm := range fms {
+		for _, lm := range fm.LineMatches {
+			occurrences[lm.Preview] = append(occurrences[lm.Preview], occurrence{path: fm.Path, line: lm})
+		}
+	}
+	for _, os := range occurrences {
+		sort.Slice(os, func(i, j int) bool {
+			if os[i].path != os[j].path {
+				return os[i].path < os[j].path
+			}
+			return os[i].line.LineNumber < os[j].line.LineNumber
+		})
+	}
+	return occurrences
+}
+
+// unpaired removes the occurrences of a and b which can be paired with an
+// occurrence in the same file of the other.
+func unpaired(a, b []occurrence) ([]occurrence, []occurrence) {
+	countA, countB := map[string]int{}, map[string]int{}
+	for _, o := range a {
+		countA[o.path]++
+	}
+	for _, o := range b {
+		countB[o.path]++
+	}
+	keep := func(os []occurrence, other map[string]int) []occurrence {
+		var kept []occurrence
+		for _, o := range os {
+			if other[o.path] > 0 {
+				other[o.path]--
+				continue
+			}
+			kept = append(kept, o)
+		}
+		return kept
+	}
+	return keep(a, countB), keep(b, countA)
+}
@@ -0,0 +1,86 @@
+package matchdiff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func fileMatch(path string, lines ...string) *result.FileMatch {
+	fm := &result.FileMatch{File: result.File{Path: path}}
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		fm.LineMatches = append(fm.LineMatches, &result.LineMatch{
+			Preview:          line,
+			LineNumber:       int32(i),
+			OffsetAndLengths: [][2]int32{{0, int32(len(line))}},
+		})
+	}
+	return fm
+}
+
+func diffLine(status result.MatchDiffStatus, line string, lineNumber int32) *result.MatchDiffLine {
+	return &result.MatchDiffLine{
+		LineMatch: result.LineMatch{
+			Preview:          line,
+			LineNumber:       lineNumber,
+			OffsetAndLengths: [][2]int32{{0, int32(len(line))}},
+		},
+		Status: status,
+	}
+}
+
+func TestDiff(t *testing.T) {
+	base := []*result.FileMatch{
+		fileMatch("a.go", "foo()", "", "foo(1)", "foo(2)"),
+		fileMatch("b.go", "foo(3)"),
+		fileMatch("c.go", "foo()"),
+	}
+	head := []*result.FileMatch{
+		// foo() and foo(1) moved down a line, which is not a change.
+		fileMatch("a.go", "", "foo()", "", "foo(1)", "foo(4)"),
+		fileMatch("d.go", "foo(3)"),
+		fileMatch("c.go", "foo()", "foo()"),
+	}
+
+	moved := diffLine(result.MatchMoved, "foo(3)", 0)
+	moved.OldPath = "b.go"
+	moved.OldLineNumber = 0
+
+	want := []*result.MatchDiffMatch{{
+		Path: "a.go",
+		Lines: []*result.MatchDiffLine{
+			diffLine(result.MatchRemoved, "foo(2)", 3),
+			diffLine(result.MatchAdded, "foo(4)", 4),
+		},
+	}, {
+		Path: "c.go",
+		Lines: []*result.MatchDiffLine{
+			diffLine(result.MatchAdded, "foo()", 1),
+		},
+	}, {
+		Path:  "d.go",
+		Lines: []*result.MatchDiffLine{moved},
+	}}
+
+	got := diff(base, head)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", d)
+	}
+
+	if got := diff(base, base); len(got) != 0 {
+		t.Fatalf("expected no difference between identical revisions, got %d files", len(got))
+	}
+}
+
+func TestSplitRevRanges(t *testing.T) {
+	got := splitRevRanges([]string{"foo@v1.2..v1.3", "bar", "baz@main"})
+	want := []string{"foo@v1.2:v1.3", "bar", "baz@main"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", d)
+	}
+}
@@ -14,6 +14,8 @@ const (
 	FieldType               = "type"
 	FieldRepoHasFile        = "repohasfile"
 	FieldRepoHasCommitAfter = "repohascommitafter"
+	FieldRepoHasTopic       = "repohastopic"
+	FieldRepoHasDescription = "repohasdescription"
 	FieldPatternType        = "patterntype"
 	FieldContent            = "content"
 	FieldVisibility         = "visibility"
@@ -26,6 +28,7 @@ const (
 	FieldAuthor    = "author"
 	FieldCommitter = "committer"
 	FieldMessage   = "message"
+	FieldFollow    = "follow"
 
 	// Temporary experimental fields:
 	FieldIndex     = "index"
@@ -54,6 +57,8 @@ var allFields = map[string]struct{}{
 	FieldVisibility:         empty,
 	FieldRepoHasFile:        empty,
 	FieldRepoHasCommitAfter: empty,
+	FieldRepoHasTopic:       empty,
+	FieldRepoHasDescription: empty,
 	FieldBefore:             empty,
 	"until":                 empty,
 	FieldAfter:              empty,
@@ -63,6 +68,7 @@ var allFields = map[string]struct{}{
 	FieldMessage:            empty,
 	"m":                     empty,
 	"msg":                   empty,
+	FieldFollow:             empty,
 	FieldIndex:              empty,
 	FieldCount:              empty,
 	FieldTimeout:            empty,
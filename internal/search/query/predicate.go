@@ -31,10 +31,14 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"contains.file":         func() Predicate { return &RepoContainsFilePredicate{} },
 		"contains.content":      func() Predicate { return &RepoContainsContentPredicate{} },
 		"contains.commit.after": func() Predicate { return &RepoContainsCommitAfterPredicate{} },
+		"contains.symbol":       func() Predicate { return &RepoContainsSymbolPredicate{} },
+		"has.description":       func() Predicate { return &RepoHasDescriptionPredicate{} },
+		"has.topic":             func() Predicate { return &RepoHasTopicPredicate{} },
 	},
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"contains":         func() Predicate { return &FileContainsContentPredicate{} },
+		"contains.symbol":  func() Predicate { return &FileContainsSymbolPredicate{} },
 	},
 }
 
@@ -261,6 +265,110 @@ func (f *RepoContainsCommitAfterPredicate) Plan(parent Basic) (Plan, error) {
 	return ToPlan(Dnf(nodes))
 }
 
+/* repo:contains.symbol(pattern) */
+
+type RepoContainsSymbolPredicate struct {
+	Pattern string
+}
+
+func (f *RepoContainsSymbolPredicate) ParseParams(params string) error {
+	if _, err := regexp.Compile(params); err != nil {
+		return errors.Errorf("contains.symbol argument: %w", err)
+	}
+	if params == "" {
+		return errors.Errorf("contains.symbol argument should not be empty")
+	}
+	f.Pattern = params
+	return nil
+}
+
+func (f *RepoContainsSymbolPredicate) Field() string { return FieldRepo }
+func (f *RepoContainsSymbolPredicate) Name() string  { return "contains.symbol" }
+func (f *RepoContainsSymbolPredicate) Plan(parent Basic) (Plan, error) {
+	nodes := make([]Node, 0, 4)
+	nodes = append(nodes, Parameter{
+		Field: FieldSelect,
+		Value: "repo",
+	}, Parameter{
+		Field: FieldCount,
+		Value: "99999",
+	}, Parameter{
+		Field: FieldType,
+		Value: "symbol",
+	}, Pattern{
+		Value:      f.Pattern,
+		Annotation: Annotation{Labels: Regexp},
+	})
+
+	nodes = append(nodes, nonPredicateRepos(parent)...)
+	return ToPlan(Dnf(nodes))
+}
+
+/* repo:has.description(pattern) */
+
+type RepoHasDescriptionPredicate struct {
+	Pattern string
+}
+
+func (f *RepoHasDescriptionPredicate) ParseParams(params string) error {
+	if _, err := regexp.Compile(params); err != nil {
+		return errors.Errorf("has.description argument: %w", err)
+	}
+	if params == "" {
+		return errors.Errorf("has.description argument should not be empty")
+	}
+	f.Pattern = params
+	return nil
+}
+
+func (f *RepoHasDescriptionPredicate) Field() string { return FieldRepo }
+func (f *RepoHasDescriptionPredicate) Name() string  { return "has.description" }
+func (f *RepoHasDescriptionPredicate) Plan(parent Basic) (Plan, error) {
+	nodes := make([]Node, 0, 3)
+	nodes = append(nodes, Parameter{
+		Field: FieldCount,
+		Value: "99999",
+	}, Parameter{
+		Field: FieldRepoHasDescription,
+		Value: f.Pattern,
+	})
+
+	nodes = append(nodes, nonPredicateRepos(parent)...)
+	return ToPlan(Dnf(nodes))
+}
+
+/* repo:has.topic(name) */
+
+type RepoHasTopicPredicate struct {
+	Topic string
+}
+
+func (f *RepoHasTopicPredicate) ParseParams(params string) error {
+	if params == "" {
+		return errors.Errorf("has.topic argument should not be empty")
+	}
+	f.Topic = params
+	return nil
+}
+
+func (f *RepoHasTopicPredicate) Field() string { return FieldRepo }
+func (f *RepoHasTopicPredicate) Name() string  { return "has.topic" }
+func (f *RepoHasTopicPredicate) Plan(parent Basic) (Plan, error) {
+	nodes := make([]Node, 0, 3)
+	nodes = append(nodes, Parameter{
+		Field: FieldCount,
+		Value: "99999",
+	}, Parameter{
+		Field: FieldRepoHasTopic,
+		Value: f.Topic,
+	})
+
+	nodes = append(nodes, nonPredicateRepos(parent)...)
+	return ToPlan(Dnf(nodes))
+}
+
+/* file:contains.content(pattern) */
+
 type FileContainsContentPredicate struct {
 	Pattern string
 }
@@ -296,6 +404,46 @@ func (f *FileContainsContentPredicate) Plan(parent Basic) (Plan, error) {
 	return ToPlan(Dnf(nodes))
 }
 
+/* file:contains.symbol(pattern) */
+
+type FileContainsSymbolPredicate struct {
+	Pattern string
+}
+
+func (f *FileContainsSymbolPredicate) ParseParams(params string) error {
+	if _, err := regexp.Compile(params); err != nil {
+		return errors.Errorf("file:contains.symbol argument: %w", err)
+	}
+	if params == "" {
+		return errors.Errorf("file:contains.symbol argument should not be empty")
+	}
+	f.Pattern = params
+	return nil
+}
+
+func (f FileContainsSymbolPredicate) Field() string { return FieldFile }
+func (f FileContainsSymbolPredicate) Name() string  { return "contains.symbol" }
+
+func (f *FileContainsSymbolPredicate) Plan(parent Basic) (Plan, error) {
+	nodes := make([]Node, 0, 4)
+	nodes = append(nodes, Parameter{
+		Field: FieldSelect,
+		Value: "file",
+	}, Parameter{
+		Field: FieldCount,
+		Value: "99999",
+	}, Parameter{
+		Field: FieldType,
+		Value: "symbol",
+	}, Pattern{
+		Value:      f.Pattern,
+		Annotation: Annotation{Labels: Regexp},
+	})
+
+	nodes = append(nodes, nonPredicateRepos(parent)...)
+	return ToPlan(Dnf(nodes))
+}
+
 // nonPredicateRepos returns the repo nodes in a query that aren't predicates,
 // respecting parameters that determine repo results.
 func nonPredicateRepos(q Basic) []Node {
@@ -3,6 +3,8 @@ package query
 import (
 	"reflect"
 	"testing"
+
+	"github.com/hexops/autogold"
 )
 
 func TestRepoContainsPredicate(t *testing.T) {
@@ -56,6 +58,113 @@ func TestRepoContainsPredicate(t *testing.T) {
 	})
 }
 
+func TestContainsSymbolPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		repo := &RepoContainsSymbolPredicate{}
+		if err := repo.ParseParams(`^New[A-Z]`); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (&RepoContainsSymbolPredicate{Pattern: `^New[A-Z]`}); !reflect.DeepEqual(want, repo) {
+			t.Fatalf("expected %#v, got %#v", want, repo)
+		}
+
+		file := &FileContainsSymbolPredicate{}
+		if err := file.ParseParams(`Handler$`); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (&FileContainsSymbolPredicate{Pattern: `Handler$`}); !reflect.DeepEqual(want, file) {
+			t.Fatalf("expected %#v, got %#v", want, file)
+		}
+
+		for _, params := range []string{``, `([)`} {
+			if err := (&RepoContainsSymbolPredicate{}).ParseParams(params); err == nil {
+				t.Fatalf("expected error for repo:contains.symbol(%s) but got none", params)
+			}
+			if err := (&FileContainsSymbolPredicate{}).ParseParams(params); err == nil {
+				t.Fatalf("expected error for file:contains.symbol(%s) but got none", params)
+			}
+		}
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		test := func(input string) string {
+			plan, err := Pipeline(Init(input, SearchTypeLiteral))
+			if err != nil {
+				return err.Error()
+			}
+			var pred Predicate
+			VisitParameter(plan[0].ToParseTree(), func(field, value string, _ bool, ann Annotation) {
+				if ann.Labels.IsSet(IsPredicate) {
+					name, params := ParseAsPredicate(value)
+					pred = DefaultPredicateRegistry.Get(field, name)
+					_ = pred.ParseParams(params)
+				}
+			})
+			predicatePlan, err := pred.Plan(plan[0])
+			if err != nil {
+				return err.Error()
+			}
+			return predicatePlan.ToParseTree().String()
+		}
+
+		autogold.Want("repo contains symbol", `(and "select:repo" "count:99999" "type:symbol" "repo:foo" "^Parse")`).Equal(t, test(`repo:foo repo:contains.symbol(^Parse) bar`))
+		autogold.Want("file contains symbol", `(and "select:file" "count:99999" "type:symbol" "repo:foo" "Handler$")`).Equal(t, test(`repo:foo file:contains.symbol(Handler$) bar`))
+	})
+}
+
+func TestRepoHasPredicates(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		topic := &RepoHasTopicPredicate{}
+		if err := topic.ParseParams(`payments`); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (&RepoHasTopicPredicate{Topic: `payments`}); !reflect.DeepEqual(want, topic) {
+			t.Fatalf("expected %#v, got %#v", want, topic)
+		}
+		if err := (&RepoHasTopicPredicate{}).ParseParams(``); err == nil {
+			t.Fatal("expected error for empty topic but got none")
+		}
+
+		description := &RepoHasDescriptionPredicate{}
+		if err := description.ParseParams(`payment.*service`); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (&RepoHasDescriptionPredicate{Pattern: `payment.*service`}); !reflect.DeepEqual(want, description) {
+			t.Fatalf("expected %#v, got %#v", want, description)
+		}
+		for _, params := range []string{``, `([)`} {
+			if err := (&RepoHasDescriptionPredicate{}).ParseParams(params); err == nil {
+				t.Fatalf("expected error for repo:has.description(%s) but got none", params)
+			}
+		}
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		test := func(input string) string {
+			plan, err := Pipeline(Init(input, SearchTypeLiteral))
+			if err != nil {
+				return err.Error()
+			}
+			var pred Predicate
+			VisitParameter(plan[0].ToParseTree(), func(field, value string, _ bool, ann Annotation) {
+				if ann.Labels.IsSet(IsPredicate) {
+					name, params := ParseAsPredicate(value)
+					pred = DefaultPredicateRegistry.Get(field, name)
+					_ = pred.ParseParams(params)
+				}
+			})
+			predicatePlan, err := pred.Plan(plan[0])
+			if err != nil {
+				return err.Error()
+			}
+			return predicatePlan.ToParseTree().String()
+		}
+
+		autogold.Want("repo has topic", `(and "count:99999" "repohastopic:payments" "repo:foo")`).Equal(t, test(`repo:foo repo:has.topic(payments) bar`))
+		autogold.Want("repo has description", `(and "count:99999" "repohasdescription:payment.*service" "repo:foo")`).Equal(t, test(`repo:foo repo:has.description(payment.*service) bar`))
+	})
+}
+
 func TestParseAsPredicate(t *testing.T) {
 	tests := []struct {
 		input  string
@@ -316,6 +316,17 @@ func TestEllipsesForHoles(t *testing.T) {
 	})
 }
 
+func TestLabelFuzzy(t *testing.T) {
+	query, _ := Run(InitFuzzy("hello wrold"))
+	got := toString(query)
+	if diff := cmp.Diff(`"hello wrold"`, got); diff != "" {
+		t.Fatal(diff)
+	}
+	if !(Basic{Pattern: query[0]}).IsFuzzy() {
+		t.Fatalf("expected pattern to be labeled fuzzy")
+	}
+}
+
 func TestConvertEmptyGroupsToLiteral(t *testing.T) {
 	cases := []struct {
 		input      string
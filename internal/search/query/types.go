@@ -29,6 +29,7 @@ const (
 	SearchTypeRegex SearchType = iota
 	SearchTypeLiteral
 	SearchTypeStructural
+	SearchTypeFuzzy
 )
 
 func (s SearchType) String() string {
@@ -39,6 +40,8 @@ func (s SearchType) String() string {
 		return "literal"
 	case SearchTypeStructural:
 		return "structural"
+	case SearchTypeFuzzy:
+		return "fuzzy"
 	default:
 		return fmt.Sprintf("unknown{%d}", s)
 	}
@@ -177,6 +180,10 @@ func (b Basic) IsStructural() bool {
 	return b.HasPatternLabel(Structural)
 }
 
+func (b Basic) IsFuzzy() bool {
+	return b.HasPatternLabel(Fuzzy)
+}
+
 // FindParameter calls f on parameters matching field in b.
 func (b Basic) FindParameter(field string, f func(value string, negated bool, annotation Annotation)) {
 	for _, p := range b.Parameters {
@@ -330,6 +337,13 @@ func (q Q) IsCaseSensitive() bool {
 	return q.BoolValue("case")
 }
 
+// FollowsRenames returns whether diff searches should follow file renames
+// when matching file: filters. It is opt-in because finding renames walks the
+// whole history of a repository.
+func (q Q) FollowsRenames() bool {
+	return q.BoolValue(FieldFollow)
+}
+
 func (q Q) Repositories() (repos []string, negatedRepos []string) {
 	VisitField(q, FieldRepo, func(value string, negated bool, _ Annotation) {
 		if negated {
@@ -371,7 +385,8 @@ func (q Q) valueToTypedValue(field, value string, label labels) []*Value {
 		return []*Value{{String: &value}}
 
 	case
-		FieldCase:
+		FieldCase,
+		FieldFollow:
 		b, _ := parseBool(value)
 		return []*Value{{Bool: &b}}
 
@@ -396,11 +411,14 @@ func (q Q) valueToTypedValue(field, value string, label labels) []*Value {
 		FieldContent:
 		return []*Value{{String: &value}}
 
-	case FieldRepoHasFile:
+	case
+		FieldRepoHasFile,
+		FieldRepoHasDescription:
 		return []*Value{{Regexp: parseRegexpOrPanic(field, value)}}
 
 	case
 		FieldRepoHasCommitAfter,
+		FieldRepoHasTopic,
 		FieldBefore, "until",
 		FieldAfter, "since":
 		return []*Value{{String: &value}}
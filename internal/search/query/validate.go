@@ -294,7 +294,8 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldDefault:
 		// Search patterns are not validated here, as it depends on the search type.
 	case
-		FieldCase:
+		FieldCase,
+		FieldFollow:
 		return satisfies(isSingular, isBoolean, isNotNegated)
 	case
 		FieldRepo:
@@ -322,6 +323,12 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldRepoHasCommitAfter:
 		return satisfies(isSingular, isNotNegated)
+	case
+		FieldRepoHasTopic:
+		return satisfies(isNotNegated)
+	case
+		FieldRepoHasDescription:
+		return satisfies(isValidRegexp, isNotNegated)
 	case
 		FieldBefore,
 		FieldAfter:
@@ -396,18 +403,28 @@ func validateRepoRevPair(nodes []Node) error {
 // valid. cf. https://docs.sourcegraph.com/code_search/reference/language#commit-parameter
 func validateCommitParameters(nodes []Node) error {
 	var seenCommitParam string
-	var typeCommitExists bool
+	var seenFollow bool
+	var typeCommitExists, typeDiffExists bool
 	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
 		if field == FieldAuthor || field == FieldBefore || field == FieldAfter || field == FieldMessage {
 			seenCommitParam = field
 		}
+		if field == FieldFollow {
+			seenFollow = true
+		}
 		if field == FieldType && (value == "commit" || value == "diff") {
 			typeCommitExists = true
 		}
+		if field == FieldType && value == "diff" {
+			typeDiffExists = true
+		}
 	})
 	if seenCommitParam != "" && !typeCommitExists {
 		return errors.Errorf(`your query contains the field '%s', which requires type:commit or type:diff in the query`, seenCommitParam)
 	}
+	if seenFollow && !typeDiffExists {
+		return errors.Errorf(`your query contains the field '%s', which requires type:diff in the query`, FieldFollow)
+	}
 	return nil
 }
 
@@ -435,6 +452,96 @@ func validateTypeStructural(nodes []Node) error {
 	return nil
 }
 
+func validateTypeFuzzy(nodes []Node) error {
+	seenFuzzy := false
+	seenType := false
+	invalid := Exists(nodes, func(node Node) bool {
+		if p, ok := node.(Pattern); ok && p.Annotation.Labels.IsSet(Fuzzy) {
+			seenFuzzy = true
+		}
+		if p, ok := node.(Parameter); ok && p.Field == FieldType {
+			seenType = true
+		}
+		return seenFuzzy && seenType
+	})
+	if invalid {
+		return errors.New("this fuzzy search query specifies `type:` and is not supported. Fuzzy search only applies to searching file contents")
+	}
+	return nil
+}
+
+// A type:matchdiff query compares the content matches of two revisions, which
+// are specified as a revision range like rev:v1.2..v1.3. It can't be combined
+// with other result types.
+func validateTypeMatchDiff(nodes []Node) error {
+	seenMatchDiff := false
+	seenOtherType := false
+	VisitField(nodes, FieldType, func(value string, _ bool, _ Annotation) {
+		if value == "matchdiff" {
+			seenMatchDiff = true
+		} else {
+			seenOtherType = true
+		}
+	})
+	if !seenMatchDiff {
+		return nil
+	}
+	if seenOtherType {
+		return errors.New("type:matchdiff cannot be combined with other result types")
+	}
+
+	seenPattern := Exists(nodes, func(node Node) bool {
+		p, ok := node.(Pattern)
+		return ok && p.Value != ""
+	})
+	if !seenPattern {
+		return errors.New("type:matchdiff requires a search pattern")
+	}
+
+	seenRange := false
+	invalidRange := ""
+	visitRevision := func(value string) {
+		if _, _, ok := ParseRevRange(value); ok {
+			seenRange = true
+		} else if invalidRange == "" {
+			invalidRange = value
+		}
+	}
+	VisitField(nodes, FieldRepo, func(value string, negated bool, _ Annotation) {
+		if i := strings.Index(value, "@"); i >= 0 && !negated {
+			visitRevision(value[i+1:])
+		}
+	})
+	VisitField(nodes, FieldRev, func(value string, _ bool, _ Annotation) {
+		visitRevision(value)
+	})
+	if invalidRange != "" {
+		return errors.Errorf("type:matchdiff requires a revision range like `rev:v1.2..v1.3`, but got %q", invalidRange)
+	}
+	if !seenRange {
+		return errors.New("type:matchdiff requires a revision range like `rev:v1.2..v1.3`")
+	}
+	return nil
+}
+
+// ParseRevRange splits a revision range like v1.2..v1.3 into its base and
+// head revisions. Symmetric differences (v1.2...v1.3), multiple revisions and
+// ref globs are not revision ranges.
+func ParseRevRange(spec string) (base, head string, ok bool) {
+	if strings.Contains(spec, "...") || strings.ContainsAny(spec, ":*") {
+		return "", "", false
+	}
+	i := strings.Index(spec, "..")
+	if i < 0 {
+		return "", "", false
+	}
+	base, head = spec[:i], spec[i+2:]
+	if base == "" || head == "" || strings.Contains(head, "..") {
+		return "", "", false
+	}
+	return base, head, true
+}
+
 func validateRefGlobs(nodes []Node) error {
 	if !ContainsRefGlobs(nodes) {
 		return nil
@@ -534,6 +641,9 @@ func validatePattern(nodes []Node) error {
 		if annotation.Labels.IsSet(Structural) && negated {
 			err = errors.New("the query contains a negated search pattern. Structural search does not support negated search patterns at the moment")
 		}
+		if annotation.Labels.IsSet(Fuzzy) && negated {
+			err = errors.New("the query contains a negated search pattern. Fuzzy search does not support negated search patterns")
+		}
 	})
 	return err
 }
@@ -555,6 +665,8 @@ func validate(nodes []Node) error {
 		validateRepoHasFile,
 		validateCommitParameters,
 		validateTypeStructural,
+		validateTypeFuzzy,
+		validateTypeMatchDiff,
 		validateRefGlobs,
 	)
 }
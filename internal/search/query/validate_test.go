@@ -83,6 +83,10 @@ func TestValidation(t *testing.T) {
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
 		},
+		{
+			input: "type:commit file:foo follow:yes",
+			want:  `your query contains the field 'follow', which requires type:diff in the query`,
+		},
 		{
 			input: "repohasfile:README type:symbol yolo",
 			want:  "repohasfile is not compatible for type:symbol. Subscribe to https://github.com/sourcegraph/sourcegraph/issues/4610 for updates",
@@ -109,6 +113,32 @@ func TestValidation(t *testing.T) {
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents and is not currently supported for diff searches",
 			searchType: SearchTypeStructural,
 		},
+		{
+			input:      "-content:foo bar",
+			want:       "the query contains a negated search pattern. Fuzzy search does not support negated search patterns",
+			searchType: SearchTypeFuzzy,
+		},
+		{
+			input:      "type:symbol nice try",
+			want:       "this fuzzy search query specifies `type:` and is not supported. Fuzzy search only applies to searching file contents",
+			searchType: SearchTypeFuzzy,
+		},
+		{
+			input: "repo:foo type:matchdiff bar",
+			want:  "type:matchdiff requires a revision range like `rev:v1.2..v1.3`",
+		},
+		{
+			input: "repo:foo rev:v1.2 type:matchdiff bar",
+			want:  "type:matchdiff requires a revision range like `rev:v1.2..v1.3`, but got \"v1.2\"",
+		},
+		{
+			input: "repo:foo rev:v1.2..v1.3 type:matchdiff",
+			want:  "type:matchdiff requires a search pattern",
+		},
+		{
+			input: "repo:foo rev:v1.2..v1.3 type:matchdiff type:file bar",
+			want:  "type:matchdiff cannot be combined with other result types",
+		},
 	}
 	for _, c := range cases {
 		t.Run("validate and/or query", func(t *testing.T) {
@@ -125,6 +155,30 @@ func TestValidation(t *testing.T) {
 	}
 }
 
+func TestParseRevRange(t *testing.T) {
+	cases := []struct {
+		spec       string
+		base, head string
+		ok         bool
+	}{
+		{spec: "v1.2..v1.3", base: "v1.2", head: "v1.3", ok: true},
+		{spec: "main..feature/foo", base: "main", head: "feature/foo", ok: true},
+		{spec: "v1.2"},
+		{spec: "v1.2..."},
+		{spec: "..v1.3"},
+		{spec: "v1.2...v1.3"},
+		{spec: "v1.2..v1.3..v1.4"},
+		{spec: "v1.2..v1.3:v1.4"},
+		{spec: "*refs/tags/*"},
+	}
+	for _, c := range cases {
+		base, head, ok := ParseRevRange(c.spec)
+		if base != c.base || head != c.head || ok != c.ok {
+			t.Errorf("ParseRevRange(%q) got (%q, %q, %v), want (%q, %q, %v)", c.spec, base, head, ok, c.base, c.head, c.ok)
+		}
+	}
+}
+
 func TestIsCaseSensitive(t *testing.T) {
 	cases := []struct {
 		name  string
@@ -119,16 +119,16 @@ func ToTextPatternInfo(q query.Basic, p Protocol, transform query.BasicPass) *Te
 	// Handle file: and -file: filters.
 	filesInclude, filesExclude := IncludeExcludeValues(q, query.FieldFile)
 	// Handle lang: and -lang: filters.
+	// Languages are detected by each backend, see Languages.
 	langInclude, langExclude := IncludeExcludeValues(q, query.FieldLang)
-	filesInclude = append(filesInclude, mapSlice(langInclude, LangToFileRegexp)...)
-	filesExclude = append(filesExclude, mapSlice(langExclude, LangToFileRegexp)...)
 	filesReposMustInclude, filesReposMustExclude := IncludeExcludeValues(q, query.FieldRepoHasFile)
 	selector, _ := filter.SelectPathFromString(q.FindValue(query.FieldSelect)) // Invariant: select is validated
 	count := count(q, p)
 
 	// Ugly assumption: for a literal search, the IsRegexp member of
 	// TextPatternInfo must be set true. The logic assumes that a literal
-	// pattern is an escaped regular expression.
+	// pattern is an escaped regular expression. Fuzzy patterns are matched
+	// as-is by searcher.
 	isRegexp := q.IsLiteral() || q.IsRegexp()
 
 	var pattern string
@@ -156,6 +156,7 @@ func ToTextPatternInfo(q query.Basic, p Protocol, transform query.BasicPass) *Te
 		// Values dependent on pattern atom.
 		IsRegExp:        isRegexp,
 		IsStructuralPat: q.IsStructural(),
+		IsFuzzy:         q.IsFuzzy(),
 		IsCaseSensitive: q.IsCaseSensitive(),
 		FileMatchLimit:  int32(count),
 		Pattern:         pattern,
@@ -167,6 +168,7 @@ func ToTextPatternInfo(q query.Basic, p Protocol, transform query.BasicPass) *Te
 		FilePatternsReposMustInclude: filesReposMustInclude,
 		FilePatternsReposMustExclude: filesReposMustExclude,
 		Languages:                    langInclude,
+		ExcludeLanguages:             langExclude,
 		PathPatternsAreCaseSensitive: q.IsCaseSensitive(),
 		CombyRule:                    q.FindValue(query.FieldCombyRule),
 		Index:                        q.Index(),
@@ -263,18 +265,21 @@ func QueryToZoektQuery(p *TextPatternInfo, typ IndexedRequestType) (zoekt.Q, err
 
 	and = append(and, q)
 
-	// zoekt also uses regular expressions for file paths
+	// zoekt also uses regular expressions for file paths. Zoekt only
+	// classifies files by their name, so lang: filters are applied as file
+	// patterns.
 	// TODO PathPatternsAreCaseSensitive
 	// TODO whitespace in file path patterns?
-	for _, i := range p.IncludePatterns {
+	includePatterns, excludePattern := p.FilePatterns()
+	for _, i := range includePatterns {
 		q, err := fileRe(i, p.IsCaseSensitive)
 		if err != nil {
 			return nil, err
 		}
 		and = append(and, q)
 	}
-	if p.ExcludePattern != "" {
-		q, err := fileRe(p.ExcludePattern, p.IsCaseSensitive)
+	if excludePattern != "" {
+		q, err := fileRe(excludePattern, p.IsCaseSensitive)
 		if err != nil {
 			return nil, err
 		}
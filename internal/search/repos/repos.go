@@ -151,6 +151,8 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved
 		OnlyForks:              op.OnlyForks,
 		NoArchived:             op.NoArchived,
 		OnlyArchived:           op.OnlyArchived,
+		Topics:                 op.HasTopics,
+		DescriptionPatterns:    op.HasDescriptionPatterns,
 		NoPrivate:              op.Visibility == query.Public,
 		OnlyPrivate:            op.Visibility == query.Private,
 		SearchContextID:        searchContext.ID,
@@ -373,6 +375,8 @@ func (r *Resolver) Excluded(ctx context.Context, op search.RepoOptions) (ex Excl
 		OnlyForks:              op.OnlyForks,
 		NoArchived:             op.NoArchived,
 		OnlyArchived:           op.OnlyArchived,
+		Topics:                 op.HasTopics,
+		DescriptionPatterns:    op.HasDescriptionPatterns,
 		NoPrivate:              op.Visibility == query.Public,
 		OnlyPrivate:            op.Visibility == query.Private,
 		SearchContextID:        searchContext.ID,
@@ -431,6 +435,54 @@ func (r *Resolver) Excluded(ctx context.Context, op search.RepoOptions) (ex Excl
 	return excluded.ExcludedRepos, g.Wait()
 }
 
+// Count returns the number of repositories the given RepoOptions match. It
+// does not validate revisions or apply repohascommitafter:, so it is an upper
+// bound of the number of repositories Resolve returns.
+func (r *Resolver) Count(ctx context.Context, op search.RepoOptions) (count int, err error) {
+	tr, ctx := trace.New(ctx, "searchrepos.Resolver.Count", op.String())
+	defer func() {
+		tr.LazyPrintf("count: %d", count)
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	includePatterns := op.RepoFilters
+	if includePatterns != nil {
+		// Copy to avoid race condition.
+		includePatterns = append([]string{}, includePatterns...)
+	}
+
+	// note that this mutates the strings in includePatterns, stripping their
+	// revision specs, if they had any.
+	_, err = findPatternRevs(includePatterns)
+	if err != nil {
+		return 0, err
+	}
+
+	searchContext, err := searchcontexts.ResolveSearchContextSpec(ctx, r.DB, op.SearchContextSpec)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.DB.Repos().Count(ctx, database.ReposListOptions{
+		IncludePatterns:        includePatterns,
+		ExcludePattern:         UnionRegExps(op.MinusRepoFilters),
+		CaseSensitivePatterns:  op.CaseSensitiveRepoFilters,
+		NoForks:                op.NoForks,
+		OnlyForks:              op.OnlyForks,
+		NoArchived:             op.NoArchived,
+		OnlyArchived:           op.OnlyArchived,
+		Topics:                 op.HasTopics,
+		DescriptionPatterns:    op.HasDescriptionPatterns,
+		NoPrivate:              op.Visibility == query.Public,
+		OnlyPrivate:            op.Visibility == query.Private,
+		SearchContextID:        searchContext.ID,
+		UserID:                 searchContext.NamespaceUserID,
+		OrgID:                  searchContext.NamespaceOrgID,
+		IncludeUserPublicRepos: searchContext.ID == 0 && searchContext.NamespaceUserID != 0,
+	})
+}
+
 // ExactlyOneRepo returns whether exactly one repo: literal field is specified and
 // delineated by regex anchors ^ and $. This function helps determine whether we
 // should return results for a single repo regardless of whether it is a fork or
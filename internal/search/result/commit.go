@@ -21,6 +21,17 @@ type CommitMatch struct {
 	MessagePreview *HighlightedString
 	DiffPreview    *HighlightedString
 	Body           HighlightedString
+	// Renames are the matched files which the commit modifies under another
+	// name than the one they matched by. They are only set for diff searches
+	// which follow renames.
+	Renames []FileRename
+}
+
+// FileRename is a file which had the name OldPath before it was renamed to
+// NewPath.
+type FileRename struct {
+	OldPath string
+	NewPath string
 }
 
 // ResultCount for CommitSearchResult returns the number of highlights if there
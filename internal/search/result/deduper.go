@@ -23,6 +23,8 @@ func (d *deduper) Add(m Match) {
 			prevMatch.AppendMatches(m.(*FileMatch))
 		case *CommitMatch:
 			prevMatch.AppendMatches(m.(*CommitMatch))
+		case *MatchDiffMatch:
+			prevMatch.AppendMatches(m.(*MatchDiffMatch))
 		}
 		return
 	}
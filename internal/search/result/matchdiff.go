@@ -0,0 +1,97 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// MatchDiffMatch is the difference between the content matches of a file in
+// two revisions of a repository. It is produced by type:matchdiff.
+type MatchDiffMatch struct {
+	Repo types.MinimalRepo
+
+	// Base and Head are the compared revisions as specified in the query,
+	// e.g. "v1.2" and "v1.3" for rev:v1.2..v1.3.
+	Base, Head             string
+	BaseCommit, HeadCommit api.CommitID
+
+	// Path is the path of the file in Head, or in Base if all lines were
+	// removed.
+	Path string
+
+	Lines []*MatchDiffLine
+}
+
+// MatchDiffStatus is how a line match changed between two revisions.
+type MatchDiffStatus string
+
+const (
+	// MatchAdded is a line match which only appears in Head.
+	MatchAdded MatchDiffStatus = "added"
+	// MatchRemoved is a line match which only appears in Base.
+	MatchRemoved MatchDiffStatus = "removed"
+	// MatchMoved is a line match which appears in different files in Base
+	// and Head.
+	MatchMoved MatchDiffStatus = "moved"
+)
+
+// MatchDiffLine is a line match which was added, removed or moved between two
+// revisions. LineNumber refers to Head, except for removed matches where it
+// refers to Base.
+type MatchDiffLine struct {
+	LineMatch
+
+	Status MatchDiffStatus
+
+	// OldPath and OldLineNumber locate a moved match in Base.
+	OldPath       string
+	OldLineNumber int32
+}
+
+func (m *MatchDiffMatch) RepoName() types.MinimalRepo {
+	return m.Repo
+}
+
+func (m *MatchDiffMatch) ResultCount() int {
+	return len(m.Lines)
+}
+
+// Limit will mutate m such that it only has limit results. limit is a number
+// greater than 0.
+func (m *MatchDiffMatch) Limit(limit int) int {
+	if after := limit - len(m.Lines); after >= 0 {
+		return after
+	}
+	m.Lines = m.Lines[:limit]
+	return 0
+}
+
+func (m *MatchDiffMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: m.Repo.Name,
+			ID:   m.Repo.ID,
+		}
+	case filter.Content:
+		return m
+	}
+	return nil
+}
+
+func (m *MatchDiffMatch) AppendMatches(src *MatchDiffMatch) {
+	m.Lines = append(m.Lines, src.Lines...)
+}
+
+func (m *MatchDiffMatch) Key() Key {
+	return Key{
+		TypeRank: rankMatchDiffMatch,
+		Repo:     m.Repo.Name,
+		Rev:      m.Base + ".." + m.Head,
+		Commit:   m.HeadCommit,
+		Path:     m.Path,
+	}
+}
+
+func (m *MatchDiffMatch) searchResultMarker() {}
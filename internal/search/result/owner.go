@@ -0,0 +1,52 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is an owner of one or more files matched by a search, as
+// declared in the CODEOWNERS file of the repository. It is produced by
+// select:file.owners.
+type OwnerMatch struct {
+	Repo     types.MinimalRepo
+	CommitID api.CommitID
+
+	// Owner is the owner as written in CODEOWNERS, e.g. "@alice",
+	// "@org/team" or "alice@example.com".
+	Owner string
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	return o.Repo
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	if len(path) > 1 && path.Root() == filter.File && path[1] == "owners" {
+		return o
+	}
+	return nil
+}
+
+func (o *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Repo:     o.Repo.Name,
+		Commit:   o.CommitID,
+		// An owner is not associated with a path, but we store it in Path
+		// so that each owner is deduplicated per repository.
+		Path: o.Owner,
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
@@ -7,7 +7,7 @@ import "strings"
 //
 // For example, the set of file and repo results
 // is represented as Types(TypeFile|TypeRepo)
-type Types uint8
+type Types uint16
 
 const (
 	TypeEmpty Types = 0
@@ -18,6 +18,8 @@ const (
 	TypeDiff
 	TypeCommit
 	TypeStructural
+	TypeFuzzy
+	TypeMatchDiff
 )
 
 var TypeFromString = map[string]Types{
@@ -28,6 +30,8 @@ var TypeFromString = map[string]Types{
 	"diff":       TypeDiff,
 	"commit":     TypeCommit,
 	"structural": TypeStructural,
+	"fuzzy":      TypeFuzzy,
+	"matchdiff":  TypeMatchDiff,
 }
 
 func (r Types) Has(t Types) bool {
@@ -45,6 +45,8 @@ func (s *RepoSearch) Run(ctx context.Context, stream streaming.Sender, repos sea
 		query.FieldCase:               {},
 		query.FieldRepoHasFile:        {},
 		query.FieldRepoHasCommitAfter: {},
+		query.FieldRepoHasTopic:       {},
+		query.FieldRepoHasDescription: {},
 		query.FieldPatternType:        {},
 		query.FieldSelect:             {},
 	}
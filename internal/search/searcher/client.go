@@ -63,12 +63,14 @@ func Search(
 			ExcludePattern:               p.ExcludePattern,
 			IncludePatterns:              p.IncludePatterns,
 			Languages:                    p.Languages,
+			ExcludeLanguages:             p.ExcludeLanguages,
 			CombyRule:                    p.CombyRule,
 			PathPatternsAreRegExps:       true,
 			Select:                       p.Select.Root(),
 			Limit:                        int(p.FileMatchLimit),
 			IsRegExp:                     p.IsRegExp,
 			IsStructuralPat:              p.IsStructuralPat,
+			IsFuzzy:                      p.IsFuzzy,
 			IsWordMatch:                  p.IsWordMatch,
 			IsCaseSensitive:              p.IsCaseSensitive,
 			PathPatternsAreCaseSensitive: p.PathPatternsAreCaseSensitive,
@@ -54,12 +54,31 @@ type ProgressStats struct {
 
 	DisplayLimit int
 
+	// CostBudget is set if the query was not admitted as is by the search
+	// cost budget of the user.
+	CostBudget *CostBudget
+
 	// we smuggle in the namer via this field. Note: we don't calculate the
 	// name of every repository in Timedout, Missing, etc since we only need a
 	// subset of the names. As such we lazily calculate the names via namer.
 	namer RepoNamer
 }
 
+// CostBudget describes how the search cost budget admitted a query.
+type CostBudget struct {
+	// Action is one of "queue", "downgrade" or "reject".
+	Action string
+
+	// Cost is the estimated cost of the query.
+	Cost int
+
+	// Remaining is the budget the user had left before the query.
+	Remaining int
+
+	// DelayMilliseconds is how long the query was queued for.
+	DelayMilliseconds int
+}
+
 func skippedReposHandler(repos []api.RepoID, namer RepoNamer, titleVerb, messageReason string, base Skipped) (Skipped, bool) {
 	if len(repos) == 0 {
 		return Skipped{}, false
@@ -196,6 +215,39 @@ func excludedArchiveHandler(resultsResolver ProgressStats) (Skipped, bool) {
 	}, true
 }
 
+func costBudgetHandler(resultsResolver ProgressStats) (Skipped, bool) {
+	budget := resultsResolver.CostBudget
+	if budget == nil {
+		return Skipped{}, false
+	}
+
+	var title, message string
+	switch budget.Action {
+	case "queue":
+		title = "query queued"
+		message = fmt.Sprintf("This query has an estimated cost of %s, but you only had %s left of your search budget. It was delayed by %dms until enough budget was available.", number(budget.Cost), number(budget.Remaining), budget.DelayMilliseconds)
+	case "downgrade":
+		title = "query downgraded"
+		message = fmt.Sprintf("This query has an estimated cost of %s, but you only had %s left of your search budget. Only indexed repositories were searched, with the default result limit.", number(budget.Cost), number(budget.Remaining))
+	case "reject":
+		title = "query rejected"
+		message = fmt.Sprintf("This query has an estimated cost of %s, but you only had %s left of your search budget. Try again in a minute or reduce the scope of your query.", number(budget.Cost), number(budget.Remaining))
+	default:
+		return Skipped{}, false
+	}
+
+	return Skipped{
+		Reason:   QueryCostBudget,
+		Title:    title,
+		Message:  message,
+		Severity: SeverityWarn,
+		Suggested: &SkippedSuggested{
+			Title:           "reduce scope",
+			QueryExpression: "repo:",
+		},
+	}, true
+}
+
 // TODO implement all skipped reasons
 var skippedHandlers = []func(stats ProgressStats) (Skipped, bool){
 	repositoryMissingHandler,
@@ -207,6 +259,7 @@ var skippedHandlers = []func(stats ProgressStats) (Skipped, bool){
 	excludedForkHandler,
 	excludedArchiveHandler,
 	displayLimitHandler,
+	costBudgetHandler,
 }
 
 func number(i int) string {
@@ -54,6 +54,14 @@ func TestSearchProgress(t *testing.T) {
 			SuggestedLimit:      1000,
 			DisplayLimit:        math.MaxInt32,
 		},
+		"costbudget": {
+			RepositoriesCount: intPtr(10),
+			CostBudget: &CostBudget{
+				Action:    "downgrade",
+				Cost:      2500,
+				Remaining: 400,
+			},
+		},
 		"traced": {
 			Trace: "abcd",
 		},
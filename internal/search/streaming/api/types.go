@@ -80,6 +80,9 @@ const (
 	// ExcludedArchive is when we did not search a repository because it is
 	// archived.
 	ExcludedArchive SkippedReason = "excluded-archive"
+	// QueryCostBudget is when the query was queued, downgraded or not run at
+	// all because its estimated cost exceeded the search budget of the user.
+	QueryCostBudget SkippedReason = "query-cost-budget"
 )
 
 // SkippedSeverity is an enum for Skipped.Severity.
@@ -128,6 +128,10 @@ func (r *eventMatchUnmarshaller) UnmarshalJSON(b []byte) error {
 		r.EventMatch = &EventSymbolMatch{}
 	case CommitMatchType:
 		r.EventMatch = &EventCommitMatch{}
+	case OwnerMatchType:
+		r.EventMatch = &EventOwnerMatch{}
+	case MatchDiffMatchType:
+		r.EventMatch = &EventMatchDiffMatch{}
 	default:
 		return errors.Errorf("unknown MatchType %v", typeU.Type)
 	}
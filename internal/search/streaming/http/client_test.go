@@ -48,6 +48,18 @@ func TestFrontendClient(t *testing.T) {
 				Type:   CommitMatchType,
 				Detail: "test",
 			},
+			&EventOwnerMatch{
+				Type:  OwnerMatchType,
+				Owner: "@test",
+			},
+			&EventMatchDiffMatch{
+				Type: MatchDiffMatchType,
+				Path: "test",
+				LineMatches: []EventMatchDiffLine{{
+					Status: "added",
+					Line:   "test",
+				}},
+			},
 		},
 	}, {
 		Name: "filters",
@@ -142,10 +142,68 @@ type EventCommitMatch struct {
 	Content         string     `json:"content"`
 	// [line, character, length]
 	Ranges [][3]int32 `json:"ranges"`
+	// Renames are the matched files which the commit modifies under another
+	// name than the one they matched by.
+	Renames []EventFileRename `json:"renames,omitempty"`
 }
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventFileRename is a file which had the name OldPath before it was renamed
+// to NewPath.
+type EventFileRename struct {
+	OldPath string `json:"oldPath"`
+	NewPath string `json:"newPath"`
+}
+
+// EventOwnerMatch is an owner of files matched by a search, as declared in
+// the CODEOWNERS file of the repository.
+type EventOwnerMatch struct {
+	// Type is always OwnerMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	Owner           string     `json:"owner"`
+	RepositoryID    int32      `json:"repositoryID"`
+	Repository      string     `json:"repository"`
+	RepoStars       int        `json:"repoStars,omitempty"`
+	RepoLastFetched *time.Time `json:"repoLastFetched,omitempty"`
+	Commit          string     `json:"commit,omitempty"`
+}
+
+func (e *EventOwnerMatch) eventMatch() {}
+
+// EventMatchDiffMatch is the difference between the content matches of a file
+// in two revisions, as returned by type:matchdiff.
+type EventMatchDiffMatch struct {
+	// Type is always MatchDiffMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	Path            string               `json:"path"`
+	RepositoryID    int32                `json:"repositoryID"`
+	Repository      string               `json:"repository"`
+	RepoStars       int                  `json:"repoStars,omitempty"`
+	RepoLastFetched *time.Time           `json:"repoLastFetched,omitempty"`
+	Base            string               `json:"base"`
+	Head            string               `json:"head"`
+	BaseCommit      string               `json:"baseCommit"`
+	HeadCommit      string               `json:"headCommit"`
+	LineMatches     []EventMatchDiffLine `json:"lineMatches"`
+}
+
+func (e *EventMatchDiffMatch) eventMatch() {}
+
+// EventMatchDiffLine is a line match which was added, removed or moved.
+// LineNumber refers to the head revision, except for removed lines where it
+// refers to the base revision.
+type EventMatchDiffLine struct {
+	Status           string     `json:"status"`
+	Line             string     `json:"line"`
+	LineNumber       int32      `json:"lineNumber"`
+	OffsetAndLengths [][2]int32 `json:"offsetAndLengths"`
+	OldPath          string     `json:"oldPath,omitempty"`
+	OldLineNumber    int32      `json:"oldLineNumber,omitempty"`
+}
+
 // EventFilter is a suggestion for a search filter. Currently has a 1-1
 // correspondance with the SearchFilter graphql type.
 type EventFilter struct {
@@ -184,6 +242,8 @@ const (
 	SymbolMatchType
 	CommitMatchType
 	PathMatchType
+	OwnerMatchType
+	MatchDiffMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -198,6 +258,10 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"commit"`), nil
 	case PathMatchType:
 		return []byte(`"path"`), nil
+	case OwnerMatchType:
+		return []byte(`"owner"`), nil
+	case MatchDiffMatchType:
+		return []byte(`"matchdiff"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -214,6 +278,10 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = CommitMatchType
 	} else if bytes.Equal(b, []byte(`"path"`)) {
 		*t = PathMatchType
+	} else if bytes.Equal(b, []byte(`"owner"`)) {
+		*t = OwnerMatchType
+	} else if bytes.Equal(b, []byte(`"matchdiff"`)) {
+		*t = MatchDiffMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
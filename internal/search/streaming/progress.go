@@ -7,6 +7,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/cost"
 )
 
 // Stats contains fields that should be returned by all funcs
@@ -31,6 +32,10 @@ type Stats struct {
 
 	// IsIndexUnavailable is true if indexed search was unavailable.
 	IsIndexUnavailable bool
+
+	// CostBudget is set if the query was not admitted as is by the search
+	// cost budget of the user.
+	CostBudget *cost.Decision
 }
 
 // update updates c with the other data, deduping as necessary. It modifies c but
@@ -56,6 +61,10 @@ func (c *Stats) Update(other *Stats) {
 
 	c.ExcludedForks = c.ExcludedForks + other.ExcludedForks
 	c.ExcludedArchived = c.ExcludedArchived + other.ExcludedArchived
+
+	if other.CostBudget != nil {
+		c.CostBudget = other.CostBudget
+	}
 }
 
 // Zero returns true if stats is empty. IE calling Update will result in no
@@ -70,7 +79,8 @@ func (c *Stats) Zero() bool {
 		c.Status.Len() > 0 ||
 		c.ExcludedForks > 0 ||
 		c.ExcludedArchived > 0 ||
-		c.IsIndexUnavailable)
+		c.IsIndexUnavailable ||
+		c.CostBudget != nil)
 }
 
 func (c *Stats) String() string {
@@ -100,6 +110,9 @@ func (c *Stats) String() string {
 	if c.IsIndexUnavailable {
 		parts = append(parts, "indexUnavailable")
 	}
+	if c.CostBudget != nil {
+		parts = append(parts, fmt.Sprintf("costBudget=%s", c.CostBudget.Action))
+	}
 
 	return "Stats{" + strings.Join(parts, " ") + "}"
 }
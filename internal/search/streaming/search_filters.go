@@ -141,6 +141,13 @@ func (s *SearchFilters) Update(event SearchEvent) {
 			// We leave "rev" empty, instead of using "CommitMatch.Commit.ID". This way we
 			// get 1 filter per repo instead of 1 filter per sha in the side-bar.
 			addRepoFilter(v.Repo.Name, v.Repo.ID, "", int32(v.ResultCount()))
+		case *result.OwnerMatch:
+			addRepoFilter(v.Repo.Name, v.Repo.ID, "", 1)
+		case *result.MatchDiffMatch:
+			lines := int32(v.ResultCount())
+			addRepoFilter(v.Repo.Name, v.Repo.ID, "", lines)
+			addLangFilter(v.Path, lines, false)
+			addFileFilter(v.Path, lines, false)
 		}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/inconshreveable/log15"
 	"go.uber.org/atomic"
 
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
@@ -107,6 +108,91 @@ func WithSelect(parent Sender, s filter.SelectPath) Sender {
 	})
 }
 
+// ownerWorkers is the number of file matches whose owners WithOwners
+// resolves concurrently.
+const ownerWorkers = 8
+
+// WithOwners returns a child Stream of parent that replaces each file match
+// with a match for each of its owners, as returned by owners. Owners are only
+// sent once per repository commit. Other match types are dropped.
+//
+// Owners are resolved by a pool of workers so that Send does not wait on
+// reading CODEOWNERS files. The returned done function must be called once
+// no more events are sent on the child stream. It waits until the owners of
+// every file match sent have been passed on to parent. File matches sent
+// after done is called are dropped.
+func WithOwners(ctx context.Context, parent Sender, owners func(context.Context, *result.FileMatch) ([]string, error)) (Sender, func()) {
+	var (
+		mux   sync.Mutex
+		dedup = result.NewDeduper()
+		wg    sync.WaitGroup
+		queue = make(chan *result.FileMatch, 256)
+
+		// closedMu guards sending on queue against closing it.
+		closedMu sync.RWMutex
+		closed   bool
+	)
+
+	wg.Add(ownerWorkers)
+	for i := 0; i < ownerWorkers; i++ {
+		go func() {
+			defer wg.Done()
+			for fm := range queue {
+				names, err := owners(ctx, fm)
+				if err != nil {
+					log15.Warn("failed to resolve file owners", "repo", fm.Repo.Name, "commit", fm.CommitID, "path", fm.Path, "error", err)
+					continue
+				}
+
+				var matches []result.Match
+				mux.Lock()
+				for _, name := range names {
+					om := &result.OwnerMatch{Repo: fm.Repo, CommitID: fm.CommitID, Owner: name}
+					if dedup.Seen(om) {
+						continue
+					}
+					dedup.Add(om)
+					matches = append(matches, om)
+				}
+				mux.Unlock()
+
+				if len(matches) > 0 {
+					parent.Send(SearchEvent{Results: matches})
+				}
+			}
+		}()
+	}
+
+	done := func() {
+		closedMu.Lock()
+		if !closed {
+			closed = true
+			close(queue)
+		}
+		closedMu.Unlock()
+		wg.Wait()
+	}
+
+	return StreamFunc(func(e SearchEvent) {
+		if parent == nil {
+			return
+		}
+
+		closedMu.RLock()
+		if !closed {
+			for _, match := range e.Results {
+				if fm, ok := match.(*result.FileMatch); ok {
+					queue <- fm
+				}
+			}
+		}
+		closedMu.RUnlock()
+		e.Results = nil
+
+		parent.Send(e)
+	}), done
+}
+
 type StreamFunc func(SearchEvent)
 
 func (f StreamFunc) Send(se SearchEvent) {
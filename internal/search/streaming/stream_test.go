@@ -0,0 +1,64 @@
+package streaming
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestWithOwners(t *testing.T) {
+	repo := types.MinimalRepo{Name: "foo"}
+	owners := func(_ context.Context, fm *result.FileMatch) ([]string, error) {
+		if fm.Path == "a.go" {
+			return []string{"@alice", "@bob"}, nil
+		}
+		return []string{"@alice"}, nil
+	}
+
+	var (
+		mu       sync.Mutex
+		got      []string
+		limitHit bool
+	)
+	parent := StreamFunc(func(e SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, m := range e.Results {
+			got = append(got, m.(*result.OwnerMatch).Owner)
+		}
+		limitHit = limitHit || e.Stats.IsLimitHit
+	})
+
+	stream, done := WithOwners(context.Background(), parent, owners)
+	stream.Send(SearchEvent{
+		Results: []result.Match{
+			&result.FileMatch{File: result.File{Repo: repo, Path: "a.go"}},
+			&result.RepoMatch{Name: repo.Name},
+		},
+	})
+	stream.Send(SearchEvent{
+		Results: []result.Match{&result.FileMatch{File: result.File{Repo: repo, Path: "b.go"}}},
+		Stats:   Stats{IsLimitHit: true},
+	})
+	done()
+
+	// File matches sent after done are dropped.
+	stream.Send(SearchEvent{
+		Results: []result.Match{&result.FileMatch{File: result.File{Repo: repo, Path: "c.go"}}},
+	})
+	done()
+
+	sort.Strings(got)
+	if diff := cmp.Diff([]string{"@alice", "@bob"}, got); diff != "" {
+		t.Errorf("unexpected owners (-want +got):\n%s", diff)
+	}
+	if !limitHit {
+		t.Error("expected stats to be passed on")
+	}
+}
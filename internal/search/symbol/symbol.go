@@ -153,14 +153,17 @@ func searchInRepo(ctx context.Context, repoRevs *search.RepositoryRevisions, pat
 	}
 	span.SetTag("commit", string(commitID))
 
+	// The symbols service only sees file names, so it filters lang: by
+	// extension.
+	includePatterns, excludePattern := patternInfo.FilePatterns()
 	symbols, err := backend.Symbols.ListTags(ctx, search.SymbolsParameters{
 		Repo:            repoRevs.Repo.Name,
 		CommitID:        commitID,
 		Query:           patternInfo.Pattern,
 		IsCaseSensitive: patternInfo.IsCaseSensitive,
 		IsRegExp:        patternInfo.IsRegExp,
-		IncludePatterns: patternInfo.IncludePatterns,
-		ExcludePattern:  patternInfo.ExcludePattern,
+		IncludePatterns: includePatterns,
+		ExcludePattern:  excludePattern,
 		// Ask for limit + 1 so we can detect whether there are more results than the limit.
 		First: limit + 1,
 	})
@@ -172,6 +172,7 @@ type TextPatternInfo struct {
 	IsNegated       bool
 	IsRegExp        bool
 	IsStructuralPat bool
+	IsFuzzy         bool
 	CombyRule       string
 	IsWordMatch     bool
 	IsCaseSensitive bool
@@ -192,7 +193,25 @@ type TextPatternInfo struct {
 	PatternMatchesContent bool
 	PatternMatchesPath    bool
 
-	Languages []string
+	// Languages and ExcludeLanguages are the values of the lang: and -lang:
+	// filters. Searcher classifies files by their name and content, see
+	// inventory.GetLanguage. Backends which only see file names, such as
+	// Zoekt, use FilePatterns instead.
+	Languages        []string
+	ExcludeLanguages []string
+}
+
+// FilePatterns returns IncludePatterns and ExcludePattern extended with the
+// file name patterns of Languages and ExcludeLanguages. Files with a name
+// shared by several languages, such as .h files, match and are excluded by
+// every one of them.
+func (p *TextPatternInfo) FilePatterns() (include []string, exclude string) {
+	include = append(append(include, p.IncludePatterns...), mapSlice(p.Languages, LangToFileRegexp)...)
+	excludes := mapSlice(p.ExcludeLanguages, LangToFileRegexp)
+	if p.ExcludePattern != "" {
+		excludes = append([]string{p.ExcludePattern}, excludes...)
+	}
+	return include, unionRegexp(excludes)
 }
 
 func (p *TextPatternInfo) String() string {
@@ -207,6 +226,9 @@ func (p *TextPatternInfo) String() string {
 			args = append(args, "comby")
 		}
 	}
+	if p.IsFuzzy {
+		args = append(args, "fuzzy")
+	}
 	if p.IsWordMatch {
 		args = append(args, "word")
 	}
@@ -225,6 +247,9 @@ func (p *TextPatternInfo) String() string {
 	for _, lang := range p.Languages {
 		args = append(args, fmt.Sprintf("lang:%s", lang))
 	}
+	for _, lang := range p.ExcludeLanguages {
+		args = append(args, fmt.Sprintf("-lang:%s", lang))
+	}
 
 	for _, inc := range p.FilePatternsReposMustInclude {
 		args = append(args, fmt.Sprintf("repositoryPathPattern:%s", inc))
@@ -258,6 +283,8 @@ type RepoOptions struct {
 	NoArchived               bool
 	OnlyArchived             bool
 	CommitAfter              string
+	HasTopics                []string
+	HasDescriptionPatterns   []string
 	Visibility               query.RepoVisibility
 	Limit                    int
 	Cursors                  []*types.Cursor
@@ -283,6 +310,12 @@ func (op *RepoOptions) String() string {
 	if op.CommitAfter != "" {
 		_, _ = fmt.Fprintf(&b, " CommitAfter=%q", op.CommitAfter)
 	}
+	if len(op.HasTopics) > 0 {
+		_, _ = fmt.Fprintf(&b, " HasTopics=%q", op.HasTopics)
+	}
+	if len(op.HasDescriptionPatterns) > 0 {
+		_, _ = fmt.Fprintf(&b, " HasDescriptionPatterns=%q", op.HasDescriptionPatterns)
+	}
 
 	if op.CaseSensitiveRepoFilters {
 		b.WriteString(" CaseSensitiveRepoFilters")
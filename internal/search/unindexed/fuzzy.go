@@ -0,0 +1,63 @@
+package unindexed
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	zoektutil "github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+)
+
+// FuzzySearch searches file contents for approximate occurrences of a
+// pattern. Fuzzy patterns are always evaluated by searcher. For indexed
+// repositories, searcher only considers the candidate files that Zoekt
+// returns.
+type FuzzySearch struct {
+	ZoektArgs    *search.ZoektParameters
+	SearcherArgs *search.SearcherParameters
+
+	NotSearcherOnly   bool
+	UseIndex          query.YesNoOnly
+	ContainsRefGlobs  bool
+	OnMissingRepoRevs zoektutil.OnMissingRepoRevs
+}
+
+func (s *FuzzySearch) Run(ctx context.Context, stream streaming.Sender, repos searchrepos.Pager) error {
+	return repos.Paginate(ctx, nil, func(page *searchrepos.Resolved) error {
+		request, ok, err := zoektutil.OnlyUnindexed(page.RepoRevs, s.ZoektArgs.Zoekt, s.UseIndex, s.ContainsRefGlobs, s.OnMissingRepoRevs)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			request, err = zoektutil.NewIndexedSubsetSearchRequest(ctx, page.RepoRevs, s.UseIndex, s.ZoektArgs, s.OnMissingRepoRevs)
+			if err != nil {
+				return err
+			}
+		}
+
+		partitionedRepos, err := PartitionRepos(request, s.NotSearcherOnly)
+		if err != nil {
+			return err
+		}
+
+		return streamFuzzySearch(ctx, s.SearcherArgs, partitionedRepos, stream)
+	})
+}
+
+func (*FuzzySearch) Name() string {
+	return "Fuzzy"
+}
+
+// streamFuzzySearch runs fuzzy search jobs and streams the results.
+func streamFuzzySearch(ctx context.Context, args *search.SearcherParameters, repos []repoData, stream streaming.Sender) error {
+	ctx, stream, cleanup := streaming.WithLimit(ctx, stream, int(args.PatternInfo.FileMatchLimit))
+	defer cleanup()
+
+	jobs := make([]*searchRepos, 0, len(repos))
+	for _, repoSet := range repos {
+		jobs = append(jobs, &searchRepos{args: args, stream: stream, repoSet: repoSet})
+	}
+	return runJobs(ctx, jobs)
+}
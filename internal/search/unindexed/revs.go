@@ -0,0 +1,225 @@
+package unindexed
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/endpoint"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// maxChangedPaths is the maximum number of paths in which a commit may differ
+// from the base commit of a multi-revision search to only be searched in those
+// paths. Commits with more changes are searched in full.
+const maxChangedPaths = 1000
+
+// searchFilesInRepoRevs searches several revisions of a repository, such as
+// all branches matched by a ref glob. Every distinct file blob is searched only
+// once: revisions that resolve to the same commit are searched once, the first
+// commit (the base) is searched in full, and every other commit is only
+// searched in the paths in which its tree differs from the base. The matches of
+// the base in all other paths are reported for those commits as well, as the
+// content of those files is identical.
+//
+// Like for indexed multi-branch search, a match is reported once for each
+// revision it appears in.
+func searchFilesInRepoRevs(ctx context.Context, searcherURLs *endpoint.Map, repo types.MinimalRepo, gitserverRepo api.RepoName, revs []string, index bool, info *search.TextPatternInfo, fetchTimeout time.Duration, stream streaming.Sender) (bool, error) {
+	var (
+		commits    []api.CommitID
+		commitRevs = map[api.CommitID][]string{}
+	)
+	for _, rev := range revs {
+		// Do not trigger a repo-updater lookup, see searchFilesInRepo.
+		commit, err := git.ResolveRevision(ctx, gitserverRepo, rev, git.ResolveRevisionOptions{NoEnsureRevision: true})
+		if err != nil {
+			return false, err
+		}
+		if _, ok := commitRevs[commit]; !ok {
+			commits = append(commits, commit)
+		}
+		commitRevs[commit] = append(commitRevs[commit], rev)
+	}
+
+	var indexerEndpoints []string
+	if info.IsStructuralPat || info.IsFuzzy {
+		var err error
+		indexerEndpoints, err = search.Indexers().Map.Endpoints()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	s := &repoRevsSearcher{
+		searcherURLs:     searcherURLs,
+		repo:             repo,
+		gitserverRepo:    gitserverRepo,
+		commitRevs:       commitRevs,
+		index:            index,
+		info:             info,
+		fetchTimeout:     fetchTimeout,
+		indexerEndpoints: indexerEndpoints,
+		stream:           stream,
+	}
+
+	base := commits[0]
+	baseSearched, baseMatches, err := s.searchFull(ctx, base)
+	if err != nil || len(commits) == 1 {
+		return s.limitHit, err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, commit := range commits[1:] {
+		commit := commit
+		g.Go(func() error {
+			if !baseSearched {
+				// We have no matches of the base to reuse.
+				_, _, err := s.searchFull(ctx, commit)
+				return err
+			}
+			return s.searchIncremental(ctx, base, baseMatches, commit)
+		})
+	}
+	err = g.Wait()
+	return s.limitHit, err
+}
+
+type repoRevsSearcher struct {
+	searcherURLs     *endpoint.Map
+	repo             types.MinimalRepo
+	gitserverRepo    api.RepoName
+	commitRevs       map[api.CommitID][]string
+	index            bool
+	info             *search.TextPatternInfo
+	fetchTimeout     time.Duration
+	indexerEndpoints []string
+	stream           streaming.Sender
+
+	mu       sync.Mutex
+	limitHit bool
+}
+
+// searchFull searches commit in full. It returns whether the commit was
+// searched and its matches.
+func (s *repoRevsSearcher) searchFull(ctx context.Context, commit api.CommitID) (searched bool, matches []*protocol.FileMatch, err error) {
+	shouldBeSearched, err := repoShouldBeSearched(ctx, s.searcherURLs, s.info, s.repo, commit, s.fetchTimeout)
+	if err != nil || !shouldBeSearched {
+		return false, nil, err
+	}
+
+	err = s.search(ctx, commit, s.info, func(fms []*protocol.FileMatch) []*protocol.FileMatch {
+		matches = append(matches, fms...)
+		return fms
+	})
+	return true, matches, err
+}
+
+// searchIncremental searches commit in the paths in which it differs from
+// base, and reports the matches of base in all other paths for commit.
+func (s *repoRevsSearcher) searchIncremental(ctx context.Context, base api.CommitID, baseMatches []*protocol.FileMatch, commit api.CommitID) error {
+	shouldBeSearched, err := repoShouldBeSearched(ctx, s.searcherURLs, s.info, s.repo, commit, s.fetchTimeout)
+	if err != nil || !shouldBeSearched {
+		return err
+	}
+
+	changed, err := git.DiffFileNames(ctx, s.gitserverRepo, base, commit)
+	if err != nil {
+		return err
+	}
+	if len(changed) > maxChangedPaths {
+		return s.search(ctx, commit, s.info, nil)
+	}
+
+	changedSet := make(map[string]struct{}, len(changed))
+	for _, path := range changed {
+		changedSet[path] = struct{}{}
+	}
+
+	if len(changed) > 0 {
+		info := *s.info
+		info.IncludePatterns = append(append([]string{}, s.info.IncludePatterns...), pathsPattern(changed))
+		err := s.search(ctx, commit, &info, func(fms []*protocol.FileMatch) []*protocol.FileMatch {
+			// Path patterns may be case-insensitive, so we drop matches in
+			// unchanged files that only differ in case from a changed file.
+			// Those are reported from the base below.
+			filtered := make([]*protocol.FileMatch, 0, len(fms))
+			for _, fm := range fms {
+				if _, ok := changedSet[fm.Path]; ok {
+					filtered = append(filtered, fm)
+				}
+			}
+			return filtered
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	unchanged := make([]*protocol.FileMatch, 0, len(baseMatches))
+	for _, fm := range baseMatches {
+		if _, ok := changedSet[fm.Path]; !ok {
+			unchanged = append(unchanged, fm)
+		}
+	}
+	s.send(commit, unchanged)
+	return nil
+}
+
+// search runs searcher on commit with pattern info and reports its matches for
+// every revision of commit. If filter is non-nil, only the matches it returns
+// are reported.
+func (s *repoRevsSearcher) search(ctx context.Context, commit api.CommitID, info *search.TextPatternInfo, filter func([]*protocol.FileMatch) []*protocol.FileMatch) error {
+	ctx, done, err := textSearchLimiter.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	onMatches := func(fms []*protocol.FileMatch) {
+		if filter != nil {
+			fms = filter(fms)
+		}
+		s.send(commit, fms)
+	}
+
+	revs := s.commitRevs[commit]
+	limitHit, err := searcher.Search(ctx, s.searcherURLs, s.gitserverRepo, s.repo.ID, revs[0], commit, s.index, info, s.fetchTimeout, s.indexerEndpoints, onMatches)
+	if limitHit {
+		s.mu.Lock()
+		s.limitHit = true
+		s.mu.Unlock()
+	}
+	return err
+}
+
+func (s *repoRevsSearcher) send(commit api.CommitID, fms []*protocol.FileMatch) {
+	if len(fms) == 0 {
+		return
+	}
+	for _, rev := range s.commitRevs[commit] {
+		rev := rev // copy so we can take the pointer
+		s.stream.Send(streaming.SearchEvent{
+			Results: newToMatches(s.repo, commit, &rev)(fms),
+		})
+	}
+}
+
+// pathsPattern returns a regular expression that matches exactly the given
+// paths.
+func pathsPattern(paths []string) string {
+	quoted := make([]string, 0, len(paths))
+	for _, path := range paths {
+		quoted = append(quoted, regexp.QuoteMeta(path))
+	}
+	return "^(?:" + strings.Join(quoted, "|") + ")$"
+}
@@ -102,7 +102,7 @@ func searchFilesInRepo(ctx context.Context, searcherURLs *endpoint.Map, repo typ
 	}
 
 	var indexerEndpoints []string
-	if info.IsStructuralPat {
+	if info.IsStructuralPat || info.IsFuzzy {
 		indexerEndpoints, err = search.Indexers().Map.Endpoints()
 		if err != nil {
 			return false, err
@@ -270,6 +270,26 @@ func callSearcherOverRepos(
 				return err
 			}
 
+			if len(revSpecs) > 1 {
+				// Search all revisions together, so that files which are
+				// identical across revisions are only searched once.
+				// searchFilesInRepoRevs acquires textSearchLimiter itself.
+				repoRev := repoAllRevs
+				g.Go(func() error {
+					repoLimitHit, err := searchFilesInRepoRevs(ctx, args.SearcherURLs, repoRev.Repo, repoRev.GitserverRepo(), revSpecs, index, args.PatternInfo, fetchTimeout, stream)
+					if err != nil {
+						tr.LogFields(otlog.String("repo", string(repoRev.Repo.Name)), otlog.Error(err), otlog.Bool("timeout", errcode.IsTimeout(err)), otlog.Bool("temporary", errcode.IsTemporary(err)))
+						log15.Warn("searchFilesInRepoRevs failed", "error", err, "repo", repoRev.Repo.Name)
+					}
+					stats, err := searchrepos.HandleRepoSearchResult(repoRev, repoLimitHit, false, err)
+					stream.Send(streaming.SearchEvent{
+						Stats: stats,
+					})
+					return err
+				})
+				continue
+			}
+
 			for _, rev := range revSpecs {
 				limitCtx, limitDone, err := textSearchLimiter.Acquire(ctx)
 				if err != nil {
@@ -251,25 +251,24 @@ func assertReposStatus(t *testing.T, repoNames map[api.RepoID]string, got search
 }
 
 func TestSearchFilesInRepos_multipleRevsPerRepo(t *testing.T) {
-	mockSearchFilesInRepo = func(ctx context.Context, repo types.MinimalRepo, gitserverRepo api.RepoName, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration, stream streaming.Sender) (limitHit bool, err error) {
-		repoName := repo.Name
-		switch repoName {
+	git.Mocks.ResolveRevision = func(spec string, opt git.ResolveRevisionOptions) (api.CommitID, error) {
+		return api.CommitID(spec), nil
+	}
+	git.Mocks.DiffFileNames = func(repo api.RepoName, base, head api.CommitID) ([]string, error) {
+		return nil, nil
+	}
+	defer git.ResetMocks()
+
+	searcher.MockSearch = func(ctx context.Context, repo api.RepoName, repoID api.RepoID, commit api.CommitID, p *search.TextPatternInfo, fetchTimeout time.Duration, onMatches func([]*protocol.FileMatch)) (limitHit bool, err error) {
+		switch repo {
 		case "foo":
-			stream.Send(streaming.SearchEvent{
-				Results: []result.Match{&result.FileMatch{
-					File: result.File{
-						Repo:     repo,
-						CommitID: api.CommitID(rev),
-						Path:     "main.go",
-					},
-				}},
-			})
+			onMatches([]*protocol.FileMatch{{Path: "main.go"}})
 			return false, nil
 		default:
 			panic("unexpected repo")
 		}
 	}
-	defer func() { mockSearchFilesInRepo = nil }()
+	defer func() { searcher.MockSearch = nil }()
 
 	trueVal := true
 	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
@@ -329,6 +328,76 @@ func TestSearchFilesInRepos_multipleRevsPerRepo(t *testing.T) {
 	}
 }
 
+func TestSearchFilesInRepoRevs(t *testing.T) {
+	commits := map[string]api.CommitID{
+		"refs/heads/release/1": "c1",
+		"refs/heads/release/2": "c2",
+		"refs/heads/release/3": "c1",
+	}
+	git.Mocks.ResolveRevision = func(spec string, opt git.ResolveRevisionOptions) (api.CommitID, error) {
+		return commits[spec], nil
+	}
+	git.Mocks.DiffFileNames = func(repo api.RepoName, base, head api.CommitID) ([]string, error) {
+		if base != "c1" || head != "c2" {
+			t.Errorf("unexpected diff %s..%s", base, head)
+		}
+		return []string{"b.go", "deleted.go"}, nil
+	}
+	defer git.ResetMocks()
+
+	searched := map[api.CommitID][]string{}
+	searcher.MockSearch = func(ctx context.Context, repo api.RepoName, repoID api.RepoID, commit api.CommitID, p *search.TextPatternInfo, fetchTimeout time.Duration, onMatches func([]*protocol.FileMatch)) (limitHit bool, err error) {
+		searched[commit] = p.IncludePatterns
+		switch commit {
+		case "c1":
+			onMatches([]*protocol.FileMatch{{Path: "a.go"}, {Path: "b.go"}, {Path: "deleted.go"}})
+		case "c2":
+			// B.go is unchanged, but matched by the case-insensitive
+			// pattern for b.go.
+			onMatches([]*protocol.FileMatch{{Path: "b.go"}, {Path: "B.go"}})
+		}
+		return false, nil
+	}
+	defer func() { searcher.MockSearch = nil }()
+
+	var got []string
+	stream := streaming.StreamFunc(func(e streaming.SearchEvent) {
+		for _, m := range e.Results {
+			fm := m.(*result.FileMatch)
+			got = append(got, fmt.Sprintf("%s@%s:%s", fm.CommitID, *fm.InputRev, fm.Path))
+		}
+	})
+
+	revs := []string{"refs/heads/release/1", "refs/heads/release/2", "refs/heads/release/3"}
+	_, err := searchFilesInRepoRevs(context.Background(), endpoint.Static("test"), mkRepos("foo")[0], "foo", revs, false, &search.TextPatternInfo{}, time.Minute, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantSearched := map[api.CommitID][]string{
+		"c1": nil,
+		"c2": {`^(?:b\.go|deleted\.go)$`},
+	}
+	if diff := cmp.Diff(wantSearched, searched); diff != "" {
+		t.Errorf("unexpected searches (-want +got):\n%s", diff)
+	}
+
+	sort.Strings(got)
+	want := []string{
+		"c1@refs/heads/release/1:a.go",
+		"c1@refs/heads/release/1:b.go",
+		"c1@refs/heads/release/1:deleted.go",
+		"c1@refs/heads/release/3:a.go",
+		"c1@refs/heads/release/3:b.go",
+		"c1@refs/heads/release/3:deleted.go",
+		"c2@refs/heads/release/2:a.go",
+		"c2@refs/heads/release/2:b.go",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected matches (-want +got):\n%s", diff)
+	}
+}
+
 func TestRepoShouldBeSearched(t *testing.T) {
 	searcher.MockSearch = func(ctx context.Context, repo api.RepoName, repoID api.RepoID, commit api.CommitID, p *search.TextPatternInfo, fetchTimeout time.Duration, onMatches func([]*protocol.FileMatch)) (limitHit bool, err error) {
 		repoName := repo
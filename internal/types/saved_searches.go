@@ -10,4 +10,7 @@ type SavedSearch struct {
 	UserID          *int32  // if non-nil, the owner is this user. UserID/OrgID are mutually exclusive.
 	OrgID           *int32  // if non-nil, the owner is this organization. UserID/OrgID are mutually exclusive.
 	SlackWebhookURL *string // if non-nil && NotifySlack == true, indicates that this Slack webhook URL should be used instead of the owners default Slack webhook.
+	NotifyWebhook   bool    // whether or not to post new results of this saved search to WebhookURL
+	WebhookURL      *string // the URL new results are posted to if NotifyWebhook == true
+	WebhookSecret   *string // if non-nil, the shared secret used to sign webhook payloads with HMAC-SHA256
 }
@@ -461,6 +461,17 @@ type GitserverRepo struct {
 	// The last time a fetch updated the repository.
 	LastChanged time.Time
 	UpdatedAt   time.Time
+	// The address of the gitserver the repo is being transferred from while
+	// rebalancing, or empty.
+	RebalanceFrom string
+	// The time the last transfer between gitservers started.
+	RebalanceStartedAt time.Time
+	// The last time gitserver ran maintenance tasks on the repository.
+	LastMaintenanceAt time.Time
+	// The error of the last maintenance run, or empty.
+	LastMaintenanceError string
+	// The number of packfiles after the last maintenance run.
+	PackCount int
 }
 
 // ExternalService is a connection to an external service.
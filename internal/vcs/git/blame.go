@@ -11,7 +11,9 @@ import (
 	"github.com/cockroachdb/errors"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 )
 
@@ -46,6 +48,18 @@ func BlameFile(ctx context.Context, repo api.RepoName, path string, opt *BlameOp
 	return blameFileCmd(ctx, gitserverCmdFunc(repo), path, opt)
 }
 
+// BlameStats returns the number of lines each author changed last and when
+// they last changed them in the files below the directory path, without
+// running a blame for every file on the client.
+func BlameStats(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (*protocol.BlameStatsResponse, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Git: BlameStats")
+	span.SetTag("repo", repo)
+	span.SetTag("commit", commit)
+	span.SetTag("path", path)
+	defer span.Finish()
+	return gitserver.DefaultClient.BlameStats(ctx, repo, commit, path)
+}
+
 func blameFileCmd(ctx context.Context, command cmdFunc, path string, opt *BlameOptions) ([]*Hunk, error) {
 	if opt == nil {
 		opt = &BlameOptions{}
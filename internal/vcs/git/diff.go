@@ -3,6 +3,7 @@ package git
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 )
 
 type DiffOptions struct {
@@ -84,6 +86,32 @@ func DiffPath(ctx context.Context, repo api.RepoName, sourceCommit, targetCommit
 	return d.Hunks, nil
 }
 
+// DiffFileNames returns the paths of the files that differ between the trees
+// of the commits base and head. A renamed file is reported with both its old
+// and its new path.
+func DiffFileNames(ctx context.Context, repo api.RepoName, base, head api.CommitID) ([]string, error) {
+	if Mocks.DiffFileNames != nil {
+		return Mocks.DiffFileNames(repo, base, head)
+	}
+	if err := checkSpecArgSafety(string(base)); err != nil {
+		return nil, err
+	}
+	if err := checkSpecArgSafety(string(head)); err != nil {
+		return nil, err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", "diff", "--name-only", "--no-renames", "-z", string(base), string(head), "--")
+	cmd.Repo = repo
+	out, err := cmd.CombinedOutput(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00"), nil
+}
+
 type DiffFileIterator struct {
 	rdr  io.ReadCloser
 	mfdr *diff.MultiFileDiffReader
@@ -3,6 +3,7 @@ package git
 import (
 	"context"
 	"io"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -186,3 +187,47 @@ func (c *closer) Close() error {
 	*c = true
 	return nil
 }
+
+func TestDiffFileNames(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	repo := MakeGitRepository(t,
+		"echo a > a",
+		"echo b > b",
+		"mkdir dir && echo c > dir/c",
+		"git add a b dir/c",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git tag base",
+		"echo a2 > a",
+		"git mv b b2",
+		"echo d > d",
+		"git add a d",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m bar --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+	)
+
+	base, err := ResolveRevision(ctx, repo, "base", ResolveRevisionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := ResolveRevision(ctx, repo, "HEAD", ResolveRevisionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := DiffFileNames(ctx, repo, base, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "b2", "d"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %q, want %q", names, want)
+	}
+
+	names, err = DiffFileNames(ctx, repo, head, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 0 {
+		t.Errorf("got %q, want no names", names)
+	}
+}
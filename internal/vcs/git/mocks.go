@@ -24,6 +24,7 @@ var Mocks, emptyMocks struct {
 	Stat                  func(commit api.CommitID, name string) (fs.FileInfo, error)
 	Commits               func(repo api.RepoName, opt CommitsOptions) ([]*gitdomain.Commit, error)
 	MergeBase             func(repo api.RepoName, a, b api.CommitID) (api.CommitID, error)
+	DiffFileNames         func(repo api.RepoName, base, head api.CommitID) ([]string, error)
 	GetDefaultBranch      func(repo api.RepoName) (refName string, commit api.CommitID, err error)
 	GetDefaultBranchShort func(repo api.RepoName) (refName string, commit api.CommitID, err error)
 }
@@ -0,0 +1,70 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func DotNetPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		// *.sln file in any directory
+		extensionPattern(rawPattern("sln")),
+		// *.csproj file in any directory
+		extensionPattern(rawPattern("csproj")),
+	}
+}
+
+const lsifDotNetImage = "sourcegraph/lsif-dotnet:latest"
+
+func InferDotNetIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	// Solution files reference the projects that make up an application, so we
+	// index each solution as a whole when there are any. Otherwise, we fall back
+	// to indexing each project file on its own.
+	for _, path := range paths {
+		if isDotNetSolutionPath(path) {
+			indexes = append(indexes, dotNetIndexJob(path))
+		}
+	}
+	if len(indexes) > 0 {
+		return indexes
+	}
+
+	for _, path := range paths {
+		if isDotNetProjectPath(path) {
+			indexes = append(indexes, dotNetIndexJob(path))
+		}
+	}
+
+	return indexes
+}
+
+func dotNetIndexJob(path string) config.IndexJob {
+	root := dirWithoutDot(path)
+	base := filepath.Base(path)
+
+	return config.IndexJob{
+		Steps: []config.DockerStep{
+			{
+				Root:     root,
+				Image:    lsifDotNetImage,
+				Commands: []string{"dotnet restore " + base},
+			},
+		},
+		Root:        root,
+		Indexer:     lsifDotNetImage,
+		IndexerArgs: []string{"lsif-dotnet", base, "--output", "dump.lsif"},
+		Outfile:     "dump.lsif",
+	}
+}
+
+var dotNetSegmentBlockList = append([]string{"bin", "obj", "packages"}, segmentBlockList...)
+
+func isDotNetSolutionPath(path string) bool {
+	return filepath.Ext(path) == ".sln" && containsNoSegments(path, dotNetSegmentBlockList...)
+}
+
+func isDotNetProjectPath(path string) bool {
+	return filepath.Ext(path) == ".csproj" && containsNoSegments(path, dotNetSegmentBlockList...)
+}
@@ -0,0 +1,89 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotNetPatterns(t *testing.T) {
+	testLangPatterns(t, DotNetPatterns(), []PathTestCase{
+		{"App.sln", true},
+		{"src/App.sln", true},
+		{"App.csproj", true},
+		{"src/App/App.csproj", true},
+		{"App.cs", false},
+		{"App.csproj/subdir", false},
+		{"App.vbproj", false},
+	})
+}
+
+func TestInferDotNetIndexJobsSolutions(t *testing.T) {
+	paths := []string{
+		"App.sln",
+		"src/App/App.csproj",
+		"src/Lib/Lib.csproj",
+		"tools/Tools.sln",
+		"tests/App.Tests.sln",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "",
+					Image:    lsifDotNetImage,
+					Commands: []string{"dotnet restore App.sln"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifDotNetImage,
+			IndexerArgs: []string{"lsif-dotnet", "App.sln", "--output", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "tools",
+					Image:    lsifDotNetImage,
+					Commands: []string{"dotnet restore Tools.sln"},
+				},
+			},
+			Root:        "tools",
+			Indexer:     lsifDotNetImage,
+			IndexerArgs: []string{"lsif-dotnet", "Tools.sln", "--output", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferDotNetIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestInferDotNetIndexJobsProjects(t *testing.T) {
+	paths := []string{
+		"src/App/App.csproj",
+		"src/App/obj/Generated.csproj",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "src/App",
+					Image:    lsifDotNetImage,
+					Commands: []string{"dotnet restore App.csproj"},
+				},
+			},
+			Root:        "src/App",
+			Indexer:     lsifDotNetImage,
+			IndexerArgs: []string{"lsif-dotnet", "App.csproj", "--output", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferDotNetIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
@@ -64,3 +64,27 @@ var segmentBlockList = []string{
 	"testdata",
 	"tests",
 }
+
+// isDescendant returns true if the given path is strictly below the given directory.
+// The empty directory name denotes the repository root.
+func isDescendant(path, dir string) bool {
+	for _, ancestor := range ancestorDirs(path) {
+		if ancestor == dir && ancestor != path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasAncestorIn returns true if any of the given directories is a strict ancestor
+// of the given path.
+func hasAncestorIn(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if isDescendant(path, dir) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -61,3 +61,28 @@ func TestContainsSegment(t *testing.T) {
 		})
 	}
 }
+
+func TestIsDescendant(t *testing.T) {
+	testCases := []struct {
+		path     string
+		dir      string
+		expected bool
+	}{
+		{path: "foo/bar", dir: "foo", expected: true},
+		{path: "foo/bar/baz", dir: "foo", expected: true},
+		{path: "foo", dir: "", expected: true},
+		{path: "foo", dir: "foo", expected: false},
+		{path: "", dir: "", expected: false},
+		{path: "foobar/baz", dir: "foo", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := fmt.Sprintf("%s below %q", testCase.path, testCase.dir)
+
+		t.Run(name, func(t *testing.T) {
+			if value := isDescendant(testCase.path, testCase.dir); value != testCase.expected {
+				t.Errorf("unexpected result. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
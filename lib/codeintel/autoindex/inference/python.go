@@ -0,0 +1,91 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func PythonPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("setup.py")),
+		pathPattern(rawPattern("pyproject.toml")),
+		pathPattern(rawPattern("requirements.txt")),
+	}
+}
+
+const lsifPyImage = "sourcegraph/lsif-py:latest"
+
+func InferPythonIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	projectDirs := pythonProjectDirs(paths)
+
+	for _, root := range projectDirs {
+		if hasAncestorIn(root, projectDirs) {
+			// lsif-py indexes all packages below its root, so nested
+			// projects are covered by the index job of the outermost one.
+			continue
+		}
+
+		var dockerSteps []config.DockerStep
+		for _, dir := range projectDirs {
+			if dir != root && !isDescendant(dir, root) {
+				continue
+			}
+
+			var command string
+			if contains(paths, filepath.Join(dir, "requirements.txt")) {
+				command = "pip install -r requirements.txt"
+			} else {
+				command = "pip install ."
+			}
+
+			dockerSteps = append(dockerSteps, config.DockerStep{
+				Root:     dir,
+				Image:    lsifPyImage,
+				Commands: []string{command},
+			})
+		}
+
+		indexes = append(indexes, config.IndexJob{
+			Steps:       dockerSteps,
+			Root:        root,
+			Indexer:     lsifPyImage,
+			IndexerArgs: []string{"lsif-py", ".", "--file", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		})
+	}
+
+	return indexes
+}
+
+var pythonSegmentBlockList = append([]string{"venv", ".venv", "site-packages"}, segmentBlockList...)
+
+// pythonProjectDirs returns the sorted set of directories containing a Python
+// project manifest.
+func pythonProjectDirs(paths []string) []string {
+	dirMap := map[string]struct{}{}
+	for _, path := range paths {
+		if isPythonProjectPath(path) {
+			dirMap[dirWithoutDot(path)] = struct{}{}
+		}
+	}
+
+	dirs := make([]string, 0, len(dirMap))
+	for dir := range dirMap {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	return dirs
+}
+
+func isPythonProjectPath(path string) bool {
+	switch filepath.Base(path) {
+	case "setup.py", "pyproject.toml", "requirements.txt":
+		return containsNoSegments(path, pythonSegmentBlockList...)
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPythonPatterns(t *testing.T) {
+	testLangPatterns(t, PythonPatterns(), []PathTestCase{
+		{"setup.py", true},
+		{"pyproject.toml", true},
+		{"requirements.txt", true},
+		{"subdir/setup.py", true},
+		{"subdir/requirements.txt", true},
+		{"dev-requirements.txt", false},
+		{"setup.py/subdir", false},
+		{"foo.py", false},
+	})
+}
+
+func TestInferPythonIndexJobs(t *testing.T) {
+	paths := []string{
+		"requirements.txt",
+		"setup.py",
+		"lib/pyproject.toml",
+		"services/api/requirements.txt",
+		"venv/lib/site-packages/foo/setup.py",
+		"tests/fixtures/setup.py",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "",
+					Image:    lsifPyImage,
+					Commands: []string{"pip install -r requirements.txt"},
+				},
+				{
+					Root:     "lib",
+					Image:    lsifPyImage,
+					Commands: []string{"pip install ."},
+				},
+				{
+					Root:     "services/api",
+					Image:    lsifPyImage,
+					Commands: []string{"pip install -r requirements.txt"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifPyImage,
+			IndexerArgs: []string{"lsif-py", ".", "--file", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferPythonIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestInferPythonIndexJobsSubdirs(t *testing.T) {
+	paths := []string{
+		"a/setup.py",
+		"b/pyproject.toml",
+		"b/requirements.txt",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "a",
+					Image:    lsifPyImage,
+					Commands: []string{"pip install ."},
+				},
+			},
+			Root:        "a",
+			Indexer:     lsifPyImage,
+			IndexerArgs: []string{"lsif-py", ".", "--file", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "b",
+					Image:    lsifPyImage,
+					Commands: []string{"pip install -r requirements.txt"},
+				},
+			},
+			Root:        "b",
+			Indexer:     lsifPyImage,
+			IndexerArgs: []string{"lsif-py", ".", "--file", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferPythonIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,51 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func RubyPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		// Gemfile in any directory
+		pathPattern(rawPattern("Gemfile")),
+	}
+}
+
+const lsifRubyImage = "sourcegraph/lsif-ruby:latest"
+
+func InferRubyIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	for _, path := range paths {
+		if !isRubyProjectPath(path) {
+			continue
+		}
+
+		root := dirWithoutDot(path)
+
+		dockerSteps := []config.DockerStep{
+			{
+				Root:     root,
+				Image:    lsifRubyImage,
+				Commands: []string{"bundle install"},
+			},
+		}
+
+		indexes = append(indexes, config.IndexJob{
+			Steps:       dockerSteps,
+			Root:        root,
+			Indexer:     lsifRubyImage,
+			IndexerArgs: []string{"lsif-ruby", "index", "--output", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		})
+	}
+
+	return indexes
+}
+
+var rubySegmentBlockList = append([]string{"vendor"}, segmentBlockList...)
+
+func isRubyProjectPath(path string) bool {
+	return filepath.Base(path) == "Gemfile" && containsNoSegments(path, rubySegmentBlockList...)
+}
@@ -0,0 +1,59 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestRubyPatterns(t *testing.T) {
+	testLangPatterns(t, RubyPatterns(), []PathTestCase{
+		{"Gemfile", true},
+		{"subdir/Gemfile", true},
+		{"Gemfile.lock", false},
+		{"Gemfile/subdir", false},
+		{"foo.rb", false},
+	})
+}
+
+func TestInferRubyIndexJobs(t *testing.T) {
+	paths := []string{
+		"Gemfile",
+		"engines/billing/Gemfile",
+		"vendor/bundle/ruby/gems/rake/Gemfile",
+	}
+
+	expectedIndexJobs := []config.IndexJob{
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "",
+					Image:    lsifRubyImage,
+					Commands: []string{"bundle install"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifRubyImage,
+			IndexerArgs: []string{"lsif-ruby", "index", "--output", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		},
+		{
+			Steps: []config.DockerStep{
+				{
+					Root:     "engines/billing",
+					Image:    lsifRubyImage,
+					Commands: []string{"bundle install"},
+				},
+			},
+			Root:        "engines/billing",
+			Indexer:     lsifRubyImage,
+			IndexerArgs: []string{"lsif-ruby", "index", "--output", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, InferRubyIndexJobs(NewMockGitClient(), paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
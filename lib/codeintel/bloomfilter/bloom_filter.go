@@ -7,6 +7,8 @@ import (
 	"math"
 	"unicode"
 	"unicode/utf16"
+
+	"github.com/cockroachdb/errors"
 )
 
 // BloomFilterBits is the number of bits allocated for new bloom filters.
@@ -45,16 +47,11 @@ func CreateFilter(identifiers []string) ([]byte, error) {
 // probably a member of the underlying set. This method returns an error if the encoded filter cannot be
 // decoded (improperly compressed or invalid JSON).
 func Decode(encodedFilter []byte) (func(identifier string) bool, error) {
-	r, err := gzip.NewReader(bytes.NewReader(encodedFilter))
+	payload, err := decodeFilter(encodedFilter)
 	if err != nil {
 		return nil, err
 	}
 
-	var payload encodedFilterPayload
-	if err := json.NewDecoder(r).Decode(&payload); err != nil {
-		return nil, err
-	}
-
 	buckets := payload.Buckets
 	numHashFunctions := payload.NumHashFunctions
 
@@ -65,6 +62,60 @@ func Decode(encodedFilter []byte) (func(identifier string) bool, error) {
 	return test, nil
 }
 
+// Union returns an encoded filter that contains every identifier contained in any of the given
+// encoded filters. This method returns an error if the given filters were not created with the
+// same number of bits and hash functions.
+func Union(encodedFilters ...[]byte) ([]byte, error) {
+	if len(encodedFilters) == 0 {
+		return nil, errors.New("no filters to union")
+	}
+
+	var buckets []int32
+	var numHashFunctions int32
+
+	for i, encodedFilter := range encodedFilters {
+		payload, err := decodeFilter(encodedFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			buckets = payload.Buckets
+			numHashFunctions = payload.NumHashFunctions
+			continue
+		}
+
+		if len(payload.Buckets) != len(buckets) || payload.NumHashFunctions != numHashFunctions {
+			return nil, errors.Errorf(
+				"incompatible filters: %d buckets and %d hash functions, want %d buckets and %d hash functions",
+				len(payload.Buckets), payload.NumHashFunctions,
+				len(buckets), numHashFunctions,
+			)
+		}
+
+		for j, bucket := range payload.Buckets {
+			buckets[j] |= bucket
+		}
+	}
+
+	return encodeFilter(buckets, numHashFunctions)
+}
+
+// decodeFilter decompresses and unmarshalls the given bloom filter state.
+func decodeFilter(encodedFilter []byte) (encodedFilterPayload, error) {
+	r, err := gzip.NewReader(bytes.NewReader(encodedFilter))
+	if err != nil {
+		return encodedFilterPayload{}, err
+	}
+
+	var payload encodedFilterPayload
+	if err := json.NewDecoder(r).Decode(&payload); err != nil {
+		return encodedFilterPayload{}, err
+	}
+
+	return payload, nil
+}
+
 // encodeFilters marshalls and compresses the given bloom filter state.
 func encodeFilter(buckets []int32, numHashFunctions int32) ([]byte, error) {
 	payload := encodedFilterPayload{
@@ -46,6 +46,44 @@ func TestCreateFilter(t *testing.T) {
 	}
 }
 
+func TestUnion(t *testing.T) {
+	loremFilter, err := CreateFilter(readTestWords(t, "lorem-ipsum"))
+	if err != nil {
+		t.Fatalf("unexpected error creating filter: %s", err)
+	}
+	emojiFilter, err := CreateFilter(readTestWords(t, "emojis"))
+	if err != nil {
+		t.Fatalf("unexpected error creating filter: %s", err)
+	}
+
+	filter, err := Union(loremFilter, emojiFilter)
+	if err != nil {
+		t.Fatalf("unexpected error unioning filters: %s", err)
+	}
+
+	test, err := Decode(filter)
+	if err != nil {
+		t.Fatalf("unexpected error decoding filter: %s", err)
+	}
+
+	for _, includeFile := range []string{"lorem-ipsum", "emojis"} {
+		for _, v := range readTestWords(t, includeFile) {
+			if !test(v) {
+				t.Errorf("expected %s to be in bloom filter", v)
+			}
+		}
+	}
+	for _, v := range readTestWords(t, "corporate-ipsum") {
+		if test(v) {
+			t.Errorf("expected %s not to be in bloom filter", v)
+		}
+	}
+
+	if _, err := Union(loremFilter, readTestFilter(t, "stress", "32kb-16")); err == nil {
+		t.Errorf("expected error unioning incompatible filters")
+	}
+}
+
 func TestTestTypeScriptGeneratedBloomFilters(t *testing.T) {
 	testCases := []struct {
 		filterFile  string
@@ -57,8 +57,9 @@ func TestCorrelate(t *testing.T) {
 						End:   protocol.Pos{Line: 5, Character: 6},
 					},
 				},
-				DefinitionResultID: 13,
-				HoverResultID:      17,
+				DefinitionResultID:     13,
+				TypeDefinitionResultID: 102,
+				HoverResultID:          17,
 			},
 			7: {
 				Range: reader.Range{
@@ -108,6 +109,9 @@ func TestCorrelate(t *testing.T) {
 		ImplementationData: map[int]*datastructures.DefaultIDSetMap{
 			100: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{2: datastructures.IDSetWith(5)}),
 		},
+		TypeDefinitionData: map[int]*datastructures.DefaultIDSetMap{
+			102: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{3: datastructures.IDSetWith(9)}),
+		},
 		HoverData: map[int]string{
 			16: "```go\ntext A\n```",
 			17: "```go\ntext B\n```",
@@ -223,6 +227,7 @@ func TestCorrelateMetaDataRoot(t *testing.T) {
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
 		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
@@ -273,6 +278,7 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
 		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
@@ -24,7 +24,7 @@ const resultsPerResultChunk = 512
 
 // groupBundleData converts a raw (but canonicalized) correlation State into a GroupedBundleData.
 func groupBundleData(ctx context.Context, state *State) (*precise.GroupedBundleDataChans, error) {
-	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData)
+	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData) + len(state.TypeDefinitionData)
 	numResultChunks := int(math.Max(1, math.Floor(float64(numResults)/resultsPerResultChunk)))
 
 	meta := precise.MetaData{NumResultChunks: numResultChunks}
@@ -123,9 +123,11 @@ func serializeDocument(state *State, documentID int) precise.DocumentData {
 			DefinitionResultID:     toID(rangeData.DefinitionResultID),
 			ReferenceResultID:      toID(rangeData.ReferenceResultID),
 			ImplementationResultID: toID(rangeData.ImplementationResultID),
+			TypeDefinitionResultID: toID(rangeData.TypeDefinitionResultID),
 			HoverResultID:          toID(rangeData.HoverResultID),
 			DocumentationResultID:  toID(rangeData.DocumentationResultID),
 			MonikerIDs:             monikerIDs,
+			Symbol:                 serializeSymbol(rangeData),
 		}
 
 		if rangeData.HoverResultID != 0 {
@@ -152,6 +154,22 @@ func serializeDocument(state *State, documentID int) precise.DocumentData {
 	return document
 }
 
+// serializeSymbol returns the symbol defined at the given range, if the range is tagged as a
+// definition spanning a full range.
+func serializeSymbol(rangeData Range) *precise.SymbolData {
+	if rangeData.Tag == nil || rangeData.Tag.Type != "definition" || rangeData.Tag.FullRange == nil {
+		return nil
+	}
+
+	return &precise.SymbolData{
+		Kind:           rangeData.Tag.Kind,
+		StartLine:      rangeData.Tag.FullRange.Start.Line,
+		StartCharacter: rangeData.Tag.FullRange.Start.Character,
+		EndLine:        rangeData.Tag.FullRange.End.Line,
+		EndCharacter:   rangeData.Tag.FullRange.End.Character,
+	}
+}
+
 func serializeResultChunks(ctx context.Context, state *State, numResultChunks int) chan precise.IndexedResultChunkData {
 	type entry struct {
 		id     int
@@ -170,6 +188,10 @@ func serializeResultChunks(ctx context.Context, state *State, numResultChunks in
 		index := precise.HashKey(toID(id), numResultChunks)
 		chunkAssignments[index] = append(chunkAssignments[index], entry{id: id, ranges: ranges})
 	}
+	for id, ranges := range state.TypeDefinitionData {
+		index := precise.HashKey(toID(id), numResultChunks)
+		chunkAssignments[index] = append(chunkAssignments[index], entry{id: id, ranges: ranges})
+	}
 
 	ch := make(chan precise.IndexedResultChunkData)
 
@@ -89,6 +89,14 @@ func TestGroupBundleData(t *testing.T) {
 						Start: protocol.Pos{Line: 7, Character: 8},
 						End:   protocol.Pos{Line: 9, Character: 0},
 					},
+					Tag: &protocol.RangeTag{
+						Type: "definition",
+						Kind: protocol.Function,
+						FullRange: &protocol.RangeData{
+							Start: protocol.Pos{Line: 7, Character: 0},
+							End:   protocol.Pos{Line: 12, Character: 1},
+						},
+					},
 				},
 				DefinitionResultID: 3004,
 				ReferenceResultID:  0,
@@ -515,6 +523,13 @@ func TestGroupBundleData(t *testing.T) {
 					ReferenceResultID:  "",
 					HoverResultID:      "",
 					MonikerIDs:         []precise.ID{},
+					Symbol: &precise.SymbolData{
+						Kind:           protocol.Function,
+						StartLine:      7,
+						StartCharacter: 0,
+						EndLine:        12,
+						EndCharacter:   1,
+					},
 				},
 				"2008": {
 					StartLine:          8,
@@ -15,6 +15,7 @@ type State struct {
 	DefinitionData         map[int]*datastructures.DefaultIDSetMap // maps definitionResult ID -> document ID -> range ID
 	ReferenceData          map[int]*datastructures.DefaultIDSetMap // maps referenceResult ID -> document ID -> range ID
 	ImplementationData     map[int]*datastructures.DefaultIDSetMap // maps implementationResult ID -> document ID -> range ID
+	TypeDefinitionData     map[int]*datastructures.DefaultIDSetMap // maps typeDefinitionResult ID -> document ID -> range ID
 	HoverData              map[int]string                          // maps hoverResult ID -> hover string
 	MonikerData            map[int]Moniker                         // maps moniker ID -> Moniker (which has kind, scheme, identifier, and packageInformation ID)
 	PackageInformationData map[int]PackageInformation              // maps packageInformation ID -> PackageInformation (which has name and version)
@@ -47,6 +48,7 @@ func newState() *State {
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
 		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]Moniker{},
 		PackageInformationData: map[int]PackageInformation{},
@@ -13,6 +13,7 @@ type Range struct {
 	DefinitionResultID     int
 	ReferenceResultID      int
 	ImplementationResultID int
+	TypeDefinitionResultID int
 	HoverResultID          int
 	DocumentationResultID  int
 }
@@ -37,6 +38,7 @@ func (r Range) SetDefinitionResultID(id int) Range {
 		DefinitionResultID:     id,
 		ReferenceResultID:      r.ReferenceResultID,
 		ImplementationResultID: r.ImplementationResultID,
+		TypeDefinitionResultID: r.TypeDefinitionResultID,
 		HoverResultID:          r.HoverResultID,
 		DocumentationResultID:  r.DocumentationResultID,
 	}
@@ -51,6 +53,7 @@ func (r Range) SetReferenceResultID(id int) Range {
 		DefinitionResultID:     r.DefinitionResultID,
 		ReferenceResultID:      id,
 		ImplementationResultID: r.ImplementationResultID,
+		TypeDefinitionResultID: r.TypeDefinitionResultID,
 		HoverResultID:          r.HoverResultID,
 		DocumentationResultID:  r.DocumentationResultID,
 	}
@@ -65,6 +68,22 @@ func (r Range) SetImplementationResultID(id int) Range {
 		DefinitionResultID:     r.DefinitionResultID,
 		ReferenceResultID:      r.ReferenceResultID,
 		ImplementationResultID: id,
+		TypeDefinitionResultID: r.TypeDefinitionResultID,
+		HoverResultID:          r.HoverResultID,
+		DocumentationResultID:  r.DocumentationResultID,
+	}
+}
+
+// Convenience function for setting the field within a map.
+//
+// See Note [Assignment to fields of structs in maps]
+func (r Range) SetTypeDefinitionResultID(id int) Range {
+	return Range{
+		Range:                  r.Range,
+		DefinitionResultID:     r.DefinitionResultID,
+		ReferenceResultID:      r.ReferenceResultID,
+		ImplementationResultID: r.ImplementationResultID,
+		TypeDefinitionResultID: id,
 		HoverResultID:          r.HoverResultID,
 		DocumentationResultID:  r.DocumentationResultID,
 	}
@@ -79,6 +98,7 @@ func (r Range) SetHoverResultID(id int) Range {
 		DefinitionResultID:     r.DefinitionResultID,
 		ReferenceResultID:      r.ReferenceResultID,
 		ImplementationResultID: r.ImplementationResultID,
+		TypeDefinitionResultID: r.TypeDefinitionResultID,
 		HoverResultID:          id,
 		DocumentationResultID:  r.DocumentationResultID,
 	}
@@ -93,6 +113,7 @@ func (r Range) SetDocumentationResultID(id int) Range {
 		DefinitionResultID:     r.DefinitionResultID,
 		ReferenceResultID:      r.ReferenceResultID,
 		ImplementationResultID: r.ImplementationResultID,
+		TypeDefinitionResultID: r.TypeDefinitionResultID,
 		HoverResultID:          r.HoverResultID,
 		DocumentationResultID:  id,
 	}
@@ -103,6 +124,7 @@ type ResultSet struct {
 	DefinitionResultID     int
 	ReferenceResultID      int
 	ImplementationResultID int
+	TypeDefinitionResultID int
 	HoverResultID          int
 	DocumentationResultID  int
 }
@@ -116,6 +138,7 @@ func (rs ResultSet) SetDefinitionResultID(id int) ResultSet {
 		DefinitionResultID:     id,
 		ReferenceResultID:      rs.ReferenceResultID,
 		ImplementationResultID: rs.ImplementationResultID,
+		TypeDefinitionResultID: rs.TypeDefinitionResultID,
 		HoverResultID:          rs.HoverResultID,
 		DocumentationResultID:  rs.DocumentationResultID,
 	}
@@ -130,6 +153,7 @@ func (rs ResultSet) SetReferenceResultID(id int) ResultSet {
 		DefinitionResultID:     rs.DefinitionResultID,
 		ReferenceResultID:      id,
 		ImplementationResultID: rs.ImplementationResultID,
+		TypeDefinitionResultID: rs.TypeDefinitionResultID,
 		HoverResultID:          rs.HoverResultID,
 		DocumentationResultID:  rs.DocumentationResultID,
 	}
@@ -144,6 +168,22 @@ func (rs ResultSet) SetImplementationResultID(id int) ResultSet {
 		DefinitionResultID:     rs.DefinitionResultID,
 		ReferenceResultID:      rs.ReferenceResultID,
 		ImplementationResultID: id,
+		TypeDefinitionResultID: rs.TypeDefinitionResultID,
+		HoverResultID:          rs.HoverResultID,
+		DocumentationResultID:  rs.DocumentationResultID,
+	}
+}
+
+// Convenience function for setting the field within a map.
+//
+// See Note [Assignment to fields of structs in maps]
+func (rs ResultSet) SetTypeDefinitionResultID(id int) ResultSet {
+	return ResultSet{
+		ResultSet:              rs.ResultSet,
+		DefinitionResultID:     rs.DefinitionResultID,
+		ReferenceResultID:      rs.ReferenceResultID,
+		ImplementationResultID: rs.ImplementationResultID,
+		TypeDefinitionResultID: id,
 		HoverResultID:          rs.HoverResultID,
 		DocumentationResultID:  rs.DocumentationResultID,
 	}
@@ -158,6 +198,7 @@ func (rs ResultSet) SetHoverResultID(id int) ResultSet {
 		DefinitionResultID:     rs.DefinitionResultID,
 		ReferenceResultID:      rs.ReferenceResultID,
 		ImplementationResultID: rs.ImplementationResultID,
+		TypeDefinitionResultID: rs.TypeDefinitionResultID,
 		HoverResultID:          id,
 		DocumentationResultID:  rs.DocumentationResultID,
 	}
@@ -172,6 +213,7 @@ func (rs ResultSet) SetDocumentationResultID(id int) ResultSet {
 		DefinitionResultID:     rs.DefinitionResultID,
 		ReferenceResultID:      rs.ReferenceResultID,
 		ImplementationResultID: rs.ImplementationResultID,
+		TypeDefinitionResultID: rs.TypeDefinitionResultID,
 		HoverResultID:          rs.HoverResultID,
 		DocumentationResultID:  id,
 	}
@@ -25,16 +25,29 @@ type DocumentData struct {
 // that was reachable via a result set has been collapsed into this object during
 // conversion.
 type RangeData struct {
-	StartLine              int  // 0-indexed, inclusive
-	StartCharacter         int  // 0-indexed, inclusive
-	EndLine                int  // 0-indexed, inclusive
-	EndCharacter           int  // 0-indexed, inclusive
-	DefinitionResultID     ID   // possibly empty
-	ReferenceResultID      ID   // possibly empty
-	ImplementationResultID ID   // possibly empty
-	HoverResultID          ID   // possibly empty
-	DocumentationResultID  ID   // possibly empty
-	MonikerIDs             []ID // possibly empty
+	StartLine              int         // 0-indexed, inclusive
+	StartCharacter         int         // 0-indexed, inclusive
+	EndLine                int         // 0-indexed, inclusive
+	EndCharacter           int         // 0-indexed, inclusive
+	DefinitionResultID     ID          // possibly empty
+	ReferenceResultID      ID          // possibly empty
+	ImplementationResultID ID          // possibly empty
+	TypeDefinitionResultID ID          // possibly empty
+	HoverResultID          ID          // possibly empty
+	DocumentationResultID  ID          // possibly empty
+	MonikerIDs             []ID        // possibly empty
+	Symbol                 *SymbolData // possibly nil
+}
+
+// SymbolData describes the symbol defined at a range. It is taken from the definition tag
+// of the range and spans the entire symbol (e.g. a function including its body), which is
+// used to determine the caller of a reference.
+type SymbolData struct {
+	Kind           protocol.SymbolKind
+	StartLine      int // 0-indexed, inclusive
+	StartCharacter int // 0-indexed, inclusive
+	EndLine        int // 0-indexed, inclusive
+	EndCharacter   int // 0-indexed, inclusive
 }
 
 // MonikerData represent a unique name (eventually) attached to a range.
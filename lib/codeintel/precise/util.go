@@ -3,6 +3,8 @@ package precise
 import (
 	"context"
 	"sort"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
 )
 
 // FindRanges filters the given ranges and returns those that contain the position constructed
@@ -147,6 +149,26 @@ func RangeIntersectsSpan(r RangeData, startLine, endLine int) bool {
 	return (startLine <= r.StartLine && r.StartLine < endLine) || (startLine <= r.EndLine && r.EndLine < endLine)
 }
 
+// SymbolContainsRange determines if the given range falls entirely within the extent of the
+// given symbol.
+func SymbolContainsRange(s SymbolData, r RangeData) bool {
+	return comparePositions(s.StartLine, s.StartCharacter, r.StartLine, r.StartCharacter) <= 0 &&
+		comparePositions(r.EndLine, r.EndCharacter, s.EndLine, s.EndCharacter) <= 0
+}
+
+// IsCallable returns true if the given symbol is a function, method, or constructor.
+func (s SymbolData) IsCallable() bool {
+	return s.Kind == protocol.Function || s.Kind == protocol.Method || s.Kind == protocol.Constructor
+}
+
+func comparePositions(line1, character1, line2, character2 int) int {
+	if line1 != line2 {
+		return line1 - line2
+	}
+
+	return character1 - character2
+}
+
 // CAUTION: Data is not deep copied.
 func GroupedBundleDataMapsToChans(ctx context.Context, maps *GroupedBundleDataMaps) *GroupedBundleDataChans {
 	documentChan := make(chan KeyedDocumentData, len(maps.Documents))
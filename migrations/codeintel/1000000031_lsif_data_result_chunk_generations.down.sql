@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS lsif_data_result_chunk_generations;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS lsif_data_result_chunk_generations (
+    dump_id             INTEGER NOT NULL,
+    id_offset           BIGINT  NOT NULL,
+    id_bound            BIGINT  NOT NULL,
+    result_chunk_offset INTEGER NOT NULL,
+    num_result_chunks   INTEGER NOT NULL,
+    PRIMARY KEY (dump_id, id_offset)
+);
+
+COMMENT ON TABLE  lsif_data_result_chunk_generations                     IS 'Describes how the result set identifiers of a dump are hashed into result chunks. A dump processed from an incremental upload holds one row for the dump itself and one for each of its (transitive) parents, whose result chunks have been copied into the dump.';
+COMMENT ON COLUMN lsif_data_result_chunk_generations.dump_id             IS 'The identifier of the associated dump in the lsif_uploads table (state=completed).';
+COMMENT ON COLUMN lsif_data_result_chunk_generations.id_offset           IS 'The (inclusive) lower bound of the range and result set identifiers written by this generation. Identifiers are hashed into result chunks after subtracting this offset.';
+COMMENT ON COLUMN lsif_data_result_chunk_generations.id_bound            IS 'The (exclusive) upper bound of the range and result set identifiers written by this generation.';
+COMMENT ON COLUMN lsif_data_result_chunk_generations.result_chunk_offset IS 'The first index in the lsif_data_result_chunks table populated by this generation.';
+COMMENT ON COLUMN lsif_data_result_chunk_generations.num_result_chunks   IS 'The number of result chunks written by this generation. This value is used to hash identifiers into the result chunk index to which they belong.';
+
+COMMIT;
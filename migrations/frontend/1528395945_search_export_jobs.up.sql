@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS search_export_jobs (
+  id                BIGSERIAL PRIMARY KEY,
+
+  user_id           INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  query             TEXT NOT NULL,
+  format            TEXT NOT NULL,
+  object_key        TEXT,
+  result_count      INTEGER NOT NULL DEFAULT 0,
+
+  state             TEXT DEFAULT 'queued',
+  failure_message   TEXT,
+  started_at        TIMESTAMP WITH TIME ZONE,
+  finished_at       TIMESTAMP WITH TIME ZONE,
+  process_after     TIMESTAMP WITH TIME ZONE,
+  num_resets        INTEGER NOT NULL DEFAULT 0,
+  num_failures      INTEGER NOT NULL DEFAULT 0,
+  execution_logs    JSON[],
+  worker_hostname   TEXT NOT NULL DEFAULT '',
+  last_heartbeat_at TIMESTAMP WITH TIME ZONE,
+
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS search_export_jobs_user_id ON search_export_jobs(user_id);
+
+COMMENT ON COLUMN search_export_jobs.format IS 'The format results are written in, either csv or jsonl.';
+COMMENT ON COLUMN search_export_jobs.object_key IS 'The key of the exported file in the blob store. Set once the export has been uploaded.';
+COMMENT ON COLUMN search_export_jobs.result_count IS 'The number of results written so far.';
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE saved_searches
+    DROP COLUMN IF EXISTS notify_webhook,
+    DROP COLUMN IF EXISTS webhook_url,
+    DROP COLUMN IF EXISTS webhook_secret;
+
+COMMIT;
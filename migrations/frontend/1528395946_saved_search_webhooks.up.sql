@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE saved_searches
+    ADD COLUMN IF NOT EXISTS notify_webhook boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS webhook_url text,
+    ADD COLUMN IF NOT EXISTS webhook_secret text;
+
+COMMENT ON COLUMN saved_searches.webhook_url IS 'The URL new results are posted to when notify_webhook is true.';
+COMMENT ON COLUMN saved_searches.webhook_secret IS 'The shared secret used to sign webhook payloads with HMAC-SHA256.';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE gitserver_repos
+    DROP COLUMN IF EXISTS rebalance_from,
+    DROP COLUMN IF EXISTS rebalance_started_at;
+
+COMMIT;
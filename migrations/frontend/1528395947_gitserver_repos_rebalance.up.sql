@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE gitserver_repos
+    ADD COLUMN IF NOT EXISTS rebalance_from text,
+    ADD COLUMN IF NOT EXISTS rebalance_started_at timestamp with time zone;
+
+COMMENT ON COLUMN gitserver_repos.rebalance_from IS 'The address of the gitserver the repository is being transferred from while rebalancing. NULL if no transfer is in progress.';
+COMMENT ON COLUMN gitserver_repos.rebalance_started_at IS 'The time the last transfer of the repository between gitservers started.';
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE gitserver_repos
+    DROP COLUMN IF EXISTS last_maintenance_at,
+    DROP COLUMN IF EXISTS last_maintenance_error,
+    DROP COLUMN IF EXISTS pack_count;
+
+COMMIT;
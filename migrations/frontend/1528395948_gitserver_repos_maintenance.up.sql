@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE gitserver_repos
+    ADD COLUMN IF NOT EXISTS last_maintenance_at timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS last_maintenance_error text,
+    ADD COLUMN IF NOT EXISTS pack_count integer;
+
+COMMENT ON COLUMN gitserver_repos.last_maintenance_at IS 'The time gitserver last ran maintenance tasks, such as writing commit-graphs, multi-pack-indexes and bitmaps, on the repository.';
+COMMENT ON COLUMN gitserver_repos.last_maintenance_error IS 'The error of the last maintenance run. NULL if it succeeded.';
+COMMENT ON COLUMN gitserver_repos.pack_count IS 'The number of packfiles of the repository after the last maintenance run.';
+
+COMMIT;
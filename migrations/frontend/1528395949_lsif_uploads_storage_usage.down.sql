@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE lsif_uploads
+    DROP COLUMN IF EXISTS storage_bytes,
+    DROP COLUMN IF EXISTS last_queried_at;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE lsif_uploads
+    ADD COLUMN IF NOT EXISTS storage_bytes bigint,
+    ADD COLUMN IF NOT EXISTS last_queried_at timestamp with time zone;
+
+COMMENT ON COLUMN lsif_uploads.storage_bytes IS 'The number of bytes occupied by the processed upload data in the codeintel database. NULL until the upload has been measured.';
+COMMENT ON COLUMN lsif_uploads.last_queried_at IS 'The last time this upload was used to resolve a code intelligence query. Updated at most once an hour.';
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS parent_upload_id integer;
+COMMENT ON COLUMN lsif_uploads.parent_upload_id IS 'The identifier of the upload this incremental upload was based on. The processed data of documents not contained in this upload is copied from the parent upload. NULL for full uploads.';
+
+DROP VIEW lsif_uploads_with_repository_name;
+
+CREATE VIEW lsif_uploads_with_repository_name AS
+    SELECT u.id,
+        u.commit,
+        u.root,
+        u.uploaded_at,
+        u.state,
+        u.failure_message,
+        u.started_at,
+        u.finished_at,
+        u.repository_id,
+        u.indexer,
+        u.num_parts,
+        u.uploaded_parts,
+        u.process_after,
+        u.num_resets,
+        u.upload_size,
+        u.num_failures,
+        u.associated_index_id,
+        u.expired,
+        u.last_retention_scan_at,
+        u.parent_upload_id,
+        r.name AS repository_name
+    FROM lsif_uploads u
+    JOIN repo r ON r.id = u.repository_id
+    WHERE r.deleted_at IS NULL;
+
+COMMIT;
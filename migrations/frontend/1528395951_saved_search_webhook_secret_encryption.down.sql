@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE saved_searches DROP COLUMN IF EXISTS webhook_secret_encryption_key_id;
+
+COMMIT;
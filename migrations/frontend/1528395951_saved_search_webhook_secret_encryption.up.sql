@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE saved_searches ADD COLUMN IF NOT EXISTS webhook_secret_encryption_key_id text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN saved_searches.webhook_secret_encryption_key_id IS 'The identifier of the key webhook_secret is encrypted with. Empty if the secret is stored in plaintext.';
+
+COMMIT;
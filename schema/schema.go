@@ -491,6 +491,7 @@ type EncryptionKeys struct {
 	// EnableCache description: enable LRU cache for decryption APIs
 	EnableCache            bool           `json:"enableCache,omitempty"`
 	ExternalServiceKey     *EncryptionKey `json:"externalServiceKey,omitempty"`
+	SavedSearchWebhookKey  *EncryptionKey `json:"savedSearchWebhookKey,omitempty"`
 	UserExternalAccountKey *EncryptionKey `json:"userExternalAccountKey,omitempty"`
 	WebhookLogKey          *EncryptionKey `json:"webhookLogKey,omitempty"`
 }
@@ -575,8 +576,14 @@ type ExperimentalFeatures struct {
 	EnablePostSignupFlow bool `json:"enablePostSignupFlow,omitempty"`
 	// EventLogging description: Enables user event logging inside of the Sourcegraph instance. This will allow admins to have greater visibility of user activity, such as frequently viewed pages, frequent searches, and more. These event logs (and any specific user actions) are only stored locally, and never leave this Sourcegraph instance.
 	EventLogging string `json:"eventLogging,omitempty"`
+	// GitLFS description: Fetches the Git LFS objects of text files at the default branch of repositories cloned over HTTP(S) when they are updated, so that unindexed searches and repository archives use their contents instead of the LFS pointer files.
+	GitLFS *GitLFS `json:"gitLFS,omitempty"`
+	// GitPartialClone description: JSON array of configuration that maps from Git clone URL domain/path to the filter of a partial clone. Matching repositories are cloned without the objects the filter excludes, which are fetched from the code host when they are needed. Only affects new clones.
+	GitPartialClone []*GitPartialCloneMapping `json:"gitPartialClone,omitempty"`
 	// JvmPackages description: Allow adding JVM packages code host connections
 	JvmPackages string `json:"jvmPackages,omitempty"`
+	// Mercurial description: Allow adding Mercurial code host connections
+	Mercurial string `json:"mercurial,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
 	Pagure string `json:"pagure,omitempty"`
 	// Perforce description: Allow adding Perforce code host connections
@@ -592,6 +599,8 @@ type ExperimentalFeatures struct {
 	// StructuralSearch description: Enables structural search.
 	StructuralSearch   string              `json:"structuralSearch,omitempty"`
 	SubRepoPermissions *SubRepoPermissions `json:"subRepoPermissions,omitempty"`
+	// Svn description: Allow adding Subversion code host connections
+	Svn string `json:"svn,omitempty"`
 	// TlsExternal description: Global TLS/SSL settings for Sourcegraph to use when communicating with code hosts.
 	TlsExternal *TlsExternal `json:"tls.external,omitempty"`
 	// VersionContexts description: DEPRECATED: Use search contexts instead.
@@ -663,6 +672,14 @@ type GitCommitDescription struct {
 	Message string `json:"message"`
 }
 
+// GitLFS description: Fetches the Git LFS objects of text files at the default branch of repositories cloned over HTTP(S) when they are updated, so that unindexed searches and repository archives use their contents instead of the LFS pointer files.
+type GitLFS struct {
+	// Extensions description: The file extensions of the LFS objects to fetch.
+	Extensions []string `json:"extensions,omitempty"`
+	// MaxFileSize description: The maximum size of the LFS objects to fetch in bytes.
+	MaxFileSize int `json:"maxFileSize,omitempty"`
+}
+
 // GitHubAuthProvider description: Configures the GitHub (or GitHub Enterprise) OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth App on your GitHub instance: https://developer.github.com/apps/building-oauth-apps/creating-an-oauth-app/. When a user signs into Sourcegraph or links their GitHub account to their existing Sourcegraph account, GitHub will prompt the user for the repo scope.
 type GitHubAuthProvider struct {
 	// AllowGroupsPermissionsSync description: Experimental: Allows sync of GitHub teams and organizations permissions across all external services associated with this provider to allow enabling of [repository permissions caching](https://docs.sourcegraph.com/admin/repo/permissions#permissions-caching).
@@ -852,6 +869,14 @@ type GitLabWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GitPartialCloneMapping description: Mapping from Git clone URL domain/path to the object filter of a partial clone.
+type GitPartialCloneMapping struct {
+	// DomainPath description: Git clone URL domain/path
+	DomainPath string `json:"domainPath"`
+	// Filter description: The object filter passed to git fetch --filter. The default clones without blobs.
+	Filter string `json:"filter,omitempty"`
+}
+
 // GitoliteConnection description: Configuration for a connection to Gitolite.
 type GitoliteConnection struct {
 	// Exclude description: A list of repositories to never mirror from this Gitolite instance. Supports excluding by exact name ({"name": "foo"}).
@@ -989,6 +1014,24 @@ type MavenRateLimit struct {
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
 
+// MercurialConnection description: Configuration for a connection to Mercurial repositories. Their history is converted to Git when they are cloned.
+type MercurialConnection struct {
+	// Password description: The password used to authenticate to the Mercurial server over HTTP(S).
+	Password string `json:"password,omitempty"`
+	// Repos description: The paths of the Mercurial repositories to be discovered, relative to `url`.
+	Repos []string `json:"repos"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Mercurial repository. In the pattern, the variable "{base}" is replaced with the host and path of `url`, and "{repo}" is replaced with the repository path taken from the `repos` field.
+	//
+	// For example, if `url` is https://hg.example.org/ and `repos` contains "legacy/billing", then a repositoryPathPattern of "hg/{repo}" would mean that the repository is available on Sourcegraph at https://sourcegraph.example.com/hg/legacy/billing.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this Mercurial server. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The base URL of the Mercurial server. The repository paths in `repos` are relative to it.
+	Url string `json:"url"`
+	// Username description: The username used to authenticate to the Mercurial server over HTTP(S).
+	Username string `json:"username,omitempty"`
+}
+
 // MountedEncryptionKey description: This encryption key is mounted from a given file path or an environment variable.
 type MountedEncryptionKey struct {
 	EnvVarName string `json:"envVarName,omitempty"`
@@ -1365,6 +1408,48 @@ type SMTPServerConfig struct {
 	Username string `json:"username,omitempty"`
 }
 
+// SVNConnection description: Configuration for a connection to Subversion repositories. Their history is imported into Git when they are cloned.
+type SVNConnection struct {
+	// Authors description: Maps Subversion usernames to the Git authors of imported commits in the form "Name <email>". Commits by other users are attributed to "username <username>". Changes only apply to revisions imported afterwards.
+	Authors map[string]string `json:"authors,omitempty"`
+	// Layout description: The locations of trunk, branches and tags in each repository, relative to the repository. Trunk becomes the master branch, every directory below a branches location a branch and every directory below a tags location a tag. If omitted, the standard layout of trunk, branches and tags is used.
+	Layout *SVNLayout `json:"layout,omitempty"`
+	// Password description: The password used to authenticate to the Subversion server.
+	Password string `json:"password,omitempty"`
+	// Repos description: The paths of the Subversion repositories to add, relative to `url`. If omitted, every directory directly below `url` is added.
+	Repos []string `json:"repos,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Subversion repository. In the pattern, the variable "{base}" is replaced with the host and path of `url`, and "{repo}" is replaced with the path of the repository relative to `url`.
+	//
+	// For example, if `url` is https://svn.example.org/repos/ and a repository lives in the directory "monorepo", then a repositoryPathPattern of "svn/{repo}" would mean that the repository is available on Sourcegraph at https://sourcegraph.example.com/svn/monorepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this Subversion server. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The root URL under which the Subversion repositories live. Unless `repos` is set, every directory directly below it is added as a repository, which requires the server to serve Subversion over HTTP(S).
+	Url string `json:"url"`
+	// Username description: The username used to authenticate to the Subversion server.
+	Username string `json:"username,omitempty"`
+}
+
+// SVNLayout description: The locations of trunk, branches and tags in each repository, relative to the repository. Trunk becomes the master branch, every directory below a branches location a branch and every directory below a tags location a tag. If omitted, the standard layout of trunk, branches and tags is used.
+type SVNLayout struct {
+	// Branches description: The locations of branches.
+	Branches []string `json:"branches,omitempty"`
+	// Tags description: The locations of tags.
+	Tags []string `json:"tags,omitempty"`
+	// Trunk description: The location of trunk. Set it to "/" to import the repository root as the master branch.
+	Trunk string `json:"trunk,omitempty"`
+}
+
+// SearchCostBudget description: Admission control for search queries based on their estimated cost. Every user may spend up to perUserPerMinute cost units per minute on searches. Queries whose estimated cost exceeds the remaining budget of the user are rejected, queued or downgraded, depending on action. If unset, the cost of queries is not limited.
+type SearchCostBudget struct {
+	// Action description: What to do with a query that exceeds the remaining budget of the user. "reject" does not run the query. "queue" runs the query once enough budget is available, if that is within maxQueueSeconds. "downgrade" runs the query only over indexed repositories with the default result limit.
+	Action string `json:"action,omitempty"`
+	// MaxQueueSeconds description: The maximum time a query is queued for when action is "queue". Queries that would have to wait longer are rejected.
+	MaxQueueSeconds int `json:"maxQueueSeconds,omitempty"`
+	// PerUserPerMinute description: The number of cost units every user may spend on searches per minute. An unfiltered literal search over an indexed repository costs about 1 unit, a search over an unindexed repository revision about 10 units. Regular expressions, structural and fuzzy patterns, and commit and diff searches cost more.
+	PerUserPerMinute int `json:"perUserPerMinute"`
+}
+
 // SearchLimits description: Limits that search applies for number of repositories searched and timeouts.
 type SearchLimits struct {
 	// CommitDiffMaxRepos description: The maximum number of repositories to search across when doing a "type:diff" or "type:commit". The user is prompted to narrow their query if the limit is exceeded. There is a separate limit (commitDiffWithTimeFilterMaxRepos) when "after:" or "before:" is specified because those queries are faster. Defaults to 50.
@@ -1586,6 +1671,10 @@ type SiteConfiguration struct {
 	CodeIntelAutoIndexingEnabled *bool `json:"codeIntelAutoIndexing.enabled,omitempty"`
 	// CodeIntelAutoIndexingPolicyRepositoryMatchLimit description: The maximum number of repositories to which a single auto-indexing policy can apply. Default is -1, which is unlimited.
 	CodeIntelAutoIndexingPolicyRepositoryMatchLimit *int `json:"codeIntelAutoIndexing.policyRepositoryMatchLimit,omitempty"`
+	// CodeIntelStorageQuotaOrganizationBytes description: The maximum number of bytes of precise code intelligence data stored for all repositories synced by the code host connections of a single organization. Once exceeded, the least recently queried uploads of the organization's repositories are expired. Default is 0, which is unlimited.
+	CodeIntelStorageQuotaOrganizationBytes *int `json:"codeIntelStorageQuota.organizationBytes,omitempty"`
+	// CodeIntelStorageQuotaRepositoryBytes description: The maximum number of bytes of precise code intelligence data stored for a single repository. Once exceeded, the least recently queried uploads of the repository are expired. Default is 0, which is unlimited.
+	CodeIntelStorageQuotaRepositoryBytes *int `json:"codeIntelStorageQuota.repositoryBytes,omitempty"`
 	// CorsOrigin description: Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.
 	CorsOrigin string `json:"corsOrigin,omitempty"`
 	// DebugSearchSymbolsParallelism description: (debug) controls the amount of symbol search parallelism. Defaults to 20. It is not recommended to change this outside of debugging scenarios. This option will be removed in a future version.
@@ -1628,6 +1717,8 @@ type SiteConfiguration struct {
 	GitMaxCodehostRequestsPerSecond *int `json:"gitMaxCodehostRequestsPerSecond,omitempty"`
 	// GitMaxConcurrentClones description: Maximum number of git clone processes that will be run concurrently per gitserver to update repositories. Note: the global git update scheduler respects gitMaxConcurrentClones. However, we allow each gitserver to run upto gitMaxConcurrentClones to allow for urgent fetches. Urgent fetches are used when a user is browsing a PR and we do not have the commit yet.
 	GitMaxConcurrentClones int `json:"gitMaxConcurrentClones,omitempty"`
+	// GitReplicationFactor description: Number of gitserver instances that keep a copy of each repository. Clones, fetches and commits created from patches are sent to every replica, and reads fail over to another replica when a gitserver is unavailable. Each gitserver repairs missing replicas in the background. The default is 1, which disables replication.
+	GitReplicationFactor int `json:"gitReplicationFactor,omitempty"`
 	// GitUpdateInterval description: JSON array of repo name patterns and update intervals. If a repo matches a pattern, the associated interval will be used. If it matches no patterns a default backoff heuristic will be used. Pattern matches are attempted in the order they are provided.
 	GitUpdateInterval []*UpdateIntervalRule `json:"gitUpdateInterval,omitempty"`
 	// GithubClientID description: Client ID for GitHub. (DEPRECATED)
@@ -1684,6 +1775,8 @@ type SiteConfiguration struct {
 	RepoConcurrentExternalServiceSyncers int `json:"repoConcurrentExternalServiceSyncers,omitempty"`
 	// RepoListUpdateInterval description: Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.
 	RepoListUpdateInterval int `json:"repoListUpdateInterval,omitempty"`
+	// SearchCostBudget description: Admission control for search queries based on their estimated cost. Every user may spend up to perUserPerMinute cost units per minute on searches. Queries whose estimated cost exceeds the remaining budget of the user are rejected, queued or downgraded, depending on action. If unset, the cost of queries is not limited.
+	SearchCostBudget *SearchCostBudget `json:"search.costBudget,omitempty"`
 	// SearchIndexEnabled description: Whether indexed search is enabled. If unset Sourcegraph detects the environment to decide if indexed search is enabled. Indexed search is RAM heavy, and is disabled by default in the single docker image. All other environments will have it enabled by default. The size of all your repository working copies is the amount of additional RAM required.
 	SearchIndexEnabled *bool `json:"search.index.enabled,omitempty"`
 	// SearchIndexSymbolsEnabled description: Whether indexed symbol search is enabled. This is contingent on the indexed search configuration, and is true by default for instances with indexed search enabled. Enabling this will cause every repository to re-index, which is a time consuming (several hours) operation. Additionally, it requires more storage and ram to accommodate the added symbols information in the search index.
@@ -38,6 +38,10 @@ var GitoliteSchemaJSON string
 //go:embed jvm-packages.schema.json
 var JVMPackagesSchemaJSON string
 
+// MercurialSchemaJSON is the content of the file "mercurial.schema.json".
+//go:embed mercurial.schema.json
+var MercurialSchemaJSON string
+
 // OtherExternalServiceSchemaJSON is the content of the file "other_external_service.schema.json".
 //go:embed other_external_service.schema.json
 var OtherExternalServiceSchemaJSON string
@@ -54,6 +58,10 @@ var PhabricatorSchemaJSON string
 //go:embed pagure.schema.json
 var PagureSchemaJSON string
 
+// SVNSchemaJSON is the content of the file "svn.schema.json".
+//go:embed svn.schema.json
+var SVNSchemaJSON string
+
 // SettingsSchemaJSON is the content of the file "settings.schema.json".
 //go:embed settings.schema.json
 var SettingsSchemaJSON string